@@ -6,6 +6,8 @@ import (
 	"digital-wallet/internal/router"
 	"digital-wallet/pkg/response"
 	"log"
+	"net"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -50,6 +52,9 @@ func startServer() {
 	// validation
 	e.Validator = di.Validator
 
+	// client address for c.RealIP, which login lockout counts failures against
+	e.IPExtractor = newIPExtractor(cfg.Server.TrustedProxies)
+
 	// Add middleware
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
@@ -65,3 +70,23 @@ func startServer() {
 		log.Fatal("Failed to start server:", err)
 	}
 }
+
+// newIPExtractor reads the client address from X-Forwarded-For only when the request comes
+// through one of the trusted proxies, and otherwise uses the address of the connection, so a
+// client cannot pick its own address by sending the header
+func newIPExtractor(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range trustedProxies {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			log.Fatalf("Invalid trusted proxy range %q: %v", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
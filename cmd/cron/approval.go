@@ -0,0 +1,36 @@
+package cron
+
+import (
+	"context"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var approvalBatchSize int
+
+var expireApprovalsCmd = &cobra.Command{
+	Use:   "expire-approvals",
+	Short: "Expire overdue approval requests",
+	Long:  "Move pending maker-checker approval requests past their expiry time to EXPIRED",
+	Run: func(cmd *cobra.Command, args []string) {
+		expireApprovals()
+	},
+}
+
+func init() {
+	expireApprovalsCmd.Flags().IntVarP(&approvalBatchSize, "batch-size", "b", 500, "Maximum number of requests to expire in one run")
+}
+
+func expireApprovals() {
+	log.Println("Starting approval request expiry...")
+
+	di := initContainer()
+	n, err := di.ApprovalService.ExpirePending(context.Background(), approvalBatchSize)
+	if err != nil {
+		log.Printf("❌ Approval request expiry failed: %v", err)
+		return
+	}
+
+	log.Printf("✅ Expired %d approval requests", n)
+}
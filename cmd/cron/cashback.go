@@ -0,0 +1,36 @@
+package cron
+
+import (
+	"context"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var releaseBatchSize int
+
+var releaseCashbackCmd = &cobra.Command{
+	Use:   "release-cashback",
+	Short: "Release held cashback rewards",
+	Long:  "Credit cashback rewards whose holding period has ended to the users' wallets",
+	Run: func(cmd *cobra.Command, args []string) {
+		releaseCashback()
+	},
+}
+
+func init() {
+	releaseCashbackCmd.Flags().IntVarP(&releaseBatchSize, "batch-size", "b", 500, "Maximum number of rewards to release in one run")
+}
+
+func releaseCashback() {
+	log.Println("Starting cashback release...")
+
+	di := initContainer()
+	n, err := di.CashbackService.ReleaseHeldRewards(context.Background(), releaseBatchSize)
+	if err != nil {
+		log.Printf("❌ Cashback release failed after %d rewards: %v", n, err)
+		return
+	}
+
+	log.Printf("✅ Released %d cashback rewards", n)
+}
@@ -15,6 +15,14 @@ var CronCmd = &cobra.Command{
 func init() {
 	// Add subcommands for different cron jobs
 	CronCmd.AddCommand(healthCheckCmd)
+	CronCmd.AddCommand(expirePaymentIntentsCmd)
+	CronCmd.AddCommand(releaseCashbackCmd)
+	CronCmd.AddCommand(releaseEscrowsCmd)
+	CronCmd.AddCommand(summarizeTransactionsCmd)
+	CronCmd.AddCommand(sendNotificationsCmd)
+	CronCmd.AddCommand(expireApprovalsCmd)
+	CronCmd.AddCommand(checkpointLedgerCmd)
+	CronCmd.AddCommand(verifyLedgerCmd)
 }
 
 // Helper function to initialize di for cron jobs
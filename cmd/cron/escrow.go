@@ -0,0 +1,36 @@
+package cron
+
+import (
+	"context"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var escrowBatchSize int
+
+var releaseEscrowsCmd = &cobra.Command{
+	Use:   "release-escrows",
+	Short: "Auto-release timed out escrows",
+	Long:  "Release funded escrows to their sellers once the auto-release time has passed without a release or refund",
+	Run: func(cmd *cobra.Command, args []string) {
+		releaseEscrows()
+	},
+}
+
+func init() {
+	releaseEscrowsCmd.Flags().IntVarP(&escrowBatchSize, "batch-size", "b", 500, "Maximum number of escrows to release in one run")
+}
+
+func releaseEscrows() {
+	log.Println("Starting escrow auto-release...")
+
+	di := initContainer()
+	n, err := di.EscrowService.AutoRelease(context.Background(), escrowBatchSize)
+	if err != nil {
+		log.Printf("❌ Escrow auto-release failed after %d escrows: %v", n, err)
+		return
+	}
+
+	log.Printf("✅ Auto-released %d escrows", n)
+}
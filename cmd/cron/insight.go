@@ -0,0 +1,36 @@
+package cron
+
+import (
+	"context"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var summaryBatchSize int
+
+var summarizeTransactionsCmd = &cobra.Command{
+	Use:   "summarize-transactions",
+	Short: "Update monthly spending summaries",
+	Long:  "Fold wallet transactions created since the last run into the monthly per-category summaries used by the insights API",
+	Run: func(cmd *cobra.Command, args []string) {
+		summarizeTransactions()
+	},
+}
+
+func init() {
+	summarizeTransactionsCmd.Flags().IntVarP(&summaryBatchSize, "batch-size", "b", 1000, "Number of transactions to summarize per database transaction")
+}
+
+func summarizeTransactions() {
+	log.Println("Starting transaction summary...")
+
+	di := initContainer()
+	n, err := di.InsightService.SummarizeTransactions(context.Background(), summaryBatchSize)
+	if err != nil {
+		log.Printf("❌ Transaction summary failed after %d transactions: %v", n, err)
+		return
+	}
+
+	log.Printf("✅ Summarized %d transactions", n)
+}
@@ -0,0 +1,36 @@
+package cron
+
+import (
+	"context"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var notificationBatchSize int
+
+var sendNotificationsCmd = &cobra.Command{
+	Use:   "send-notifications",
+	Short: "Deliver queued notifications",
+	Long:  "Send pending email, SMS and push notifications whose next attempt is due, retrying failures with backoff",
+	Run: func(cmd *cobra.Command, args []string) {
+		sendNotifications()
+	},
+}
+
+func init() {
+	sendNotificationsCmd.Flags().IntVarP(&notificationBatchSize, "batch-size", "b", 200, "Maximum number of notifications to send in one run")
+}
+
+func sendNotifications() {
+	log.Println("Starting notification delivery...")
+
+	di := initContainer()
+	n, err := di.NotificationService.DeliverPending(context.Background(), notificationBatchSize)
+	if err != nil {
+		log.Printf("❌ Notification delivery failed: %v", err)
+		return
+	}
+
+	log.Printf("✅ Delivered %d notifications", n)
+}
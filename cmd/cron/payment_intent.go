@@ -0,0 +1,36 @@
+package cron
+
+import (
+	"context"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var expireBatchSize int
+
+var expirePaymentIntentsCmd = &cobra.Command{
+	Use:   "expire-payment-intents",
+	Short: "Expire overdue payment intents",
+	Long:  "Move unconfirmed payment intents past their expiry time to EXPIRED and notify merchants",
+	Run: func(cmd *cobra.Command, args []string) {
+		expirePaymentIntents()
+	},
+}
+
+func init() {
+	expirePaymentIntentsCmd.Flags().IntVarP(&expireBatchSize, "batch-size", "b", 500, "Maximum number of intents to expire in one run")
+}
+
+func expirePaymentIntents() {
+	log.Println("Starting payment intent expiry...")
+
+	di := initContainer()
+	n, err := di.PaymentIntentService.ExpireIntents(context.Background(), expireBatchSize)
+	if err != nil {
+		log.Printf("❌ Payment intent expiry failed after %d intents: %v", n, err)
+		return
+	}
+
+	log.Printf("✅ Expired %d payment intents", n)
+}
@@ -1,6 +1,7 @@
 package migrate
 
 import (
+	"digital-wallet/configs"
 	"digital-wallet/di"
 	"fmt"
 	"log"
@@ -36,9 +37,13 @@ func init() {
 }
 
 func runMigration(direction migrate.MigrationDirection) {
-	container := di.SetUp()
+	cfg := configs.LoadDefault()
 
-	db, err := container.DB.DB()
+	if cfg.Database.MigrationUsername == "" {
+		log.Printf("DB_MIGRATION_USERNAME is not set, running migrations as %s; that user can drop the audit_events triggers", cfg.Database.Username)
+	}
+
+	db, err := di.MigrationConn(cfg).DB()
 	if err != nil {
 		log.Fatalf("Failed to get sql.DB: %v", err)
 	}
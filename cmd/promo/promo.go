@@ -0,0 +1,94 @@
+package promo
+
+import (
+	"context"
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var PromoCmd = &cobra.Command{
+	Use:   "promo",
+	Short: "Promo code commands",
+}
+
+var (
+	generateReq       dto.GeneratePromoCodesRequest
+	generateExpiresAt string
+	generateOutput    string
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a batch of promo codes",
+	Long:  "Generate random promo codes that share the same reward and redemption rules, and write them one per line",
+	Run: func(cmd *cobra.Command, args []string) {
+		generateCodes()
+	},
+}
+
+func init() {
+	flags := generateCmd.Flags()
+	flags.IntVarP(&generateReq.Count, "count", "n", 1, "Number of codes to generate")
+	flags.StringVar(&generateReq.Prefix, "prefix", "", "Prefix put in front of every code, e.g. XMAS")
+	flags.IntVar(&generateReq.Length, "length", 8, "Number of random characters in each code")
+	flags.StringVar(&generateReq.Description, "description", "", "Description of the batch")
+	flags.StringVar(&generateReq.RewardType, "reward", "CREDIT", "Reward type: CREDIT or DISCOUNT")
+	flags.StringVar(&generateReq.ValueType, "value-type", "FIXED", "Value type: FIXED or PERCENTAGE")
+	flags.Float64Var(&generateReq.Value, "value", 0, "Fixed amount or percentage of the reward")
+	flags.Float64Var(&generateReq.MaxValue, "max-value", 0, "Cap on the reward, 0 for none")
+	flags.Float64Var(&generateReq.MinAmount, "min-amount", 0, "Minimum transaction or payment amount")
+	flags.StringVar(&generateReq.TransactionType, "transaction-type", "", "Only transactions of this type qualify")
+	flags.IntVar(&generateReq.MaxRedemptions, "max-redemptions", 1, "Redemptions allowed per code, 0 for unlimited")
+	flags.IntVar(&generateReq.MaxPerUser, "max-per-user", 1, "Redemptions allowed per user and code, 0 for unlimited")
+	flags.StringVar(&generateExpiresAt, "expires-at", "", "Expiry time in RFC3339, e.g. 2026-12-31T23:59:59Z")
+	flags.StringVarP(&generateOutput, "output", "o", "", "File to write the codes to instead of stdout")
+
+	PromoCmd.AddCommand(generateCmd)
+}
+
+func generateCodes() {
+	generateReq.RewardType = strings.ToUpper(generateReq.RewardType)
+	generateReq.ValueType = strings.ToUpper(generateReq.ValueType)
+	generateReq.TransactionType = strings.ToUpper(generateReq.TransactionType)
+
+	if generateExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, generateExpiresAt)
+		if err != nil {
+			log.Fatalf("Invalid --expires-at: %v", err)
+		}
+		generateReq.ExpiresAt = &expiresAt
+	}
+
+	container := di.SetUp()
+
+	if err := container.Validator.Validate(&generateReq); err != nil {
+		log.Fatalf("Invalid promo code options: %v", err)
+	}
+
+	promos, err := container.PromoService.GenerateCodes(context.Background(), generateReq)
+	if err != nil {
+		log.Fatalf("❌ Promo code generation failed: %v", err)
+	}
+
+	out := os.Stdout
+	if generateOutput != "" {
+		out, err = os.Create(generateOutput)
+		if err != nil {
+			log.Fatalf("Failed to create output file: %v", err)
+		}
+		defer out.Close()
+	}
+
+	for _, promo := range promos {
+		fmt.Fprintln(out, promo.Code)
+	}
+
+	log.Printf("✅ Generated %d promo codes in batch %s", len(promos), promos[0].BatchID)
+}
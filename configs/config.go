@@ -12,6 +12,9 @@ type Config struct {
 		NAME    string `envconfig:"APP_NAME" required:"true"`
 		PORT    string `envconfig:"APP_PORT" required:"true"`
 		DEBUG   bool   `envconfig:"APP_DEBUG" default:"false"`
+		// TrustedProxies are the CIDR ranges of the proxies whose X-Forwarded-For is believed;
+		// without them the client address is the one the connection comes from
+		TrustedProxies []string `envconfig:"APP_TRUSTED_PROXIES"`
 	}
 
 	Database struct {
@@ -22,6 +25,10 @@ type Config struct {
 		DBName             string `envconfig:"DB_NAME" required:"true"`
 		MinIdleConnections int    `envconfig:"DB_MIN_IDDLE_CONN" required:"true"`
 		MaxOpenConnections int    `envconfig:"DB_MAX_OPEN_CONN" required:"true"`
+		// MigrationUsername and MigrationPassword are the user migrations run as. The user
+		// of DB_USERNAME only reads and writes rows, so it cannot drop the audit_events triggers.
+		MigrationUsername string `envconfig:"DB_MIGRATION_USERNAME"`
+		MigrationPassword string `envconfig:"DB_MIGRATION_PASSWORD"`
 	}
 
 	Redis struct {
@@ -32,12 +39,134 @@ type Config struct {
 	}
 
 	JWT struct {
-		SigningKey                       string `envconfig:"JWT_SIGNING_KEY" required:"true"`
-		TokenExpiration                  int    `envconfig:"JWT_TOKEN_EXPIRATION" required:"true"`
-		RefreshTokenExpirationDay        int    `envconfig:"JWT_REFRESH_TOKEN_EXPIRATION_DAY" required:"true"`
-		EncryptionKey                    string `envconfig:"JWT_ENCRYPTION_KEY" required:"true"`
-		AccountActivationTokenExpiration string `envconfig:"ACCOUNT_ACTIVATION_TOKEN_EXPIRATION" required:"true"`
-		ForgotPasswordTokenExpiration    int    `envconfig:"FORGOT_PASSWORD_TOKEN_EXPIRATION" required:"true"`
+		SigningKey                       string   `envconfig:"JWT_SIGNING_KEY" required:"true"`
+		TokenExpiration                  int      `envconfig:"JWT_TOKEN_EXPIRATION" required:"true"`
+		RefreshTokenExpirationDay        int      `envconfig:"JWT_REFRESH_TOKEN_EXPIRATION_DAY" required:"true"`
+		EncryptionKey                    string   `envconfig:"JWT_ENCRYPTION_KEY" required:"true"`
+		AccountActivationTokenExpiration string   `envconfig:"ACCOUNT_ACTIVATION_TOKEN_EXPIRATION" required:"true"`
+		ForgotPasswordTokenExpiration    int      `envconfig:"FORGOT_PASSWORD_TOKEN_EXPIRATION" required:"true"`
+		Issuer                           string   `envconfig:"JWT_ISSUER" default:"digital-wallet"`
+		Audience                         string   `envconfig:"JWT_AUDIENCE" default:"digital-wallet"`
+		SigningKeyID                     string   `envconfig:"JWT_SIGNING_KEY_ID"`
+		PrivateKeyFile                   string   `envconfig:"JWT_PRIVATE_KEY_FILE"`
+		VerificationKeys                 []string `envconfig:"JWT_VERIFICATION_KEYS"`
+		AcceptHMAC                       bool     `envconfig:"JWT_ACCEPT_HMAC" default:"false"`
+		TrustedIssuer                    string   `envconfig:"JWT_TRUSTED_ISSUER"`
+		TrustedJWKS                      string   `envconfig:"JWT_TRUSTED_JWKS"`
+		TrustedJWKSRefresh               int      `envconfig:"JWT_TRUSTED_JWKS_REFRESH" default:"300"`
+		Leeway                           int      `envconfig:"JWT_LEEWAY" default:"30"`
+	}
+
+	PIN struct {
+		MaxAttempts  int `envconfig:"PIN_MAX_ATTEMPTS" default:"5"`
+		LockDuration int `envconfig:"PIN_LOCK_DURATION" default:"3600"`
+	}
+
+	Login struct {
+		MaxAttempts   int `envconfig:"LOGIN_MAX_ATTEMPTS" default:"10"`
+		IPMaxAttempts int `envconfig:"LOGIN_IP_MAX_ATTEMPTS" default:"100"`
+		LockDuration  int `envconfig:"LOGIN_LOCK_DURATION" default:"900"`
+		DelayAfter    int `envconfig:"LOGIN_DELAY_AFTER" default:"3"`
+		MaxDelay      int `envconfig:"LOGIN_MAX_DELAY" default:"60"`
+	}
+
+	MFA struct {
+		Issuer                 string `envconfig:"MFA_ISSUER" default:"Digital Wallet"`
+		PendingTokenExpiration int    `envconfig:"MFA_PENDING_TOKEN_EXPIRATION" default:"300"`
+		MaxAttempts            int    `envconfig:"MFA_MAX_ATTEMPTS" default:"5"`
+		LockDuration           int    `envconfig:"MFA_LOCK_DURATION" default:"900"`
+		RecoveryCodes          int    `envconfig:"MFA_RECOVERY_CODES" default:"10"`
+	}
+
+	StepUp struct {
+		AmountThreshold float64 `envconfig:"STEP_UP_AMOUNT_THRESHOLD" default:"5000000"`
+		NewDeviceWindow int     `envconfig:"STEP_UP_NEW_DEVICE_WINDOW" default:"86400"`
+		Channel         string  `envconfig:"STEP_UP_CHANNEL" default:"sms"`
+		CodeExpiration  int     `envconfig:"STEP_UP_CODE_EXPIRATION" default:"300"`
+		MaxAttempts     int     `envconfig:"STEP_UP_MAX_ATTEMPTS" default:"3"`
+		MaxChallenges   int     `envconfig:"STEP_UP_MAX_CHALLENGES" default:"5"`
+		RateWindow      int     `envconfig:"STEP_UP_RATE_WINDOW" default:"900"`
+	}
+
+	Device struct {
+		NewWithdrawalLimit float64 `envconfig:"DEVICE_NEW_WITHDRAWAL_LIMIT" default:"1000000"`
+		TouchInterval      int     `envconfig:"DEVICE_TOUCH_INTERVAL" default:"60"`
+	}
+
+	KYC struct {
+		StorageDir                   string  `envconfig:"KYC_STORAGE_DIR" default:"storage"`
+		BasicMaxBalance              float64 `envconfig:"KYC_BASIC_MAX_BALANCE" default:"2000000"`
+		BasicDailyWithdrawalLimit    float64 `envconfig:"KYC_BASIC_DAILY_WITHDRAWAL_LIMIT" default:"1000000"`
+		VerifiedMaxBalance           float64 `envconfig:"KYC_VERIFIED_MAX_BALANCE" default:"20000000"`
+		VerifiedDailyWithdrawalLimit float64 `envconfig:"KYC_VERIFIED_DAILY_WITHDRAWAL_LIMIT" default:"20000000"`
+	}
+
+	Ledger struct {
+		SigningKeyID     string   `envconfig:"LEDGER_SIGNING_KEY_ID"`
+		SigningKeyFile   string   `envconfig:"LEDGER_SIGNING_KEY_FILE"`
+		VerificationKeys []string `envconfig:"LEDGER_VERIFICATION_KEYS"`
+	}
+
+	Session struct {
+		MaxLifetimeDay int `envconfig:"SESSION_MAX_LIFETIME_DAY" default:"30"`
+		TouchInterval  int `envconfig:"SESSION_TOUCH_INTERVAL" default:"60"`
+	}
+
+	Payment struct {
+		IntentExpiration int `envconfig:"PAYMENT_INTENT_EXPIRATION" default:"900"`
+		CallbackTimeout  int `envconfig:"PAYMENT_CALLBACK_TIMEOUT" default:"5"`
+	}
+
+	Promo struct {
+		FundingWalletID string `envconfig:"PROMO_FUNDING_WALLET_ID"`
+	}
+
+	Cashback struct {
+		FundingWalletID string `envconfig:"CASHBACK_FUNDING_WALLET_ID"`
+	}
+
+	Escrow struct {
+		FeePercentage    float64 `envconfig:"ESCROW_FEE_PERCENTAGE" default:"0"`
+		FeeWalletID      string  `envconfig:"ESCROW_FEE_WALLET_ID"`
+		AutoReleaseHours int     `envconfig:"ESCROW_AUTO_RELEASE_HOURS" default:"168"`
+	}
+
+	Approval struct {
+		Expiration        int     `envconfig:"APPROVAL_EXPIRATION" default:"86400"`
+		ReversalThreshold float64 `envconfig:"APPROVAL_REVERSAL_THRESHOLD" default:"1000000"`
+	}
+
+	APIKey struct {
+		MaxLifetimeDay int `envconfig:"API_KEY_MAX_LIFETIME_DAY" default:"365"`
+		RotationGrace  int `envconfig:"API_KEY_ROTATION_GRACE" default:"86400"`
+		TouchInterval  int `envconfig:"API_KEY_TOUCH_INTERVAL" default:"60"`
+	}
+
+	Notification struct {
+		DefaultLocale string `envconfig:"NOTIFICATION_DEFAULT_LOCALE" default:"en"`
+		MaxAttempts   int    `envconfig:"NOTIFICATION_MAX_ATTEMPTS" default:"5"`
+		RetryBackoff  int    `envconfig:"NOTIFICATION_RETRY_BACKOFF" default:"60"`
+		Timeout       int    `envconfig:"NOTIFICATION_TIMEOUT" default:"10"`
+		LogFile       string `envconfig:"NOTIFICATION_LOG_FILE" default:"./log/notifications.log"`
+	}
+
+	SMTP struct {
+		Host     string `envconfig:"SMTP_HOST"`
+		Port     string `envconfig:"SMTP_PORT" default:"587"`
+		Username string `envconfig:"SMTP_USERNAME"`
+		Password string `envconfig:"SMTP_PASSWORD"`
+		From     string `envconfig:"SMTP_FROM" default:"no-reply@digital-wallet.local"`
+	}
+
+	SMS struct {
+		GatewayURL string `envconfig:"SMS_GATEWAY_URL"`
+		APIKey     string `envconfig:"SMS_API_KEY"`
+		Sender     string `envconfig:"SMS_SENDER" default:"WALLET"`
+	}
+
+	Push struct {
+		GatewayURL string `envconfig:"PUSH_GATEWAY_URL"`
+		ServerKey  string `envconfig:"PUSH_SERVER_KEY"`
 	}
 
 	Logger struct {
@@ -34,3 +34,16 @@ func MySQLConn(cfg *configs.Config) *gorm.DB {
 
 	return conn
 }
+
+// MigrationConn connects as the migration user, which owns the schema, or as the application's
+// user when no migration user is configured
+func MigrationConn(cfg *configs.Config) *gorm.DB {
+	if cfg.Database.MigrationUsername == "" {
+		return MySQLConn(cfg)
+	}
+
+	migrationCfg := *cfg
+	migrationCfg.Database.Username = cfg.Database.MigrationUsername
+	migrationCfg.Database.Password = cfg.Database.MigrationPassword
+	return MySQLConn(&migrationCfg)
+}
@@ -5,6 +5,8 @@ import (
 	"digital-wallet/internal/interfaces"
 	"digital-wallet/internal/repositories"
 	"digital-wallet/internal/services"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/storage"
 	"log/slog"
 
 	"github.com/redis/go-redis/v9"
@@ -12,13 +14,34 @@ import (
 )
 
 type Container struct {
-	DB            *gorm.DB
-	RedisClient   *redis.Client
-	Config        *configs.Config
-	RepoRegistry  interfaces.RegistryRepository
-	WalletService interfaces.WalletService
-	Validator     *CustomValidator
-	Logger        *slog.Logger
+	DB                   *gorm.DB
+	RedisClient          *redis.Client
+	Config               *configs.Config
+	Keyring              *auth.Keyring
+	RepoRegistry         interfaces.RegistryRepository
+	WalletService        interfaces.WalletService
+	Validator            *CustomValidator
+	Logger               *slog.Logger
+	MerchantService      interfaces.MerchantService
+	PaymentIntentService interfaces.PaymentIntentService
+	PocketService        interfaces.PocketService
+	CashbackService      interfaces.CashbackService
+	PromoService         interfaces.PromoService
+	EscrowService        interfaces.EscrowService
+	InsightService       interfaces.InsightService
+	BudgetService        interfaces.BudgetService
+	NotificationService  interfaces.NotificationService
+	AuthService          interfaces.AuthService
+	PINService           interfaces.PINService
+	MFAService           interfaces.MFAService
+	AccountService       interfaces.AccountService
+	AdminService         interfaces.AdminService
+	ApprovalService      interfaces.ApprovalService
+	APIKeyService        interfaces.APIKeyService
+	DeviceService        interfaces.DeviceService
+	KYCService           interfaces.KYCService
+	AuditService         interfaces.AuditService
+	LedgerService        interfaces.LedgerService
 }
 
 func SetUp() *Container {
@@ -26,6 +49,7 @@ func SetUp() *Container {
 		cfg       = configs.LoadDefault()
 		validator = NewCustomValidator()
 		logger    = newLogger(cfg)
+		keyring   = NewKeyring(cfg)
 	)
 
 	// initial cache and database
@@ -38,15 +62,68 @@ func SetUp() *Container {
 	repoRegistry := repositories.NewRepositoryRegistry(db, redisClient, cfg)
 
 	// Initialize services
-	walletService := services.NewWalletService(repoRegistry, cfg)
+	cashbackService := services.NewCashbackService(repoRegistry, cfg)
+	budgetService := services.NewBudgetService(repoRegistry, cfg)
+	notifiers := newNotifiers(cfg)
+	notificationService := services.NewNotificationService(repoRegistry, cfg, notifiers)
+
+	// listeners run inside the transaction that settles a wallet transaction
+	listeners := []interfaces.TransactionListener{cashbackService, budgetService, notificationService}
+
+	otpSender := services.NewNotifierOTPSender(notifiers, cfg.StepUp.Channel)
+	walletService := services.NewWalletService(repoRegistry, cfg, otpSender, listeners...)
+	merchantService := services.NewMerchantService(repoRegistry, cfg)
+	paymentIntentService := services.NewPaymentIntentService(repoRegistry, cfg, otpSender, listeners...)
+	pocketService := services.NewPocketService(repoRegistry, cfg, listeners...)
+	promoService := services.NewPromoService(repoRegistry, cfg, listeners...)
+	escrowService := services.NewEscrowService(repoRegistry, cfg, otpSender, listeners...)
+	insightService := services.NewInsightService(repoRegistry, cfg)
+	accountSender := newAccountSender(cfg, logger, notifiers)
+	authService := services.NewAuthService(repoRegistry, cfg, keyring, accountSender, notificationService)
+	deviceService := services.NewDeviceService(repoRegistry, cfg, notificationService)
+	accountService := services.NewAccountService(repoRegistry, cfg, accountSender)
+	pinService := services.NewPINService(repoRegistry, cfg)
+	mfaService := services.NewMFAService(repoRegistry, cfg)
+	adminService := services.NewAdminService(repoRegistry, cfg, listeners...)
+
+	// executors run the operations of approved maker-checker requests
+	approvalService := services.NewApprovalService(repoRegistry, cfg, adminService)
+	apiKeyService := services.NewAPIKeyService(repoRegistry, cfg)
+
+	// identity documents are kept out of the database, under the storage directory
+	fileStorage := storage.NewLocal(cfg.KYC.StorageDir)
+	kycService := services.NewKYCService(repoRegistry, cfg, fileStorage)
+	auditService := services.NewAuditService(repoRegistry, cfg)
+	ledgerService := services.NewLedgerService(repoRegistry, cfg, NewLedgerKeyring(cfg))
 
 	return &Container{
-		DB:            db,
-		RedisClient:   redisClient,
-		Config:        cfg,
-		RepoRegistry:  repoRegistry,
-		WalletService: walletService,
-		Validator:     validator,
-		Logger:        logger,
+		DB:                   db,
+		RedisClient:          redisClient,
+		Config:               cfg,
+		Keyring:              keyring,
+		RepoRegistry:         repoRegistry,
+		WalletService:        walletService,
+		Validator:            validator,
+		Logger:               logger,
+		MerchantService:      merchantService,
+		PaymentIntentService: paymentIntentService,
+		PocketService:        pocketService,
+		CashbackService:      cashbackService,
+		PromoService:         promoService,
+		EscrowService:        escrowService,
+		InsightService:       insightService,
+		BudgetService:        budgetService,
+		NotificationService:  notificationService,
+		AuthService:          authService,
+		PINService:           pinService,
+		MFAService:           mfaService,
+		AccountService:       accountService,
+		AdminService:         adminService,
+		ApprovalService:      approvalService,
+		APIKeyService:        apiKeyService,
+		DeviceService:        deviceService,
+		KYCService:           kycService,
+		AuditService:         auditService,
+		LedgerService:        ledgerService,
 	}
 }
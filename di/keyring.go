@@ -0,0 +1,44 @@
+package di
+
+import (
+	"digital-wallet/configs"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/signing"
+	"log/slog"
+	"time"
+)
+
+// NewKeyring builds the keyring that signs and verifies access tokens from the JWT settings
+func NewKeyring(cfg *configs.Config) *auth.Keyring {
+	keyring, err := auth.NewKeyring(auth.KeyringConfig{
+		Issuer:             cfg.JWT.Issuer,
+		Audience:           cfg.JWT.Audience,
+		HMACSecret:         cfg.JWT.SigningKey,
+		AcceptHMAC:         cfg.JWT.AcceptHMAC,
+		SigningKeyID:       cfg.JWT.SigningKeyID,
+		SigningKeyFile:     cfg.JWT.PrivateKeyFile,
+		VerificationKeys:   cfg.JWT.VerificationKeys,
+		TrustedIssuer:      cfg.JWT.TrustedIssuer,
+		TrustedJWKS:        cfg.JWT.TrustedJWKS,
+		TrustedJWKSRefresh: time.Duration(cfg.JWT.TrustedJWKSRefresh) * time.Second,
+		Leeway:             time.Duration(cfg.JWT.Leeway) * time.Second,
+	})
+
+	if err != nil {
+		slog.Error("Failed to load JWT keys", "error", err)
+		panic(err)
+	}
+
+	return keyring
+}
+
+// NewLedgerKeyring loads the key that signs ledger checkpoints and the keys verifying them
+func NewLedgerKeyring(cfg *configs.Config) *signing.Keyring {
+	keyring, err := signing.NewKeyring(cfg.Ledger.SigningKeyID, cfg.Ledger.SigningKeyFile, cfg.Ledger.VerificationKeys)
+	if err != nil {
+		slog.Error("Failed to load ledger keys", "error", err)
+		panic(err)
+	}
+
+	return keyring
+}
@@ -0,0 +1,92 @@
+package di
+
+import (
+	"digital-wallet/configs"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/services"
+	"digital-wallet/pkg/notifier"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// newNotifiers registers a notifier for every channel. Channels without a configured
+// gateway write to the notification log file, which is what development uses.
+func newNotifiers(cfg *configs.Config) notifier.Registry {
+	client := &http.Client{Timeout: time.Duration(cfg.Notification.Timeout) * time.Second}
+
+	var logOutput io.Writer
+	logFor := func(channel string) notifier.Notifier {
+		if logOutput == nil {
+			logOutput = openNotificationLog(cfg.Notification.LogFile)
+		}
+		return notifier.NewLogNotifier(channel, logOutput)
+	}
+
+	notifiers := make([]notifier.Notifier, 0, 3)
+
+	if cfg.SMTP.Host != "" {
+		notifiers = append(notifiers, notifier.NewSMTPNotifier(notifier.SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+		}))
+	} else {
+		notifiers = append(notifiers, logFor(notifier.ChannelEmail))
+	}
+
+	if cfg.SMS.GatewayURL != "" {
+		notifiers = append(notifiers, notifier.NewSMSNotifier(notifier.SMSConfig{
+			GatewayURL: cfg.SMS.GatewayURL,
+			APIKey:     cfg.SMS.APIKey,
+			Sender:     cfg.SMS.Sender,
+		}, client))
+	} else {
+		notifiers = append(notifiers, logFor(notifier.ChannelSMS))
+	}
+
+	if cfg.Push.GatewayURL != "" {
+		notifiers = append(notifiers, notifier.NewPushNotifier(notifier.PushConfig{
+			GatewayURL: cfg.Push.GatewayURL,
+			ServerKey:  cfg.Push.ServerKey,
+		}, client))
+	} else {
+		notifiers = append(notifiers, logFor(notifier.ChannelPush))
+	}
+
+	return notifier.NewRegistry(notifiers...)
+}
+
+// newAccountSender logs activation and password reset tokens in development, and delivers
+// them through the notifiers elsewhere
+func newAccountSender(cfg *configs.Config, logger *slog.Logger, notifiers notifier.Registry) interfaces.AccountSender {
+	if cfg.Server.ENV.IsDev() || cfg.Server.ENV.IsLocal() {
+		return services.NewLogAccountSender(logger)
+	}
+	return services.NewNotifierAccountSender(notifiers, cfg.Server.BASEURL)
+}
+
+// openNotificationLog appends to the log file, falling back to stdout when it cannot be opened
+func openNotificationLog(path string) io.Writer {
+	if path == "" {
+		return os.Stdout
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		slog.Warn("Cannot create notification log directory, logging to stdout", "path", path, "error", err)
+		return os.Stdout
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		slog.Warn("Cannot open notification log, logging to stdout", "path", path, "error", err)
+		return os.Stdout
+	}
+
+	return file
+}
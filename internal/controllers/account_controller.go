@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AccountController struct {
+	accountService interfaces.AccountService
+}
+
+func NewAccountController(di *di.Container) *AccountController {
+	return &AccountController{
+		accountService: di.AccountService,
+	}
+}
+
+// Activate is
+func (ac *AccountController) Activate(c echo.Context) error {
+	var req dto.ActivateAccountRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	if err := ac.accountService.Activate(ctx, req); err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Account activated successfully", nil)
+}
+
+// ResendActivation is
+func (ac *AccountController) ResendActivation(c echo.Context) error {
+	var req dto.AccountLookupRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	if err := ac.accountService.ResendActivation(ctx, req); err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "If the account is waiting for activation, a new token has been sent", nil)
+}
+
+// ForgotPassword is
+func (ac *AccountController) ForgotPassword(c echo.Context) error {
+	var req dto.AccountLookupRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	if err := ac.accountService.ForgotPassword(ctx, req); err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "If the account exists, a password reset token has been sent", nil)
+}
+
+// ResetPassword is
+func (ac *AccountController) ResetPassword(c echo.Context) error {
+	var req dto.ResetPasswordRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	if err := ac.accountService.ResetPassword(ctx, req); err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Password reset successfully", nil)
+}
@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	exportDateLayout    = "2006-01-02"
+	defaultExportPeriod = 30 * 24 * time.Hour
+)
+
+type AdminController struct {
+	adminService interfaces.AdminService
+}
+
+func NewAdminController(di *di.Container) *AdminController {
+	return &AdminController{
+		adminService: di.AdminService,
+	}
+}
+
+// SearchUsers is
+func (ac *AdminController) SearchUsers(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := ac.adminService.SearchUsers(ctx, c.QueryParam("q"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Users retrieved successfully", res)
+}
+
+// GetWallet is
+func (ac *AdminController) GetWallet(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	limit := 10
+	offset := 0
+
+	if l := c.QueryParam("limit"); l != "" {
+		fmt.Sscanf(l, "%d", &limit)
+	}
+
+	if o := c.QueryParam("offset"); o != "" {
+		fmt.Sscanf(o, "%d", &offset)
+	}
+
+	res, err := ac.adminService.GetWallet(ctx, c.Param("wallet_id"), limit, offset)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Wallet retrieved successfully", res)
+}
+
+// Adjust is
+func (ac *AdminController) Adjust(c echo.Context) error {
+	var req dto.AdjustmentRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	req.WalletID = c.Param("wallet_id")
+	req.ActorID = auth.GetLoggedInUser(ctx).ID
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := ac.adminService.Adjust(ctx, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.Accepted(c, "Adjustment submitted for approval", res)
+}
+
+// Reverse is
+func (ac *AdminController) Reverse(c echo.Context) error {
+	var req dto.ReversalRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	req.TransactionID = c.Param("transaction_id")
+	req.ActorID = auth.GetLoggedInUser(ctx).ID
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := ac.adminService.Reverse(ctx, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	if res.Approval != nil {
+		return response.Accepted(c, "Reversal submitted for approval", res)
+	}
+
+	return response.Created(c, "Transaction reversed successfully", res)
+}
+
+// UnlockLogin is
+func (ac *AdminController) UnlockLogin(c echo.Context) error {
+	var req dto.UnlockLoginRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	req.UserID = c.Param("user_id")
+	req.ActorID = auth.GetLoggedInUser(ctx).ID
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	if err := ac.adminService.UnlockLogin(ctx, req); err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Login unlocked successfully", nil)
+}
+
+// Freeze is
+func (ac *AdminController) Freeze(c echo.Context) error {
+	return ac.setFrozen(c, true)
+}
+
+// Unfreeze is
+func (ac *AdminController) Unfreeze(c echo.Context) error {
+	return ac.setFrozen(c, false)
+}
+
+func (ac *AdminController) setFrozen(c echo.Context, frozen bool) error {
+	var req dto.FreezeWalletRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	req.WalletID = c.Param("wallet_id")
+	req.ActorID = auth.GetLoggedInUser(ctx).ID
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	if frozen {
+		res, err := ac.adminService.Freeze(ctx, req)
+		if err != nil {
+			return response.GenerateResponseFromIError(err)
+		}
+		return response.OK(c, "Wallet frozen successfully", res)
+	}
+
+	res, err := ac.adminService.Unfreeze(ctx, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+	return response.OK(c, "Wallet unfrozen successfully", res)
+}
+
+// ExportTransactions sends the transactions of a user as a CSV file. from and to are dates and
+// both days are included; the last 30 days are exported when they are left out.
+func (ac *AdminController) ExportTransactions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	to := time.Now()
+	if v := c.QueryParam("to"); v != "" {
+		day, err := time.Parse(exportDateLayout, v)
+		if err != nil {
+			return response.NewValidationError("to must be a date formatted as YYYY-MM-DD")
+		}
+		to = day.AddDate(0, 0, 1)
+	}
+
+	from := to.Add(-defaultExportPeriod)
+	if v := c.QueryParam("from"); v != "" {
+		day, err := time.Parse(exportDateLayout, v)
+		if err != nil {
+			return response.NewValidationError("from must be a date formatted as YYYY-MM-DD")
+		}
+		from = day
+	}
+
+	export, err := ac.adminService.ExportTransactions(ctx, dto.ExportTransactionsRequest{
+		UserID:  c.Param("user_id"),
+		ActorID: auth.GetLoggedInUser(ctx).ID,
+		From:    from,
+		To:      to,
+		Reason:  c.QueryParam("reason"),
+	})
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", export.FileName))
+	return c.Blob(http.StatusOK, "text/csv", export.Content)
+}
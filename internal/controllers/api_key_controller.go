@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+)
+
+type APIKeyController struct {
+	apiKeyService interfaces.APIKeyService
+}
+
+func NewAPIKeyController(di *di.Container) *APIKeyController {
+	return &APIKeyController{
+		apiKeyService: di.APIKeyService,
+	}
+}
+
+// Create is
+func (ac *APIKeyController) Create(c echo.Context) error {
+	var req dto.CreateAPIKeyRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	req.CreatedBy = auth.GetLoggedInUser(ctx).ID
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := ac.apiKeyService.Create(ctx, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.Created(c, "API key created successfully, store it now as it is not shown again", res)
+}
+
+// List is
+func (ac *APIKeyController) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	limit := 10
+	offset := 0
+
+	if l := c.QueryParam("limit"); l != "" {
+		fmt.Sscanf(l, "%d", &limit)
+	}
+
+	if o := c.QueryParam("offset"); o != "" {
+		fmt.Sscanf(o, "%d", &offset)
+	}
+
+	res, err := ac.apiKeyService.List(ctx, limit, offset)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "API keys retrieved successfully", res)
+}
+
+// Rotate is
+func (ac *APIKeyController) Rotate(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := ac.apiKeyService.Rotate(ctx, c.Param("id"), auth.GetLoggedInUser(ctx).ID)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.Created(c, "API key rotated successfully, store it now as it is not shown again", res)
+}
+
+// Revoke is
+func (ac *APIKeyController) Revoke(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := ac.apiKeyService.Revoke(ctx, c.Param("id"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "API key revoked successfully", res)
+}
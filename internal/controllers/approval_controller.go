@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ApprovalController struct {
+	approvalService interfaces.ApprovalService
+}
+
+func NewApprovalController(di *di.Container) *ApprovalController {
+	return &ApprovalController{
+		approvalService: di.ApprovalService,
+	}
+}
+
+// List is
+func (ac *ApprovalController) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	limit := 10
+	offset := 0
+
+	if l := c.QueryParam("limit"); l != "" {
+		fmt.Sscanf(l, "%d", &limit)
+	}
+
+	if o := c.QueryParam("offset"); o != "" {
+		fmt.Sscanf(o, "%d", &offset)
+	}
+
+	res, err := ac.approvalService.List(ctx, c.QueryParam("status"), limit, offset)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Approval requests retrieved successfully", res)
+}
+
+// Get is
+func (ac *ApprovalController) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := ac.approvalService.Get(ctx, c.Param("id"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Approval request retrieved successfully", res)
+}
+
+// Approve is
+func (ac *ApprovalController) Approve(c echo.Context) error {
+	req, err := ac.bindDecision(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := ac.approvalService.Approve(c.Request().Context(), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Approval request approved successfully", res)
+}
+
+// Reject is
+func (ac *ApprovalController) Reject(c echo.Context) error {
+	req, err := ac.bindDecision(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := ac.approvalService.Reject(c.Request().Context(), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Approval request rejected successfully", res)
+}
+
+func (ac *ApprovalController) bindDecision(c echo.Context) (dto.ApprovalDecisionRequest, error) {
+	var req dto.ApprovalDecisionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return req, response.ErrBadRequest(err)
+	}
+
+	req.ApprovalID = c.Param("id")
+	req.CheckerID = auth.GetLoggedInUser(c.Request().Context()).ID
+
+	if err := c.Validate(&req); err != nil {
+		return req, response.NewValidationError(err.Error())
+	}
+
+	return req, nil
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/response"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuditController struct {
+	auditService interfaces.AuditService
+}
+
+func NewAuditController(di *di.Container) *AuditController {
+	return &AuditController{
+		auditService: di.AuditService,
+	}
+}
+
+// List returns the audit events matching the query parameters, newest first. from and to are
+// dates and both days are included.
+func (ac *AuditController) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	limit := 10
+	offset := 0
+
+	if l := c.QueryParam("limit"); l != "" {
+		fmt.Sscanf(l, "%d", &limit)
+	}
+
+	if o := c.QueryParam("offset"); o != "" {
+		fmt.Sscanf(o, "%d", &offset)
+	}
+
+	query := dto.AuditEventQuery{
+		ActorID:       c.QueryParam("actor_id"),
+		PrincipalType: c.QueryParam("principal_type"),
+		Action:        c.QueryParam("action"),
+		ResourceType:  c.QueryParam("resource_type"),
+		ResourceID:    c.QueryParam("resource_id"),
+		RequestID:     c.QueryParam("request_id"),
+	}
+
+	if v := c.QueryParam("from"); v != "" {
+		day, err := time.Parse(exportDateLayout, v)
+		if err != nil {
+			return response.NewValidationError("from must be a date formatted as YYYY-MM-DD")
+		}
+		query.From = day
+	}
+
+	if v := c.QueryParam("to"); v != "" {
+		day, err := time.Parse(exportDateLayout, v)
+		if err != nil {
+			return response.NewValidationError("to must be a date formatted as YYYY-MM-DD")
+		}
+		query.To = day.AddDate(0, 0, 1)
+	}
+
+	res, err := ac.auditService.List(ctx, query, limit, offset)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Audit events retrieved successfully", res)
+}
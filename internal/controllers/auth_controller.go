@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuthController struct {
+	authService interfaces.AuthService
+	keyring     *auth.Keyring
+}
+
+func NewAuthController(di *di.Container) *AuthController {
+	return &AuthController{
+		authService: di.AuthService,
+		keyring:     di.Keyring,
+	}
+}
+
+// Register is
+func (ac *AuthController) Register(c echo.Context) error {
+	var req dto.RegisterRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	readDeviceInfo(c, &req.DeviceInfo)
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := ac.authService.Register(ctx, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.Created(c, "User registered successfully, activate the account to log in", res)
+}
+
+// Login is
+func (ac *AuthController) Login(c echo.Context) error {
+	var req dto.LoginRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	readDeviceInfo(c, &req.DeviceInfo)
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := ac.authService.Login(ctx, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	if res.MFARequired {
+		return response.OK(c, "Two-factor verification required", res)
+	}
+
+	return response.OK(c, "Logged in successfully", res)
+}
+
+// VerifyMFA is
+func (ac *AuthController) VerifyMFA(c echo.Context) error {
+	var req dto.VerifyMFARequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	readDeviceInfo(c, &req.DeviceInfo)
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := ac.authService.VerifyMFA(ctx, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Logged in successfully", res)
+}
+
+// Refresh is
+func (ac *AuthController) Refresh(c echo.Context) error {
+	var req dto.RefreshTokenRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := ac.authService.Refresh(ctx, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Token refreshed successfully", res)
+}
+
+// Logout is
+func (ac *AuthController) Logout(c echo.Context) error {
+	var req dto.RefreshTokenRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	if err := ac.authService.Logout(ctx, req); err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Logged out successfully", nil)
+}
+
+// ListSessions is
+func (ac *AuthController) ListSessions(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := auth.GetLoggedInUser(ctx)
+
+	res, err := ac.authService.ListSessions(ctx, user.ID, user.SessionID)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Sessions retrieved successfully", res)
+}
+
+// RevokeSession is
+func (ac *AuthController) RevokeSession(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := auth.GetLoggedInUser(ctx)
+
+	if err := ac.authService.RevokeSession(ctx, user.ID, c.Param("session_id")); err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Session revoked successfully", nil)
+}
+
+// RevokeAllSessions is
+func (ac *AuthController) RevokeAllSessions(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := auth.GetLoggedInUser(ctx)
+
+	res, err := ac.authService.RevokeAllSessions(ctx, user.ID)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Sessions revoked successfully", res)
+}
+
+// JWKS is the public JSON Web Key Set other services verify our access tokens with. It is
+// served as a plain JWKS document rather than in the response envelope.
+func (ac *AuthController) JWKS(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(http.StatusOK, ac.keyring.JWKS())
+}
+
+// readDeviceInfo completes the device a session is started from with the X-Device-ID header,
+// when the body names no device, and the user agent and IP address of the request
+func readDeviceInfo(c echo.Context, device *dto.DeviceInfo) {
+	if device.DeviceID == "" {
+		device.DeviceID = c.Request().Header.Get(dto.HeaderDeviceID)
+	}
+	device.UserAgent = c.Request().UserAgent()
+	device.IPAddress = c.RealIP()
+}
@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+type BudgetController struct {
+	budgetService interfaces.BudgetService
+}
+
+func NewBudgetController(di *di.Container) *BudgetController {
+	return &BudgetController{
+		budgetService: di.BudgetService,
+	}
+}
+
+// CreateBudget is
+func (bc *BudgetController) CreateBudget(c echo.Context) error {
+	var req dto.CreateBudgetRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := bc.budgetService.CreateBudget(ctx, walletUserID(c), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.Created(c, "Budget created successfully", res)
+}
+
+// ListBudgets is
+func (bc *BudgetController) ListBudgets(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := bc.budgetService.ListBudgets(ctx, walletUserID(c))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Budgets retrieved successfully", res)
+}
+
+// UpdateBudget is
+func (bc *BudgetController) UpdateBudget(c echo.Context) error {
+	var req dto.UpdateBudgetRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := bc.budgetService.UpdateBudget(ctx, walletUserID(c), c.Param("budget_id"), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Budget updated successfully", res)
+}
+
+// DeleteBudget is
+func (bc *BudgetController) DeleteBudget(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if err := bc.budgetService.DeleteBudget(ctx, walletUserID(c), c.Param("budget_id")); err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Budget deleted successfully", nil)
+}
+
+// GetBudgetStatus is
+func (bc *BudgetController) GetBudgetStatus(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := bc.budgetService.GetStatus(ctx, walletUserID(c), c.QueryParam("month"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Budget status retrieved successfully", res)
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CashbackController struct {
+	cashbackService interfaces.CashbackService
+}
+
+func NewCashbackController(di *di.Container) *CashbackController {
+	return &CashbackController{
+		cashbackService: di.CashbackService,
+	}
+}
+
+// CreateCampaign is
+func (cc *CashbackController) CreateCampaign(c echo.Context) error {
+	var req dto.CreateCashbackCampaignRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	req.CreatedBy = auth.GetLoggedInUser(ctx).ID
+
+	res, err := cc.cashbackService.CreateCampaign(ctx, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.Created(c, "Cashback campaign created successfully", res)
+}
+
+// ListCampaigns is
+func (cc *CashbackController) ListCampaigns(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	limit := 10
+	offset := 0
+
+	if l := c.QueryParam("limit"); l != "" {
+		fmt.Sscanf(l, "%d", &limit)
+	}
+
+	if o := c.QueryParam("offset"); o != "" {
+		fmt.Sscanf(o, "%d", &offset)
+	}
+
+	campaigns, total, err := cc.cashbackService.ListCampaigns(ctx, limit, offset)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	res := dto.PaginatedCashbackCampaignResponse{
+		Data: campaigns,
+		Meta: dto.PaginationMeta{
+			Total:  total,
+			Limit:  limit,
+			Offset: offset,
+		},
+	}
+
+	return response.OK(c, "Cashback campaigns retrieved successfully", res)
+}
+
+// DeactivateCampaign is
+func (cc *CashbackController) DeactivateCampaign(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := cc.cashbackService.DeactivateCampaign(ctx, c.Param("id"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Cashback campaign deactivated successfully", res)
+}
+
+// GetCampaignReport is
+func (cc *CashbackController) GetCampaignReport(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := cc.cashbackService.GetCampaignReport(ctx, c.Param("id"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Cashback campaign report retrieved successfully", res)
+}
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DeviceController struct {
+	deviceService interfaces.DeviceService
+}
+
+func NewDeviceController(di *di.Container) *DeviceController {
+	return &DeviceController{
+		deviceService: di.DeviceService,
+	}
+}
+
+// List is
+func (dc *DeviceController) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := dc.deviceService.List(ctx, auth.GetLoggedInUser(ctx).ID, c.Request().Header.Get(dto.HeaderDeviceID))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Devices retrieved successfully", res)
+}
+
+// Remove is
+func (dc *DeviceController) Remove(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := dc.deviceService.Remove(ctx, auth.GetLoggedInUser(ctx).ID, c.Param("device_id"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Device removed successfully", res)
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EscrowController struct {
+	escrowService interfaces.EscrowService
+}
+
+func NewEscrowController(di *di.Container) *EscrowController {
+	return &EscrowController{
+		escrowService: di.EscrowService,
+	}
+}
+
+// CreateEscrow is
+func (ec *EscrowController) CreateEscrow(c echo.Context) error {
+	var req dto.CreateEscrowRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	if c.Param("user_id") == "" {
+		req.SessionID = auth.GetLoggedInUser(ctx).SessionID
+	}
+
+	res, err := ec.escrowService.CreateEscrow(ctx, walletUserID(c), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	if res.Challenge != nil {
+		return response.Accepted(c, "Escrow funding requires verification", res.Challenge)
+	}
+
+	return response.Created(c, "Escrow funded successfully", res)
+}
+
+// GetEscrow is
+func (ec *EscrowController) GetEscrow(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := ec.escrowService.GetEscrow(ctx, walletUserID(c), c.Param("id"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Escrow retrieved successfully", res)
+}
+
+// Release is
+func (ec *EscrowController) Release(c echo.Context) error {
+	var req dto.EscrowActionRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := ec.escrowService.Release(ctx, walletUserID(c), c.Param("id"), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Escrow released successfully", res)
+}
+
+// Refund is
+func (ec *EscrowController) Refund(c echo.Context) error {
+	var req dto.EscrowActionRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := ec.escrowService.Refund(ctx, walletUserID(c), c.Param("id"), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Escrow refunded successfully", res)
+}
@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/response"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+)
+
+type InsightController struct {
+	insightService interfaces.InsightService
+}
+
+func NewInsightController(di *di.Container) *InsightController {
+	return &InsightController{
+		insightService: di.InsightService,
+	}
+}
+
+// GetInsights is
+func (ic *InsightController) GetInsights(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	months := 3
+	if m := c.QueryParam("months"); m != "" {
+		fmt.Sscanf(m, "%d", &months)
+	}
+
+	res, err := ic.insightService.GetInsights(ctx, walletUserID(c), c.QueryParam("month"), months)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Insights retrieved successfully", res)
+}
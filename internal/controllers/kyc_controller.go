@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type KYCController struct {
+	kycService interfaces.KYCService
+}
+
+func NewKYCController(di *di.Container) *KYCController {
+	return &KYCController{
+		kycService: di.KYCService,
+	}
+}
+
+// GetStatus is
+func (kc *KYCController) GetStatus(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := kc.kycService.GetStatus(ctx, auth.GetLoggedInUser(ctx).ID)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "KYC status retrieved successfully", res)
+}
+
+// Submit is
+func (kc *KYCController) Submit(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.KYCSubmitRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	file, err := c.FormFile("document")
+	if err != nil {
+		return response.NewValidationError("document is required")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return response.ErrBadRequest(err)
+	}
+	defer src.Close()
+
+	req.UserID = auth.GetLoggedInUser(ctx).ID
+	req.FileName = file.Filename
+	req.Size = file.Size
+	req.Content = src
+
+	res, err := kc.kycService.Submit(ctx, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.Created(c, "KYC submission created successfully", res)
+}
+
+// List is
+func (kc *KYCController) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	limit := 10
+	offset := 0
+
+	if l := c.QueryParam("limit"); l != "" {
+		fmt.Sscanf(l, "%d", &limit)
+	}
+
+	if o := c.QueryParam("offset"); o != "" {
+		fmt.Sscanf(o, "%d", &offset)
+	}
+
+	res, err := kc.kycService.List(ctx, c.QueryParam("status"), limit, offset)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "KYC submissions retrieved successfully", res)
+}
+
+// Get is
+func (kc *KYCController) Get(c echo.Context) error {
+	res, err := kc.kycService.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "KYC submission retrieved successfully", res)
+}
+
+// Document is
+func (kc *KYCController) Document(c echo.Context) error {
+	doc, err := kc.kycService.OpenDocument(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+	defer doc.Content.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", doc.FileName))
+	return c.Stream(http.StatusOK, doc.ContentType, doc.Content)
+}
+
+// Approve is
+func (kc *KYCController) Approve(c echo.Context) error {
+	req, err := kc.bindReview(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := kc.kycService.Approve(c.Request().Context(), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "KYC submission approved successfully", res)
+}
+
+// Reject is
+func (kc *KYCController) Reject(c echo.Context) error {
+	req, err := kc.bindReview(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := kc.kycService.Reject(c.Request().Context(), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "KYC submission rejected successfully", res)
+}
+
+func (kc *KYCController) bindReview(c echo.Context) (dto.KYCReviewRequest, error) {
+	var req dto.KYCReviewRequest
+
+	if err := c.Bind(&req); err != nil {
+		return req, response.ErrBadRequest(err)
+	}
+
+	req.SubmissionID = c.Param("id")
+	req.ReviewerID = auth.GetLoggedInUser(c.Request().Context()).ID
+
+	if err := c.Validate(&req); err != nil {
+		return req, response.NewValidationError(err.Error())
+	}
+
+	return req, nil
+}
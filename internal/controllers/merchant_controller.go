@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MerchantController struct {
+	merchantService interfaces.MerchantService
+}
+
+func NewMerchantController(di *di.Container) *MerchantController {
+	return &MerchantController{
+		merchantService: di.MerchantService,
+	}
+}
+
+// CreateMerchant is
+func (mc *MerchantController) CreateMerchant(c echo.Context) error {
+	var req dto.CreateMerchantRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+	req.UserID = walletUserID(c)
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := mc.merchantService.CreateMerchant(ctx, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.Created(c, "Merchant created successfully", res)
+}
+
+// GetMerchant is
+func (mc *MerchantController) GetMerchant(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := mc.merchantService.GetMerchant(ctx, auth.GetLoggedInUser(ctx).ID, c.Param("id"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Merchant retrieved successfully", res)
+}
+
+// UpdateMerchant is
+func (mc *MerchantController) UpdateMerchant(c echo.Context) error {
+	var req dto.UpdateMerchantRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := mc.merchantService.UpdateMerchant(ctx, auth.GetLoggedInUser(ctx).ID, c.Param("id"), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Merchant updated successfully", res)
+}
+
+// RotateAPIKey is
+func (mc *MerchantController) RotateAPIKey(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := mc.merchantService.RotateAPIKey(ctx, auth.GetLoggedInUser(ctx).ID, c.Param("id"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Merchant API key rotated successfully", res)
+}
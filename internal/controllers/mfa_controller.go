@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MFAController struct {
+	mfaService interfaces.MFAService
+}
+
+func NewMFAController(di *di.Container) *MFAController {
+	return &MFAController{
+		mfaService: di.MFAService,
+	}
+}
+
+// GetStatus is
+func (mc *MFAController) GetStatus(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := mc.mfaService.GetStatus(ctx, auth.GetLoggedInUser(ctx).ID)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Two-factor authentication retrieved successfully", res)
+}
+
+// Enroll is
+func (mc *MFAController) Enroll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := mc.mfaService.Enroll(ctx, auth.GetLoggedInUser(ctx).ID)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.Created(c, "Two-factor enrollment started", res)
+}
+
+// ConfirmEnrollment is
+func (mc *MFAController) ConfirmEnrollment(c echo.Context) error {
+	var req dto.MFACodeRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := mc.mfaService.ConfirmEnrollment(ctx, auth.GetLoggedInUser(ctx).ID, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Two-factor authentication enabled successfully", res)
+}
+
+// RegenerateRecoveryCodes is
+func (mc *MFAController) RegenerateRecoveryCodes(c echo.Context) error {
+	var req dto.MFACodeRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := mc.mfaService.RegenerateRecoveryCodes(ctx, auth.GetLoggedInUser(ctx).ID, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Recovery codes regenerated successfully", res)
+}
+
+// UpdateSettings is
+func (mc *MFAController) UpdateSettings(c echo.Context) error {
+	var req dto.UpdateMFASettingsRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := mc.mfaService.UpdateSettings(ctx, auth.GetLoggedInUser(ctx).ID, req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Two-factor settings updated successfully", res)
+}
+
+// Disable is
+func (mc *MFAController) Disable(c echo.Context) error {
+	var req dto.MFACodeRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	if err := mc.mfaService.Disable(ctx, auth.GetLoggedInUser(ctx).ID, req); err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Two-factor authentication disabled successfully", nil)
+}
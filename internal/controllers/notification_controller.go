@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationController struct {
+	notificationService interfaces.NotificationService
+}
+
+func NewNotificationController(di *di.Container) *NotificationController {
+	return &NotificationController{
+		notificationService: di.NotificationService,
+	}
+}
+
+// GetPreferences is
+func (nc *NotificationController) GetPreferences(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := nc.notificationService.GetPreferences(ctx, walletUserID(c))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Notification preferences retrieved successfully", res)
+}
+
+// UpdatePreferences is
+func (nc *NotificationController) UpdatePreferences(c echo.Context) error {
+	var req dto.UpdateNotificationPreferencesRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := nc.notificationService.UpdatePreferences(ctx, walletUserID(c), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Notification preferences updated successfully", res)
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PaymentIntentController struct {
+	paymentIntentService interfaces.PaymentIntentService
+}
+
+func NewPaymentIntentController(di *di.Container) *PaymentIntentController {
+	return &PaymentIntentController{
+		paymentIntentService: di.PaymentIntentService,
+	}
+}
+
+// CreateIntent is
+func (pc *PaymentIntentController) CreateIntent(c echo.Context) error {
+	var req dto.CreatePaymentIntentRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := pc.paymentIntentService.CreateIntent(ctx, auth.GetMerchantID(ctx), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.Created(c, "Payment intent created successfully", res)
+}
+
+// GetIntent is
+func (pc *PaymentIntentController) GetIntent(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := pc.paymentIntentService.GetIntent(ctx, auth.GetMerchantID(ctx), c.Param("id"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Payment intent retrieved successfully", res)
+}
+
+// CancelIntent is
+func (pc *PaymentIntentController) CancelIntent(c echo.Context) error {
+	var req dto.CancelPaymentIntentRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	res, err := pc.paymentIntentService.CancelIntent(ctx, auth.GetMerchantID(ctx), c.Param("id"), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Payment intent canceled successfully", res)
+}
+
+// GetCheckout is
+func (pc *PaymentIntentController) GetCheckout(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := pc.paymentIntentService.GetCheckout(ctx, c.Param("id"))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Checkout retrieved successfully", res)
+}
+
+// ConfirmIntent is
+func (pc *PaymentIntentController) ConfirmIntent(c echo.Context) error {
+	var req dto.ConfirmPaymentIntentRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	if c.Param("user_id") == "" {
+		req.SessionID = auth.GetLoggedInUser(ctx).SessionID
+	}
+
+	res, err := pc.paymentIntentService.ConfirmIntent(ctx, walletUserID(c), c.Param("id"), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	if res.Challenge != nil {
+		return response.Accepted(c, "Payment requires verification", res.Challenge)
+	}
+
+	return response.OK(c, "Payment completed successfully", res)
+}
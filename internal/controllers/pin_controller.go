@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PINController struct {
+	pinService interfaces.PINService
+}
+
+func NewPINController(di *di.Container) *PINController {
+	return &PINController{
+		pinService: di.PINService,
+	}
+}
+
+// SetupPIN is
+func (pc *PINController) SetupPIN(c echo.Context) error {
+	var req dto.SetupPINRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	if err := pc.pinService.SetupPIN(ctx, auth.GetLoggedInUser(ctx).ID, req); err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.Created(c, "PIN set successfully", nil)
+}
+
+// ChangePIN is
+func (pc *PINController) ChangePIN(c echo.Context) error {
+	var req dto.ChangePINRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	if err := pc.pinService.ChangePIN(ctx, auth.GetLoggedInUser(ctx).ID, req); err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "PIN changed successfully", nil)
+}
+
+// ResetPIN is
+func (pc *PINController) ResetPIN(c echo.Context) error {
+	var req dto.ResetPINRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	if err := pc.pinService.ResetPIN(ctx, auth.GetLoggedInUser(ctx).ID, req); err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "PIN reset successfully", nil)
+}
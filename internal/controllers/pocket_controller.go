@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PocketController struct {
+	pocketService interfaces.PocketService
+}
+
+func NewPocketController(di *di.Container) *PocketController {
+	return &PocketController{
+		pocketService: di.PocketService,
+	}
+}
+
+// CreatePocket is
+func (pc *PocketController) CreatePocket(c echo.Context) error {
+	var req dto.CreatePocketRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := pc.pocketService.CreatePocket(ctx, walletUserID(c), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.Created(c, "Pocket created successfully", res)
+}
+
+// ListPockets is
+func (pc *PocketController) ListPockets(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	res, err := pc.pocketService.ListPockets(ctx, walletUserID(c))
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Pockets retrieved successfully", res)
+}
+
+// UpdatePocket is
+func (pc *PocketController) UpdatePocket(c echo.Context) error {
+	var req dto.UpdatePocketRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := pc.pocketService.UpdatePocket(ctx, walletUserID(c), c.Param("pocket_id"), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Pocket updated successfully", res)
+}
+
+// DeletePocket is
+func (pc *PocketController) DeletePocket(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if err := pc.pocketService.DeletePocket(ctx, walletUserID(c), c.Param("pocket_id")); err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Pocket deleted successfully", nil)
+}
+
+// Transfer is
+func (pc *PocketController) Transfer(c echo.Context) error {
+	var req dto.PocketTransferRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := pc.pocketService.Transfer(ctx, walletUserID(c), c.Param("pocket_id"), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Pocket transfer processed successfully", res)
+}
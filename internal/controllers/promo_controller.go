@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PromoController struct {
+	promoService interfaces.PromoService
+}
+
+func NewPromoController(di *di.Container) *PromoController {
+	return &PromoController{
+		promoService: di.PromoService,
+	}
+}
+
+// Redeem is
+func (pc *PromoController) Redeem(c echo.Context) error {
+	var req dto.RedeemPromoRequest
+	ctx := c.Request().Context()
+
+	if err := c.Bind(&req); err != nil {
+		return response.ErrBadRequest(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.NewValidationError(err.Error())
+	}
+
+	res, err := pc.promoService.Redeem(ctx, walletUserID(c), req)
+	if err != nil {
+		return response.GenerateResponseFromIError(err)
+	}
+
+	return response.OK(c, "Promo code redeemed successfully", res)
+}
@@ -4,6 +4,7 @@ import (
 	"digital-wallet/di"
 	"digital-wallet/internal/dto"
 	"digital-wallet/internal/interfaces"
+	"digital-wallet/pkg/auth"
 	"digital-wallet/pkg/response"
 	"fmt"
 
@@ -20,10 +21,20 @@ func NewWalletController(di *di.Container) *WalletController {
 	}
 }
 
+// walletUserID returns the user whose wallet a request acts on: the :user_id path parameter
+// on the /v1/wallet routes, which need a wallet permission to reach, and the
+// authenticated user on the /v1/me/wallet, merchant, checkout, promo and escrow routes
+func walletUserID(c echo.Context) string {
+	if userID := c.Param("user_id"); userID != "" {
+		return userID
+	}
+	return auth.GetLoggedInUser(c.Request().Context()).ID
+}
+
 // GetBalance is
 func (wc *WalletController) GetBalance(c echo.Context) error {
 	ctx := c.Request().Context()
-	userID := c.Param("user_id")
+	userID := walletUserID(c)
 
 	res, err := wc.walletService.GetBalance(ctx, userID)
 	if err != nil {
@@ -35,6 +46,17 @@ func (wc *WalletController) GetBalance(c echo.Context) error {
 
 // Withdraw is
 func (wc *WalletController) Withdraw(c echo.Context) error {
+	return wc.withdraw(c, "")
+}
+
+// WithdrawOwn is
+func (wc *WalletController) WithdrawOwn(c echo.Context) error {
+	return wc.withdraw(c, auth.GetLoggedInUser(c.Request().Context()).ID)
+}
+
+// withdraw processes a withdrawal from the wallet of userID, or of the user named in the
+// request body when userID is empty
+func (wc *WalletController) withdraw(c echo.Context, userID string) error {
 	var req dto.WithdrawRequest
 	ctx := c.Request().Context()
 
@@ -43,6 +65,11 @@ func (wc *WalletController) Withdraw(c echo.Context) error {
 		return response.ErrBadRequest(err)
 	}
 
+	if userID != "" {
+		req.UserID = userID
+		req.SessionID = auth.GetLoggedInUser(ctx).SessionID
+	}
+
 	// Validate request
 	if err := c.Validate(&req); err != nil {
 		return response.NewValidationError(err.Error())
@@ -54,13 +81,17 @@ func (wc *WalletController) Withdraw(c echo.Context) error {
 		return response.GenerateResponseFromIError(err)
 	}
 
+	if res.Status == dto.WithdrawStatusChallengeRequired {
+		return response.Accepted(c, "Withdrawal requires verification", res.Challenge)
+	}
+
 	return response.OK(c, "Withdrawal processed successfully", res)
 }
 
 // GetTransactionHistory is
 func (wc *WalletController) GetTransactionHistory(c echo.Context) error {
 	ctx := c.Request().Context()
-	userID := c.Param("user_id")
+	userID := walletUserID(c)
 
 	limit := 10
 	offset := 0
@@ -85,6 +116,8 @@ func (wc *WalletController) GetTransactionHistory(c echo.Context) error {
 			ID:          tx.ID,
 			Amount:      tx.Amount,
 			Type:        tx.Type,
+			Direction:   tx.Direction,
+			Category:    tx.Category,
 			Status:      tx.Status,
 			Description: tx.Description,
 			CreatedAt:   tx.CreatedAt.String(),
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -12,14 +13,21 @@ import (
 	"digital-wallet/internal/dto"
 	"digital-wallet/internal/mocks"
 	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
 	"digital-wallet/pkg/response"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+func withLoggedInUser(ctx context.Context, userID string) context.Context {
+	token := &jwt.Token{Claims: jwt.MapClaims{"user_id": userID, "type": auth.TokenTypeAccess}}
+	return context.WithValue(ctx, auth.ContextKeyUser, token)
+}
+
 func TestWalletController_GetBalance(t *testing.T) {
 	e := echo.New()
 
@@ -55,6 +63,21 @@ func TestWalletController_GetBalance(t *testing.T) {
 		assert.Equal(t, 1000.0, data["balance"])
 	})
 
+	t.Run("own balance uses the authenticated user", func(t *testing.T) {
+		mockSvc := mocks.NewWalletService(t)
+		wc := NewWalletController(&di.Container{WalletService: mockSvc})
+
+		mockSvc.On("GetBalance", mock.Anything, "user-789").Return(&dto.BalanceResponse{WalletID: "wallet-2"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/me/wallet/balance", nil)
+		req = req.WithContext(withLoggedInUser(req.Context(), "user-789"))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, wc.GetBalance(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
 	t.Run("service error", func(t *testing.T) {
 		mockSvc := mocks.NewWalletService(t)
 		container := &di.Container{WalletService: mockSvc}
@@ -85,7 +108,7 @@ func TestWalletController_Withdraw(t *testing.T) {
 		e.Validator = container.Validator
 
 		userID := "user-123"
-		reqBody := `{"user_id": "user-123", "amount": 500.0, "description": "test"}`
+		reqBody := `{"user_id": "user-123", "amount": 500.0, "description": "test", "pin": "123456"}`
 		mockSvc.On("Withdraw", mock.Anything, mock.MatchedBy(func(r dto.WithdrawRequest) bool {
 			return r.UserID == userID && r.Amount == 500.0
 		})).Return(&dto.WithdrawResponse{
@@ -105,6 +128,84 @@ func TestWalletController_Withdraw(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	})
 
+	t.Run("own withdrawal ignores the user in the body", func(t *testing.T) {
+		mockSvc := mocks.NewWalletService(t)
+		container := &di.Container{WalletService: mockSvc, Validator: di.NewCustomValidator()}
+		wc := NewWalletController(container)
+		e.Validator = container.Validator
+
+		mockSvc.On("Withdraw", mock.Anything, mock.MatchedBy(func(r dto.WithdrawRequest) bool {
+			return r.UserID == "user-123"
+		})).Return(&dto.WithdrawResponse{TransactionID: "tx-1", Status: "COMPLETED"}, nil)
+
+		reqBody := `{"user_id": "someone-else", "amount": 500.0, "pin": "123456"}`
+		req := httptest.NewRequest(http.MethodPost, "/v1/me/wallet/withdraw", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req = req.WithContext(withLoggedInUser(req.Context(), "user-123"))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, wc.WithdrawOwn(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("challenged withdrawal answers 202 with the challenge", func(t *testing.T) {
+		mockSvc := mocks.NewWalletService(t)
+		container := &di.Container{WalletService: mockSvc, Validator: di.NewCustomValidator()}
+		wc := NewWalletController(container)
+		e.Validator = container.Validator
+
+		mockSvc.On("Withdraw", mock.Anything, mock.Anything).Return(&dto.WithdrawResponse{
+			Status:    dto.WithdrawStatusChallengeRequired,
+			Challenge: &dto.StepUpChallenge{ChallengeID: "challenge-1", Reasons: []string{"large_amount"}},
+		}, nil)
+
+		reqBody := `{"amount": 9000000, "pin": "123456"}`
+		req := httptest.NewRequest(http.MethodPost, "/v1/me/wallet/withdraw", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req = req.WithContext(withLoggedInUser(req.Context(), "user-123"))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, wc.WithdrawOwn(c))
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"challenge_id":"challenge-1"`)
+	})
+
+	t.Run("challenge ID needs its code", func(t *testing.T) {
+		mockSvc := mocks.NewWalletService(t)
+		container := &di.Container{WalletService: mockSvc, Validator: di.NewCustomValidator()}
+		wc := NewWalletController(container)
+		e.Validator = container.Validator
+
+		reqBody := `{"amount": 9000000, "pin": "123456", "challenge_id": "6f1c2a52-8a8e-4d4e-9a57-3b4cf2d7c111"}`
+		req := httptest.NewRequest(http.MethodPost, "/v1/me/wallet/withdraw", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req = req.WithContext(withLoggedInUser(req.Context(), "user-123"))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := wc.WithdrawOwn(c)
+		require.Error(t, err)
+		mockSvc.AssertNotCalled(t, "Withdraw", mock.Anything, mock.Anything)
+	})
+
+	t.Run("PIN must be 6 digits", func(t *testing.T) {
+		mockSvc := mocks.NewWalletService(t)
+		container := &di.Container{WalletService: mockSvc, Validator: di.NewCustomValidator()}
+		wc := NewWalletController(container)
+		e.Validator = container.Validator
+
+		reqBody := `{"user_id": "user-123", "amount": 500.0, "pin": "12a456"}`
+		req := httptest.NewRequest(http.MethodPost, "/withdraw", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.Error(t, wc.Withdraw(c))
+		mockSvc.AssertNotCalled(t, "Withdraw", mock.Anything, mock.Anything)
+	})
+
 	t.Run("bind error", func(t *testing.T) {
 		mockSvc := mocks.NewWalletService(t)
 		container := &di.Container{WalletService: mockSvc}
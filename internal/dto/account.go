@@ -0,0 +1,15 @@
+package dto
+
+// AccountLookupRequest names the account an activation or password reset token is sent for
+type AccountLookupRequest struct {
+	PhoneNumber string `json:"phone_number" validate:"required"`
+}
+
+type ActivateAccountRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=6"`
+}
@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"digital-wallet/internal/models"
+	"time"
+)
+
+// AdminUserResult is a user found by the back-office search, with their wallet if they have one
+type AdminUserResult struct {
+	User   *models.User   `json:"user"`
+	Wallet *models.Wallet `json:"wallet"`
+}
+
+// AdminWalletResponse is a wallet with its owner, a page of its transactions and its latest
+// back-office actions, newest first
+type AdminWalletResponse struct {
+	Wallet       *models.Wallet             `json:"wallet"`
+	User         *models.User               `json:"user"`
+	Transactions []models.WalletTransaction `json:"transactions"`
+	Meta         PaginationMeta             `json:"meta"`
+	Actions      []models.AdminAction       `json:"actions"`
+}
+
+// AdjustmentRequest is a manual credit or debit of a wallet
+type AdjustmentRequest struct {
+	WalletID  string  `json:"-"`
+	ActorID   string  `json:"-"`
+	Direction string  `json:"direction" validate:"required,oneof=CREDIT DEBIT"`
+	Amount    float64 `json:"amount" validate:"required,gt=0"`
+	Reason    string  `json:"reason" validate:"required,max=255"`
+}
+
+// FreezeWalletRequest freezes or unfreezes a wallet
+type FreezeWalletRequest struct {
+	WalletID string `json:"-"`
+	ActorID  string `json:"-"`
+	Reason   string `json:"reason" validate:"required,max=255"`
+}
+
+// UnlockLoginRequest lifts the login lockout of a user
+type UnlockLoginRequest struct {
+	UserID  string `json:"-"`
+	ActorID string `json:"-"`
+	Reason  string `json:"reason" validate:"required,max=255"`
+}
+
+// ReversalRequest reverses a settled transaction
+type ReversalRequest struct {
+	TransactionID string `json:"-"`
+	ActorID       string `json:"-"`
+	Reason        string `json:"reason" validate:"required,max=255"`
+}
+
+// AdminActionResponse is the wallet after a back-office action and the record of that action.
+// When the action needs a second person's approval only Approval is set.
+type AdminActionResponse struct {
+	Wallet      *models.Wallet            `json:"wallet,omitempty"`
+	Action      *models.AdminAction       `json:"action,omitempty"`
+	Transaction *models.WalletTransaction `json:"transaction,omitempty"`
+	Approval    *models.ApprovalRequest   `json:"approval,omitempty"`
+}
+
+// ExportTransactionsRequest exports the transactions a user's wallet made in [From, To)
+type ExportTransactionsRequest struct {
+	UserID  string
+	ActorID string
+	From    time.Time
+	To      time.Time
+	Reason  string
+}
+
+// TransactionExport is a CSV file of transactions
+type TransactionExport struct {
+	FileName string
+	Content  []byte
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "digital-wallet/internal/models"
+
+// CreateAPIKeyRequest issues an API key for an internal service. The key lives for
+// ExpiresInDays days, or the longest lifetime allowed when it is left out.
+type CreateAPIKeyRequest struct {
+	CreatedBy     string   `json:"-"`
+	Name          string   `json:"name" validate:"required,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1"`
+	ExpiresInDays int      `json:"expires_in_days" validate:"omitempty,gt=0"`
+}
+
+// APIKeyCredentialsResponse is an API key with its secret, which is only ever shown once
+type APIKeyCredentialsResponse struct {
+	APIKey *models.APIKey `json:"api_key"`
+	Key    string         `json:"key"`
+}
+
+type PaginatedAPIKeyResponse struct {
+	Data []models.APIKey `json:"data"`
+	Meta PaginationMeta  `json:"meta"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "digital-wallet/internal/models"
+
+// ApprovalDecisionRequest approves or rejects an approval request
+type ApprovalDecisionRequest struct {
+	ApprovalID string `json:"-"`
+	CheckerID  string `json:"-"`
+	Note       string `json:"note" validate:"max=255"`
+}
+
+// ApprovalDecisionResponse is the decided request and, once approved, the result of the
+// operation it ran
+type ApprovalDecisionResponse struct {
+	Approval *models.ApprovalRequest `json:"approval"`
+	Result   interface{}             `json:"result,omitempty"`
+}
+
+type PaginatedApprovalResponse struct {
+	Data []models.ApprovalRequest `json:"data"`
+	Meta PaginationMeta           `json:"meta"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"digital-wallet/internal/models"
+	"time"
+)
+
+// AuditEventQuery filters the audit log; empty fields match every event. The controller
+// parses From and To from YYYY-MM-DD dates, To being the day after the last one included.
+type AuditEventQuery struct {
+	ActorID       string
+	PrincipalType string
+	Action        string
+	ResourceType  string
+	ResourceID    string
+	RequestID     string
+	From          time.Time
+	To            time.Time
+}
+
+type PaginatedAuditEventResponse struct {
+	Data []models.AuditEvent `json:"data"`
+	Meta PaginationMeta      `json:"meta"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+type CreateBudgetRequest struct {
+	Category string  `json:"category" validate:"required,oneof=FOOD_AND_DRINK GROCERIES TRANSPORT SHOPPING BILLS ENTERTAINMENT HEALTH CASH_OUT FEES OTHER"`
+	Amount   float64 `json:"amount" validate:"required,gt=0"`
+}
+
+type UpdateBudgetRequest struct {
+	Amount float64 `json:"amount" validate:"required,gt=0"`
+}
+
+// BudgetStatus is the spending against one budget in a month
+type BudgetStatus struct {
+	BudgetID       string                `json:"budget_id"`
+	Category       string                `json:"category"`
+	Period         string                `json:"period"`
+	Amount         float64               `json:"amount"`
+	Spent          float64               `json:"spent"`
+	Remaining      float64               `json:"remaining"`
+	PercentageUsed float64               `json:"percentage_used"`
+	Status         string                `json:"status"`
+	Alerts         []BudgetAlertResponse `json:"alerts"`
+}
+
+type BudgetAlertResponse struct {
+	Threshold   int     `json:"threshold"`
+	SpentAmount float64 `json:"spent_amount"`
+	TriggeredAt string  `json:"triggered_at"`
+}
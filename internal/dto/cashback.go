@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"digital-wallet/internal/models"
+	"time"
+)
+
+type CreateCashbackCampaignRequest struct {
+	CreatedBy     string    `json:"-"`
+	Name          string    `json:"name" validate:"required"`
+	Percentage    float64   `json:"percentage" validate:"required,gt=0,lte=100"`
+	MaxCashback   float64   `json:"max_cashback" validate:"gte=0"`
+	MinSpend      float64   `json:"min_spend" validate:"gte=0"`
+	StartsAt      time.Time `json:"starts_at" validate:"required"`
+	EndsAt        time.Time `json:"ends_at" validate:"required"`
+	PerUserBudget float64   `json:"per_user_budget" validate:"gte=0"`
+	TotalBudget   float64   `json:"total_budget" validate:"required,gt=0"`
+	HoldingDays   int       `json:"holding_days" validate:"gte=0"`
+}
+
+type CashbackCampaignReport struct {
+	CampaignID      string  `json:"campaign_id"`
+	Name            string  `json:"name"`
+	IsActive        bool    `json:"is_active"`
+	TotalBudget     float64 `json:"total_budget"`
+	UsedBudget      float64 `json:"used_budget"`
+	RemainingBudget float64 `json:"remaining_budget"`
+	HeldRewards     int64   `json:"held_rewards"`
+	HeldAmount      float64 `json:"held_amount"`
+	CreditedRewards int64   `json:"credited_rewards"`
+	CreditedAmount  float64 `json:"credited_amount"`
+	UniqueUsers     int64   `json:"unique_users"`
+}
+
+type PaginatedCashbackCampaignResponse struct {
+	Data []models.CashbackCampaign `json:"data"`
+	Meta PaginationMeta            `json:"meta"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "digital-wallet/internal/models"
+
+// DeviceResponse is one of the user's devices. A device is trusted once it is no longer new,
+// at TrustedAt; until then its withdrawals are limited.
+type DeviceResponse struct {
+	*models.Device
+	Current   bool   `json:"current"`
+	Trusted   bool   `json:"trusted"`
+	TrustedAt string `json:"trusted_at"`
+}
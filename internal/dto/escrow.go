@@ -0,0 +1,35 @@
+package dto
+
+import "digital-wallet/internal/models"
+
+// CreateEscrowRequest funds an escrow from the wallet of the authenticated user, the buyer
+type CreateEscrowRequest struct {
+	SellerUserID       string  `json:"seller_user_id" validate:"required"`
+	Amount             float64 `json:"amount" validate:"required,gt=0"`
+	Reference          string  `json:"reference"`
+	Description        string  `json:"description"`
+	AutoReleaseInHours int     `json:"auto_release_in_hours" validate:"omitempty,gt=0"`
+	PIN                string  `json:"pin" validate:"required,len=6,number"`
+	// MFACode is required when the amount is above the buyer's two-factor withdrawal threshold
+	MFACode string `json:"mfa_code" validate:"omitempty,len=6,number"`
+	// ChallengeID and ChallengeCode confirm a request that was answered with a step-up
+	// challenge. The rest of the request must be the same as when the challenge was issued.
+	ChallengeID   string `json:"challenge_id" validate:"omitempty,uuid"`
+	ChallengeCode string `json:"challenge_code" validate:"required_with=ChallengeID,omitempty,len=6,number"`
+	// SessionID is the caller's session when they fund an escrow from their own wallet
+	SessionID string `json:"-"`
+}
+
+// EscrowActionRequest is sent by the party releasing or refunding an escrow
+type EscrowActionRequest struct {
+	Note string `json:"note"`
+}
+
+// EscrowResponse is an escrow together with its status history, oldest first
+type EscrowResponse struct {
+	*models.Escrow
+	History []models.EscrowEvent `json:"history"`
+	// Challenge is set, and nothing else, when the funding waits for a step-up challenge; the
+	// controller responds with it alone
+	Challenge *StepUpChallenge `json:"challenge,omitempty"`
+}
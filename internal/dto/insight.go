@@ -0,0 +1,28 @@
+package dto
+
+type InsightsResponse struct {
+	WalletID string           `json:"wallet_id"`
+	Currency string           `json:"currency"`
+	Months   []MonthlyInsight `json:"months"`
+}
+
+// MonthlyInsight compares one month against the month before it
+type MonthlyInsight struct {
+	Period                string            `json:"period"`
+	TotalSpent            float64           `json:"total_spent"`
+	TotalReceived         float64           `json:"total_received"`
+	PreviousTotalSpent    float64           `json:"previous_total_spent"`
+	SpentChange           float64           `json:"spent_change"`
+	SpentChangePercentage *float64          `json:"spent_change_percentage"`
+	Categories            []CategoryInsight `json:"categories"`
+}
+
+type CategoryInsight struct {
+	Category         string   `json:"category"`
+	Direction        string   `json:"direction"`
+	Total            float64  `json:"total"`
+	Count            int64    `json:"count"`
+	PreviousTotal    float64  `json:"previous_total"`
+	Change           float64  `json:"change"`
+	ChangePercentage *float64 `json:"change_percentage"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"digital-wallet/internal/models"
+	"io"
+)
+
+// KYCSubmitRequest is an identity document uploaded by a user. The controller fills in the
+// file from the multipart upload.
+type KYCSubmitRequest struct {
+	UserID       string    `form:"-"`
+	DocumentType string    `form:"document_type" validate:"required,oneof=KTP PASSPORT"`
+	FileName     string    `form:"-"`
+	Size         int64     `form:"-"`
+	Content      io.Reader `form:"-"`
+}
+
+// KYCStatusResponse is the KYC level of a user, the limits it sets and their latest submission
+type KYCStatusResponse struct {
+	Level                string                `json:"level"`
+	MaxBalance           float64               `json:"max_balance"`
+	DailyWithdrawalLimit float64               `json:"daily_withdrawal_limit"`
+	Submission           *models.KYCSubmission `json:"submission"`
+}
+
+// KYCReviewRequest approves or rejects a submission. A note is required to reject.
+type KYCReviewRequest struct {
+	SubmissionID string `json:"-"`
+	ReviewerID   string `json:"-"`
+	Note         string `json:"note" validate:"max=255"`
+}
+
+// KYCDocument is the uploaded file of a submission. The caller closes Content.
+type KYCDocument struct {
+	FileName    string
+	ContentType string
+	Content     io.ReadCloser
+}
+
+type PaginatedKYCSubmissionResponse struct {
+	Data []models.KYCSubmission `json:"data"`
+	Meta PaginationMeta         `json:"meta"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+// LedgerVerification is the result of walking the hash chains of the ledger
+type LedgerVerification struct {
+	Wallets      int          `json:"wallets"`
+	Transactions int          `json:"transactions"`
+	Checkpoints  int          `json:"checkpoints"`
+	Broken       []BrokenLink `json:"broken"`
+}
+
+// BrokenLink is the first place a wallet's chain stops matching its transactions or
+// checkpoints. Nothing after it can be trusted until it is explained.
+type BrokenLink struct {
+	WalletID      string `json:"wallet_id"`
+	TransactionID string `json:"transaction_id,omitempty"`
+	ChainSeq      int64  `json:"chain_seq"`
+	Reason        string `json:"reason"`
+}
@@ -0,0 +1,86 @@
+package dto
+
+// CreateMerchantRequest registers a merchant owned by UserID, the authenticated user
+type CreateMerchantRequest struct {
+	UserID      string `json:"-" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+	CallbackURL string `json:"callback_url" validate:"omitempty,url,startswith=https://"`
+}
+
+// UpdateMerchantRequest changes only the fields it carries; an empty callback_url removes the URL
+type UpdateMerchantRequest struct {
+	Name        string  `json:"name"`
+	CallbackURL *string `json:"callback_url" validate:"omitempty,url,startswith=https://"`
+}
+
+// MerchantCredentialsResponse carries the plain API key and callback secret, which are only ever
+// returned once
+type MerchantCredentialsResponse struct {
+	MerchantID         string `json:"merchant_id"`
+	Name               string `json:"name"`
+	SettlementWalletID string `json:"settlement_wallet_id"`
+	CallbackURL        string `json:"callback_url"`
+	APIKey             string `json:"api_key"`
+	CallbackSecret     string `json:"callback_secret"`
+}
+
+type CreatePaymentIntentRequest struct {
+	Amount            float64 `json:"amount" validate:"required,gt=0"`
+	Currency          string  `json:"currency" validate:"omitempty,len=3"`
+	Description       string  `json:"description"`
+	MerchantReference string  `json:"merchant_reference"`
+	ExpiresInSeconds  int     `json:"expires_in_seconds" validate:"omitempty,gt=0,lte=86400"`
+}
+
+// ConfirmPaymentIntentRequest pays a checkout from the wallet of the payer, proven by their PIN
+type ConfirmPaymentIntentRequest struct {
+	PIN string `json:"pin" validate:"required,len=6,number"`
+	// MFACode is required when the amount due is above the user's two-factor withdrawal threshold
+	MFACode string `json:"mfa_code" validate:"omitempty,len=6,number"`
+	// ChallengeID and ChallengeCode confirm a request that was answered with a step-up
+	// challenge. The rest of the request must be the same as when the challenge was issued.
+	ChallengeID   string `json:"challenge_id" validate:"omitempty,uuid"`
+	ChallengeCode string `json:"challenge_code" validate:"required_with=ChallengeID,omitempty,len=6,number"`
+	// SessionID is the caller's session when they pay from their own wallet
+	SessionID string `json:"-"`
+}
+
+type CancelPaymentIntentRequest struct {
+	Reason string `json:"reason"`
+}
+
+type PaymentIntentResponse struct {
+	ID                  string  `json:"id"`
+	MerchantID          string  `json:"merchant_id"`
+	MerchantReference   string  `json:"merchant_reference"`
+	Amount              float64 `json:"amount"`
+	DiscountAmount      float64 `json:"discount_amount"`
+	AmountDue           float64 `json:"amount_due"`
+	Currency            string  `json:"currency"`
+	Description         string  `json:"description"`
+	Status              string  `json:"status"`
+	WalletTransactionID *string `json:"wallet_transaction_id"`
+	ExpiresAt           string  `json:"expires_at"`
+	// Challenge is set, and nothing else, when the payment waits for a step-up challenge; the
+	// controller responds with it alone
+	Challenge *StepUpChallenge `json:"challenge,omitempty"`
+}
+
+// CheckoutResponse is what the hosted checkout page shows to the paying wallet user
+type CheckoutResponse struct {
+	PaymentIntentID string  `json:"payment_intent_id"`
+	MerchantName    string  `json:"merchant_name"`
+	Amount          float64 `json:"amount"`
+	DiscountAmount  float64 `json:"discount_amount"`
+	AmountDue       float64 `json:"amount_due"`
+	Currency        string  `json:"currency"`
+	Description     string  `json:"description"`
+	Status          string  `json:"status"`
+	ExpiresAt       string  `json:"expires_at"`
+}
+
+// PaymentIntentCallback is the body POSTed to a merchant callback URL when an intent changes status
+type PaymentIntentCallback struct {
+	Event         string                `json:"event"`
+	PaymentIntent PaymentIntentResponse `json:"payment_intent"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+// MFACodeRequest carries a TOTP code or, where accepted, a recovery code
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required,min=6,max=32"`
+}
+
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required,min=6,max=32"`
+	DeviceInfo
+}
+
+type UpdateMFASettingsRequest struct {
+	// WithdrawalThreshold requires a code for withdrawals above it; null turns the requirement off
+	WithdrawalThreshold *float64 `json:"withdrawal_threshold" validate:"omitempty,gte=0"`
+	Code                string   `json:"code" validate:"required,len=6,number"`
+}
+
+type MFAEnrollmentResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type MFAStatusResponse struct {
+	Enabled                bool     `json:"enabled"`
+	EnabledAt              *string  `json:"enabled_at"`
+	WithdrawalThreshold    *float64 `json:"withdrawal_threshold"`
+	RecoveryCodesRemaining int64    `json:"recovery_codes_remaining"`
+}
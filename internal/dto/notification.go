@@ -0,0 +1,12 @@
+package dto
+
+// UpdateNotificationPreferencesRequest replaces all notification preferences of a user
+type UpdateNotificationPreferencesRequest struct {
+	Locale       string `json:"locale" validate:"omitempty,oneof=en id"`
+	Email        string `json:"email" validate:"omitempty,email"`
+	PhoneNumber  string `json:"phone_number" validate:"omitempty,e164"`
+	PushToken    string `json:"push_token" validate:"omitempty,max=512"`
+	EmailEnabled bool   `json:"email_enabled"`
+	SMSEnabled   bool   `json:"sms_enabled"`
+	PushEnabled  bool   `json:"push_enabled"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+type SetupPINRequest struct {
+	PIN string `json:"pin" validate:"required,len=6,number"`
+}
+
+type ChangePINRequest struct {
+	CurrentPIN string `json:"current_pin" validate:"required,len=6,number"`
+	NewPIN     string `json:"new_pin" validate:"required,len=6,number,nefield=CurrentPIN"`
+}
+
+// ResetPINRequest sets a new PIN for a user who forgot theirs, proven by the account password
+type ResetPINRequest struct {
+	Password string `json:"password" validate:"required"`
+	NewPIN   string `json:"new_pin" validate:"required,len=6,number"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// Pocket transfer directions
+const (
+	PocketTransferIn  = "IN"
+	PocketTransferOut = "OUT"
+)
+
+type CreatePocketRequest struct {
+	Name         string     `json:"name" validate:"required,max=100"`
+	TargetAmount *float64   `json:"target_amount" validate:"omitempty,gt=0"`
+	Deadline     *time.Time `json:"deadline"`
+}
+
+type UpdatePocketRequest struct {
+	Name         string     `json:"name" validate:"omitempty,max=100"`
+	TargetAmount *float64   `json:"target_amount" validate:"omitempty,gt=0"`
+	Deadline     *time.Time `json:"deadline"`
+}
+
+// PocketTransferRequest moves money into (IN) or out of (OUT) a pocket from the main balance
+type PocketTransferRequest struct {
+	Direction   string  `json:"direction" validate:"required,oneof=IN OUT"`
+	Amount      float64 `json:"amount" validate:"required,gt=0"`
+	Description string  `json:"description"`
+	PIN         string  `json:"pin" validate:"required,len=6,number"`
+}
+
+type PocketBalance struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Balance      float64  `json:"balance"`
+	TargetAmount *float64 `json:"target_amount"`
+	Deadline     *string  `json:"deadline"`
+	Progress     *float64 `json:"progress"`
+}
+
+type PocketTransferResponse struct {
+	TransactionID string        `json:"transaction_id"`
+	WalletBalance float64       `json:"wallet_balance"`
+	Pocket        PocketBalance `json:"pocket"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+type RedeemPromoRequest struct {
+	Code            string `json:"code" validate:"required"`
+	TransactionID   string `json:"transaction_id"`
+	PaymentIntentID string `json:"payment_intent_id"`
+}
+
+type PromoRedemptionResponse struct {
+	RedemptionID    string   `json:"redemption_id"`
+	Code            string   `json:"code"`
+	RewardType      string   `json:"reward_type"`
+	Amount          float64  `json:"amount"`
+	TransactionID   *string  `json:"transaction_id,omitempty"`
+	WalletBalance   *float64 `json:"wallet_balance,omitempty"`
+	PaymentIntentID *string  `json:"payment_intent_id,omitempty"`
+	AmountDue       *float64 `json:"amount_due,omitempty"`
+}
+
+// GeneratePromoCodesRequest describes a batch of codes sharing the same rules
+type GeneratePromoCodesRequest struct {
+	Count           int        `validate:"required,gt=0,lte=100000"`
+	Prefix          string     `validate:"omitempty,alphanum,max=16"`
+	Length          int        `validate:"gte=6,lte=32"`
+	Description     string     `validate:"max=255"`
+	RewardType      string     `validate:"required,oneof=CREDIT DISCOUNT"`
+	ValueType       string     `validate:"required,oneof=FIXED PERCENTAGE"`
+	Value           float64    `validate:"required,gt=0"`
+	MaxValue        float64    `validate:"gte=0"`
+	MinAmount       float64    `validate:"gte=0"`
+	TransactionType string     `validate:"omitempty,oneof=WITHDRAWAL DEPOSIT PAYMENT TRANSFER"`
+	MaxRedemptions  int        `validate:"gte=0"`
+	MaxPerUser      int        `validate:"gte=0"`
+	ExpiresAt       *time.Time `validate:"omitempty"`
+}
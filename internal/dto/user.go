@@ -1,23 +1,63 @@
 package dto
 
+// HeaderDeviceID carries the ID the client gives the device it runs on
+const HeaderDeviceID = "X-Device-ID"
+
+// MaxDeviceIDLength is the longest device ID a client may send
+const MaxDeviceIDLength = 64
+
+// DeviceInfo identifies the device a session is started from. The client names the device;
+// the user agent and IP address are taken from the request.
+type DeviceInfo struct {
+	DeviceID   string `json:"device_id" validate:"omitempty,max=64"`
+	DeviceName string `json:"device_name" validate:"omitempty,max=100"`
+	UserAgent  string `json:"-"`
+	IPAddress  string `json:"-"`
+}
+
 type LoginRequest struct {
 	PhoneNumber string `json:"phone_number" validate:"required"`
 	Password    string `json:"password" validate:"required"`
+	DeviceInfo
 }
 
 type RegisterRequest struct {
 	FullName    string  `json:"full_name" validate:"required"`
-	Email       *string `json:"email"`
+	Email       *string `json:"email" validate:"omitempty,email"`
 	Password    string  `json:"password" validate:"required,min=6"`
 	PhoneNumber string  `json:"phone_number" validate:"required,min=12"`
+	DeviceInfo
 }
 
+// AuthResponse holds the token pair of a new session. When the user has two-factor
+// authentication enabled, login returns only an MFA token, to be exchanged for the token
+// pair at /v1/auth/mfa/verify, and ExpiresAt is the expiry of that token. Registration
+// returns no tokens, only ActivationRequired, since new accounts have to be activated first.
 type AuthResponse struct {
-	AccessToken  string `json:"access_token"`
-	ExpiresAt    string `json:"expires_at"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken        string `json:"access_token,omitempty"`
+	ExpiresAt          string `json:"expires_at,omitempty"`
+	RefreshToken       string `json:"refresh_token,omitempty"`
+	MFARequired        bool   `json:"mfa_required,omitempty"`
+	MFAToken           string `json:"mfa_token,omitempty"`
+	ActivationRequired bool   `json:"activation_required,omitempty"`
 }
 
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
 }
+
+type SessionResponse struct {
+	ID         string `json:"id"`
+	DeviceID   string `json:"device_id,omitempty"`
+	DeviceName string `json:"device_name,omitempty"`
+	UserAgent  string `json:"user_agent,omitempty"`
+	IPAddress  string `json:"ip_address,omitempty"`
+	Current    bool   `json:"current"`
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at"`
+	ExpiresAt  string `json:"expires_at"`
+}
+
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
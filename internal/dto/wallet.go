@@ -1,9 +1,23 @@
 package dto
 
+// WithdrawStatusChallengeRequired is the status of a withdrawal that waits for a step-up challenge
+const WithdrawStatusChallengeRequired = "CHALLENGE_REQUIRED"
+
 type WithdrawRequest struct {
 	Amount      float64 `json:"amount" validate:"required,gt=0"`
 	UserID      string  `json:"user_id" validate:"required"`
 	Description string  `json:"description"`
+	PIN         string  `json:"pin" validate:"required,len=6,number"`
+	// MFACode is required when the amount is above the user's two-factor withdrawal threshold
+	MFACode string `json:"mfa_code" validate:"omitempty,len=6,number"`
+	// Beneficiary identifies where the money goes; a first withdrawal to it needs a challenge
+	Beneficiary string `json:"beneficiary" validate:"omitempty,max=100"`
+	// ChallengeID and ChallengeCode confirm a withdrawal that returned CHALLENGE_REQUIRED.
+	// The rest of the request must be the same as when the challenge was issued.
+	ChallengeID   string `json:"challenge_id" validate:"omitempty,uuid"`
+	ChallengeCode string `json:"challenge_code" validate:"required_with=ChallengeID,omitempty,len=6,number"`
+	// SessionID is the caller's session when they withdraw from their own wallet
+	SessionID string `json:"-"`
 }
 
 type WithdrawResponse struct {
@@ -14,19 +28,35 @@ type WithdrawResponse struct {
 	TransactionID string  `json:"transaction_id"`
 	Status        string  `json:"status"`
 	Timestamp     string  `json:"timestamp"`
+	// Challenge is set, and nothing else, when Status is CHALLENGE_REQUIRED; the controller
+	// responds with it alone
+	Challenge *StepUpChallenge `json:"challenge,omitempty"`
+}
+
+// StepUpChallenge tells the user a withdrawal, payment or escrow funding has to be confirmed
+// with the one-time code that was sent to them
+type StepUpChallenge struct {
+	ChallengeID string   `json:"challenge_id"`
+	Reasons     []string `json:"reasons"`
+	ExpiresAt   string   `json:"expires_at"`
 }
 
 type BalanceResponse struct {
-	WalletID string  `json:"wallet_id"`
-	Balance  float64 `json:"balance"`
-	Currency string  `json:"currency"`
-	IsActive bool    `json:"is_active"`
+	WalletID     string          `json:"wallet_id"`
+	Balance      float64         `json:"balance"`
+	PocketTotal  float64         `json:"pocket_total"`
+	TotalBalance float64         `json:"total_balance"`
+	Currency     string          `json:"currency"`
+	IsActive     bool            `json:"is_active"`
+	Pockets      []PocketBalance `json:"pockets"`
 }
 
 type TransactionHistoryResponse struct {
 	ID          string  `json:"id"`
 	Amount      float64 `json:"amount"`
 	Type        string  `json:"type"`
+	Direction   string  `json:"direction"`
+	Category    string  `json:"category"`
 	Status      string  `json:"status"`
 	Description string  `json:"description"`
 	CreatedAt   string  `json:"created_at"`
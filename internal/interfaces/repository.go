@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 	"digital-wallet/internal/models"
+	"time"
 )
 
 //go:generate mockery --name WalletRepository --case snake --output ../mocks --disable-version-string
@@ -16,6 +17,8 @@ type WalletRepository interface {
 	UpdateBalance(ctx context.Context, walletID string, amount float64) error
 	Update(ctx context.Context, wallet *models.Wallet) error
 	Withdraw(ctx context.Context, walletID string, amount float64) (*models.Wallet, error)
+	Deposit(ctx context.Context, walletID string, amount float64) (*models.Wallet, error)
+	SetActive(ctx context.Context, walletID string, active bool) (bool, error)
 }
 
 //go:generate mockery --name WalletTransactionRepository --case snake --output ../mocks --disable-version-string
@@ -26,12 +29,354 @@ type WalletTransactionRepository interface {
 	GetByID(ctx context.Context, id string) (*models.WalletTransaction, error)
 	GetByWalletID(ctx context.Context, walletID string, limit, offset int) ([]models.WalletTransaction, error)
 	CountByWalletID(ctx context.Context, walletID string) (int64, error)
+	GetCreatedAfter(ctx context.Context, createdAt time.Time, id string, until time.Time, limit int) ([]models.WalletTransaction, error)
+	SumSpent(ctx context.Context, walletID, category string, from, to time.Time) (float64, error)
+	HasCompletedWithdrawalTo(ctx context.Context, walletID, beneficiary string) (bool, error)
+	SumOutgoing(ctx context.Context, walletID string, from time.Time) (float64, error)
+	GetByWalletIDBetween(ctx context.Context, walletID string, from, to time.Time) ([]models.WalletTransaction, error)
+	IsReversed(ctx context.Context, transactionID string) (bool, error)
 	Update(ctx context.Context, transaction *models.WalletTransaction) error
 }
 
+//go:generate mockery --name MerchantRepository --case snake --output ../mocks --disable-version-string
+
+// MerchantRepository interface
+type MerchantRepository interface {
+	Create(ctx context.Context, merchant *models.Merchant) error
+	GetByID(ctx context.Context, id string) (*models.Merchant, error)
+	GetByAPIKeyPrefix(ctx context.Context, prefix string) (*models.Merchant, error)
+	Update(ctx context.Context, merchant *models.Merchant) error
+}
+
+//go:generate mockery --name PaymentIntentRepository --case snake --output ../mocks --disable-version-string
+
+// PaymentIntentRepository interface
+type PaymentIntentRepository interface {
+	Create(ctx context.Context, intent *models.PaymentIntent) error
+	GetByID(ctx context.Context, id string) (*models.PaymentIntent, error)
+	GetByIDForUpdate(ctx context.Context, id string) (*models.PaymentIntent, error)
+	GetExpired(ctx context.Context, now time.Time, limit int) ([]models.PaymentIntent, error)
+	Update(ctx context.Context, intent *models.PaymentIntent) error
+}
+
+//go:generate mockery --name PocketRepository --case snake --output ../mocks --disable-version-string
+
+// PocketRepository interface
+type PocketRepository interface {
+	Create(ctx context.Context, pocket *models.Pocket) error
+	GetByID(ctx context.Context, id string) (*models.Pocket, error)
+	GetByIDForUpdate(ctx context.Context, id string) (*models.Pocket, error)
+	GetByWalletID(ctx context.Context, walletID string) ([]models.Pocket, error)
+	Update(ctx context.Context, pocket *models.Pocket) error
+	Delete(ctx context.Context, id string) error
+	Deposit(ctx context.Context, pocketID string, amount float64) (*models.Pocket, error)
+	Withdraw(ctx context.Context, pocketID string, amount float64) (*models.Pocket, error)
+}
+
+//go:generate mockery --name CashbackCampaignRepository --case snake --output ../mocks --disable-version-string
+
+// CashbackCampaignRepository interface
+type CashbackCampaignRepository interface {
+	Create(ctx context.Context, campaign *models.CashbackCampaign) error
+	GetByID(ctx context.Context, id string) (*models.CashbackCampaign, error)
+	GetByIDForUpdate(ctx context.Context, id string) (*models.CashbackCampaign, error)
+	GetRunning(ctx context.Context, now time.Time, amount float64) ([]models.CashbackCampaign, error)
+	List(ctx context.Context, limit, offset int) ([]models.CashbackCampaign, int64, error)
+	SetActive(ctx context.Context, id string, active bool) (bool, error)
+	AddUsedBudget(ctx context.Context, id string, amount float64) error
+}
+
+//go:generate mockery --name CashbackRewardRepository --case snake --output ../mocks --disable-version-string
+
+// CashbackRewardRepository interface
+type CashbackRewardRepository interface {
+	Create(ctx context.Context, reward *models.CashbackReward) error
+	GetByIDForUpdate(ctx context.Context, id string) (*models.CashbackReward, error)
+	GetDueHeld(ctx context.Context, now time.Time, limit int) ([]models.CashbackReward, error)
+	SumByCampaignAndUser(ctx context.Context, campaignID, userID string) (float64, error)
+	SummarizeByCampaign(ctx context.Context, campaignID string) ([]models.CashbackRewardSummary, error)
+	CountUsersByCampaign(ctx context.Context, campaignID string) (int64, error)
+	Update(ctx context.Context, reward *models.CashbackReward) error
+}
+
+//go:generate mockery --name PromoCodeRepository --case snake --output ../mocks --disable-version-string
+
+// PromoCodeRepository interface
+type PromoCodeRepository interface {
+	Create(ctx context.Context, promo *models.PromoCode) error
+	CreateBatch(ctx context.Context, promos []models.PromoCode) error
+	GetByCodeForUpdate(ctx context.Context, code string) (*models.PromoCode, error)
+	GetByIDForUpdate(ctx context.Context, id string) (*models.PromoCode, error)
+	Update(ctx context.Context, promo *models.PromoCode) error
+}
+
+//go:generate mockery --name PromoRedemptionRepository --case snake --output ../mocks --disable-version-string
+
+// PromoRedemptionRepository interface
+type PromoRedemptionRepository interface {
+	Create(ctx context.Context, redemption *models.PromoRedemption) error
+	CountByPromoAndUser(ctx context.Context, promoCodeID, userID string) (int64, error)
+	ExistsBySourceTransaction(ctx context.Context, promoCodeID, transactionID string) (bool, error)
+	DeleteByPaymentIntentID(ctx context.Context, paymentIntentID string) error
+}
+
+//go:generate mockery --name EscrowRepository --case snake --output ../mocks --disable-version-string
+
+// EscrowRepository interface
+type EscrowRepository interface {
+	Create(ctx context.Context, escrow *models.Escrow) error
+	GetByID(ctx context.Context, id string) (*models.Escrow, error)
+	GetByIDForUpdate(ctx context.Context, id string) (*models.Escrow, error)
+	GetDueForAutoRelease(ctx context.Context, now time.Time, limit int) ([]models.Escrow, error)
+	Update(ctx context.Context, escrow *models.Escrow) error
+}
+
+//go:generate mockery --name EscrowEventRepository --case snake --output ../mocks --disable-version-string
+
+// EscrowEventRepository interface
+type EscrowEventRepository interface {
+	Create(ctx context.Context, event *models.EscrowEvent) error
+	GetByEscrowID(ctx context.Context, escrowID string) ([]models.EscrowEvent, error)
+}
+
+//go:generate mockery --name AdminActionRepository --case snake --output ../mocks --disable-version-string
+
+// AdminActionRepository interface
+type AdminActionRepository interface {
+	Create(ctx context.Context, action *models.AdminAction) error
+	GetByWalletID(ctx context.Context, walletID string, limit int) ([]models.AdminAction, error)
+}
+
+//go:generate mockery --name ApprovalRequestRepository --case snake --output ../mocks --disable-version-string
+
+// ApprovalRequestRepository interface
+type ApprovalRequestRepository interface {
+	Create(ctx context.Context, approval *models.ApprovalRequest) error
+	GetByID(ctx context.Context, id string) (*models.ApprovalRequest, error)
+	GetByIDForUpdate(ctx context.Context, id string) (*models.ApprovalRequest, error)
+	List(ctx context.Context, status string, limit, offset int) ([]models.ApprovalRequest, int64, error)
+	ExpirePending(ctx context.Context, now time.Time, limit int) (int64, error)
+	Update(ctx context.Context, approval *models.ApprovalRequest) error
+}
+
+//go:generate mockery --name APIKeyRepository --case snake --output ../mocks --disable-version-string
+
+// APIKeyRepository interface
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	GetByIDForUpdate(ctx context.Context, id string) (*models.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	List(ctx context.Context, limit, offset int) ([]models.APIKey, int64, error)
+	Update(ctx context.Context, key *models.APIKey) error
+	TouchLastUsed(ctx context.Context, id string, now, since time.Time) error
+}
+
+//go:generate mockery --name DeviceRepository --case snake --output ../mocks --disable-version-string
+
+// DeviceRepository interface
+type DeviceRepository interface {
+	CreateIfAbsent(ctx context.Context, device *models.Device) (bool, error)
+	GetByID(ctx context.Context, userID, id string) (*models.Device, error)
+	GetByDeviceID(ctx context.Context, userID, deviceID string) (*models.Device, error)
+	ListByUserID(ctx context.Context, userID string) ([]models.Device, error)
+	Touch(ctx context.Context, device *models.Device, now, since time.Time) error
+	Delete(ctx context.Context, id string) error
+}
+
+//go:generate mockery --name TransactionSummaryRepository --case snake --output ../mocks --disable-version-string
+
+// TransactionSummaryRepository interface
+type TransactionSummaryRepository interface {
+	GetWatermarkForUpdate(ctx context.Context, name string) (*models.SummaryWatermark, error)
+	SaveWatermark(ctx context.Context, watermark *models.SummaryWatermark) error
+	Accumulate(ctx context.Context, summaries []models.TransactionSummary) error
+	GetByWalletID(ctx context.Context, walletID string, from, to time.Time) ([]models.TransactionSummary, error)
+}
+
+//go:generate mockery --name BudgetRepository --case snake --output ../mocks --disable-version-string
+
+// BudgetRepository interface
+type BudgetRepository interface {
+	Create(ctx context.Context, budget *models.Budget) error
+	GetByID(ctx context.Context, id string) (*models.Budget, error)
+	GetByWalletID(ctx context.Context, walletID string) ([]models.Budget, error)
+	GetByWalletIDAndCategory(ctx context.Context, walletID, category string) (*models.Budget, error)
+	Update(ctx context.Context, budget *models.Budget) error
+	Delete(ctx context.Context, id string) error
+}
+
+//go:generate mockery --name BudgetAlertRepository --case snake --output ../mocks --disable-version-string
+
+// BudgetAlertRepository interface
+type BudgetAlertRepository interface {
+	CreateIfAbsent(ctx context.Context, alert *models.BudgetAlert) (bool, error)
+	GetByBudgetIDs(ctx context.Context, budgetIDs []string, period time.Time) ([]models.BudgetAlert, error)
+}
+
+//go:generate mockery --name NotificationRepository --case snake --output ../mocks --disable-version-string
+
+// NotificationRepository interface
+type NotificationRepository interface {
+	CreateIfAbsent(ctx context.Context, notification *models.Notification) (bool, error)
+	GetDueForUpdate(ctx context.Context, now time.Time, limit int) ([]models.Notification, error)
+	Update(ctx context.Context, notification *models.Notification) error
+}
+
+//go:generate mockery --name NotificationPreferenceRepository --case snake --output ../mocks --disable-version-string
+
+// NotificationPreferenceRepository interface
+type NotificationPreferenceRepository interface {
+	GetByUserID(ctx context.Context, userID string) (*models.NotificationPreference, error)
+	Save(ctx context.Context, preference *models.NotificationPreference) error
+}
+
+//go:generate mockery --name UserRepository --case snake --output ../mocks --disable-version-string
+
+// UserRepository interface
+type UserRepository interface {
+	Create(ctx context.Context, user *models.User) error
+	GetByID(ctx context.Context, id string) (*models.User, error)
+	GetByPhoneNumber(ctx context.Context, phoneNumber string) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	Search(ctx context.Context, query string, limit int) ([]models.User, error)
+	Activate(ctx context.Context, userID string, at time.Time) (bool, error)
+	UpdatePassword(ctx context.Context, userID, passwordHash string) error
+	UpdatePIN(ctx context.Context, userID, pinHash string) error
+	UpdateKYCLevel(ctx context.Context, userID, level string) error
+}
+
+//go:generate mockery --name KYCSubmissionRepository --case snake --output ../mocks --disable-version-string
+
+// KYCSubmissionRepository interface
+type KYCSubmissionRepository interface {
+	Create(ctx context.Context, submission *models.KYCSubmission) error
+	GetByID(ctx context.Context, id string) (*models.KYCSubmission, error)
+	GetLatestByUserID(ctx context.Context, userID string) (*models.KYCSubmission, error)
+	List(ctx context.Context, status string, limit, offset int) ([]models.KYCSubmission, int64, error)
+	Review(ctx context.Context, submission *models.KYCSubmission) (bool, error)
+}
+
+//go:generate mockery --name AuditEventRepository --case snake --output ../mocks --disable-version-string
+
+// AuditEventRepository interface
+type AuditEventRepository interface {
+	Create(ctx context.Context, event *models.AuditEvent) error
+	List(ctx context.Context, filter models.AuditEventFilter, limit, offset int) ([]models.AuditEvent, int64, error)
+}
+
+//go:generate mockery --name LedgerChainRepository --case snake --output ../mocks --disable-version-string
+
+// LedgerChainRepository interface
+type LedgerChainRepository interface {
+	GetHead(ctx context.Context, walletID string) (*models.WalletTransaction, error)
+	GetChain(ctx context.Context, walletID string, afterSeq int64, limit int) ([]models.WalletTransaction, error)
+	CountUnchainedSince(ctx context.Context, walletID string, since time.Time) (int64, error)
+	GetChainedWalletIDs(ctx context.Context, afterID string, limit int) ([]string, error)
+	GetUncheckpointedWalletIDs(ctx context.Context, afterID string, limit int) ([]string, error)
+	CreateCheckpoint(ctx context.Context, checkpoint *models.LedgerCheckpoint) error
+	GetCheckpoints(ctx context.Context, walletID string) ([]models.LedgerCheckpoint, error)
+	GetLatestCheckpoint(ctx context.Context, walletID string) (*models.LedgerCheckpoint, error)
+}
+
+//go:generate mockery --name SessionRepository --case snake --output ../mocks --disable-version-string
+
+// SessionRepository interface
+type SessionRepository interface {
+	Save(ctx context.Context, session *models.Session) error
+	Get(ctx context.Context, userID, sessionID string) (*models.Session, error)
+	GetByUserID(ctx context.Context, userID string) ([]models.Session, error)
+	Delete(ctx context.Context, userID, sessionID string) error
+	DeleteByUserID(ctx context.Context, userID string) (int, error)
+	IsValidSession(ctx context.Context, userID, sessionID string) (bool, error)
+}
+
+//go:generate mockery --name AttemptRepository --case snake --output ../mocks --disable-version-string
+
+// AttemptRepository interface
+type AttemptRepository interface {
+	Get(ctx context.Context, key string) (int, error)
+	Increment(ctx context.Context, key string, ttl time.Duration) (int, error)
+	Reset(ctx context.Context, key string) error
+	Block(ctx context.Context, key string, ttl time.Duration) error
+	BlockedFor(ctx context.Context, key string) (time.Duration, error)
+}
+
+//go:generate mockery --name AccountTokenRepository --case snake --output ../mocks --disable-version-string
+
+// AccountTokenRepository interface
+type AccountTokenRepository interface {
+	Save(ctx context.Context, purpose, userID, tokenHash string, ttl time.Duration) error
+	Consume(ctx context.Context, purpose, tokenHash string) (string, error)
+}
+
+//go:generate mockery --name ChallengeRepository --case snake --output ../mocks --disable-version-string
+
+// ChallengeRepository interface
+type ChallengeRepository interface {
+	Save(ctx context.Context, challenge *models.Challenge) error
+	Get(ctx context.Context, id string) (*models.Challenge, error)
+	Delete(ctx context.Context, id string) (bool, error)
+}
+
+//go:generate mockery --name RoleRepository --case snake --output ../mocks --disable-version-string
+
+// RoleRepository interface
+type RoleRepository interface {
+	GetPermissions(ctx context.Context, role string) ([]string, error)
+}
+
+//go:generate mockery --name MFARepository --case snake --output ../mocks --disable-version-string
+
+// MFARepository interface
+type MFARepository interface {
+	GetByUserID(ctx context.Context, userID string) (*models.UserMFA, error)
+	Save(ctx context.Context, mfa *models.UserMFA) error
+	UseStep(ctx context.Context, userID string, step int64) (bool, error)
+	Delete(ctx context.Context, userID string) error
+}
+
+//go:generate mockery --name MFARecoveryCodeRepository --case snake --output ../mocks --disable-version-string
+
+// MFARecoveryCodeRepository interface
+type MFARecoveryCodeRepository interface {
+	Replace(ctx context.Context, userID string, codes []models.MFARecoveryCode) error
+	Use(ctx context.Context, userID, codeHash string) (bool, error)
+	CountUnused(ctx context.Context, userID string) (int64, error)
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
 type InTransaction func(ctx context.Context, repoRegistry RegistryRepository) (interface{}, error)
 type RegistryRepository interface {
 	DoInTransaction(ctx context.Context, txFunc InTransaction) (out interface{}, err error)
 	GetWalletRepository() WalletRepository
 	GetWalletTransactionRepository() WalletTransactionRepository
+	GetMerchantRepository() MerchantRepository
+	GetPaymentIntentRepository() PaymentIntentRepository
+	GetPocketRepository() PocketRepository
+	GetCashbackCampaignRepository() CashbackCampaignRepository
+	GetCashbackRewardRepository() CashbackRewardRepository
+	GetPromoCodeRepository() PromoCodeRepository
+	GetPromoRedemptionRepository() PromoRedemptionRepository
+	GetEscrowRepository() EscrowRepository
+	GetEscrowEventRepository() EscrowEventRepository
+	GetTransactionSummaryRepository() TransactionSummaryRepository
+	GetBudgetRepository() BudgetRepository
+	GetBudgetAlertRepository() BudgetAlertRepository
+	GetNotificationRepository() NotificationRepository
+	GetNotificationPreferenceRepository() NotificationPreferenceRepository
+	GetUserRepository() UserRepository
+	GetSessionRepository() SessionRepository
+	GetAttemptRepository() AttemptRepository
+	GetMFARepository() MFARepository
+	GetMFARecoveryCodeRepository() MFARecoveryCodeRepository
+	GetChallengeRepository() ChallengeRepository
+	GetAccountTokenRepository() AccountTokenRepository
+	GetRoleRepository() RoleRepository
+	GetAdminActionRepository() AdminActionRepository
+	GetApprovalRequestRepository() ApprovalRequestRepository
+	GetAPIKeyRepository() APIKeyRepository
+	GetDeviceRepository() DeviceRepository
+	GetKYCSubmissionRepository() KYCSubmissionRepository
+	GetAuditEventRepository() AuditEventRepository
+	GetLedgerChainRepository() LedgerChainRepository
 }
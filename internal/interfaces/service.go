@@ -4,8 +4,33 @@ import (
 	"context"
 	"digital-wallet/internal/dto"
 	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
 )
 
+//go:generate mockery --name TransactionListener --case snake --output ../mocks --disable-version-string
+
+// TransactionListener is told about every wallet transaction that reaches a final status.
+// It runs inside the caller's DoInTransaction with the transaction-scoped registry, so its
+// writes commit or roll back together with the transaction itself.
+type TransactionListener interface {
+	OnTransactionSettled(ctx context.Context, repo RegistryRepository, transaction *models.WalletTransaction) error
+}
+
+//go:generate mockery --name DeviceListener --case snake --output ../mocks --disable-version-string
+
+// DeviceListener is told when a user is seen on a device for the first time. Like a
+// TransactionListener it runs inside the transaction that records the device.
+type DeviceListener interface {
+	OnNewDevice(ctx context.Context, repo RegistryRepository, device *models.Device) error
+}
+
+//go:generate mockery --name OTPSender --case snake --output ../mocks --disable-version-string
+
+// OTPSender delivers the one-time code of a step-up challenge to the user
+type OTPSender interface {
+	SendOTP(ctx context.Context, user *models.User, code string) error
+}
+
 //go:generate mockery --name WalletService --case snake --output ../mocks --disable-version-string
 
 // WalletService interface
@@ -15,3 +40,240 @@ type WalletService interface {
 	Withdraw(ctx context.Context, req dto.WithdrawRequest) (*dto.WithdrawResponse, error)
 	GetTransactionHistory(ctx context.Context, userID string, limit, offset int) ([]models.WalletTransaction, int64, error)
 }
+
+//go:generate mockery --name MerchantService --case snake --output ../mocks --disable-version-string
+
+// MerchantService interface
+type MerchantService interface {
+	CreateMerchant(ctx context.Context, req dto.CreateMerchantRequest) (*dto.MerchantCredentialsResponse, error)
+	GetMerchant(ctx context.Context, userID, id string) (*models.Merchant, error)
+	UpdateMerchant(ctx context.Context, userID, id string, req dto.UpdateMerchantRequest) (*models.Merchant, error)
+	RotateAPIKey(ctx context.Context, userID, id string) (*dto.MerchantCredentialsResponse, error)
+	Authenticate(ctx context.Context, apiKey string) (*models.Merchant, error)
+}
+
+//go:generate mockery --name PaymentIntentService --case snake --output ../mocks --disable-version-string
+
+// PaymentIntentService interface
+type PaymentIntentService interface {
+	CreateIntent(ctx context.Context, merchantID string, req dto.CreatePaymentIntentRequest) (*dto.PaymentIntentResponse, error)
+	GetIntent(ctx context.Context, merchantID, id string) (*dto.PaymentIntentResponse, error)
+	GetCheckout(ctx context.Context, id string) (*dto.CheckoutResponse, error)
+	ConfirmIntent(ctx context.Context, userID, id string, req dto.ConfirmPaymentIntentRequest) (*dto.PaymentIntentResponse, error)
+	CancelIntent(ctx context.Context, merchantID, id string, req dto.CancelPaymentIntentRequest) (*dto.PaymentIntentResponse, error)
+	ExpireIntents(ctx context.Context, limit int) (int, error)
+}
+
+//go:generate mockery --name PocketService --case snake --output ../mocks --disable-version-string
+
+// PocketService interface
+type PocketService interface {
+	CreatePocket(ctx context.Context, userID string, req dto.CreatePocketRequest) (*dto.PocketBalance, error)
+	ListPockets(ctx context.Context, userID string) ([]dto.PocketBalance, error)
+	UpdatePocket(ctx context.Context, userID, pocketID string, req dto.UpdatePocketRequest) (*dto.PocketBalance, error)
+	DeletePocket(ctx context.Context, userID, pocketID string) error
+	Transfer(ctx context.Context, userID, pocketID string, req dto.PocketTransferRequest) (*dto.PocketTransferResponse, error)
+}
+
+//go:generate mockery --name CashbackService --case snake --output ../mocks --disable-version-string
+
+// CashbackService interface
+type CashbackService interface {
+	TransactionListener
+	CreateCampaign(ctx context.Context, req dto.CreateCashbackCampaignRequest) (*models.CashbackCampaign, error)
+	ListCampaigns(ctx context.Context, limit, offset int) ([]models.CashbackCampaign, int64, error)
+	DeactivateCampaign(ctx context.Context, id string) (*models.CashbackCampaign, error)
+	GetCampaignReport(ctx context.Context, id string) (*dto.CashbackCampaignReport, error)
+	ReleaseHeldRewards(ctx context.Context, limit int) (int, error)
+}
+
+//go:generate mockery --name PromoService --case snake --output ../mocks --disable-version-string
+
+// PromoService interface
+type PromoService interface {
+	Redeem(ctx context.Context, userID string, req dto.RedeemPromoRequest) (*dto.PromoRedemptionResponse, error)
+	GenerateCodes(ctx context.Context, req dto.GeneratePromoCodesRequest) ([]models.PromoCode, error)
+}
+
+//go:generate mockery --name EscrowService --case snake --output ../mocks --disable-version-string
+
+// EscrowService interface
+type EscrowService interface {
+	CreateEscrow(ctx context.Context, buyerUserID string, req dto.CreateEscrowRequest) (*dto.EscrowResponse, error)
+	GetEscrow(ctx context.Context, userID, id string) (*dto.EscrowResponse, error)
+	Release(ctx context.Context, userID, id string, req dto.EscrowActionRequest) (*dto.EscrowResponse, error)
+	Refund(ctx context.Context, userID, id string, req dto.EscrowActionRequest) (*dto.EscrowResponse, error)
+	AutoRelease(ctx context.Context, limit int) (int, error)
+}
+
+//go:generate mockery --name ApprovalExecutor --case snake --output ../mocks --disable-version-string
+
+// ApprovalExecutor runs the operations of approved requests. ApprovalOperations lists the
+// operations it handles; ExecuteApproval runs one with the transaction-scoped registry, so the
+// operation commits together with the approval.
+type ApprovalExecutor interface {
+	ApprovalOperations() []string
+	ExecuteApproval(ctx context.Context, repo RegistryRepository, approval *models.ApprovalRequest) (interface{}, error)
+}
+
+//go:generate mockery --name ApprovalService --case snake --output ../mocks --disable-version-string
+
+// ApprovalService interface
+type ApprovalService interface {
+	List(ctx context.Context, status string, limit, offset int) (*dto.PaginatedApprovalResponse, error)
+	Get(ctx context.Context, id string) (*models.ApprovalRequest, error)
+	Approve(ctx context.Context, req dto.ApprovalDecisionRequest) (*dto.ApprovalDecisionResponse, error)
+	Reject(ctx context.Context, req dto.ApprovalDecisionRequest) (*dto.ApprovalDecisionResponse, error)
+	ExpirePending(ctx context.Context, limit int) (int, error)
+}
+
+//go:generate mockery --name APIKeyService --case snake --output ../mocks --disable-version-string
+
+// APIKeyService interface
+type APIKeyService interface {
+	Create(ctx context.Context, req dto.CreateAPIKeyRequest) (*dto.APIKeyCredentialsResponse, error)
+	List(ctx context.Context, limit, offset int) (*dto.PaginatedAPIKeyResponse, error)
+	Rotate(ctx context.Context, id, actorID string) (*dto.APIKeyCredentialsResponse, error)
+	Revoke(ctx context.Context, id string) (*models.APIKey, error)
+	Authenticate(ctx context.Context, apiKey string) (*auth.Principal, error)
+}
+
+//go:generate mockery --name AdminService --case snake --output ../mocks --disable-version-string
+
+// AdminService interface
+type AdminService interface {
+	ApprovalExecutor
+	SearchUsers(ctx context.Context, query string) ([]dto.AdminUserResult, error)
+	GetWallet(ctx context.Context, walletID string, limit, offset int) (*dto.AdminWalletResponse, error)
+	Adjust(ctx context.Context, req dto.AdjustmentRequest) (*dto.AdminActionResponse, error)
+	Reverse(ctx context.Context, req dto.ReversalRequest) (*dto.AdminActionResponse, error)
+	Freeze(ctx context.Context, req dto.FreezeWalletRequest) (*dto.AdminActionResponse, error)
+	Unfreeze(ctx context.Context, req dto.FreezeWalletRequest) (*dto.AdminActionResponse, error)
+	UnlockLogin(ctx context.Context, req dto.UnlockLoginRequest) error
+	ExportTransactions(ctx context.Context, req dto.ExportTransactionsRequest) (*dto.TransactionExport, error)
+}
+
+//go:generate mockery --name AuditService --case snake --output ../mocks --disable-version-string
+
+// AuditService interface
+type AuditService interface {
+	List(ctx context.Context, query dto.AuditEventQuery, limit, offset int) (*dto.PaginatedAuditEventResponse, error)
+	RecordSecurityEvent(ctx context.Context, event, resourceType, resourceID string, details map[string]interface{}) error
+}
+
+//go:generate mockery --name LedgerService --case snake --output ../mocks --disable-version-string
+
+// LedgerService interface
+type LedgerService interface {
+	Checkpoint(ctx context.Context, batchSize int) (int, error)
+	Verify(ctx context.Context, walletID string, batchSize int) (*dto.LedgerVerification, error)
+}
+
+//go:generate mockery --name KYCService --case snake --output ../mocks --disable-version-string
+
+// KYCService interface
+type KYCService interface {
+	GetStatus(ctx context.Context, userID string) (*dto.KYCStatusResponse, error)
+	Submit(ctx context.Context, req dto.KYCSubmitRequest) (*models.KYCSubmission, error)
+	List(ctx context.Context, status string, limit, offset int) (*dto.PaginatedKYCSubmissionResponse, error)
+	Get(ctx context.Context, id string) (*models.KYCSubmission, error)
+	OpenDocument(ctx context.Context, id string) (*dto.KYCDocument, error)
+	Approve(ctx context.Context, req dto.KYCReviewRequest) (*models.KYCSubmission, error)
+	Reject(ctx context.Context, req dto.KYCReviewRequest) (*models.KYCSubmission, error)
+}
+
+//go:generate mockery --name InsightService --case snake --output ../mocks --disable-version-string
+
+// InsightService interface
+type InsightService interface {
+	GetInsights(ctx context.Context, userID, month string, months int) (*dto.InsightsResponse, error)
+	SummarizeTransactions(ctx context.Context, batchSize int) (int, error)
+}
+
+//go:generate mockery --name BudgetService --case snake --output ../mocks --disable-version-string
+
+// BudgetService interface
+type BudgetService interface {
+	TransactionListener
+	CreateBudget(ctx context.Context, userID string, req dto.CreateBudgetRequest) (*models.Budget, error)
+	ListBudgets(ctx context.Context, userID string) ([]models.Budget, error)
+	UpdateBudget(ctx context.Context, userID, budgetID string, req dto.UpdateBudgetRequest) (*models.Budget, error)
+	DeleteBudget(ctx context.Context, userID, budgetID string) error
+	GetStatus(ctx context.Context, userID, month string) ([]dto.BudgetStatus, error)
+}
+
+//go:generate mockery --name NotificationService --case snake --output ../mocks --disable-version-string
+
+// NotificationService interface
+type NotificationService interface {
+	TransactionListener
+	DeviceListener
+	GetPreferences(ctx context.Context, userID string) (*models.NotificationPreference, error)
+	UpdatePreferences(ctx context.Context, userID string, req dto.UpdateNotificationPreferencesRequest) (*models.NotificationPreference, error)
+	DeliverPending(ctx context.Context, limit int) (int, error)
+}
+
+//go:generate mockery --name PINService --case snake --output ../mocks --disable-version-string
+
+// PINService interface
+type PINService interface {
+	SetupPIN(ctx context.Context, userID string, req dto.SetupPINRequest) error
+	ChangePIN(ctx context.Context, userID string, req dto.ChangePINRequest) error
+	ResetPIN(ctx context.Context, userID string, req dto.ResetPINRequest) error
+}
+
+//go:generate mockery --name MFAService --case snake --output ../mocks --disable-version-string
+
+// MFAService interface
+type MFAService interface {
+	GetStatus(ctx context.Context, userID string) (*dto.MFAStatusResponse, error)
+	Enroll(ctx context.Context, userID string) (*dto.MFAEnrollmentResponse, error)
+	ConfirmEnrollment(ctx context.Context, userID string, req dto.MFACodeRequest) (*dto.MFARecoveryCodesResponse, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID string, req dto.MFACodeRequest) (*dto.MFARecoveryCodesResponse, error)
+	UpdateSettings(ctx context.Context, userID string, req dto.UpdateMFASettingsRequest) (*dto.MFAStatusResponse, error)
+	Disable(ctx context.Context, userID string, req dto.MFACodeRequest) error
+}
+
+//go:generate mockery --name AccountSender --case snake --output ../mocks --disable-version-string
+
+// AccountSender delivers account activation and password reset tokens to users
+type AccountSender interface {
+	SendActivation(ctx context.Context, user *models.User, token string) error
+	SendPasswordReset(ctx context.Context, user *models.User, token string) error
+}
+
+//go:generate mockery --name AccountService --case snake --output ../mocks --disable-version-string
+
+// AccountService interface
+type AccountService interface {
+	ResendActivation(ctx context.Context, req dto.AccountLookupRequest) error
+	Activate(ctx context.Context, req dto.ActivateAccountRequest) error
+	ForgotPassword(ctx context.Context, req dto.AccountLookupRequest) error
+	ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error
+	CheckActive(ctx context.Context, userID string) error
+}
+
+//go:generate mockery --name AuthService --case snake --output ../mocks --disable-version-string
+
+// AuthService interface
+type AuthService interface {
+	Register(ctx context.Context, req dto.RegisterRequest) (*dto.AuthResponse, error)
+	Login(ctx context.Context, req dto.LoginRequest) (*dto.AuthResponse, error)
+	Refresh(ctx context.Context, req dto.RefreshTokenRequest) (*dto.AuthResponse, error)
+	VerifyMFA(ctx context.Context, req dto.VerifyMFARequest) (*dto.AuthResponse, error)
+	Logout(ctx context.Context, req dto.RefreshTokenRequest) error
+	TouchSession(ctx context.Context, userID, sessionID string) (*models.Session, error)
+	LocalPrincipal(ctx context.Context, userID string) (*auth.Principal, error)
+	ListSessions(ctx context.Context, userID, currentSessionID string) ([]dto.SessionResponse, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID string) (*dto.RevokeSessionsResponse, error)
+}
+
+//go:generate mockery --name DeviceService --case snake --output ../mocks --disable-version-string
+
+// DeviceService interface
+type DeviceService interface {
+	Record(ctx context.Context, userID string, device dto.DeviceInfo) error
+	List(ctx context.Context, userID, currentDeviceID string) ([]dto.DeviceResponse, error)
+	Remove(ctx context.Context, userID, id string) (*dto.RevokeSessionsResponse, error)
+}
@@ -44,6 +44,586 @@ func (_m *RegistryRepository) DoInTransaction(ctx context.Context, txFunc interf
 	return r0, r1
 }
 
+// GetAPIKeyRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetAPIKeyRepository() interfaces.APIKeyRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKeyRepository")
+	}
+
+	var r0 interfaces.APIKeyRepository
+	if rf, ok := ret.Get(0).(func() interfaces.APIKeyRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.APIKeyRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetAccountTokenRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetAccountTokenRepository() interfaces.AccountTokenRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountTokenRepository")
+	}
+
+	var r0 interfaces.AccountTokenRepository
+	if rf, ok := ret.Get(0).(func() interfaces.AccountTokenRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.AccountTokenRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetAdminActionRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetAdminActionRepository() interfaces.AdminActionRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdminActionRepository")
+	}
+
+	var r0 interfaces.AdminActionRepository
+	if rf, ok := ret.Get(0).(func() interfaces.AdminActionRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.AdminActionRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetApprovalRequestRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetApprovalRequestRepository() interfaces.ApprovalRequestRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApprovalRequestRepository")
+	}
+
+	var r0 interfaces.ApprovalRequestRepository
+	if rf, ok := ret.Get(0).(func() interfaces.ApprovalRequestRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.ApprovalRequestRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetAttemptRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetAttemptRepository() interfaces.AttemptRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttemptRepository")
+	}
+
+	var r0 interfaces.AttemptRepository
+	if rf, ok := ret.Get(0).(func() interfaces.AttemptRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.AttemptRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetAuditEventRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetAuditEventRepository() interfaces.AuditEventRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuditEventRepository")
+	}
+
+	var r0 interfaces.AuditEventRepository
+	if rf, ok := ret.Get(0).(func() interfaces.AuditEventRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.AuditEventRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetBudgetAlertRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetBudgetAlertRepository() interfaces.BudgetAlertRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBudgetAlertRepository")
+	}
+
+	var r0 interfaces.BudgetAlertRepository
+	if rf, ok := ret.Get(0).(func() interfaces.BudgetAlertRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.BudgetAlertRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetBudgetRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetBudgetRepository() interfaces.BudgetRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBudgetRepository")
+	}
+
+	var r0 interfaces.BudgetRepository
+	if rf, ok := ret.Get(0).(func() interfaces.BudgetRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.BudgetRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetCashbackCampaignRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetCashbackCampaignRepository() interfaces.CashbackCampaignRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCashbackCampaignRepository")
+	}
+
+	var r0 interfaces.CashbackCampaignRepository
+	if rf, ok := ret.Get(0).(func() interfaces.CashbackCampaignRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.CashbackCampaignRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetCashbackRewardRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetCashbackRewardRepository() interfaces.CashbackRewardRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCashbackRewardRepository")
+	}
+
+	var r0 interfaces.CashbackRewardRepository
+	if rf, ok := ret.Get(0).(func() interfaces.CashbackRewardRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.CashbackRewardRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetChallengeRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetChallengeRepository() interfaces.ChallengeRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChallengeRepository")
+	}
+
+	var r0 interfaces.ChallengeRepository
+	if rf, ok := ret.Get(0).(func() interfaces.ChallengeRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.ChallengeRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetDeviceRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetDeviceRepository() interfaces.DeviceRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceRepository")
+	}
+
+	var r0 interfaces.DeviceRepository
+	if rf, ok := ret.Get(0).(func() interfaces.DeviceRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.DeviceRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetEscrowEventRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetEscrowEventRepository() interfaces.EscrowEventRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEscrowEventRepository")
+	}
+
+	var r0 interfaces.EscrowEventRepository
+	if rf, ok := ret.Get(0).(func() interfaces.EscrowEventRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.EscrowEventRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetEscrowRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetEscrowRepository() interfaces.EscrowRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEscrowRepository")
+	}
+
+	var r0 interfaces.EscrowRepository
+	if rf, ok := ret.Get(0).(func() interfaces.EscrowRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.EscrowRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetKYCSubmissionRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetKYCSubmissionRepository() interfaces.KYCSubmissionRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetKYCSubmissionRepository")
+	}
+
+	var r0 interfaces.KYCSubmissionRepository
+	if rf, ok := ret.Get(0).(func() interfaces.KYCSubmissionRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.KYCSubmissionRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetLedgerChainRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetLedgerChainRepository() interfaces.LedgerChainRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLedgerChainRepository")
+	}
+
+	var r0 interfaces.LedgerChainRepository
+	if rf, ok := ret.Get(0).(func() interfaces.LedgerChainRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.LedgerChainRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetMFARecoveryCodeRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetMFARecoveryCodeRepository() interfaces.MFARecoveryCodeRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMFARecoveryCodeRepository")
+	}
+
+	var r0 interfaces.MFARecoveryCodeRepository
+	if rf, ok := ret.Get(0).(func() interfaces.MFARecoveryCodeRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.MFARecoveryCodeRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetMFARepository provides a mock function with no fields
+func (_m *RegistryRepository) GetMFARepository() interfaces.MFARepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMFARepository")
+	}
+
+	var r0 interfaces.MFARepository
+	if rf, ok := ret.Get(0).(func() interfaces.MFARepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.MFARepository)
+		}
+	}
+
+	return r0
+}
+
+// GetMerchantRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetMerchantRepository() interfaces.MerchantRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMerchantRepository")
+	}
+
+	var r0 interfaces.MerchantRepository
+	if rf, ok := ret.Get(0).(func() interfaces.MerchantRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.MerchantRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetNotificationPreferenceRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetNotificationPreferenceRepository() interfaces.NotificationPreferenceRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationPreferenceRepository")
+	}
+
+	var r0 interfaces.NotificationPreferenceRepository
+	if rf, ok := ret.Get(0).(func() interfaces.NotificationPreferenceRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.NotificationPreferenceRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetNotificationRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetNotificationRepository() interfaces.NotificationRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationRepository")
+	}
+
+	var r0 interfaces.NotificationRepository
+	if rf, ok := ret.Get(0).(func() interfaces.NotificationRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.NotificationRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetPaymentIntentRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetPaymentIntentRepository() interfaces.PaymentIntentRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPaymentIntentRepository")
+	}
+
+	var r0 interfaces.PaymentIntentRepository
+	if rf, ok := ret.Get(0).(func() interfaces.PaymentIntentRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.PaymentIntentRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetPocketRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetPocketRepository() interfaces.PocketRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPocketRepository")
+	}
+
+	var r0 interfaces.PocketRepository
+	if rf, ok := ret.Get(0).(func() interfaces.PocketRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.PocketRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetPromoCodeRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetPromoCodeRepository() interfaces.PromoCodeRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromoCodeRepository")
+	}
+
+	var r0 interfaces.PromoCodeRepository
+	if rf, ok := ret.Get(0).(func() interfaces.PromoCodeRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.PromoCodeRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetPromoRedemptionRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetPromoRedemptionRepository() interfaces.PromoRedemptionRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromoRedemptionRepository")
+	}
+
+	var r0 interfaces.PromoRedemptionRepository
+	if rf, ok := ret.Get(0).(func() interfaces.PromoRedemptionRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.PromoRedemptionRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetRoleRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetRoleRepository() interfaces.RoleRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoleRepository")
+	}
+
+	var r0 interfaces.RoleRepository
+	if rf, ok := ret.Get(0).(func() interfaces.RoleRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.RoleRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetSessionRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetSessionRepository() interfaces.SessionRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessionRepository")
+	}
+
+	var r0 interfaces.SessionRepository
+	if rf, ok := ret.Get(0).(func() interfaces.SessionRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.SessionRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetTransactionSummaryRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetTransactionSummaryRepository() interfaces.TransactionSummaryRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionSummaryRepository")
+	}
+
+	var r0 interfaces.TransactionSummaryRepository
+	if rf, ok := ret.Get(0).(func() interfaces.TransactionSummaryRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.TransactionSummaryRepository)
+		}
+	}
+
+	return r0
+}
+
+// GetUserRepository provides a mock function with no fields
+func (_m *RegistryRepository) GetUserRepository() interfaces.UserRepository {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserRepository")
+	}
+
+	var r0 interfaces.UserRepository
+	if rf, ok := ret.Get(0).(func() interfaces.UserRepository); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.UserRepository)
+		}
+	}
+
+	return r0
+}
+
 // GetWalletRepository provides a mock function with no fields
 func (_m *RegistryRepository) GetWalletRepository() interfaces.WalletRepository {
 	ret := _m.Called()
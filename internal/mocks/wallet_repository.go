@@ -33,6 +33,36 @@ func (_m *WalletRepository) Create(ctx context.Context, wallet *models.Wallet) e
 	return r0
 }
 
+// Deposit provides a mock function with given fields: ctx, walletID, amount
+func (_m *WalletRepository) Deposit(ctx context.Context, walletID string, amount float64) (*models.Wallet, error) {
+	ret := _m.Called(ctx, walletID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Deposit")
+	}
+
+	var r0 *models.Wallet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64) (*models.Wallet, error)); ok {
+		return rf(ctx, walletID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64) *models.Wallet); ok {
+		r0 = rf(ctx, walletID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Wallet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64) error); ok {
+		r1 = rf(ctx, walletID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBalance provides a mock function with given fields: ctx, walletID
 func (_m *WalletRepository) GetBalance(ctx context.Context, walletID string) (float64, error) {
 	ret := _m.Called(ctx, walletID)
@@ -121,6 +151,34 @@ func (_m *WalletRepository) GetByUserID(ctx context.Context, userID string) (*mo
 	return r0, r1
 }
 
+// SetActive provides a mock function with given fields: ctx, walletID, active
+func (_m *WalletRepository) SetActive(ctx context.Context, walletID string, active bool) (bool, error) {
+	ret := _m.Called(ctx, walletID, active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetActive")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (bool, error)); ok {
+		return rf(ctx, walletID, active)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) bool); ok {
+		r0 = rf(ctx, walletID, active)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, walletID, active)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, wallet
 func (_m *WalletRepository) Update(ctx context.Context, wallet *models.Wallet) error {
 	ret := _m.Called(ctx, wallet)
@@ -8,6 +8,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	models "digital-wallet/internal/models"
+
+	time "time"
 )
 
 // WalletTransactionRepository is an autogenerated mock type for the WalletTransactionRepository type
@@ -121,6 +123,178 @@ func (_m *WalletTransactionRepository) GetByWalletID(ctx context.Context, wallet
 	return r0, r1
 }
 
+// GetByWalletIDBetween provides a mock function with given fields: ctx, walletID, from, to
+func (_m *WalletTransactionRepository) GetByWalletIDBetween(ctx context.Context, walletID string, from time.Time, to time.Time) ([]models.WalletTransaction, error) {
+	ret := _m.Called(ctx, walletID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByWalletIDBetween")
+	}
+
+	var r0 []models.WalletTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]models.WalletTransaction, error)); ok {
+		return rf(ctx, walletID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []models.WalletTransaction); ok {
+		r0 = rf(ctx, walletID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WalletTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, walletID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCreatedAfter provides a mock function with given fields: ctx, createdAt, id, until, limit
+func (_m *WalletTransactionRepository) GetCreatedAfter(ctx context.Context, createdAt time.Time, id string, until time.Time, limit int) ([]models.WalletTransaction, error) {
+	ret := _m.Called(ctx, createdAt, id, until, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCreatedAfter")
+	}
+
+	var r0 []models.WalletTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, string, time.Time, int) ([]models.WalletTransaction, error)); ok {
+		return rf(ctx, createdAt, id, until, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, string, time.Time, int) []models.WalletTransaction); ok {
+		r0 = rf(ctx, createdAt, id, until, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WalletTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, string, time.Time, int) error); ok {
+		r1 = rf(ctx, createdAt, id, until, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasCompletedWithdrawalTo provides a mock function with given fields: ctx, walletID, beneficiary
+func (_m *WalletTransactionRepository) HasCompletedWithdrawalTo(ctx context.Context, walletID string, beneficiary string) (bool, error) {
+	ret := _m.Called(ctx, walletID, beneficiary)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasCompletedWithdrawalTo")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, walletID, beneficiary)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, walletID, beneficiary)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, walletID, beneficiary)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsReversed provides a mock function with given fields: ctx, transactionID
+func (_m *WalletTransactionRepository) IsReversed(ctx context.Context, transactionID string) (bool, error) {
+	ret := _m.Called(ctx, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsReversed")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, transactionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, transactionID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SumOutgoing provides a mock function with given fields: ctx, walletID, from
+func (_m *WalletTransactionRepository) SumOutgoing(ctx context.Context, walletID string, from time.Time) (float64, error) {
+	ret := _m.Called(ctx, walletID, from)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumOutgoing")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (float64, error)); ok {
+		return rf(ctx, walletID, from)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) float64); ok {
+		r0 = rf(ctx, walletID, from)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, walletID, from)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SumSpent provides a mock function with given fields: ctx, walletID, category, from, to
+func (_m *WalletTransactionRepository) SumSpent(ctx context.Context, walletID string, category string, from time.Time, to time.Time) (float64, error) {
+	ret := _m.Called(ctx, walletID, category, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumSpent")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (float64, error)); ok {
+		return rf(ctx, walletID, category, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) float64); ok {
+		r0 = rf(ctx, walletID, category, from, to)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, walletID, category, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, transaction
 func (_m *WalletTransactionRepository) Update(ctx context.Context, transaction *models.WalletTransaction) error {
 	ret := _m.Called(ctx, transaction)
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Back-office actions recorded in admin_actions
+const (
+	AdminActionCredit   = "CREDIT"
+	AdminActionDebit    = "DEBIT"
+	AdminActionFreeze   = "FREEZE"
+	AdminActionUnfreeze = "UNFREEZE"
+	AdminActionExport   = "EXPORT"
+	AdminActionReverse  = "REVERSE"
+)
+
+// AdminAction is one append-only entry in the back-office history of a wallet, recording who
+// did what and why
+type AdminAction struct {
+	ID            string    `json:"id" gorm:"primaryKey"`
+	ActorID       string    `json:"actor_id" gorm:"not null;index"`
+	Action        string    `json:"action" gorm:"type:enum('CREDIT','DEBIT','FREEZE','UNFREEZE','EXPORT','REVERSE');not null"`
+	UserID        string    `json:"user_id" gorm:"not null"`
+	WalletID      string    `json:"wallet_id" gorm:"not null;index"`
+	TransactionID *string   `json:"transaction_id" gorm:"null"`
+	ApprovalID    *string   `json:"approval_id,omitempty" gorm:"null"`
+	Amount        float64   `json:"amount,omitempty" gorm:"type:decimal(15,2);default:0"`
+	Reason        string    `json:"reason" gorm:"not null"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for AdminAction model
+func (AdminAction) TableName() string {
+	return "admin_actions"
+}
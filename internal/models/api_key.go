@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// APIKey authenticates an internal service such as checkout or payroll. Only the hash of the
+// key is stored; Prefix is the part before the dot, kept in clear to look the key up.
+type APIKey struct {
+	ID         string                      `json:"id" gorm:"primaryKey"`
+	Name       string                      `json:"name" gorm:"size:100;not null"`
+	Prefix     string                      `json:"prefix" gorm:"size:32;uniqueIndex;not null"`
+	KeyHash    string                      `json:"-" gorm:"size:64;not null"`
+	Scopes     datatypes.JSONSlice[string] `json:"scopes" gorm:"type:json;not null"`
+	CreatedBy  string                      `json:"created_by" gorm:"not null"`
+	ExpiresAt  time.Time                   `json:"expires_at" gorm:"not null"`
+	LastUsedAt *time.Time                  `json:"last_used_at" gorm:"null"`
+	RevokedAt  *time.Time                  `json:"revoked_at" gorm:"null"`
+	ReplacedBy *string                     `json:"replaced_by,omitempty" gorm:"size:36;null"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	UpdatedAt  time.Time                   `json:"updated_at"`
+}
+
+// TableName specifies the table name for APIKey model
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// IsUsable reports whether the key has neither been revoked nor expired
+func (k *APIKey) IsUsable(now time.Time) bool {
+	return k.RevokedAt == nil && now.Before(k.ExpiresAt)
+}
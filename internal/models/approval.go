@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// ApprovalRequest statuses
+const (
+	ApprovalStatusPending  = "PENDING"
+	ApprovalStatusApproved = "APPROVED"
+	ApprovalStatusRejected = "REJECTED"
+	ApprovalStatusExpired  = "EXPIRED"
+)
+
+// ApprovalRequest is a back-office operation proposed by a maker that only runs once a
+// different person, the checker, approves it. Operation names the permission the operation
+// needs and Payload holds its arguments.
+type ApprovalRequest struct {
+	ID        string         `json:"id" gorm:"primaryKey"`
+	Operation string         `json:"operation" gorm:"size:64;not null"`
+	Payload   datatypes.JSON `json:"payload" gorm:"type:json;not null"`
+	MakerID   string         `json:"maker_id" gorm:"not null;index"`
+	Status    string         `json:"status" gorm:"type:enum('PENDING','APPROVED','REJECTED','EXPIRED');default:'PENDING';not null"`
+	CheckerID *string        `json:"checker_id" gorm:"null"`
+	Note      string         `json:"note,omitempty" gorm:"null"`
+	DecidedAt *time.Time     `json:"decided_at" gorm:"null"`
+	ExpiresAt time.Time      `json:"expires_at" gorm:"not null"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// TableName specifies the table name for ApprovalRequest model
+func (ApprovalRequest) TableName() string {
+	return "approval_requests"
+}
+
+// IsExpired reports whether the request is still pending past its expiry time
+func (a *ApprovalRequest) IsExpired(now time.Time) bool {
+	return a.Status == ApprovalStatusPending && !now.Before(a.ExpiresAt)
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Principal types recorded in audit_events besides the auth principal types
+const (
+	// AuditPrincipalAnonymous is a request made before signing in, such as a failed login
+	AuditPrincipalAnonymous = "ANONYMOUS"
+	// AuditPrincipalSystem is a change made outside an HTTP request, by a cron job
+	AuditPrincipalSystem = "SYSTEM"
+)
+
+// Resources audit events are recorded against
+const (
+	AuditResourceWallet   = "wallet"
+	AuditResourceUser     = "user"
+	AuditResourceApproval = "approval_request"
+	AuditResourceAPIKey   = "api_key"
+	AuditResourceMerchant = "merchant"
+	AuditResourceKYC      = "kyc_submission"
+	AuditResourceDevice   = "device"
+	AuditResourcePocket   = "pocket"
+	AuditResourceIP       = "ip_address"
+	AuditResourcePhone    = "phone_number"
+	AuditResourceCampaign = "cashback_campaign"
+	AuditResourceReward   = "cashback_reward"
+	AuditResourceBudget   = "budget"
+	AuditResourcePrefs    = "notification_preference"
+	AuditResourceIntent   = "payment_intent"
+	AuditResourceEscrow   = "escrow"
+	AuditResourceSession  = "session"
+)
+
+// Actions recorded in audit_events. Security events are recorded as "security." followed by
+// the event name.
+const (
+	AuditActionWalletCredit    = "wallet.credit"
+	AuditActionWalletDebit     = "wallet.debit"
+	AuditActionWalletFreeze    = "wallet.freeze"
+	AuditActionWalletUnfreeze  = "wallet.unfreeze"
+	AuditActionWalletExport    = "wallet.export"
+	AuditActionApprovalRequest = "approval.request"
+	AuditActionApprovalApprove = "approval.approve"
+	AuditActionApprovalReject  = "approval.reject"
+	AuditActionAPIKeyCreate    = "api_key.create"
+	AuditActionAPIKeyRotate    = "api_key.rotate"
+	AuditActionAPIKeyRevoke    = "api_key.revoke"
+	AuditActionMerchantCreate  = "merchant.create"
+	AuditActionMerchantUpdate  = "merchant.update"
+	AuditActionMerchantRotate  = "merchant.rotate_key"
+	AuditActionKYCSubmit       = "kyc.submit"
+	AuditActionKYCApprove      = "kyc.approve"
+	AuditActionKYCReject       = "kyc.reject"
+	AuditActionUserRegister    = "user.register"
+	AuditActionUserActivate    = "user.activate"
+	AuditActionPasswordReset   = "user.password_reset"
+	AuditActionPINSetup        = "user.pin_setup"
+	AuditActionPINChange       = "user.pin_change"
+	AuditActionPINReset        = "user.pin_reset"
+	AuditActionMFAEnable       = "user.mfa_enable"
+	AuditActionMFADisable      = "user.mfa_disable"
+	AuditActionMFASettings     = "user.mfa_settings"
+	AuditActionMFARecovery     = "user.mfa_recovery_codes"
+	AuditActionDeviceRemove    = "device.remove"
+	AuditActionPocketCreate    = "pocket.create"
+	AuditActionPocketUpdate    = "pocket.update"
+	AuditActionPocketDelete    = "pocket.delete"
+	AuditActionCampaignCreate  = "cashback.campaign_create"
+	AuditActionCampaignStop    = "cashback.campaign_deactivate"
+	AuditActionRewardGrant     = "cashback.reward_grant"
+	AuditActionRewardCredit    = "cashback.reward_credit"
+	AuditActionBudgetCreate    = "budget.create"
+	AuditActionBudgetUpdate    = "budget.update"
+	AuditActionBudgetDelete    = "budget.delete"
+	AuditActionPrefsUpdate     = "notification.preferences_update"
+	AuditActionIntentCreate    = "payment_intent.create"
+	AuditActionIntentConfirm   = "payment_intent.confirm"
+	AuditActionIntentCancel    = "payment_intent.cancel"
+	AuditActionIntentExpire    = "payment_intent.expire"
+	AuditActionEscrowFund      = "escrow.fund"
+	AuditActionEscrowRelease   = "escrow.release"
+	AuditActionEscrowAutoRel   = "escrow.auto_release"
+	AuditActionEscrowRefund    = "escrow.refund"
+	AuditActionSessionRevoke   = "session.revoke"
+	AuditActionSessionsRevoke  = "user.sessions_revoke"
+)
+
+// AuditEvent is one append-only entry in the audit log, recording who changed what, from
+// which request, and the state of the resource before and after. The database refuses
+// updates and deletes of audit_events.
+type AuditEvent struct {
+	ID            string         `json:"id" gorm:"primaryKey"`
+	ActorID       string         `json:"actor_id" gorm:"size:36"`
+	PrincipalType string         `json:"principal_type" gorm:"size:16;not null"`
+	Action        string         `json:"action" gorm:"size:64;not null"`
+	ResourceType  string         `json:"resource_type" gorm:"size:32;not null"`
+	ResourceID    string         `json:"resource_id" gorm:"size:64;not null"`
+	Before        datatypes.JSON `json:"before,omitempty" gorm:"type:json"`
+	After         datatypes.JSON `json:"after,omitempty" gorm:"type:json"`
+	RequestID     string         `json:"request_id,omitempty" gorm:"size:64"`
+	IPAddress     string         `json:"ip_address,omitempty" gorm:"size:45"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+// TableName specifies the table name for AuditEvent model
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
+
+// AuditEventFilter narrows an audit log query; empty fields match every event
+type AuditEventFilter struct {
+	ActorID       string
+	PrincipalType string
+	Action        string
+	ResourceType  string
+	ResourceID    string
+	RequestID     string
+	From          time.Time
+	To            time.Time
+}
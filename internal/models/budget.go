@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// Budget alert thresholds, as a percentage of the budget amount
+const (
+	BudgetThresholdWarning  = 80
+	BudgetThresholdExceeded = 100
+)
+
+// BudgetThresholds are checked in ascending order
+var BudgetThresholds = []int{BudgetThresholdWarning, BudgetThresholdExceeded}
+
+// Budget status values
+const (
+	BudgetStatusOnTrack  = "ON_TRACK"
+	BudgetStatusWarning  = "WARNING"
+	BudgetStatusExceeded = "EXCEEDED"
+)
+
+// Budget caps the monthly spending of a wallet in one category
+type Budget struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	WalletID  string    `json:"wallet_id" gorm:"not null;uniqueIndex:idx_wallet_category"`
+	Category  string    `json:"category" gorm:"size:32;not null;uniqueIndex:idx_wallet_category"`
+	Amount    float64   `json:"amount" gorm:"type:decimal(15,2);not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations
+	Wallet *Wallet `json:"-" gorm:"foreignKey:WalletID;references:ID"`
+}
+
+// BudgetAlert records that a budget crossed a threshold in a period. The unique key on
+// (budget_id, period, threshold) makes each threshold fire at most once per period.
+type BudgetAlert struct {
+	ID            string    `json:"id" gorm:"primaryKey"`
+	BudgetID      string    `json:"budget_id" gorm:"not null;uniqueIndex:idx_budget_period_threshold"`
+	Period        time.Time `json:"period" gorm:"type:date;not null;uniqueIndex:idx_budget_period_threshold"`
+	Threshold     int       `json:"threshold" gorm:"not null;uniqueIndex:idx_budget_period_threshold"`
+	BudgetAmount  float64   `json:"budget_amount" gorm:"type:decimal(15,2);not null"`
+	SpentAmount   float64   `json:"spent_amount" gorm:"type:decimal(15,2);not null"`
+	TransactionID string    `json:"transaction_id" gorm:"not null"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for Budget model
+func (Budget) TableName() string {
+	return "budgets"
+}
+
+// TableName specifies the table name for BudgetAlert model
+func (BudgetAlert) TableName() string {
+	return "budget_alerts"
+}
+
+// CrossedThresholds returns the thresholds that spent has reached
+func (b *Budget) CrossedThresholds(spent float64) []int {
+	var crossed []int
+	for _, threshold := range BudgetThresholds {
+		if spent >= b.Amount*float64(threshold)/100 {
+			crossed = append(crossed, threshold)
+		}
+	}
+	return crossed
+}
+
+// StatusFor describes how much of the budget spent has used
+func (b *Budget) StatusFor(spent float64) string {
+	crossed := b.CrossedThresholds(spent)
+	switch {
+	case len(crossed) == 0:
+		return BudgetStatusOnTrack
+	case crossed[len(crossed)-1] >= BudgetThresholdExceeded:
+		return BudgetStatusExceeded
+	default:
+		return BudgetStatusWarning
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Cashback reward statuses
+const (
+	CashbackRewardStatusHeld     = "HELD"
+	CashbackRewardStatusCredited = "CREDITED"
+)
+
+type CashbackCampaign struct {
+	ID            string         `json:"id" gorm:"primaryKey"`
+	Name          string         `json:"name" gorm:"not null"`
+	Percentage    float64        `json:"percentage" gorm:"type:decimal(5,2);not null"`
+	MaxCashback   float64        `json:"max_cashback" gorm:"type:decimal(15,2);default:0"`
+	MinSpend      float64        `json:"min_spend" gorm:"type:decimal(15,2);default:0"`
+	StartsAt      time.Time      `json:"starts_at" gorm:"not null"`
+	EndsAt        time.Time      `json:"ends_at" gorm:"not null"`
+	PerUserBudget float64        `json:"per_user_budget" gorm:"type:decimal(15,2);default:0"`
+	TotalBudget   float64        `json:"total_budget" gorm:"type:decimal(15,2);not null"`
+	UsedBudget    float64        `json:"used_budget" gorm:"type:decimal(15,2);default:0"`
+	HoldingDays   int            `json:"holding_days" gorm:"default:0"`
+	IsActive      bool           `json:"is_active" gorm:"default:true"`
+	CreatedBy     string         `json:"created_by" gorm:"size:36"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+type CashbackReward struct {
+	ID                    string     `json:"id" gorm:"primaryKey"`
+	CampaignID            string     `json:"campaign_id" gorm:"not null;index"`
+	UserID                string     `json:"user_id" gorm:"not null;index"`
+	WalletID              string     `json:"wallet_id" gorm:"not null"`
+	SourceTransactionID   string     `json:"source_transaction_id" gorm:"not null"`
+	Amount                float64    `json:"amount" gorm:"type:decimal(15,2)"`
+	Status                string     `json:"status" gorm:"type:enum('HELD','CREDITED');default:'HELD'"`
+	ReleaseAt             time.Time  `json:"release_at" gorm:"not null"`
+	CreditedTransactionID *string    `json:"credited_transaction_id" gorm:"null"`
+	CreditedAt            *time.Time `json:"credited_at" gorm:"null"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+
+	// Relations
+	Campaign *CashbackCampaign `json:"-" gorm:"foreignKey:CampaignID;references:ID"`
+}
+
+// CashbackRewardSummary aggregates a campaign's rewards for one status
+type CashbackRewardSummary struct {
+	Status  string
+	Rewards int64
+	Amount  float64
+}
+
+// TableName specifies the table name for CashbackCampaign model
+func (CashbackCampaign) TableName() string {
+	return "cashback_campaigns"
+}
+
+// TableName specifies the table name for CashbackReward model
+func (CashbackReward) TableName() string {
+	return "cashback_rewards"
+}
+
+// RemainingBudget is what is left of the campaign's global budget
+func (c *CashbackCampaign) RemainingBudget() float64 {
+	return c.TotalBudget - c.UsedBudget
+}
+
+// IsRunning reports whether the campaign accepts new payments at the given time
+func (c *CashbackCampaign) IsRunning(now time.Time) bool {
+	return c.IsActive && !now.Before(c.StartsAt) && now.Before(c.EndsAt)
+}
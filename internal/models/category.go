@@ -0,0 +1,145 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Transaction categories
+const (
+	CategoryFoodAndDrink  = "FOOD_AND_DRINK"
+	CategoryGroceries     = "GROCERIES"
+	CategoryTransport     = "TRANSPORT"
+	CategoryShopping      = "SHOPPING"
+	CategoryBills         = "BILLS"
+	CategoryEntertainment = "ENTERTAINMENT"
+	CategoryHealth        = "HEALTH"
+	CategoryTopUp         = "TOP_UP"
+	CategoryIncome        = "INCOME"
+	CategoryCashOut       = "CASH_OUT"
+	CategorySavings       = "SAVINGS"
+	CategoryRewards       = "REWARDS"
+	CategoryRefunds       = "REFUNDS"
+	CategoryFees          = "FEES"
+	CategoryAdjustments   = "ADJUSTMENTS"
+	CategoryOther         = "OTHER"
+)
+
+// SpendingCategories are the categories of money leaving a wallet that budgets can cover
+var SpendingCategories = []string{
+	CategoryFoodAndDrink,
+	CategoryGroceries,
+	CategoryTransport,
+	CategoryShopping,
+	CategoryBills,
+	CategoryEntertainment,
+	CategoryHealth,
+	CategoryCashOut,
+	CategoryFees,
+	CategoryOther,
+}
+
+// categoryRule assigns a category when any keyword appears in the description or merchant name
+type categoryRule struct {
+	Category string
+	Keywords []string
+}
+
+// spendingRules are checked in order; the first match wins
+var spendingRules = []categoryRule{
+	{CategoryGroceries, []string{"grocery", "groceries", "supermarket", "mart", "indomaret", "alfamart", "hypermarket", "sayur"}},
+	{CategoryFoodAndDrink, []string{"restaurant", "resto", "cafe", "coffee", "kopi", "food", "makan", "bakery", "pizza", "burger", "warung"}},
+	{CategoryTransport, []string{"gojek", "grab", "taxi", "uber", "fuel", "pertamina", "shell", "parking", "parkir", "toll", "train", "kereta", "bus"}},
+	{CategoryBills, []string{"pln", "electricity", "listrik", "water", "pdam", "internet", "telkom", "pulsa", "bpjs", "insurance", "bill"}},
+	{CategoryEntertainment, []string{"cinema", "xxi", "netflix", "spotify", "game", "steam", "concert", "ticket"}},
+	{CategoryHealth, []string{"pharmacy", "apotek", "clinic", "klinik", "hospital", "doctor", "dokter"}},
+	{CategoryShopping, []string{"shop", "store", "toko", "tokopedia", "shopee", "lazada", "mall", "fashion"}},
+}
+
+// BeforeCreate fills in the direction and category of a transaction that was created without them
+func (t *WalletTransaction) BeforeCreate(tx *gorm.DB) error {
+	if t.Direction == "" {
+		t.Direction = DefaultDirection(t.Type)
+	}
+
+	if t.Category == "" {
+		t.Category = CategorizeTransaction(t)
+	}
+
+	return nil
+}
+
+// DefaultDirection is the usual direction of a transaction type. Types that move money both
+// ways, such as transfers and escrow, must set the direction explicitly.
+func DefaultDirection(transactionType string) string {
+	switch transactionType {
+	case TransactionTypeWithdrawal, TransactionTypePayment:
+		return TransactionDirectionDebit
+	default:
+		return TransactionDirectionCredit
+	}
+}
+
+// CategorizeTransaction assigns a category from the transaction type, its description and,
+// for merchant payments, the merchant name stored in the metadata
+func CategorizeTransaction(t *WalletTransaction) string {
+	description := strings.ToLower(t.Description)
+
+	switch t.Type {
+	case TransactionTypeTransfer:
+		return CategorySavings
+	case TransactionTypeFee:
+		return CategoryFees
+	case TransactionTypeAdjustment:
+		return CategoryAdjustments
+	case TransactionTypeRefund:
+		return CategoryRefunds
+	case TransactionTypeWithdrawal:
+		return CategoryCashOut
+	case TransactionTypeDeposit:
+		switch {
+		case strings.HasPrefix(description, "cashback"), strings.HasPrefix(description, "promo code"):
+			return CategoryRewards
+		case strings.HasPrefix(description, "settlement"):
+			return CategoryIncome
+		default:
+			return CategoryTopUp
+		}
+	case TransactionTypeEscrow:
+		if t.Direction == TransactionDirectionCredit {
+			return CategoryIncome
+		}
+	}
+
+	text := description + " " + strings.ToLower(merchantName(t))
+	for _, rule := range spendingRules {
+		for _, keyword := range rule.Keywords {
+			if strings.Contains(text, keyword) {
+				return rule.Category
+			}
+		}
+	}
+
+	if t.Type == TransactionTypeEscrow {
+		return CategoryShopping
+	}
+
+	return CategoryOther
+}
+
+func merchantName(t *WalletTransaction) string {
+	if len(t.Metadata) == 0 {
+		return ""
+	}
+
+	var metadata struct {
+		MerchantName string `json:"merchant_name"`
+	}
+	if err := json.Unmarshal(t.Metadata, &metadata); err != nil {
+		return ""
+	}
+
+	return metadata.MerchantName
+}
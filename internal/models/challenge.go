@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Reasons a withdrawal needs a step-up challenge
+const (
+	ChallengeReasonLargeAmount    = "large_amount"
+	ChallengeReasonNewDevice      = "new_device"
+	ChallengeReasonNewBeneficiary = "new_beneficiary"
+)
+
+// Challenge is a one-time code sent to a user to confirm a risky withdrawal. It is bound to
+// the withdrawal it was issued for by a hash of the payload. Challenges are kept in Redis
+// and expire with their code.
+type Challenge struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	PayloadHash string    `json:"payload_hash"`
+	CodeHash    string    `json:"code_hash"`
+	Reasons     []string  `json:"reasons"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
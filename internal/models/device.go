@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Device is a device a user has logged in or called the wallet API from, identified by the
+// ID the client sends. A device stays new, with lower withdrawal limits, for a while after
+// it is first seen.
+type Device struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	UserID      string    `json:"user_id" gorm:"size:36;not null;uniqueIndex:idx_devices_user_device"`
+	DeviceID    string    `json:"device_id" gorm:"size:64;not null;uniqueIndex:idx_devices_user_device"`
+	DeviceName  string    `json:"device_name,omitempty" gorm:"size:100"`
+	UserAgent   string    `json:"user_agent,omitempty" gorm:"size:255"`
+	IPAddress   string    `json:"ip_address,omitempty" gorm:"size:45"`
+	FirstSeenAt time.Time `json:"first_seen_at" gorm:"not null"`
+	LastSeenAt  time.Time `json:"last_seen_at" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for Device model
+func (Device) TableName() string {
+	return "devices"
+}
+
+// IsNew reports whether the device was first seen less than window before now
+func (d *Device) IsNew(now time.Time, window time.Duration) bool {
+	return now.Sub(d.FirstSeenAt) < window
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Escrow statuses
+const (
+	EscrowStatusFunded   = "FUNDED"
+	EscrowStatusReleased = "RELEASED"
+	EscrowStatusRefunded = "REFUNDED"
+)
+
+// Escrow history actions
+const (
+	EscrowActionFund        = "FUND"
+	EscrowActionRelease     = "RELEASE"
+	EscrowActionAutoRelease = "AUTO_RELEASE"
+	EscrowActionRefund      = "REFUND"
+)
+
+// EscrowActorSystem is recorded as the actor of actions taken by scheduled jobs
+const EscrowActorSystem = "system"
+
+// escrowTransitions lists the statuses each escrow status may move to
+var escrowTransitions = map[string][]string{
+	EscrowStatusFunded: {
+		EscrowStatusReleased,
+		EscrowStatusRefunded,
+	},
+}
+
+type Escrow struct {
+	ID                   string         `json:"id" gorm:"primaryKey"`
+	BuyerUserID          string         `json:"buyer_user_id" gorm:"not null;index"`
+	BuyerWalletID        string         `json:"buyer_wallet_id" gorm:"not null"`
+	SellerUserID         string         `json:"seller_user_id" gorm:"not null;index"`
+	SellerWalletID       string         `json:"seller_wallet_id" gorm:"not null"`
+	Amount               float64        `json:"amount" gorm:"type:decimal(15,2)"`
+	FeeAmount            float64        `json:"fee_amount" gorm:"type:decimal(15,2);default:0"`
+	Currency             string         `json:"currency" gorm:"default:'IDR'"`
+	Reference            string         `json:"reference" gorm:"null"`
+	Description          string         `json:"description" gorm:"null"`
+	Status               string         `json:"status" gorm:"type:enum('FUNDED','RELEASED','REFUNDED');default:'FUNDED'"`
+	AutoReleaseAt        time.Time      `json:"auto_release_at" gorm:"not null;index"`
+	FundingTransactionID *string        `json:"funding_transaction_id" gorm:"null"`
+	ReleaseTransactionID *string        `json:"release_transaction_id" gorm:"null"`
+	FeeTransactionID     *string        `json:"fee_transaction_id" gorm:"null"`
+	RefundTransactionID  *string        `json:"refund_transaction_id" gorm:"null"`
+	ReleasedAt           *time.Time     `json:"released_at" gorm:"null"`
+	RefundedAt           *time.Time     `json:"refunded_at" gorm:"null"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// EscrowEvent is one append-only entry in an escrow's status history
+type EscrowEvent struct {
+	ID            string    `json:"id" gorm:"primaryKey"`
+	EscrowID      string    `json:"escrow_id" gorm:"not null;index"`
+	Action        string    `json:"action" gorm:"not null"`
+	FromStatus    string    `json:"from_status" gorm:"null"`
+	ToStatus      string    `json:"to_status" gorm:"not null"`
+	Actor         string    `json:"actor" gorm:"not null"`
+	TransactionID *string   `json:"transaction_id" gorm:"null"`
+	Note          string    `json:"note" gorm:"null"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// Relations
+	Escrow *Escrow `json:"-" gorm:"foreignKey:EscrowID;references:ID"`
+}
+
+// TableName specifies the table name for Escrow model
+func (Escrow) TableName() string {
+	return "escrows"
+}
+
+// TableName specifies the table name for EscrowEvent model
+func (EscrowEvent) TableName() string {
+	return "escrow_events"
+}
+
+// CanTransitionTo reports whether the escrow may move from its current status to the given one
+func (e *Escrow) CanTransitionTo(status string) bool {
+	for _, next := range escrowTransitions[e.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// SellerAmount is what the seller receives on release, after fees
+func (e *Escrow) SellerAmount() float64 {
+	return e.Amount - e.FeeAmount
+}
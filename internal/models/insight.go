@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// TransactionSummaryWatermark names the watermark of the monthly category summaries
+const TransactionSummaryWatermark = "transaction_summaries"
+
+// TransactionSummary holds the completed transactions of a wallet for one month, category
+// and direction. Rows are maintained by the summarize-transactions cron.
+type TransactionSummary struct {
+	WalletID         string    `json:"wallet_id" gorm:"primaryKey"`
+	Period           time.Time `json:"period" gorm:"primaryKey;type:date"`
+	Category         string    `json:"category" gorm:"primaryKey;size:32"`
+	Direction        string    `json:"direction" gorm:"primaryKey;type:enum('DEBIT','CREDIT')"`
+	TotalAmount      float64   `json:"total_amount" gorm:"type:decimal(15,2);default:0"`
+	TransactionCount int64     `json:"transaction_count" gorm:"default:0"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// SummaryWatermark records how far a summary job has read through wallet_transactions,
+// as the (created_at, id) of the last transaction it processed
+type SummaryWatermark struct {
+	Name              string    `json:"name" gorm:"primaryKey"`
+	LastCreatedAt     time.Time `json:"last_created_at"`
+	LastTransactionID string    `json:"last_transaction_id"`
+	ProcessedCount    int64     `json:"processed_count"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for TransactionSummary model
+func (TransactionSummary) TableName() string {
+	return "transaction_summaries"
+}
+
+// TableName specifies the table name for SummaryWatermark model
+func (SummaryWatermark) TableName() string {
+	return "summary_watermarks"
+}
+
+// PeriodOf returns the first day of the month t falls in, which keys monthly summaries
+func PeriodOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
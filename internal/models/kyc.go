@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// KYC levels of a user. Every account starts at BASIC, with only a verified phone number, and
+// reaches VERIFIED once an identity document is approved.
+const (
+	KYCLevelBasic    = "BASIC"
+	KYCLevelVerified = "VERIFIED"
+)
+
+// KYCSubmission statuses
+const (
+	KYCStatusPending  = "PENDING"
+	KYCStatusApproved = "APPROVED"
+	KYCStatusRejected = "REJECTED"
+)
+
+// Identity documents a user can submit
+const (
+	KYCDocumentKTP      = "KTP"
+	KYCDocumentPassport = "PASSPORT"
+)
+
+// KYCSubmission is an identity document a user uploaded to reach a higher KYC level, waiting
+// for or past its review. The file itself is kept in the file storage under FileKey.
+type KYCSubmission struct {
+	ID           string     `json:"id" gorm:"primaryKey"`
+	UserID       string     `json:"user_id" gorm:"not null;index"`
+	Level        string     `json:"level" gorm:"size:16;not null"`
+	DocumentType string     `json:"document_type" gorm:"type:enum('KTP','PASSPORT');not null"`
+	FileKey      string     `json:"-" gorm:"not null"`
+	FileName     string     `json:"file_name" gorm:"not null"`
+	ContentType  string     `json:"content_type" gorm:"size:64;not null"`
+	Size         int64      `json:"size" gorm:"not null"`
+	Status       string     `json:"status" gorm:"type:enum('PENDING','APPROVED','REJECTED');default:'PENDING';not null"`
+	ReviewerID   *string    `json:"reviewer_id" gorm:"null"`
+	ReviewNote   string     `json:"review_note,omitempty" gorm:"null"`
+	ReviewedAt   *time.Time `json:"reviewed_at" gorm:"null"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for KYCSubmission model
+func (KYCSubmission) TableName() string {
+	return "kyc_submissions"
+}
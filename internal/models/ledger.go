@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// LedgerCheckpoint is a signature over the head of a wallet's hash chain. Each settled
+// transaction carries a hash of the previous transaction's hash and its own contents, so
+// editing, removing or reordering transactions breaks every hash after it. A checkpoint
+// signed with the server's ledger key also catches a chain that was rewritten and rehashed
+// as a whole, since the rewritten head no longer matches the signed one.
+type LedgerCheckpoint struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	WalletID  string    `json:"wallet_id" gorm:"size:36;not null"`
+	ChainSeq  int64     `json:"chain_seq" gorm:"not null"`
+	ChainHash string    `json:"chain_hash" gorm:"size:64;not null"`
+	KeyID     string    `json:"key_id" gorm:"size:64;not null"`
+	Signature string    `json:"signature" gorm:"size:128;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for LedgerCheckpoint model
+func (LedgerCheckpoint) TableName() string {
+	return "ledger_checkpoints"
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Payment intent statuses
+const (
+	PaymentIntentStatusRequiresConfirmation = "REQUIRES_CONFIRMATION"
+	PaymentIntentStatusSucceeded            = "SUCCEEDED"
+	PaymentIntentStatusCanceled             = "CANCELED"
+	PaymentIntentStatusExpired              = "EXPIRED"
+)
+
+// paymentIntentTransitions lists the statuses each payment intent status may move to
+var paymentIntentTransitions = map[string][]string{
+	PaymentIntentStatusRequiresConfirmation: {
+		PaymentIntentStatusSucceeded,
+		PaymentIntentStatusCanceled,
+		PaymentIntentStatusExpired,
+	},
+}
+
+type Merchant struct {
+	ID                 string         `json:"id" gorm:"primaryKey"`
+	UserID             string         `json:"user_id" gorm:"not null;index"`
+	Name               string         `json:"name" gorm:"not null"`
+	SettlementWalletID string         `json:"settlement_wallet_id" gorm:"not null;index"`
+	APIKeyPrefix       string         `json:"api_key_prefix" gorm:"uniqueIndex;not null"`
+	APIKeyHash         string         `json:"-" gorm:"not null"`
+	CallbackURL        string         `json:"callback_url" gorm:"null"`
+	CallbackSecret     string         `json:"-" gorm:"null"` // sealed with the application encryption key
+	IsActive           bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relations
+	User             *User   `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	SettlementWallet *Wallet `json:"-" gorm:"foreignKey:SettlementWalletID;references:ID"`
+}
+
+type PaymentIntent struct {
+	ID                      string         `json:"id" gorm:"primaryKey"`
+	MerchantID              string         `json:"merchant_id" gorm:"not null;index"`
+	MerchantReference       string         `json:"merchant_reference" gorm:"null"`
+	Amount                  float64        `json:"amount" gorm:"type:decimal(15,2)"`
+	DiscountAmount          float64        `json:"discount_amount" gorm:"type:decimal(15,2);default:0"`
+	PromoCodeID             *string        `json:"promo_code_id" gorm:"null"`
+	Currency                string         `json:"currency" gorm:"default:'IDR'"`
+	Description             string         `json:"description" gorm:"null"`
+	Status                  string         `json:"status" gorm:"type:enum('REQUIRES_CONFIRMATION','SUCCEEDED','CANCELED','EXPIRED');default:'REQUIRES_CONFIRMATION'"`
+	PayerUserID             *string        `json:"payer_user_id" gorm:"null;index"`
+	WalletTransactionID     *string        `json:"wallet_transaction_id" gorm:"null"`
+	SettlementTransactionID *string        `json:"settlement_transaction_id" gorm:"null"`
+	CancellationReason      string         `json:"cancellation_reason,omitempty" gorm:"null"`
+	ExpiresAt               time.Time      `json:"expires_at" gorm:"not null;index"`
+	ConfirmedAt             *time.Time     `json:"confirmed_at" gorm:"null"`
+	CanceledAt              *time.Time     `json:"canceled_at" gorm:"null"`
+	CreatedAt               time.Time      `json:"created_at"`
+	UpdatedAt               time.Time      `json:"updated_at"`
+	DeletedAt               gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relations
+	Merchant          *Merchant          `json:"-" gorm:"foreignKey:MerchantID;references:ID"`
+	WalletTransaction *WalletTransaction `json:"-" gorm:"foreignKey:WalletTransactionID;references:ID"`
+}
+
+// TableName specifies the table name for Merchant model
+func (Merchant) TableName() string {
+	return "merchants"
+}
+
+// TableName specifies the table name for PaymentIntent model
+func (PaymentIntent) TableName() string {
+	return "payment_intents"
+}
+
+// CanTransitionTo reports whether the intent may move from its current status to the given one
+func (p *PaymentIntent) CanTransitionTo(status string) bool {
+	for _, next := range paymentIntentTransitions[p.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired reports whether an unconfirmed intent has passed its expiry time
+func (p *PaymentIntent) IsExpired(now time.Time) bool {
+	return p.Status == PaymentIntentStatusRequiresConfirmation && !now.Before(p.ExpiresAt)
+}
+
+// AmountDue is what the payer is charged once a promo discount is applied
+func (p *PaymentIntent) AmountDue() float64 {
+	return p.Amount - p.DiscountAmount
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// UserMFA is the TOTP second factor of a user. It is created on enrollment and takes effect
+// once the first code is verified.
+type UserMFA struct {
+	UserID string `json:"user_id" gorm:"primaryKey"`
+	// Secret is the base32 TOTP secret, sealed with the application encryption key
+	Secret string `json:"-" gorm:"not null"`
+	// LastUsedStep is the time step of the last accepted code; older and equal steps are refused
+	LastUsedStep int64 `json:"-" gorm:"not null;default:0"`
+	// WithdrawalThreshold makes withdrawals above it require a code; nil means never
+	WithdrawalThreshold *float64   `json:"withdrawal_threshold" gorm:"type:decimal(15,2)"`
+	EnabledAt           *time.Time `json:"enabled_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+func (UserMFA) TableName() string {
+	return "user_mfa"
+}
+
+// IsEnabled reports whether enrollment has been completed
+func (m *UserMFA) IsEnabled() bool {
+	return m != nil && m.EnabledAt != nil
+}
+
+// RequiresCodeForWithdrawal reports whether withdrawing amount needs a second factor
+func (m *UserMFA) RequiresCodeForWithdrawal(amount float64) bool {
+	return m.IsEnabled() && m.WithdrawalThreshold != nil && amount > *m.WithdrawalThreshold
+}
+
+// MFARecoveryCode is a single-use code that stands in for a TOTP code. Only its SHA-256
+// hash is stored.
+type MFARecoveryCode struct {
+	ID        string     `json:"id" gorm:"primaryKey"`
+	UserID    string     `json:"user_id" gorm:"not null;uniqueIndex:idx_user_code_hash"`
+	CodeHash  string     `json:"-" gorm:"size:64;not null;uniqueIndex:idx_user_code_hash"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
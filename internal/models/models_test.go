@@ -2,8 +2,10 @@ package models
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
 )
 
 func TestModels_TableName(t *testing.T) {
@@ -16,6 +18,23 @@ func TestModels_TableName(t *testing.T) {
 		wt := WalletTransaction{}
 		assert.Equal(t, "wallet_transactions", wt.TableName())
 	})
+
+	t.Run("Merchant and PaymentIntent TableName", func(t *testing.T) {
+		assert.Equal(t, "merchants", Merchant{}.TableName())
+		assert.Equal(t, "payment_intents", PaymentIntent{}.TableName())
+	})
+}
+
+func TestPaymentIntent_StatusMachine(t *testing.T) {
+	intent := PaymentIntent{Status: PaymentIntentStatusRequiresConfirmation, ExpiresAt: time.Now().Add(time.Minute)}
+	assert.True(t, intent.CanTransitionTo(PaymentIntentStatusSucceeded))
+	assert.True(t, intent.CanTransitionTo(PaymentIntentStatusCanceled))
+	assert.False(t, intent.IsExpired(time.Now()))
+	assert.True(t, intent.IsExpired(time.Now().Add(2*time.Minute)))
+
+	intent.Status = PaymentIntentStatusSucceeded
+	assert.False(t, intent.CanTransitionTo(PaymentIntentStatusCanceled))
+	assert.False(t, intent.IsExpired(time.Now().Add(2*time.Minute)))
 }
 
 func TestUser_Methods(t *testing.T) {
@@ -38,3 +57,91 @@ func TestUser_Methods(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestPromoCode_RewardFor(t *testing.T) {
+	fixed := PromoCode{ValueType: PromoValueFixed, Value: 10000}
+	assert.Equal(t, 10000.0, fixed.RewardFor(50000))
+
+	percentage := PromoCode{ValueType: PromoValuePercentage, Value: 12.5, MaxValue: 20000}
+	assert.Equal(t, 12500.0, percentage.RewardFor(100000))
+	assert.Equal(t, 20000.0, percentage.RewardFor(1000000))
+
+	single := PromoCode{MaxRedemptions: 1, RedemptionCount: 1}
+	assert.True(t, single.IsExhausted())
+
+	unlimited := PromoCode{RedemptionCount: 1000}
+	assert.False(t, unlimited.IsExhausted())
+}
+
+func TestEscrow_StatusMachine(t *testing.T) {
+	escrow := Escrow{Status: EscrowStatusFunded, Amount: 1000, FeeAmount: 30}
+	assert.True(t, escrow.CanTransitionTo(EscrowStatusReleased))
+	assert.True(t, escrow.CanTransitionTo(EscrowStatusRefunded))
+	assert.Equal(t, 970.0, escrow.SellerAmount())
+
+	escrow.Status = EscrowStatusReleased
+	assert.False(t, escrow.CanTransitionTo(EscrowStatusRefunded))
+}
+
+func TestCategorizeTransaction(t *testing.T) {
+	tests := []struct {
+		name     string
+		tx       WalletTransaction
+		expected string
+	}{
+		{"merchant name decides payment category", WalletTransaction{Type: TransactionTypePayment, Description: "Payment to Kopi Kenangan", Metadata: datatypes.JSON(`{"merchant_name":"Kopi Kenangan"}`)}, CategoryFoodAndDrink},
+		{"description keywords", WalletTransaction{Type: TransactionTypePayment, Description: "PLN token"}, CategoryBills},
+		{"unknown payment", WalletTransaction{Type: TransactionTypePayment, Description: "Payment to Acme"}, CategoryOther},
+		{"cashback deposit", WalletTransaction{Type: TransactionTypeDeposit, Description: "Cashback from Launch"}, CategoryRewards},
+		{"plain deposit", WalletTransaction{Type: TransactionTypeDeposit}, CategoryTopUp},
+		{"withdrawal", WalletTransaction{Type: TransactionTypeWithdrawal, Description: "ATM"}, CategoryCashOut},
+		{"manual adjustment", WalletTransaction{Type: TransactionTypeAdjustment, Direction: TransactionDirectionDebit, Description: "Reverse duplicate top up"}, CategoryAdjustments},
+		{"escrow release to seller", WalletTransaction{Type: TransactionTypeEscrow, Direction: TransactionDirectionCredit}, CategoryIncome},
+		{"escrow funding", WalletTransaction{Type: TransactionTypeEscrow, Direction: TransactionDirectionDebit}, CategoryShopping},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CategorizeTransaction(&tt.tx))
+		})
+	}
+}
+
+func TestWalletTransaction_BeforeCreate(t *testing.T) {
+	tx := WalletTransaction{Type: TransactionTypeWithdrawal}
+	assert.NoError(t, tx.BeforeCreate(nil))
+	assert.Equal(t, TransactionDirectionDebit, tx.Direction)
+	assert.Equal(t, CategoryCashOut, tx.Category)
+
+	preset := WalletTransaction{Type: TransactionTypePayment, Direction: TransactionDirectionDebit, Category: CategoryShopping}
+	assert.NoError(t, preset.BeforeCreate(nil))
+	assert.Equal(t, CategoryShopping, preset.Category)
+}
+
+func TestBudget_Thresholds(t *testing.T) {
+	budget := Budget{Amount: 1000}
+
+	assert.Empty(t, budget.CrossedThresholds(799.99))
+	assert.Equal(t, []int{BudgetThresholdWarning}, budget.CrossedThresholds(800))
+	assert.Equal(t, []int{BudgetThresholdWarning, BudgetThresholdExceeded}, budget.CrossedThresholds(1000))
+
+	assert.Equal(t, BudgetStatusOnTrack, budget.StatusFor(100))
+	assert.Equal(t, BudgetStatusWarning, budget.StatusFor(850))
+	assert.Equal(t, BudgetStatusExceeded, budget.StatusFor(1500))
+}
+
+func TestSession_Slide(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	session := Session{CreatedAt: created}
+
+	now := created.Add(24 * time.Hour)
+	session.Slide(now, 7*24*time.Hour, 30*24*time.Hour)
+	assert.Equal(t, now, session.LastSeenAt)
+	assert.Equal(t, now.Add(7*24*time.Hour), session.ExpiresAt)
+
+	session.Slide(created.Add(28*24*time.Hour), 7*24*time.Hour, 30*24*time.Hour)
+	assert.Equal(t, created.Add(30*24*time.Hour), session.ExpiresAt)
+
+	session.Slide(created.Add(60*24*time.Hour), 7*24*time.Hour, 0)
+	assert.Equal(t, created.Add(67*24*time.Hour), session.ExpiresAt)
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"digital-wallet/pkg/notifier"
+	"time"
+)
+
+// Notification events
+const (
+	NotificationEventWithdrawalCompleted = "withdrawal_completed"
+	NotificationEventWithdrawalFailed    = "withdrawal_failed"
+	NotificationEventDepositReceived     = "deposit_received"
+	NotificationEventNewDevice           = "new_device"
+)
+
+// Notification status values
+const (
+	NotificationStatusPending = "PENDING"
+	NotificationStatusSent    = "SENT"
+	NotificationStatusFailed  = "FAILED"
+)
+
+// Notification is one message queued for one channel. Rows are written in the same
+// database transaction as the event they describe and delivered by the send-notifications cron.
+type Notification struct {
+	ID            string     `json:"id" gorm:"primaryKey"`
+	UserID        string     `json:"user_id" gorm:"not null;index"`
+	Event         string     `json:"event" gorm:"size:64;not null;uniqueIndex:idx_reference_event_channel"`
+	Channel       string     `json:"channel" gorm:"size:16;not null;uniqueIndex:idx_reference_event_channel"`
+	ReferenceID   string     `json:"reference_id" gorm:"not null;uniqueIndex:idx_reference_event_channel"`
+	Recipient     string     `json:"recipient" gorm:"not null"`
+	Locale        string     `json:"locale" gorm:"size:8;not null"`
+	Subject       string     `json:"subject"`
+	Body          string     `json:"body" gorm:"type:text"`
+	Status        string     `json:"status" gorm:"type:enum('PENDING','SENT','FAILED');default:'PENDING';index:idx_status_next_attempt_at"`
+	Attempts      int        `json:"attempts" gorm:"default:0"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"index:idx_status_next_attempt_at"`
+	LastError     string     `json:"last_error" gorm:"type:text"`
+	SentAt        *time.Time `json:"sent_at" gorm:"null"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// NotificationPreference holds where and in which language a user wants to be notified
+type NotificationPreference struct {
+	UserID       string    `json:"user_id" gorm:"primaryKey"`
+	Locale       string    `json:"locale" gorm:"size:8;not null;default:'en'"`
+	Email        string    `json:"email"`
+	PhoneNumber  string    `json:"phone_number"`
+	PushToken    string    `json:"push_token"`
+	EmailEnabled bool      `json:"email_enabled"`
+	SMSEnabled   bool      `json:"sms_enabled"`
+	PushEnabled  bool      `json:"push_enabled"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for Notification model
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// TableName specifies the table name for NotificationPreference model
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// Recipients returns the address of every enabled channel that has one, keyed by channel
+func (p *NotificationPreference) Recipients() map[string]string {
+	recipients := make(map[string]string)
+
+	if p.EmailEnabled && p.Email != "" {
+		recipients[notifier.ChannelEmail] = p.Email
+	}
+	if p.SMSEnabled && p.PhoneNumber != "" {
+		recipients[notifier.ChannelSMS] = p.PhoneNumber
+	}
+	if p.PushEnabled && p.PushToken != "" {
+		recipients[notifier.ChannelPush] = p.PushToken
+	}
+
+	return recipients
+}
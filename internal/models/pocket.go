@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Pocket is a named sub-balance set aside from a wallet's main balance
+type Pocket struct {
+	ID           string         `json:"id" gorm:"primaryKey"`
+	WalletID     string         `json:"wallet_id" gorm:"not null;index"`
+	Name         string         `json:"name" gorm:"not null"`
+	Balance      float64        `json:"balance" gorm:"type:decimal(15,2);default:0"`
+	TargetAmount *float64       `json:"target_amount" gorm:"type:decimal(15,2);null"`
+	Deadline     *time.Time     `json:"deadline" gorm:"null"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relations
+	Wallet *Wallet `json:"-" gorm:"foreignKey:WalletID;references:ID"`
+}
+
+// TableName specifies the table name for Pocket model
+func (Pocket) TableName() string {
+	return "pockets"
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Promo code reward types
+const (
+	PromoRewardCredit   = "CREDIT"
+	PromoRewardDiscount = "DISCOUNT"
+)
+
+// Promo code value types
+const (
+	PromoValueFixed      = "FIXED"
+	PromoValuePercentage = "PERCENTAGE"
+)
+
+type PromoCode struct {
+	ID              string         `json:"id" gorm:"primaryKey"`
+	Code            string         `json:"code" gorm:"uniqueIndex;not null"`
+	BatchID         string         `json:"batch_id" gorm:"null;index"`
+	Description     string         `json:"description" gorm:"null"`
+	RewardType      string         `json:"reward_type" gorm:"type:enum('CREDIT','DISCOUNT');not null"`
+	ValueType       string         `json:"value_type" gorm:"type:enum('FIXED','PERCENTAGE');not null"`
+	Value           float64        `json:"value" gorm:"type:decimal(15,2);not null"`
+	MaxValue        float64        `json:"max_value" gorm:"type:decimal(15,2);default:0"`
+	MinAmount       float64        `json:"min_amount" gorm:"type:decimal(15,2);default:0"`
+	TransactionType string         `json:"transaction_type" gorm:"null"`
+	MaxRedemptions  int            `json:"max_redemptions" gorm:"default:0"`
+	MaxPerUser      int            `json:"max_per_user" gorm:"default:0"`
+	RedemptionCount int            `json:"redemption_count" gorm:"default:0"`
+	ExpiresAt       *time.Time     `json:"expires_at" gorm:"null"`
+	IsActive        bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+type PromoRedemption struct {
+	ID                  string    `json:"id" gorm:"primaryKey"`
+	PromoCodeID         string    `json:"promo_code_id" gorm:"not null;index"`
+	UserID              string    `json:"user_id" gorm:"not null"`
+	WalletID            string    `json:"wallet_id" gorm:"not null"`
+	Amount              float64   `json:"amount" gorm:"type:decimal(15,2)"`
+	SourceTransactionID *string   `json:"source_transaction_id" gorm:"null"`
+	PaymentIntentID     *string   `json:"payment_intent_id" gorm:"null"`
+	TransactionID       *string   `json:"transaction_id" gorm:"null"`
+	CreatedAt           time.Time `json:"created_at"`
+
+	// Relations
+	PromoCode *PromoCode `json:"-" gorm:"foreignKey:PromoCodeID;references:ID"`
+}
+
+// TableName specifies the table name for PromoCode model
+func (PromoCode) TableName() string {
+	return "promo_codes"
+}
+
+// TableName specifies the table name for PromoRedemption model
+func (PromoRedemption) TableName() string {
+	return "promo_redemptions"
+}
+
+// IsExpired reports whether the code can no longer be redeemed at the given time
+func (p *PromoCode) IsExpired(now time.Time) bool {
+	return p.ExpiresAt != nil && !now.Before(*p.ExpiresAt)
+}
+
+// IsExhausted reports whether the global redemption limit has been reached; zero means unlimited
+func (p *PromoCode) IsExhausted() bool {
+	return p.MaxRedemptions > 0 && p.RedemptionCount >= p.MaxRedemptions
+}
+
+// RewardFor returns the value of the code for a base amount, capped by MaxValue and
+// rounded down to whole cents
+func (p *PromoCode) RewardFor(amount float64) float64 {
+	reward := p.Value
+	if p.ValueType == PromoValuePercentage {
+		reward = amount * p.Value / 100
+	}
+
+	if p.MaxValue > 0 {
+		reward = math.Min(reward, p.MaxValue)
+	}
+
+	return math.Floor(reward*100) / 100
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Role is a named set of permissions that users are assigned through users.role
+type Role struct {
+	Name        string    `json:"name" gorm:"primaryKey;size:32"`
+	Description string    `json:"description" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// RolePermission grants a permission to a role. Permissions are the auth.Permission* names.
+type RolePermission struct {
+	Role       string    `json:"role" gorm:"primaryKey;size:32"`
+	Permission string    `json:"permission" gorm:"primaryKey;size:64"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Session is a logged in device. Sessions are kept in Redis, not in the database; the
+// access and refresh tokens of a session carry its ID in the session_id claim.
+type Session struct {
+	ID             string    `json:"id"`
+	UserID         string    `json:"user_id"`
+	RefreshTokenID string    `json:"refresh_token_id"`
+	DeviceID       string    `json:"device_id,omitempty"`
+	DeviceName     string    `json:"device_name,omitempty"`
+	UserAgent      string    `json:"user_agent,omitempty"`
+	IPAddress      string    `json:"ip_address,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	LastSeenAt     time.Time `json:"last_seen_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// Slide marks the session as used at now and pushes its expiry to idle after now, but never
+// past maxLifetime after the session started. A zero maxLifetime leaves the lifetime unbounded.
+func (s *Session) Slide(now time.Time, idle, maxLifetime time.Duration) {
+	s.LastSeenAt = now
+	s.ExpiresAt = now.Add(idle)
+
+	if maxLifetime > 0 {
+		if limit := s.CreatedAt.Add(maxLifetime); s.ExpiresAt.After(limit) {
+			s.ExpiresAt = limit
+		}
+	}
+}
@@ -13,13 +13,29 @@ type User struct {
 	FullName    string         `json:"full_name" gorm:"uniqueIndex;not null"`
 	Email       *string        `json:"email" gorm:"uniqueIndex;null"`
 	Password    string         `json:"-" gorm:"not null"`
-	IsActive    bool           `json:"is_active" gorm:"default:true"`
+	PIN         *string        `json:"-" gorm:"column:pin"`
+	IsActive    bool           `json:"is_active"`
+	ActivatedAt *time.Time     `json:"activated_at"`
+	Role        string         `json:"role" gorm:"size:32;not null;default:USER"`
+	KYCLevel    string         `json:"kyc_level" gorm:"column:kyc_level;size:16;not null;default:BASIC"`
 	PhoneNumber string         `json:"phone_number" gorm:"uniqueIndex;not null"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
+// Purposes of the single-use tokens sent to users
+const (
+	AccountTokenActivation    = "activation"
+	AccountTokenPasswordReset = "password_reset"
+)
+
+// IsActivated reports whether the user has confirmed their account. An activated user who is
+// not active has been deactivated.
+func (u *User) IsActivated() bool {
+	return u.ActivatedAt != nil
+}
+
 // HashPassword hashes the user password
 func HashAndSalt(pwd []byte) (string, error) {
 	if len(pwd) > 72 {
@@ -7,6 +7,36 @@ import (
 	"gorm.io/gorm"
 )
 
+// Wallet transaction types
+const (
+	TransactionTypeWithdrawal = "WITHDRAWAL"
+	TransactionTypeDeposit    = "DEPOSIT"
+	TransactionTypePayment    = "PAYMENT"
+	TransactionTypeTransfer   = "TRANSFER"
+	TransactionTypeEscrow     = "ESCROW"
+	TransactionTypeRefund     = "REFUND"
+	TransactionTypeFee        = "FEE"
+	// TransactionTypeAdjustment is a manual credit or debit made from the back office
+	TransactionTypeAdjustment = "ADJUSTMENT"
+)
+
+// OutgoingTransactionTypes are the debits by which money leaves the user: withdrawals,
+// checkout payments and escrow funding. Moves into pockets stay with the user.
+var OutgoingTransactionTypes = []string{TransactionTypeWithdrawal, TransactionTypePayment, TransactionTypeEscrow}
+
+// Wallet transaction directions, seen from the wallet the transaction belongs to
+const (
+	TransactionDirectionDebit  = "DEBIT"
+	TransactionDirectionCredit = "CREDIT"
+)
+
+// Wallet transaction statuses
+const (
+	TransactionStatusPending   = "PENDING"
+	TransactionStatusCompleted = "COMPLETED"
+	TransactionStatusFailed    = "FAILED"
+)
+
 type Wallet struct {
 	ID        string         `json:"id" gorm:"primaryKey"`
 	UserID    string         `json:"user_id" gorm:"not null;index"`
@@ -25,13 +55,21 @@ type WalletTransaction struct {
 	ID          string         `json:"id" gorm:"primaryKey"`
 	WalletID    string         `json:"wallet_id" gorm:"not null;index"`
 	Amount      float64        `json:"amount" gorm:"type:decimal(15,2)"`
-	Type        string         `json:"type" gorm:"type:enum('WITHDRAWAL','DEPOSIT');not null"`
+	Type        string         `json:"type" gorm:"type:enum('WITHDRAWAL','DEPOSIT','PAYMENT','TRANSFER','ESCROW','REFUND','FEE','ADJUSTMENT');not null"`
+	Direction   string         `json:"direction" gorm:"type:enum('DEBIT','CREDIT');not null"`
 	Status      string         `json:"status" gorm:"type:enum('PENDING','COMPLETED','FAILED');default:'PENDING'"`
+	Category    string         `json:"category" gorm:"size:32;not null;index"`
 	Description string         `json:"description" gorm:"null"`
+	Beneficiary *string        `json:"beneficiary,omitempty" gorm:"size:100;index:idx_wallet_beneficiary"`
 	Metadata    datatypes.JSON `json:"metadata" gorm:"type:json;null"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ReversalOf  *string        `json:"reversal_of,omitempty" gorm:"size:36;uniqueIndex"`
+	// ChainSeq and ChainHash link the transaction into the hash chain of its wallet once it is
+	// settled, see LedgerCheckpoint. Transactions settled before the chain existed have neither.
+	ChainSeq  *int64         `json:"-"`
+	ChainHash string         `json:"-" gorm:"size:64"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relations
 	Wallet *Wallet `json:"-" gorm:"foreignKey:WalletID;references:ID"`
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AccountTokenRepository keeps the hashes of activation and password reset tokens in Redis.
+// Each user has at most one live token per purpose; saving a new one drops the previous.
+type AccountTokenRepository struct {
+	redis *redis.Client
+}
+
+// Ensure AccountTokenRepository implements interfaces.AccountTokenRepository
+var _ interfaces.AccountTokenRepository = (*AccountTokenRepository)(nil)
+
+func NewAccountTokenRepository(client *redis.Client) interfaces.AccountTokenRepository {
+	return &AccountTokenRepository{redis: client}
+}
+
+// Save stores the token hash for ttl, replacing the user's previous token of the purpose
+func (r *AccountTokenRepository) Save(ctx context.Context, purpose, userID, tokenHash string, ttl time.Duration) error {
+	previous, err := r.redis.Get(ctx, userAccountTokenKey(purpose, userID)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	_, err = r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if previous != "" {
+			pipe.Del(ctx, accountTokenKey(purpose, previous))
+		}
+		pipe.Set(ctx, accountTokenKey(purpose, tokenHash), userID, ttl)
+		pipe.Set(ctx, userAccountTokenKey(purpose, userID), tokenHash, ttl)
+		return nil
+	})
+	return err
+}
+
+// Consume deletes the token and returns its user, or an empty string when the token does
+// not exist, has expired or was already used
+func (r *AccountTokenRepository) Consume(ctx context.Context, purpose, tokenHash string) (string, error) {
+	userID, err := r.redis.GetDel(ctx, accountTokenKey(purpose, tokenHash)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil
+		}
+		return "", err
+	}
+	return userID, nil
+}
+
+func accountTokenKey(purpose, tokenHash string) string {
+	return fmt.Sprintf("account_tokens:%s:%s", purpose, tokenHash)
+}
+
+func userAccountTokenKey(purpose, userID string) string {
+	return fmt.Sprintf("account_tokens:%s:user:%s", purpose, userID)
+}
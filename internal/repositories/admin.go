@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AdminActionRepository struct {
+	db *gorm.DB
+}
+
+// Ensure AdminActionRepository implements interfaces.AdminActionRepository
+var _ interfaces.AdminActionRepository = (*AdminActionRepository)(nil)
+
+func NewAdminActionRepository(database *gorm.DB) interfaces.AdminActionRepository {
+	return &AdminActionRepository{db: database}
+}
+
+func (r *AdminActionRepository) Create(ctx context.Context, action *models.AdminAction) error {
+	return r.db.WithContext(ctx).Create(action).Error
+}
+
+// GetByWalletID returns the latest actions on the wallet, newest first
+func (r *AdminActionRepository) GetByWalletID(ctx context.Context, walletID string, limit int) ([]models.AdminAction, error) {
+	var actions []models.AdminAction
+	result := r.db.WithContext(ctx).
+		Where("wallet_id = ?", walletID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&actions)
+	return actions, result.Error
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+// Ensure APIKeyRepository implements interfaces.APIKeyRepository
+var _ interfaces.APIKeyRepository = (*APIKeyRepository)(nil)
+
+func NewAPIKeyRepository(database *gorm.DB) interfaces.APIKeyRepository {
+	return &APIKeyRepository{db: database}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+// GetByIDForUpdate loads the key and holds a row lock on it until the surrounding transaction ends
+func (r *APIKeyRepository) GetByIDForUpdate(ctx context.Context, id string) (*models.APIKey, error) {
+	var key models.APIKey
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&key)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &key, nil
+}
+
+// GetByPrefix returns the key with the lookup prefix, or nil when there is none
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	var key models.APIKey
+	result := r.db.WithContext(ctx).Where("prefix = ?", prefix).First(&key)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &key, nil
+}
+
+// List returns a page of keys, newest first
+func (r *APIKeyRepository) List(ctx context.Context, limit, offset int) ([]models.APIKey, int64, error) {
+	var (
+		keys  []models.APIKey
+		total int64
+	)
+
+	if err := r.db.WithContext(ctx).Model(&models.APIKey{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&keys)
+	return keys, total, result.Error
+}
+
+func (r *APIKeyRepository) Update(ctx context.Context, key *models.APIKey) error {
+	return r.db.WithContext(ctx).Save(key).Error
+}
+
+// TouchLastUsed records that the key was used at now, unless that was already recorded after since
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id string, now, since time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, since).
+		Update("last_used_at", now).Error
+}
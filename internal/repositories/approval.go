@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ApprovalRequestRepository struct {
+	db *gorm.DB
+}
+
+// Ensure ApprovalRequestRepository implements interfaces.ApprovalRequestRepository
+var _ interfaces.ApprovalRequestRepository = (*ApprovalRequestRepository)(nil)
+
+func NewApprovalRequestRepository(database *gorm.DB) interfaces.ApprovalRequestRepository {
+	return &ApprovalRequestRepository{db: database}
+}
+
+func (r *ApprovalRequestRepository) Create(ctx context.Context, approval *models.ApprovalRequest) error {
+	return r.db.WithContext(ctx).Create(approval).Error
+}
+
+func (r *ApprovalRequestRepository) GetByID(ctx context.Context, id string) (*models.ApprovalRequest, error) {
+	var approval models.ApprovalRequest
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&approval)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &approval, nil
+}
+
+// GetByIDForUpdate loads the request and holds a row lock on it until the surrounding transaction ends
+func (r *ApprovalRequestRepository) GetByIDForUpdate(ctx context.Context, id string) (*models.ApprovalRequest, error) {
+	var approval models.ApprovalRequest
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&approval)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &approval, nil
+}
+
+// List returns a page of requests with the given status, or of every request when status is
+// empty, newest first
+func (r *ApprovalRequestRepository) List(ctx context.Context, status string, limit, offset int) ([]models.ApprovalRequest, int64, error) {
+	var (
+		approvals []models.ApprovalRequest
+		total     int64
+	)
+
+	query := r.db.WithContext(ctx).Model(&models.ApprovalRequest{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&approvals)
+	return approvals, total, result.Error
+}
+
+// ExpirePending moves up to limit pending requests past their expiry time to EXPIRED and
+// returns how many it moved
+func (r *ApprovalRequestRepository) ExpirePending(ctx context.Context, now time.Time, limit int) (int64, error) {
+	var ids []string
+	err := r.db.WithContext(ctx).
+		Model(&models.ApprovalRequest{}).
+		Where("status = ? AND expires_at <= ?", models.ApprovalStatusPending, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	// the status is checked again in case a request was decided since it was listed
+	result := r.db.WithContext(ctx).
+		Model(&models.ApprovalRequest{}).
+		Where("id IN ? AND status = ?", ids, models.ApprovalStatusPending).
+		Update("status", models.ApprovalStatusExpired)
+	return result.RowsAffected, result.Error
+}
+
+func (r *ApprovalRequestRepository) Update(ctx context.Context, approval *models.ApprovalRequest) error {
+	return r.db.WithContext(ctx).Save(approval).Error
+}
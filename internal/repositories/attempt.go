@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AttemptRepository counts failed attempts at a secret, such as a PIN or a one-time code, in
+// Redis. Callers choose the key. The count expires ttl after the last failure, so a lock
+// based on it lifts by itself.
+type AttemptRepository struct {
+	redis *redis.Client
+}
+
+// Ensure AttemptRepository implements interfaces.AttemptRepository
+var _ interfaces.AttemptRepository = (*AttemptRepository)(nil)
+
+func NewAttemptRepository(client *redis.Client) interfaces.AttemptRepository {
+	return &AttemptRepository{redis: client}
+}
+
+// Get returns the number of recent failed attempts
+func (r *AttemptRepository) Get(ctx context.Context, key string) (int, error) {
+	n, err := r.redis.Get(ctx, attemptKey(key)).Int()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// Increment records a failed attempt and returns the new count
+func (r *AttemptRepository) Increment(ctx context.Context, key string, ttl time.Duration) (int, error) {
+	var incr *redis.IntCmd
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, attemptKey(key))
+		pipe.Expire(ctx, attemptKey(key), ttl)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(incr.Val()), nil
+}
+
+// Reset forgets the failed attempts of key and lifts any block on it
+func (r *AttemptRepository) Reset(ctx context.Context, key string) error {
+	return r.redis.Del(ctx, attemptKey(key), blockKey(key)).Err()
+}
+
+// Block refuses further attempts at key for ttl
+func (r *AttemptRepository) Block(ctx context.Context, key string, ttl time.Duration) error {
+	return r.redis.Set(ctx, blockKey(key), 1, ttl).Err()
+}
+
+// BlockedFor returns how long attempts at key are still refused, or zero if they are not
+func (r *AttemptRepository) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.redis.PTTL(ctx, blockKey(key)).Result()
+	if err != nil {
+		return 0, err
+	}
+	// negative values mean the key does not exist or never expires
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func attemptKey(key string) string {
+	return fmt.Sprintf("attempts:%s", key)
+}
+
+func blockKey(key string) string {
+	return fmt.Sprintf("attempts:blocked:%s", key)
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AuditEventRepository only inserts and reads; audit_events refuses updates and deletes
+type AuditEventRepository struct {
+	db *gorm.DB
+}
+
+// Ensure AuditEventRepository implements interfaces.AuditEventRepository
+var _ interfaces.AuditEventRepository = (*AuditEventRepository)(nil)
+
+func NewAuditEventRepository(database *gorm.DB) interfaces.AuditEventRepository {
+	return &AuditEventRepository{db: database}
+}
+
+func (r *AuditEventRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+// List returns a page of the events matching the filter, newest first, with their total
+func (r *AuditEventRepository) List(ctx context.Context, filter models.AuditEventFilter, limit, offset int) ([]models.AuditEvent, int64, error) {
+	var (
+		events []models.AuditEvent
+		total  int64
+	)
+
+	query := r.db.WithContext(ctx).Model(&models.AuditEvent{})
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.PrincipalType != "" {
+		query = query.Where("principal_type = ?", filter.PrincipalType)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		query = query.Where("resource_id = ?", filter.ResourceID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("created_at DESC").
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events)
+	return events, total, result.Error
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BudgetRepository struct {
+	db *gorm.DB
+}
+
+// Ensure BudgetRepository implements interfaces.BudgetRepository
+var _ interfaces.BudgetRepository = (*BudgetRepository)(nil)
+
+func NewBudgetRepository(database *gorm.DB) interfaces.BudgetRepository {
+	return &BudgetRepository{db: database}
+}
+
+func (r *BudgetRepository) Create(ctx context.Context, budget *models.Budget) error {
+	return r.db.WithContext(ctx).Create(budget).Error
+}
+
+func (r *BudgetRepository) GetByID(ctx context.Context, id string) (*models.Budget, error) {
+	var budget models.Budget
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&budget)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &budget, nil
+}
+
+func (r *BudgetRepository) GetByWalletID(ctx context.Context, walletID string) ([]models.Budget, error) {
+	var budgets []models.Budget
+	result := r.db.WithContext(ctx).
+		Where("wallet_id = ?", walletID).
+		Order("category ASC").
+		Find(&budgets)
+	return budgets, result.Error
+}
+
+// GetByWalletIDAndCategory returns nil without an error when the wallet has no budget for the category
+func (r *BudgetRepository) GetByWalletIDAndCategory(ctx context.Context, walletID, category string) (*models.Budget, error) {
+	var budget models.Budget
+	result := r.db.WithContext(ctx).Where("wallet_id = ? AND category = ?", walletID, category).First(&budget)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &budget, nil
+}
+
+func (r *BudgetRepository) Update(ctx context.Context, budget *models.Budget) error {
+	return r.db.WithContext(ctx).Save(budget).Error
+}
+
+func (r *BudgetRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Budget{}).Error
+}
+
+type BudgetAlertRepository struct {
+	db *gorm.DB
+}
+
+// Ensure BudgetAlertRepository implements interfaces.BudgetAlertRepository
+var _ interfaces.BudgetAlertRepository = (*BudgetAlertRepository)(nil)
+
+func NewBudgetAlertRepository(database *gorm.DB) interfaces.BudgetAlertRepository {
+	return &BudgetAlertRepository{db: database}
+}
+
+// CreateIfAbsent inserts the alert unless its budget, period and threshold already fired,
+// and reports whether it was inserted
+func (r *BudgetAlertRepository) CreateIfAbsent(ctx context.Context, alert *models.BudgetAlert) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(alert)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *BudgetAlertRepository) GetByBudgetIDs(ctx context.Context, budgetIDs []string, period time.Time) ([]models.BudgetAlert, error) {
+	var alerts []models.BudgetAlert
+	if len(budgetIDs) == 0 {
+		return alerts, nil
+	}
+
+	result := r.db.WithContext(ctx).
+		Where("budget_id IN ? AND period = ?", budgetIDs, period).
+		Order("threshold ASC").
+		Find(&alerts)
+	return alerts, result.Error
+}
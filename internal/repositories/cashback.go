@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CashbackCampaignRepository struct {
+	db *gorm.DB
+}
+
+// Ensure CashbackCampaignRepository implements interfaces.CashbackCampaignRepository
+var _ interfaces.CashbackCampaignRepository = (*CashbackCampaignRepository)(nil)
+
+func NewCashbackCampaignRepository(database *gorm.DB) interfaces.CashbackCampaignRepository {
+	return &CashbackCampaignRepository{db: database}
+}
+
+func (r *CashbackCampaignRepository) Create(ctx context.Context, campaign *models.CashbackCampaign) error {
+	return r.db.WithContext(ctx).Create(campaign).Error
+}
+
+func (r *CashbackCampaignRepository) GetByID(ctx context.Context, id string) (*models.CashbackCampaign, error) {
+	var campaign models.CashbackCampaign
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&campaign)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &campaign, nil
+}
+
+// GetByIDForUpdate loads the campaign and holds a row lock on it, serializing budget consumption
+func (r *CashbackCampaignRepository) GetByIDForUpdate(ctx context.Context, id string) (*models.CashbackCampaign, error) {
+	var campaign models.CashbackCampaign
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&campaign)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &campaign, nil
+}
+
+// GetRunning returns active campaigns open at the given time whose minimum spend is met by amount
+func (r *CashbackCampaignRepository) GetRunning(ctx context.Context, now time.Time, amount float64) ([]models.CashbackCampaign, error) {
+	var campaigns []models.CashbackCampaign
+	result := r.db.WithContext(ctx).
+		Where("is_active = ? AND starts_at <= ? AND ends_at > ? AND min_spend <= ? AND used_budget < total_budget", true, now, now, amount).
+		Order("percentage DESC, created_at ASC").
+		Find(&campaigns)
+	return campaigns, result.Error
+}
+
+func (r *CashbackCampaignRepository) List(ctx context.Context, limit, offset int) ([]models.CashbackCampaign, int64, error) {
+	var (
+		campaigns []models.CashbackCampaign
+		total     int64
+	)
+
+	if err := r.db.WithContext(ctx).Model(&models.CashbackCampaign{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&campaigns)
+	return campaigns, total, result.Error
+}
+
+// SetActive starts or stops the campaign and reports whether it changed. Only the flag is
+// written, so budget consumed by payments meanwhile is kept.
+func (r *CashbackCampaignRepository) SetActive(ctx context.Context, id string, active bool) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.CashbackCampaign{}).
+		Where("id = ? AND is_active = ?", id, !active).
+		Update("is_active", active)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *CashbackCampaignRepository) AddUsedBudget(ctx context.Context, id string, amount float64) error {
+	return r.db.WithContext(ctx).Model(&models.CashbackCampaign{}).Where("id = ?", id).Update("used_budget", gorm.Expr("used_budget + ?", amount)).Error
+}
+
+// CashbackRewardRepository implementation
+type CashbackRewardRepository struct {
+	db *gorm.DB
+}
+
+// Ensure CashbackRewardRepository implements interfaces.CashbackRewardRepository
+var _ interfaces.CashbackRewardRepository = (*CashbackRewardRepository)(nil)
+
+func NewCashbackRewardRepository(database *gorm.DB) interfaces.CashbackRewardRepository {
+	return &CashbackRewardRepository{db: database}
+}
+
+func (r *CashbackRewardRepository) Create(ctx context.Context, reward *models.CashbackReward) error {
+	return r.db.WithContext(ctx).Create(reward).Error
+}
+
+func (r *CashbackRewardRepository) GetByIDForUpdate(ctx context.Context, id string) (*models.CashbackReward, error) {
+	var reward models.CashbackReward
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&reward)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &reward, nil
+}
+
+// GetDueHeld returns held rewards whose holding period has ended
+func (r *CashbackRewardRepository) GetDueHeld(ctx context.Context, now time.Time, limit int) ([]models.CashbackReward, error) {
+	var rewards []models.CashbackReward
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND release_at <= ?", models.CashbackRewardStatusHeld, now).
+		Order("release_at ASC").
+		Limit(limit).
+		Find(&rewards)
+	return rewards, result.Error
+}
+
+func (r *CashbackRewardRepository) SumByCampaignAndUser(ctx context.Context, campaignID, userID string) (float64, error) {
+	var total float64
+	err := r.db.WithContext(ctx).
+		Model(&models.CashbackReward{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("campaign_id = ? AND user_id = ?", campaignID, userID).
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *CashbackRewardRepository) SummarizeByCampaign(ctx context.Context, campaignID string) ([]models.CashbackRewardSummary, error) {
+	var summaries []models.CashbackRewardSummary
+	err := r.db.WithContext(ctx).
+		Model(&models.CashbackReward{}).
+		Select("status, COUNT(*) AS rewards, COALESCE(SUM(amount), 0) AS amount").
+		Where("campaign_id = ?", campaignID).
+		Group("status").
+		Scan(&summaries).Error
+	return summaries, err
+}
+
+func (r *CashbackRewardRepository) CountUsersByCampaign(ctx context.Context, campaignID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.CashbackReward{}).
+		Where("campaign_id = ?", campaignID).
+		Distinct("user_id").
+		Count(&count).Error
+	return count, err
+}
+
+func (r *CashbackRewardRepository) Update(ctx context.Context, reward *models.CashbackReward) error {
+	return r.db.WithContext(ctx).Save(reward).Error
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ChallengeRepository keeps step-up challenges in Redis until they expire
+type ChallengeRepository struct {
+	redis *redis.Client
+}
+
+// Ensure ChallengeRepository implements interfaces.ChallengeRepository
+var _ interfaces.ChallengeRepository = (*ChallengeRepository)(nil)
+
+func NewChallengeRepository(client *redis.Client) interfaces.ChallengeRepository {
+	return &ChallengeRepository{redis: client}
+}
+
+// Save stores the challenge until its expiry
+func (r *ChallengeRepository) Save(ctx context.Context, challenge *models.Challenge) error {
+	ttl := time.Until(challenge.ExpiresAt)
+	if ttl <= 0 {
+		return errors.New("challenge has already expired")
+	}
+
+	value, err := json.Marshal(challenge)
+	if err != nil {
+		return err
+	}
+
+	return r.redis.Set(ctx, challengeKey(challenge.ID), value, ttl).Err()
+}
+
+// Get returns nil without an error when the challenge does not exist or has expired
+func (r *ChallengeRepository) Get(ctx context.Context, id string) (*models.Challenge, error) {
+	value, err := r.redis.Get(ctx, challengeKey(id)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var challenge models.Challenge
+	if err := json.Unmarshal(value, &challenge); err != nil {
+		return nil, err
+	}
+
+	return &challenge, nil
+}
+
+// Delete reports whether the challenge still existed. Only one of several concurrent
+// callers gets true, which makes a challenge single use.
+func (r *ChallengeRepository) Delete(ctx context.Context, id string) (bool, error) {
+	n, err := r.redis.Del(ctx, challengeKey(id)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func challengeKey(id string) string {
+	return fmt.Sprintf("challenges:%s", id)
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeviceRepository struct {
+	db *gorm.DB
+}
+
+// Ensure DeviceRepository implements interfaces.DeviceRepository
+var _ interfaces.DeviceRepository = (*DeviceRepository)(nil)
+
+func NewDeviceRepository(database *gorm.DB) interfaces.DeviceRepository {
+	return &DeviceRepository{db: database}
+}
+
+// CreateIfAbsent records the device unless the user already has a device with its device ID,
+// and reports whether it was inserted
+func (r *DeviceRepository) CreateIfAbsent(ctx context.Context, device *models.Device) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(device)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *DeviceRepository) GetByID(ctx context.Context, userID, id string) (*models.Device, error) {
+	var device models.Device
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&device)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &device, nil
+}
+
+// GetByDeviceID returns the user's device with the client's device ID, or nil when the user
+// has not been seen on it
+func (r *DeviceRepository) GetByDeviceID(ctx context.Context, userID, deviceID string) (*models.Device, error) {
+	var device models.Device
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND device_id = ?", userID, deviceID).
+		First(&device)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &device, nil
+}
+
+// ListByUserID returns the user's devices, the most recently seen first
+func (r *DeviceRepository) ListByUserID(ctx context.Context, userID string) ([]models.Device, error) {
+	var devices []models.Device
+	result := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("last_seen_at DESC").
+		Find(&devices)
+	return devices, result.Error
+}
+
+// Touch records that the device was seen at now with its user agent and IP address, unless
+// that was already recorded after since
+func (r *DeviceRepository) Touch(ctx context.Context, device *models.Device, now, since time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Device{}).
+		Where("id = ? AND last_seen_at < ?", device.ID, since).
+		Updates(map[string]interface{}{
+			"last_seen_at": now,
+			"user_agent":   device.UserAgent,
+			"ip_address":   device.IPAddress,
+		}).Error
+}
+
+func (r *DeviceRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&models.Device{}, "id = ?", id).Error
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EscrowRepository struct {
+	db *gorm.DB
+}
+
+// Ensure EscrowRepository implements interfaces.EscrowRepository
+var _ interfaces.EscrowRepository = (*EscrowRepository)(nil)
+
+func NewEscrowRepository(database *gorm.DB) interfaces.EscrowRepository {
+	return &EscrowRepository{db: database}
+}
+
+func (r *EscrowRepository) Create(ctx context.Context, escrow *models.Escrow) error {
+	return r.db.WithContext(ctx).Create(escrow).Error
+}
+
+func (r *EscrowRepository) GetByID(ctx context.Context, id string) (*models.Escrow, error) {
+	var escrow models.Escrow
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&escrow)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &escrow, nil
+}
+
+// GetByIDForUpdate loads the escrow and holds a row lock on it until the transaction ends
+func (r *EscrowRepository) GetByIDForUpdate(ctx context.Context, id string) (*models.Escrow, error) {
+	var escrow models.Escrow
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&escrow)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &escrow, nil
+}
+
+// GetDueForAutoRelease returns funded escrows whose auto-release time has passed
+func (r *EscrowRepository) GetDueForAutoRelease(ctx context.Context, now time.Time, limit int) ([]models.Escrow, error) {
+	var escrows []models.Escrow
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND auto_release_at <= ?", models.EscrowStatusFunded, now).
+		Order("auto_release_at ASC").
+		Limit(limit).
+		Find(&escrows)
+	return escrows, result.Error
+}
+
+func (r *EscrowRepository) Update(ctx context.Context, escrow *models.Escrow) error {
+	return r.db.WithContext(ctx).Save(escrow).Error
+}
+
+// EscrowEventRepository implementation
+type EscrowEventRepository struct {
+	db *gorm.DB
+}
+
+// Ensure EscrowEventRepository implements interfaces.EscrowEventRepository
+var _ interfaces.EscrowEventRepository = (*EscrowEventRepository)(nil)
+
+func NewEscrowEventRepository(database *gorm.DB) interfaces.EscrowEventRepository {
+	return &EscrowEventRepository{db: database}
+}
+
+func (r *EscrowEventRepository) Create(ctx context.Context, event *models.EscrowEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *EscrowEventRepository) GetByEscrowID(ctx context.Context, escrowID string) ([]models.EscrowEvent, error) {
+	var events []models.EscrowEvent
+	result := r.db.WithContext(ctx).
+		Where("escrow_id = ?", escrowID).
+		Order("created_at ASC").
+		Find(&events)
+	return events, result.Error
+}
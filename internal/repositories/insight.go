@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TransactionSummaryRepository struct {
+	db *gorm.DB
+}
+
+// Ensure TransactionSummaryRepository implements interfaces.TransactionSummaryRepository
+var _ interfaces.TransactionSummaryRepository = (*TransactionSummaryRepository)(nil)
+
+func NewTransactionSummaryRepository(database *gorm.DB) interfaces.TransactionSummaryRepository {
+	return &TransactionSummaryRepository{db: database}
+}
+
+// GetWatermarkForUpdate locks the watermark row so that only one summary job advances it at a time
+func (r *TransactionSummaryRepository) GetWatermarkForUpdate(ctx context.Context, name string) (*models.SummaryWatermark, error) {
+	var watermark models.SummaryWatermark
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("name = ?", name).
+		First(&watermark)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &watermark, nil
+}
+
+func (r *TransactionSummaryRepository) SaveWatermark(ctx context.Context, watermark *models.SummaryWatermark) error {
+	return r.db.WithContext(ctx).Save(watermark).Error
+}
+
+// Accumulate adds the given totals onto the stored summaries, creating missing rows
+func (r *TransactionSummaryRepository) Accumulate(ctx context.Context, summaries []models.TransactionSummary) error {
+	if len(summaries) == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"total_amount":      gorm.Expr("total_amount + VALUES(total_amount)"),
+				"transaction_count": gorm.Expr("transaction_count + VALUES(transaction_count)"),
+				"updated_at":        gorm.Expr("VALUES(updated_at)"),
+			}),
+		}).
+		Create(&summaries).Error
+}
+
+// GetByWalletID returns the summaries of a wallet for the periods between from and to, inclusive
+func (r *TransactionSummaryRepository) GetByWalletID(ctx context.Context, walletID string, from, to time.Time) ([]models.TransactionSummary, error) {
+	var summaries []models.TransactionSummary
+	result := r.db.WithContext(ctx).
+		Where("wallet_id = ? AND period BETWEEN ? AND ?", walletID, from, to).
+		Order("period ASC, category ASC").
+		Find(&summaries)
+	return summaries, result.Error
+}
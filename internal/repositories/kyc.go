@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type KYCSubmissionRepository struct {
+	db *gorm.DB
+}
+
+// Ensure KYCSubmissionRepository implements interfaces.KYCSubmissionRepository
+var _ interfaces.KYCSubmissionRepository = (*KYCSubmissionRepository)(nil)
+
+func NewKYCSubmissionRepository(database *gorm.DB) interfaces.KYCSubmissionRepository {
+	return &KYCSubmissionRepository{db: database}
+}
+
+func (r *KYCSubmissionRepository) Create(ctx context.Context, submission *models.KYCSubmission) error {
+	return r.db.WithContext(ctx).Create(submission).Error
+}
+
+func (r *KYCSubmissionRepository) GetByID(ctx context.Context, id string) (*models.KYCSubmission, error) {
+	var submission models.KYCSubmission
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&submission)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &submission, nil
+}
+
+// GetLatestByUserID returns the user's most recent submission, or nil if they have none
+func (r *KYCSubmissionRepository) GetLatestByUserID(ctx context.Context, userID string) (*models.KYCSubmission, error) {
+	var submission models.KYCSubmission
+	result := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		First(&submission)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &submission, nil
+}
+
+// List returns a page of submissions with the given status, or of every submission when
+// status is empty, oldest first so the review queue is worked in order
+func (r *KYCSubmissionRepository) List(ctx context.Context, status string, limit, offset int) ([]models.KYCSubmission, int64, error) {
+	var (
+		submissions []models.KYCSubmission
+		total       int64
+	)
+
+	query := r.db.WithContext(ctx).Model(&models.KYCSubmission{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := query.
+		Order("created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&submissions)
+	return submissions, total, result.Error
+}
+
+// Review saves the decision on a submission that is still pending and reports whether it
+// was, so two reviewers cannot both decide it
+func (r *KYCSubmissionRepository) Review(ctx context.Context, submission *models.KYCSubmission) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.KYCSubmission{}).
+		Where("id = ? AND status = ?", submission.ID, models.KYCStatusPending).
+		Updates(map[string]interface{}{
+			"status":      submission.Status,
+			"reviewer_id": submission.ReviewerID,
+			"review_note": submission.ReviewNote,
+			"reviewed_at": submission.ReviewedAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LedgerChainRepository reads the hash chains of wallet_transactions and keeps their signed
+// checkpoints. Reads of the chain include soft-deleted transactions, which are still links of it.
+type LedgerChainRepository struct {
+	db *gorm.DB
+}
+
+// Ensure LedgerChainRepository implements interfaces.LedgerChainRepository
+var _ interfaces.LedgerChainRepository = (*LedgerChainRepository)(nil)
+
+func NewLedgerChainRepository(database *gorm.DB) interfaces.LedgerChainRepository {
+	return &LedgerChainRepository{db: database}
+}
+
+// GetHead locks the wallet row and returns the last transaction of its chain, or nil before the
+// first one. Holding the wallet lock until commit keeps two transactions from taking the same
+// place in the chain.
+func (r *LedgerChainRepository) GetHead(ctx context.Context, walletID string) (*models.WalletTransaction, error) {
+	db := r.db.WithContext(ctx)
+
+	var wallet models.Wallet
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", walletID).First(&wallet).Error; err != nil {
+		return nil, err
+	}
+
+	var transaction models.WalletTransaction
+	result := db.Unscoped().
+		Where("wallet_id = ? AND chain_seq IS NOT NULL", walletID).
+		Order("chain_seq DESC").
+		First(&transaction)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &transaction, nil
+}
+
+// GetChain returns the next transactions of the wallet's chain after position afterSeq, in
+// chain order
+func (r *LedgerChainRepository) GetChain(ctx context.Context, walletID string, afterSeq int64, limit int) ([]models.WalletTransaction, error) {
+	var transactions []models.WalletTransaction
+	result := r.db.WithContext(ctx).
+		Unscoped().
+		Where("wallet_id = ? AND chain_seq > ?", walletID, afterSeq).
+		Order("chain_seq ASC").
+		Limit(limit).
+		Find(&transactions)
+	return transactions, result.Error
+}
+
+// CountUnchainedSince counts the wallet's transactions created since the given time that are not
+// in its chain
+func (r *LedgerChainRepository) CountUnchainedSince(ctx context.Context, walletID string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&models.WalletTransaction{}).
+		Where("wallet_id = ? AND chain_seq IS NULL AND created_at >= ?", walletID, since).
+		Count(&count).Error
+	return count, err
+}
+
+// GetChainedWalletIDs pages through the IDs of wallets with a chain or a checkpoint, in ID order
+// after afterID. Wallets with checkpoints are included so a chain removed as a whole is still
+// walked.
+func (r *LedgerChainRepository) GetChainedWalletIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	var ids []string
+	result := r.db.WithContext(ctx).
+		Raw(`SELECT wallet_id FROM (
+				SELECT DISTINCT wallet_id FROM wallet_transactions WHERE chain_seq IS NOT NULL AND wallet_id > ?
+				UNION
+				SELECT DISTINCT wallet_id FROM ledger_checkpoints WHERE wallet_id > ?
+			) w
+			ORDER BY wallet_id ASC
+			LIMIT ?`, afterID, afterID, limit).
+		Scan(&ids)
+	return ids, result.Error
+}
+
+// GetUncheckpointedWalletIDs pages through the IDs of wallets whose chain has grown past their
+// latest checkpoint, in ID order after afterID
+func (r *LedgerChainRepository) GetUncheckpointedWalletIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	var ids []string
+	result := r.db.WithContext(ctx).
+		Raw(`SELECT h.wallet_id FROM (
+				SELECT wallet_id, MAX(chain_seq) AS chain_seq FROM wallet_transactions
+				WHERE chain_seq IS NOT NULL AND wallet_id > ? GROUP BY wallet_id
+			) h
+			LEFT JOIN (
+				SELECT wallet_id, MAX(chain_seq) AS chain_seq FROM ledger_checkpoints GROUP BY wallet_id
+			) c ON c.wallet_id = h.wallet_id
+			WHERE c.chain_seq IS NULL OR c.chain_seq < h.chain_seq
+			ORDER BY h.wallet_id ASC
+			LIMIT ?`, afterID, limit).
+		Scan(&ids)
+	return ids, result.Error
+}
+
+func (r *LedgerChainRepository) CreateCheckpoint(ctx context.Context, checkpoint *models.LedgerCheckpoint) error {
+	return r.db.WithContext(ctx).Create(checkpoint).Error
+}
+
+// GetCheckpoints returns every checkpoint of the wallet, in chain order
+func (r *LedgerChainRepository) GetCheckpoints(ctx context.Context, walletID string) ([]models.LedgerCheckpoint, error) {
+	var checkpoints []models.LedgerCheckpoint
+	result := r.db.WithContext(ctx).
+		Where("wallet_id = ?", walletID).
+		Order("chain_seq ASC").
+		Find(&checkpoints)
+	return checkpoints, result.Error
+}
+
+// GetLatestCheckpoint returns the checkpoint furthest along the wallet's chain, or nil when it
+// has none
+func (r *LedgerChainRepository) GetLatestCheckpoint(ctx context.Context, walletID string) (*models.LedgerCheckpoint, error) {
+	var checkpoint models.LedgerCheckpoint
+	result := r.db.WithContext(ctx).
+		Where("wallet_id = ?", walletID).
+		Order("chain_seq DESC").
+		First(&checkpoint)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &checkpoint, nil
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MerchantRepository struct {
+	db *gorm.DB
+}
+
+// Ensure MerchantRepository implements interfaces.MerchantRepository
+var _ interfaces.MerchantRepository = (*MerchantRepository)(nil)
+
+func NewMerchantRepository(database *gorm.DB) interfaces.MerchantRepository {
+	return &MerchantRepository{db: database}
+}
+
+func (r *MerchantRepository) Create(ctx context.Context, merchant *models.Merchant) error {
+	return r.db.WithContext(ctx).Create(merchant).Error
+}
+
+func (r *MerchantRepository) GetByID(ctx context.Context, id string) (*models.Merchant, error) {
+	var merchant models.Merchant
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&merchant)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &merchant, nil
+}
+
+func (r *MerchantRepository) GetByAPIKeyPrefix(ctx context.Context, prefix string) (*models.Merchant, error) {
+	var merchant models.Merchant
+	result := r.db.WithContext(ctx).Where("api_key_prefix = ?", prefix).First(&merchant)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &merchant, nil
+}
+
+func (r *MerchantRepository) Update(ctx context.Context, merchant *models.Merchant) error {
+	return r.db.WithContext(ctx).Save(merchant).Error
+}
+
+// PaymentIntentRepository implementation
+type PaymentIntentRepository struct {
+	db *gorm.DB
+}
+
+// Ensure PaymentIntentRepository implements interfaces.PaymentIntentRepository
+var _ interfaces.PaymentIntentRepository = (*PaymentIntentRepository)(nil)
+
+func NewPaymentIntentRepository(database *gorm.DB) interfaces.PaymentIntentRepository {
+	return &PaymentIntentRepository{db: database}
+}
+
+func (r *PaymentIntentRepository) Create(ctx context.Context, intent *models.PaymentIntent) error {
+	return r.db.WithContext(ctx).Create(intent).Error
+}
+
+func (r *PaymentIntentRepository) GetByID(ctx context.Context, id string) (*models.PaymentIntent, error) {
+	var intent models.PaymentIntent
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&intent)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &intent, nil
+}
+
+// GetByIDForUpdate loads the intent and holds a row lock on it until the surrounding transaction ends
+func (r *PaymentIntentRepository) GetByIDForUpdate(ctx context.Context, id string) (*models.PaymentIntent, error) {
+	var intent models.PaymentIntent
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&intent)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &intent, nil
+}
+
+// GetExpired returns unconfirmed intents whose expiry time has passed
+func (r *PaymentIntentRepository) GetExpired(ctx context.Context, now time.Time, limit int) ([]models.PaymentIntent, error) {
+	var intents []models.PaymentIntent
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", models.PaymentIntentStatusRequiresConfirmation, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&intents)
+	return intents, result.Error
+}
+
+func (r *PaymentIntentRepository) Update(ctx context.Context, intent *models.PaymentIntent) error {
+	return r.db.WithContext(ctx).Save(intent).Error
+}
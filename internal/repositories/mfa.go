@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MFARepository struct {
+	db *gorm.DB
+}
+
+// Ensure MFARepository implements interfaces.MFARepository
+var _ interfaces.MFARepository = (*MFARepository)(nil)
+
+func NewMFARepository(database *gorm.DB) interfaces.MFARepository {
+	return &MFARepository{db: database}
+}
+
+// GetByUserID returns nil without an error when the user has not enrolled
+func (r *MFARepository) GetByUserID(ctx context.Context, userID string) (*models.UserMFA, error) {
+	var mfa models.UserMFA
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&mfa)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &mfa, nil
+}
+
+// Save inserts the second factor or replaces the one the user has
+func (r *MFARepository) Save(ctx context.Context, mfa *models.UserMFA) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(mfa).Error
+}
+
+// UseStep records that a code of the time step was accepted. It returns false when a code of
+// that step or a later one was already accepted, which makes each code single use.
+func (r *MFARepository) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.UserMFA{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *MFARepository) Delete(ctx context.Context, userID string) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.UserMFA{}).Error
+}
+
+type MFARecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// Ensure MFARecoveryCodeRepository implements interfaces.MFARecoveryCodeRepository
+var _ interfaces.MFARecoveryCodeRepository = (*MFARecoveryCodeRepository)(nil)
+
+func NewMFARecoveryCodeRepository(database *gorm.DB) interfaces.MFARecoveryCodeRepository {
+	return &MFARecoveryCodeRepository{db: database}
+}
+
+// Replace discards the user's recovery codes and stores codes in their place
+func (r *MFARecoveryCodeRepository) Replace(ctx context.Context, userID string, codes []models.MFARecoveryCode) error {
+	if err := r.DeleteByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&codes).Error
+}
+
+// Use marks an unused code as used and reports whether there was one
+func (r *MFARecoveryCodeRepository) Use(ctx context.Context, userID, codeHash string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *MFARecoveryCodeRepository) CountUnused(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&models.MFARecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count)
+	return count, result.Error
+}
+
+func (r *MFARecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.MFARecoveryCode{}).Error
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+// Ensure NotificationRepository implements interfaces.NotificationRepository
+var _ interfaces.NotificationRepository = (*NotificationRepository)(nil)
+
+func NewNotificationRepository(database *gorm.DB) interfaces.NotificationRepository {
+	return &NotificationRepository{db: database}
+}
+
+// CreateIfAbsent queues the notification unless the same event was already queued for the
+// reference on that channel, and reports whether it was inserted
+func (r *NotificationRepository) CreateIfAbsent(ctx context.Context, notification *models.Notification) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(notification)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetDueForUpdate locks pending notifications whose next attempt is due. Rows locked by
+// another sender are skipped so that several senders can run side by side.
+func (r *NotificationRepository) GetDueForUpdate(ctx context.Context, now time.Time, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", models.NotificationStatusPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&notifications)
+	return notifications, result.Error
+}
+
+func (r *NotificationRepository) Update(ctx context.Context, notification *models.Notification) error {
+	return r.db.WithContext(ctx).Save(notification).Error
+}
+
+type NotificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// Ensure NotificationPreferenceRepository implements interfaces.NotificationPreferenceRepository
+var _ interfaces.NotificationPreferenceRepository = (*NotificationPreferenceRepository)(nil)
+
+func NewNotificationPreferenceRepository(database *gorm.DB) interfaces.NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{db: database}
+}
+
+// GetByUserID returns nil without an error when the user has not set preferences
+func (r *NotificationPreferenceRepository) GetByUserID(ctx context.Context, userID string) (*models.NotificationPreference, error) {
+	var preference models.NotificationPreference
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&preference)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &preference, nil
+}
+
+// Save creates or replaces the preferences of a user
+func (r *NotificationPreferenceRepository) Save(ctx context.Context, preference *models.NotificationPreference) error {
+	return r.db.WithContext(ctx).Save(preference).Error
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PocketRepository struct {
+	db *gorm.DB
+}
+
+// Ensure PocketRepository implements interfaces.PocketRepository
+var _ interfaces.PocketRepository = (*PocketRepository)(nil)
+
+func NewPocketRepository(database *gorm.DB) interfaces.PocketRepository {
+	return &PocketRepository{db: database}
+}
+
+func (r *PocketRepository) Create(ctx context.Context, pocket *models.Pocket) error {
+	return r.db.WithContext(ctx).Create(pocket).Error
+}
+
+func (r *PocketRepository) GetByID(ctx context.Context, id string) (*models.Pocket, error) {
+	var pocket models.Pocket
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&pocket)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &pocket, nil
+}
+
+// GetByIDForUpdate reads the pocket and locks its row until the surrounding transaction ends
+func (r *PocketRepository) GetByIDForUpdate(ctx context.Context, id string) (*models.Pocket, error) {
+	var pocket models.Pocket
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&pocket)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &pocket, nil
+}
+
+func (r *PocketRepository) GetByWalletID(ctx context.Context, walletID string) ([]models.Pocket, error) {
+	var pockets []models.Pocket
+	result := r.db.WithContext(ctx).
+		Where("wallet_id = ?", walletID).
+		Order("created_at ASC").
+		Find(&pockets)
+	return pockets, result.Error
+}
+
+// Update writes the name, target amount and deadline of the pocket. The balance is left alone,
+// so transfers made since the pocket was read are kept; it only changes through Deposit and
+// Withdraw.
+func (r *PocketRepository) Update(ctx context.Context, pocket *models.Pocket) error {
+	return r.db.WithContext(ctx).
+		Model(pocket).
+		Select("name", "target_amount", "deadline").
+		Updates(pocket).Error
+}
+
+func (r *PocketRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Pocket{}).Error
+}
+
+func (r *PocketRepository) Deposit(ctx context.Context, pocketID string, amount float64) (*models.Pocket, error) {
+	return r.adjustBalance(ctx, pocketID, amount)
+}
+
+func (r *PocketRepository) Withdraw(ctx context.Context, pocketID string, amount float64) (*models.Pocket, error) {
+	return r.adjustBalance(ctx, pocketID, -amount)
+}
+
+// adjustBalance applies delta to the pocket balance under a row lock, refusing to go below zero
+func (r *PocketRepository) adjustBalance(ctx context.Context, pocketID string, delta float64) (*models.Pocket, error) {
+	var pocket models.Pocket
+	result := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the pocket row for update (pessimistic locking - FOR UPDATE)
+		lockResult := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", pocketID).
+			First(&pocket)
+
+		if lockResult.Error != nil {
+			if errors.Is(lockResult.Error, gorm.ErrRecordNotFound) {
+				return errors.New("pocket not found")
+			}
+			return lockResult.Error
+		}
+
+		if pocket.Balance+delta < 0 {
+			return errors.New("insufficient pocket balance")
+		}
+
+		updateResult := tx.Model(&pocket).Update("balance", gorm.Expr("balance + ?", delta))
+		if updateResult.Error != nil {
+			return updateResult.Error
+		}
+
+		if updateResult.RowsAffected == 0 {
+			return errors.New("failed to update pocket balance")
+		}
+
+		return tx.First(&pocket, "id = ?", pocketID).Error
+	})
+
+	if result != nil {
+		return nil, result
+	}
+
+	return &pocket, nil
+}
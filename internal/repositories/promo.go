@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// promoBatchSize bounds the rows inserted per statement when generating codes in bulk
+const promoBatchSize = 500
+
+type PromoCodeRepository struct {
+	db *gorm.DB
+}
+
+// Ensure PromoCodeRepository implements interfaces.PromoCodeRepository
+var _ interfaces.PromoCodeRepository = (*PromoCodeRepository)(nil)
+
+func NewPromoCodeRepository(database *gorm.DB) interfaces.PromoCodeRepository {
+	return &PromoCodeRepository{db: database}
+}
+
+func (r *PromoCodeRepository) Create(ctx context.Context, promo *models.PromoCode) error {
+	return r.db.WithContext(ctx).Create(promo).Error
+}
+
+func (r *PromoCodeRepository) CreateBatch(ctx context.Context, promos []models.PromoCode) error {
+	return r.db.WithContext(ctx).CreateInBatches(promos, promoBatchSize).Error
+}
+
+// GetByCodeForUpdate loads the code and holds a row lock on it, serializing redemptions
+func (r *PromoCodeRepository) GetByCodeForUpdate(ctx context.Context, code string) (*models.PromoCode, error) {
+	var promo models.PromoCode
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("code = ?", code).
+		First(&promo)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &promo, nil
+}
+
+func (r *PromoCodeRepository) GetByIDForUpdate(ctx context.Context, id string) (*models.PromoCode, error) {
+	var promo models.PromoCode
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&promo)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &promo, nil
+}
+
+func (r *PromoCodeRepository) Update(ctx context.Context, promo *models.PromoCode) error {
+	return r.db.WithContext(ctx).Save(promo).Error
+}
+
+// PromoRedemptionRepository implementation
+type PromoRedemptionRepository struct {
+	db *gorm.DB
+}
+
+// Ensure PromoRedemptionRepository implements interfaces.PromoRedemptionRepository
+var _ interfaces.PromoRedemptionRepository = (*PromoRedemptionRepository)(nil)
+
+func NewPromoRedemptionRepository(database *gorm.DB) interfaces.PromoRedemptionRepository {
+	return &PromoRedemptionRepository{db: database}
+}
+
+func (r *PromoRedemptionRepository) Create(ctx context.Context, redemption *models.PromoRedemption) error {
+	return r.db.WithContext(ctx).Create(redemption).Error
+}
+
+func (r *PromoRedemptionRepository) CountByPromoAndUser(ctx context.Context, promoCodeID, userID string) (int64, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&models.PromoRedemption{}).
+		Where("promo_code_id = ? AND user_id = ?", promoCodeID, userID).
+		Count(&count)
+	return count, result.Error
+}
+
+func (r *PromoRedemptionRepository) ExistsBySourceTransaction(ctx context.Context, promoCodeID, transactionID string) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&models.PromoRedemption{}).
+		Where("promo_code_id = ? AND source_transaction_id = ?", promoCodeID, transactionID).
+		Count(&count)
+	return count > 0, result.Error
+}
+
+func (r *PromoRedemptionRepository) DeleteByPaymentIntentID(ctx context.Context, paymentIntentID string) error {
+	return r.db.WithContext(ctx).Where("payment_intent_id = ?", paymentIntentID).Delete(&models.PromoRedemption{}).Error
+}
@@ -66,3 +66,119 @@ func (r *RepositoryRegistry) GetWalletRepository() interfaces.WalletRepository {
 func (r *RepositoryRegistry) GetWalletTransactionRepository() interfaces.WalletTransactionRepository {
 	return NewWalletTransactionRepository(r.db)
 }
+
+func (r *RepositoryRegistry) GetMerchantRepository() interfaces.MerchantRepository {
+	return NewMerchantRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetPaymentIntentRepository() interfaces.PaymentIntentRepository {
+	return NewPaymentIntentRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetPocketRepository() interfaces.PocketRepository {
+	return NewPocketRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetCashbackCampaignRepository() interfaces.CashbackCampaignRepository {
+	return NewCashbackCampaignRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetCashbackRewardRepository() interfaces.CashbackRewardRepository {
+	return NewCashbackRewardRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetPromoCodeRepository() interfaces.PromoCodeRepository {
+	return NewPromoCodeRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetPromoRedemptionRepository() interfaces.PromoRedemptionRepository {
+	return NewPromoRedemptionRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetEscrowRepository() interfaces.EscrowRepository {
+	return NewEscrowRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetEscrowEventRepository() interfaces.EscrowEventRepository {
+	return NewEscrowEventRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetTransactionSummaryRepository() interfaces.TransactionSummaryRepository {
+	return NewTransactionSummaryRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetBudgetRepository() interfaces.BudgetRepository {
+	return NewBudgetRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetBudgetAlertRepository() interfaces.BudgetAlertRepository {
+	return NewBudgetAlertRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetNotificationRepository() interfaces.NotificationRepository {
+	return NewNotificationRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetNotificationPreferenceRepository() interfaces.NotificationPreferenceRepository {
+	return NewNotificationPreferenceRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetUserRepository() interfaces.UserRepository {
+	return NewUserRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetSessionRepository() interfaces.SessionRepository {
+	return NewSessionRepository(r.redisCache)
+}
+
+func (r *RepositoryRegistry) GetAttemptRepository() interfaces.AttemptRepository {
+	return NewAttemptRepository(r.redisCache)
+}
+
+func (r *RepositoryRegistry) GetMFARepository() interfaces.MFARepository {
+	return NewMFARepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetMFARecoveryCodeRepository() interfaces.MFARecoveryCodeRepository {
+	return NewMFARecoveryCodeRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetChallengeRepository() interfaces.ChallengeRepository {
+	return NewChallengeRepository(r.redisCache)
+}
+
+func (r *RepositoryRegistry) GetAccountTokenRepository() interfaces.AccountTokenRepository {
+	return NewAccountTokenRepository(r.redisCache)
+}
+
+func (r *RepositoryRegistry) GetRoleRepository() interfaces.RoleRepository {
+	return NewRoleRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetAdminActionRepository() interfaces.AdminActionRepository {
+	return NewAdminActionRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetApprovalRequestRepository() interfaces.ApprovalRequestRepository {
+	return NewApprovalRequestRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetAPIKeyRepository() interfaces.APIKeyRepository {
+	return NewAPIKeyRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetDeviceRepository() interfaces.DeviceRepository {
+	return NewDeviceRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetKYCSubmissionRepository() interfaces.KYCSubmissionRepository {
+	return NewKYCSubmissionRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetAuditEventRepository() interfaces.AuditEventRepository {
+	return NewAuditEventRepository(r.db)
+}
+
+func (r *RepositoryRegistry) GetLedgerChainRepository() interfaces.LedgerChainRepository {
+	return NewLedgerChainRepository(r.db)
+}
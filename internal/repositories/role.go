@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+// Ensure RoleRepository implements interfaces.RoleRepository
+var _ interfaces.RoleRepository = (*RoleRepository)(nil)
+
+func NewRoleRepository(database *gorm.DB) interfaces.RoleRepository {
+	return &RoleRepository{db: database}
+}
+
+// GetPermissions returns the permissions granted to the role, sorted by name. An unknown role
+// has none.
+func (r *RoleRepository) GetPermissions(ctx context.Context, role string) ([]string, error) {
+	var permissions []string
+	err := r.db.WithContext(ctx).
+		Model(&models.RolePermission{}).
+		Where("role = ?", role).
+		Order("permission").
+		Pluck("permission", &permissions).Error
+	return permissions, err
+}
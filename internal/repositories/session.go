@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SessionRepository keeps each session under its own key, expiring with the session, and
+// the IDs of a user's sessions in a set so they can be listed and revoked together. The set
+// has no expiry of its own; IDs whose session has expired are pruned when the set is read.
+type SessionRepository struct {
+	redis *redis.Client
+}
+
+// Ensure SessionRepository implements interfaces.SessionRepository
+var _ interfaces.SessionRepository = (*SessionRepository)(nil)
+
+func NewSessionRepository(client *redis.Client) interfaces.SessionRepository {
+	return &SessionRepository{redis: client}
+}
+
+// Save stores the session until its expiry
+func (r *SessionRepository) Save(ctx context.Context, session *models.Session) error {
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return errors.New("session has already expired")
+	}
+
+	value, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKey(session.UserID, session.ID), value, ttl)
+		pipe.SAdd(ctx, userSessionsKey(session.UserID), session.ID)
+		return nil
+	})
+	return err
+}
+
+// Get returns nil without an error when the session does not exist or has expired
+func (r *SessionRepository) Get(ctx context.Context, userID, sessionID string) (*models.Session, error) {
+	value, err := r.redis.Get(ctx, sessionKey(userID, sessionID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var session models.Session
+	if err := json.Unmarshal(value, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// GetByUserID returns the user's active sessions, most recently used first
+func (r *SessionRepository) GetByUserID(ctx context.Context, userID string) ([]models.Session, error) {
+	ids, err := r.redis.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return []models.Session{}, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = sessionKey(userID, id)
+	}
+
+	values, err := r.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]models.Session, 0, len(values))
+	var expired []interface{}
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			expired = append(expired, ids[i])
+			continue
+		}
+
+		var session models.Session
+		if err := json.Unmarshal([]byte(raw), &session); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if len(expired) > 0 {
+		if err := r.redis.SRem(ctx, userSessionsKey(userID), expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, userID, sessionID string) error {
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(userID, sessionID))
+		pipe.SRem(ctx, userSessionsKey(userID), sessionID)
+		return nil
+	})
+	return err
+}
+
+// DeleteByUserID revokes every session of the user and returns how many were still active
+func (r *SessionRepository) DeleteByUserID(ctx context.Context, userID string) (int, error) {
+	ids, err := r.redis.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, sessionKey(userID, id))
+	}
+
+	var deleted *redis.IntCmd
+	_, err = r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(keys) > 0 {
+			deleted = pipe.Del(ctx, keys...)
+		}
+		pipe.Del(ctx, userSessionsKey(userID))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if deleted == nil {
+		return 0, nil
+	}
+	return int(deleted.Val()), nil
+}
+
+func (r *SessionRepository) IsValidSession(ctx context.Context, userID, sessionID string) (bool, error) {
+	n, err := r.redis.Exists(ctx, sessionKey(userID, sessionID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func sessionKey(userID, sessionID string) string {
+	return fmt.Sprintf("session:%s:%s", userID, sessionID)
+}
+
+func userSessionsKey(userID string) string {
+	return fmt.Sprintf("sessions:%s", userID)
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type UserRepository struct {
+	db *gorm.DB
+}
+
+// Ensure UserRepository implements interfaces.UserRepository
+var _ interfaces.UserRepository = (*UserRepository)(nil)
+
+func NewUserRepository(database *gorm.DB) interfaces.UserRepository {
+	return &UserRepository{db: database}
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
+	var user models.User
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&user)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+// Activate marks a user who was never activated as active and reports whether it did
+func (r *UserRepository) Activate(ctx context.Context, userID string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ? AND activated_at IS NULL", userID).
+		Updates(map[string]interface{}{"is_active": true, "activated_at": at})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, userID, passwordHash string) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Update("password", passwordHash).Error
+}
+
+func (r *UserRepository) UpdatePIN(ctx context.Context, userID, pinHash string) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Update("pin", pinHash).Error
+}
+
+// GetByPhoneNumber returns nil without an error when no user has the phone number
+func (r *UserRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*models.User, error) {
+	return r.findOne(ctx, "phone_number = ?", phoneNumber)
+}
+
+// GetByEmail returns nil without an error when no user has the email
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.findOne(ctx, "email = ?", email)
+}
+
+// Search finds users whose ID or email is the query, or whose phone number starts with it
+func (r *UserRepository) Search(ctx context.Context, query string, limit int) ([]models.User, error) {
+	var users []models.User
+	result := r.db.WithContext(ctx).
+		Where("id = ? OR email = ? OR phone_number LIKE ?", query, query, escapeLike(query)+"%").
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&users)
+	return users, result.Error
+}
+
+func (r *UserRepository) findOne(ctx context.Context, query string, args ...interface{}) (*models.User, error) {
+	var user models.User
+	result := r.db.WithContext(ctx).Where(query, args...).First(&user)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+// escapeLike escapes the LIKE wildcards in s so it only matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func (r *UserRepository) UpdateKYCLevel(ctx context.Context, userID, level string) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Update("kyc_level", level).Error
+}
@@ -5,6 +5,7 @@ import (
 	"digital-wallet/internal/interfaces"
 	"digital-wallet/internal/models"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -105,6 +106,54 @@ func (r *WalletRepository) Withdraw(ctx context.Context, walletID string, amount
 	return &wallet, nil
 }
 
+func (r *WalletRepository) Deposit(ctx context.Context, walletID string, amount float64) (*models.Wallet, error) {
+	var wallet models.Wallet
+	result := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the wallet row for update (pessimistic locking - FOR UPDATE)
+		lockResult := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", walletID).
+			First(&wallet)
+
+		if lockResult.Error != nil {
+			if errors.Is(lockResult.Error, gorm.ErrRecordNotFound) {
+				return errors.New("wallet not found")
+			}
+			return lockResult.Error
+		}
+
+		if !wallet.IsActive {
+			return errors.New("wallet is not active")
+		}
+
+		updateResult := tx.Model(&wallet).Update("balance", gorm.Expr("balance + ?", amount))
+		if updateResult.Error != nil {
+			return updateResult.Error
+		}
+
+		if updateResult.RowsAffected == 0 {
+			return errors.New("failed to update wallet balance")
+		}
+
+		return tx.First(&wallet, "id = ?", walletID).Error
+	})
+
+	if result != nil {
+		return nil, result
+	}
+
+	return &wallet, nil
+}
+
+// SetActive activates or deactivates the wallet and reports whether it changed. Only the flag
+// is written, so balance changes made meanwhile are kept.
+func (r *WalletRepository) SetActive(ctx context.Context, walletID string, active bool) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Wallet{}).
+		Where("id = ? AND is_active = ?", walletID, !active).
+		Update("is_active", active)
+	return result.RowsAffected > 0, result.Error
+}
+
 // WalletTransactionRepository implementation
 type WalletTransactionRepository struct {
 	db *gorm.DB
@@ -147,6 +196,75 @@ func (r *WalletTransactionRepository) CountByWalletID(ctx context.Context, walle
 	return count, err
 }
 
+// GetCreatedAfter pages through transactions in (created_at, id) order, starting after the given
+// position and stopping at until
+func (r *WalletTransactionRepository) GetCreatedAfter(ctx context.Context, createdAt time.Time, id string, until time.Time, limit int) ([]models.WalletTransaction, error) {
+	var transactions []models.WalletTransaction
+	result := r.db.WithContext(ctx).
+		Where("(created_at > ? OR (created_at = ? AND id > ?)) AND created_at <= ?", createdAt, createdAt, id, until).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&transactions)
+	return transactions, result.Error
+}
+
+// SumSpent totals the completed debits of a wallet in a category created in [from, to)
+func (r *WalletTransactionRepository) SumSpent(ctx context.Context, walletID, category string, from, to time.Time) (float64, error) {
+	var total float64
+	result := r.db.WithContext(ctx).
+		Model(&models.WalletTransaction{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("wallet_id = ? AND category = ? AND direction = ? AND status = ? AND created_at >= ? AND created_at < ?",
+			walletID, category, models.TransactionDirectionDebit, models.TransactionStatusCompleted, from, to).
+		Scan(&total)
+	return total, result.Error
+}
+
+// HasCompletedWithdrawalTo reports whether the wallet has ever completed a withdrawal to beneficiary
+func (r *WalletTransactionRepository) HasCompletedWithdrawalTo(ctx context.Context, walletID, beneficiary string) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&models.WalletTransaction{}).
+		Where("wallet_id = ? AND beneficiary = ? AND type = ? AND status = ?",
+			walletID, beneficiary, models.TransactionTypeWithdrawal, models.TransactionStatusCompleted).
+		Limit(1).
+		Count(&count)
+	return count > 0, result.Error
+}
+
+// SumOutgoing totals the completed outgoing debits of a wallet created since from
+func (r *WalletTransactionRepository) SumOutgoing(ctx context.Context, walletID string, from time.Time) (float64, error) {
+	var total float64
+	result := r.db.WithContext(ctx).
+		Model(&models.WalletTransaction{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("wallet_id = ? AND direction = ? AND type IN ? AND status = ? AND created_at >= ?",
+			walletID, models.TransactionDirectionDebit, models.OutgoingTransactionTypes, models.TransactionStatusCompleted, from).
+		Scan(&total)
+	return total, result.Error
+}
+
+// IsReversed reports whether a reversal of the transaction has been recorded
+func (r *WalletTransactionRepository) IsReversed(ctx context.Context, transactionID string) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&models.WalletTransaction{}).
+		Where("reversal_of = ?", transactionID).
+		Limit(1).
+		Count(&count)
+	return count > 0, result.Error
+}
+
+// GetByWalletIDBetween returns the transactions of a wallet created in [from, to), oldest first
+func (r *WalletTransactionRepository) GetByWalletIDBetween(ctx context.Context, walletID string, from, to time.Time) ([]models.WalletTransaction, error) {
+	var transactions []models.WalletTransaction
+	result := r.db.WithContext(ctx).
+		Where("wallet_id = ? AND created_at >= ? AND created_at < ?", walletID, from, to).
+		Order("created_at ASC, id ASC").
+		Find(&transactions)
+	return transactions, result.Error
+}
+
 func (r *WalletTransactionRepository) Update(ctx context.Context, transaction *models.WalletTransaction) error {
 	return r.db.WithContext(ctx).Save(transaction).Error
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
@@ -197,6 +198,34 @@ func TestWalletRepository_Update_Real(t *testing.T) {
 	})
 }
 
+func TestWalletRepository_SetActive_Real(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewWalletRepository(db)
+
+	t.Run("freezing an active wallet only writes the flag", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `wallets` SET `is_active`=?,`updated_at`=? WHERE (id = ? AND is_active = ?)")).
+			WithArgs(false, sqlmock.AnyArg(), "wallet-1", true).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		changed, err := repo.SetActive(context.Background(), "wallet-1", false)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("freezing a frozen wallet changes nothing", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `wallets` SET")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		changed, err := repo.SetActive(context.Background(), "wallet-1", false)
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+}
+
 func TestWalletRepository_Withdraw_Real(t *testing.T) {
 	db, mock := setupMockDB(t)
 	repo := NewWalletRepository(db)
@@ -265,6 +294,90 @@ func TestWalletRepository_Withdraw_Real(t *testing.T) {
 	})
 }
 
+func TestWalletRepository_Deposit_Real(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewWalletRepository(db)
+
+	t.Run("successfully deposit within transaction", func(t *testing.T) {
+		walletID := "wallet-1"
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM `wallets` WHERE id = \\?").
+			WithArgs(walletID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "is_active"}).
+				AddRow(walletID, "user-1", 1000.0, true))
+
+		mock.ExpectExec("UPDATE `wallets` SET").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT \\* FROM `wallets` WHERE id = \\?").
+			WithArgs(walletID, walletID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow(walletID, 1250.0))
+		mock.ExpectCommit()
+
+		wallet, err := repo.Deposit(context.Background(), walletID, 250)
+		assert.NoError(t, err)
+		assert.Equal(t, 1250.0, wallet.Balance)
+	})
+
+	t.Run("inactive wallet in deposit", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "is_active"}).AddRow("w1", false))
+		mock.ExpectRollback()
+
+		_, err := repo.Deposit(context.Background(), "w1", 100)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "wallet is not active")
+	})
+}
+
+func TestPocketRepository_Withdraw_Real(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewPocketRepository(db)
+
+	t.Run("insufficient pocket balance", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM `pockets` WHERE id = \\?").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "wallet_id", "balance"}).AddRow("pocket-1", "wallet-1", 50.0))
+		mock.ExpectRollback()
+
+		_, err := repo.Withdraw(context.Background(), "pocket-1", 100)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "insufficient pocket balance")
+	})
+}
+
+func TestCashbackCampaignRepository_AddUsedBudget_Real(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewCashbackCampaignRepository(db)
+
+	t.Run("increments used budget in place", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `cashback_campaigns` SET `used_budget`=used_budget \\+ \\?").
+			WithArgs(12.5, sqlmock.AnyArg(), "campaign-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.AddUsedBudget(context.Background(), "campaign-1", 12.5)
+		assert.NoError(t, err)
+	})
+}
+
+func TestTransactionSummaryRepository_Accumulate_Real(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewTransactionSummaryRepository(db)
+
+	t.Run("adds onto existing totals", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `transaction_summaries` .* ON DUPLICATE KEY UPDATE .*total_amount \\+ VALUES\\(total_amount\\)").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Accumulate(context.Background(), []models.TransactionSummary{
+			{WalletID: "wallet-1", Period: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Category: models.CategoryFoodAndDrink, Direction: models.TransactionDirectionDebit, TotalAmount: 50, TransactionCount: 1},
+		})
+		assert.NoError(t, err)
+	})
+}
+
 func TestWalletTransactionRepository_Create_Real(t *testing.T) {
 	db, mock := setupMockDB(t)
 	repo := NewWalletTransactionRepository(db)
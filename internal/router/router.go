@@ -3,25 +3,243 @@ package router
 import (
 	"digital-wallet/di"
 	"digital-wallet/internal/controllers"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/middleware"
 
 	"github.com/labstack/echo/v4"
 )
 
 func SetupRouter(e *echo.Echo, di *di.Container) {
 	// Initialize controllers
+	authController := controllers.NewAuthController(di)
 	walletController := controllers.NewWalletController(di)
+	merchantController := controllers.NewMerchantController(di)
+	paymentIntentController := controllers.NewPaymentIntentController(di)
+	pocketController := controllers.NewPocketController(di)
+	cashbackController := controllers.NewCashbackController(di)
+	promoController := controllers.NewPromoController(di)
+	escrowController := controllers.NewEscrowController(di)
+	insightController := controllers.NewInsightController(di)
+	budgetController := controllers.NewBudgetController(di)
+	notificationController := controllers.NewNotificationController(di)
+	pinController := controllers.NewPINController(di)
+	mfaController := controllers.NewMFAController(di)
+	accountController := controllers.NewAccountController(di)
+	adminController := controllers.NewAdminController(di)
+	approvalController := controllers.NewApprovalController(di)
+	apiKeyController := controllers.NewAPIKeyController(di)
+	deviceController := controllers.NewDeviceController(di)
+	kycController := controllers.NewKYCController(di)
+	auditController := controllers.NewAuditController(di)
+
+	// Public keys access tokens are verified with, at the well-known path other services expect
+	e.GET("/.well-known/jwks.json", authController.JWKS)
 
 	v1 := e.Group("/v1")
 	{
+		// Auth routes
+		authGroup := v1.Group("/auth")
+		{
+			authGroup.POST("/register", authController.Register)
+			authGroup.POST("/login", authController.Login)
+			authGroup.POST("/mfa/verify", authController.VerifyMFA)
+			authGroup.POST("/activate", accountController.Activate)
+			authGroup.POST("/activation/resend", accountController.ResendActivation)
+			authGroup.POST("/password/forgot", accountController.ForgotPassword)
+			authGroup.POST("/password/reset", accountController.ResetPassword)
+			authGroup.POST("/refresh", authController.Refresh)
+			authGroup.POST("/logout", authController.Logout)
+
+			sessions := authGroup.Group("/sessions", middleware.AuthMiddleware(di))
+			sessions.GET("", authController.ListSessions)
+			sessions.DELETE("", authController.RevokeAllSessions)
+			sessions.DELETE("/:session_id", authController.RevokeSession)
+		}
+
+		// Wallet routes of the authenticated user
+		me := v1.Group("/me/wallet")
+		me.Use(middleware.AuthMiddleware(di), middleware.RequireActiveAccount(di), middleware.RecordDevice(di))
+		{
+			me.GET("/balance", walletController.GetBalance)
+			me.POST("/withdraw", walletController.WithdrawOwn)
+			me.GET("/transactions", walletController.GetTransactionHistory)
+			me.GET("/insights", insightController.GetInsights)
+
+			me.GET("/pockets", pocketController.ListPockets)
+			me.POST("/pockets", pocketController.CreatePocket)
+			me.PUT("/pockets/:pocket_id", pocketController.UpdatePocket)
+			me.DELETE("/pockets/:pocket_id", pocketController.DeletePocket)
+			me.POST("/pockets/:pocket_id/transfers", pocketController.Transfer)
+
+			me.GET("/budgets", budgetController.ListBudgets)
+			me.POST("/budgets", budgetController.CreateBudget)
+			me.GET("/budgets/status", budgetController.GetBudgetStatus)
+			me.PUT("/budgets/:budget_id", budgetController.UpdateBudget)
+			me.DELETE("/budgets/:budget_id", budgetController.DeleteBudget)
+
+			me.GET("/notification-preferences", notificationController.GetPreferences)
+			me.PUT("/notification-preferences", notificationController.UpdatePreferences)
+		}
+
+		// Transaction PIN of the authenticated user
+		pin := v1.Group("/me/pin")
+		pin.Use(middleware.AuthMiddleware(di))
+		{
+			pin.POST("", pinController.SetupPIN)
+			pin.PUT("", pinController.ChangePIN)
+			pin.POST("/reset", pinController.ResetPIN)
+		}
 
-		// Wallet routes
+		// Devices the authenticated user has signed in from
+		devices := v1.Group("/me/devices")
+		devices.Use(middleware.AuthMiddleware(di))
+		{
+			devices.GET("", deviceController.List)
+			devices.DELETE("/:device_id", deviceController.Remove)
+		}
+
+		// Identity verification of the authenticated user
+		kyc := v1.Group("/me/kyc")
+		kyc.Use(middleware.AuthMiddleware(di), middleware.RequireActiveAccount(di))
+		{
+			kyc.GET("", kycController.GetStatus)
+			kyc.POST("/submissions", kycController.Submit)
+		}
+
+		// Two-factor authentication of the authenticated user
+		mfa := v1.Group("/me/mfa")
+		mfa.Use(middleware.AuthMiddleware(di))
+		{
+			mfa.GET("", mfaController.GetStatus)
+			mfa.DELETE("", mfaController.Disable)
+			mfa.POST("/enroll", mfaController.Enroll)
+			mfa.POST("/enroll/verify", mfaController.ConfirmEnrollment)
+			mfa.POST("/recovery-codes", mfaController.RegenerateRecoveryCodes)
+			mfa.PUT("/settings", mfaController.UpdateSettings)
+		}
+
+		// Wallet routes acting on any user, for principals whose role or API key scopes grant the permission
 		wallet := v1.Group("/wallet")
-		// wallet.Use(middleware.AuthMiddleware(di))
+		wallet.Use(middleware.AuthOrAPIKeyMiddleware(di), middleware.RequireActiveAccount(di), middleware.RecordDevice(di))
+		{
+			read := middleware.RequirePermission(di, auth.PermissionWalletRead)
+			manage := middleware.RequirePermission(di, auth.PermissionWalletManage)
+			withdraw := middleware.RequirePermission(di, auth.PermissionWalletWithdraw)
+
+			wallet.GET("/balance/:user_id", walletController.GetBalance, read)
+			wallet.POST("/withdraw", walletController.Withdraw, withdraw)
+			wallet.GET("/:user_id/transactions", walletController.GetTransactionHistory, read)
+			wallet.GET("/:user_id/insights", insightController.GetInsights, read)
+
+			wallet.GET("/:user_id/pockets", pocketController.ListPockets, read)
+			wallet.POST("/:user_id/pockets", pocketController.CreatePocket, manage)
+			wallet.PUT("/:user_id/pockets/:pocket_id", pocketController.UpdatePocket, manage)
+			wallet.DELETE("/:user_id/pockets/:pocket_id", pocketController.DeletePocket, manage)
+			wallet.POST("/:user_id/pockets/:pocket_id/transfers", pocketController.Transfer, manage)
+
+			wallet.GET("/:user_id/budgets", budgetController.ListBudgets, read)
+			wallet.POST("/:user_id/budgets", budgetController.CreateBudget, manage)
+			wallet.GET("/:user_id/budgets/status", budgetController.GetBudgetStatus, read)
+			wallet.PUT("/:user_id/budgets/:budget_id", budgetController.UpdateBudget, manage)
+			wallet.DELETE("/:user_id/budgets/:budget_id", budgetController.DeleteBudget, manage)
+
+			wallet.GET("/:user_id/notification-preferences", notificationController.GetPreferences, read)
+			wallet.PUT("/:user_id/notification-preferences", notificationController.UpdatePreferences, manage)
+
+			wallet.POST("/:user_id/merchants", merchantController.CreateMerchant, manage)
+			wallet.POST("/:user_id/checkout/:id/confirm", paymentIntentController.ConfirmIntent, withdraw)
+			wallet.POST("/:user_id/promos/redeem", promoController.Redeem, manage)
+			wallet.POST("/:user_id/escrows", escrowController.CreateEscrow, withdraw)
+			wallet.GET("/:user_id/escrows/:id", escrowController.GetEscrow, read)
+			wallet.POST("/:user_id/escrows/:id/release", escrowController.Release, manage)
+			wallet.POST("/:user_id/escrows/:id/refund", escrowController.Refund, manage)
+		}
+
+		// Merchants of the authenticated user
+		merchants := v1.Group("/merchants")
+		merchants.Use(middleware.AuthMiddleware(di), middleware.RequireActiveAccount(di))
 		{
-			wallet.GET("/balance/:user_id", walletController.GetBalance)
-			wallet.POST("/withdraw", walletController.Withdraw)
-			wallet.GET("/:user_id/transactions", walletController.GetTransactionHistory)
+			merchants.POST("", merchantController.CreateMerchant)
+			merchants.GET("/:id", merchantController.GetMerchant)
+			merchants.PUT("/:id", merchantController.UpdateMerchant)
+			merchants.POST("/:id/rotate-key", merchantController.RotateAPIKey)
+		}
+
+		// Payment intent routes, authenticated by merchant API key
+		paymentIntents := v1.Group("/payment-intents")
+		paymentIntents.Use(middleware.MerchantAuthMiddleware(di))
+		{
+			paymentIntents.POST("", paymentIntentController.CreateIntent)
+			paymentIntents.GET("/:id", paymentIntentController.GetIntent)
+			paymentIntents.POST("/:id/cancel", paymentIntentController.CancelIntent)
+		}
+
+		// Hosted checkout routes, paid from the wallet of the authenticated user
+		checkout := v1.Group("/checkout")
+		checkout.Use(middleware.AuthMiddleware(di), middleware.RequireActiveAccount(di), middleware.RecordDevice(di))
+		{
+			checkout.GET("/:id", paymentIntentController.GetCheckout)
+			checkout.POST("/:id/confirm", paymentIntentController.ConfirmIntent)
+		}
+
+		// Promo codes redeemed by the authenticated user
+		promos := v1.Group("/promos")
+		promos.Use(middleware.AuthMiddleware(di), middleware.RequireActiveAccount(di), middleware.RecordDevice(di))
+		{
+			promos.POST("/redeem", promoController.Redeem)
+		}
+
+		// Escrows of marketplace orders the authenticated user buys or sells
+		escrows := v1.Group("/escrows")
+		escrows.Use(middleware.AuthMiddleware(di), middleware.RequireActiveAccount(di), middleware.RecordDevice(di))
+		{
+			escrows.POST("", escrowController.CreateEscrow)
+			escrows.GET("/:id", escrowController.GetEscrow)
+			escrows.POST("/:id/release", escrowController.Release)
+			escrows.POST("/:id/refund", escrowController.Refund)
+		}
+
+		// Back-office routes, each needing the permission of the operation
+		admin := v1.Group("/admin")
+		admin.Use(middleware.AuthMiddleware(di), middleware.RequireActiveAccount(di))
+		{
+			cashbackRead := middleware.RequirePermission(di, auth.PermissionCashbackRead)
+			cashbackManage := middleware.RequirePermission(di, auth.PermissionCashbackManage)
+
+			admin.POST("/cashback/campaigns", cashbackController.CreateCampaign, cashbackManage)
+			admin.GET("/cashback/campaigns", cashbackController.ListCampaigns, cashbackRead)
+			admin.POST("/cashback/campaigns/:id/deactivate", cashbackController.DeactivateCampaign, cashbackManage)
+			admin.GET("/cashback/campaigns/:id/report", cashbackController.GetCampaignReport, cashbackRead)
+
+			admin.GET("/users", adminController.SearchUsers, middleware.RequirePermission(di, auth.PermissionUserRead))
+			admin.POST("/users/:user_id/unlock", adminController.UnlockLogin, middleware.RequirePermission(di, auth.PermissionUserUnlock))
+			admin.GET("/users/:user_id/transactions/export", adminController.ExportTransactions, middleware.RequirePermission(di, auth.PermissionWalletExport))
+			admin.GET("/wallets/:wallet_id", adminController.GetWallet, middleware.RequirePermission(di, auth.PermissionWalletRead))
+			admin.POST("/wallets/:wallet_id/adjustments", adminController.Adjust, middleware.RequirePermission(di, auth.PermissionWalletAdjust))
+			admin.POST("/wallets/:wallet_id/freeze", adminController.Freeze, middleware.RequirePermission(di, auth.PermissionWalletFreeze))
+			admin.POST("/wallets/:wallet_id/unfreeze", adminController.Unfreeze, middleware.RequirePermission(di, auth.PermissionWalletFreeze))
+			admin.POST("/transactions/:transaction_id/reverse", adminController.Reverse, middleware.RequirePermission(di, auth.PermissionWalletReverse))
+
+			approvalReview := middleware.RequirePermission(di, auth.PermissionApprovalReview)
+			admin.GET("/approvals", approvalController.List, approvalReview)
+			admin.GET("/approvals/:id", approvalController.Get, approvalReview)
+			admin.POST("/approvals/:id/approve", approvalController.Approve, approvalReview)
+			admin.POST("/approvals/:id/reject", approvalController.Reject, approvalReview)
+
+			kycReview := middleware.RequirePermission(di, auth.PermissionKYCReview)
+			admin.GET("/kyc/submissions", kycController.List, kycReview)
+			admin.GET("/kyc/submissions/:id", kycController.Get, kycReview)
+			admin.GET("/kyc/submissions/:id/document", kycController.Document, kycReview)
+			admin.POST("/kyc/submissions/:id/approve", kycController.Approve, kycReview)
+			admin.POST("/kyc/submissions/:id/reject", kycController.Reject, kycReview)
+
+			admin.GET("/audit-events", auditController.List, middleware.RequirePermission(di, auth.PermissionAuditRead))
 
+			apiKeyManage := middleware.RequirePermission(di, auth.PermissionAPIKeyManage)
+			admin.POST("/api-keys", apiKeyController.Create, apiKeyManage)
+			admin.GET("/api-keys", apiKeyController.List, apiKeyManage)
+			admin.POST("/api-keys/:id/rotate", apiKeyController.Rotate, apiKeyManage)
+			admin.POST("/api-keys/:id/revoke", apiKeyController.Revoke, apiKeyManage)
 		}
 
 		e.Any("", func(c echo.Context) error {
@@ -1,12 +1,26 @@
 package router
 
 import (
+	"digital-wallet/configs"
 	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
 	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSetupRouter(t *testing.T) {
@@ -20,6 +34,7 @@ func TestSetupRouter(t *testing.T) {
 		foundBalance := false
 		foundWithdraw := false
 		foundHistory := false
+		foundInsights := false
 
 		for _, r := range routes {
 			if r.Path == "/v1/wallet/balance/:user_id" && r.Method == http.MethodGet {
@@ -31,10 +46,253 @@ func TestSetupRouter(t *testing.T) {
 			if r.Path == "/v1/wallet/:user_id/transactions" && r.Method == http.MethodGet {
 				foundHistory = true
 			}
+			if r.Path == "/v1/wallet/:user_id/insights" && r.Method == http.MethodGet {
+				foundInsights = true
+			}
 		}
 
 		assert.True(t, foundBalance, "Balance route not found")
 		assert.True(t, foundWithdraw, "Withdraw route not found")
 		assert.True(t, foundHistory, "History route not found")
+		assert.True(t, foundInsights, "Insights route not found")
+	})
+
+	t.Run("Verify payment routes", func(t *testing.T) {
+		expected := map[string]string{
+			"/v1/merchants":                                http.MethodPost,
+			"/v1/payment-intents":                          http.MethodPost,
+			"/v1/payment-intents/:id/cancel":               http.MethodPost,
+			"/v1/checkout/:id":                             http.MethodGet,
+			"/v1/checkout/:id/confirm":                     http.MethodPost,
+			"/v1/promos/redeem":                            http.MethodPost,
+			"/v1/escrows":                                  http.MethodPost,
+			"/v1/escrows/:id/release":                      http.MethodPost,
+			"/v1/escrows/:id/refund":                       http.MethodPost,
+			"/v1/wallet/:user_id/budgets":                  http.MethodPost,
+			"/v1/wallet/:user_id/budgets/status":           http.MethodGet,
+			"/v1/wallet/:user_id/budgets/:budget_id":       http.MethodPut,
+			"/v1/wallet/:user_id/notification-preferences": http.MethodPut,
+			"/v1/auth/sessions":                            http.MethodDelete,
+			"/v1/me/wallet/balance":                        http.MethodGet,
+			"/v1/me/pin":                                   http.MethodPut,
+			"/v1/me/pin/reset":                             http.MethodPost,
+			"/v1/auth/mfa/verify":                          http.MethodPost,
+			"/v1/auth/activate":                            http.MethodPost,
+			"/v1/auth/activation/resend":                   http.MethodPost,
+			"/v1/auth/password/forgot":                     http.MethodPost,
+			"/v1/auth/password/reset":                      http.MethodPost,
+			"/v1/me/mfa":                                   http.MethodDelete,
+			"/v1/me/mfa/enroll/verify":                     http.MethodPost,
+			"/v1/me/mfa/settings":                          http.MethodPut,
+			"/v1/me/wallet/withdraw":                       http.MethodPost,
+			"/v1/me/wallet/transactions":                   http.MethodGet,
+			"/v1/me/wallet/pockets/:pocket_id/transfers":   http.MethodPost,
+			"/v1/me/wallet/budgets/status":                 http.MethodGet,
+			"/v1/auth/sessions/:session_id":                http.MethodDelete,
+		}
+
+		for path, method := range expected {
+			found := false
+			for _, r := range e.Routes() {
+				if r.Path == path && r.Method == method {
+					found = true
+				}
+			}
+			assert.True(t, found, "%s %s route not found", method, path)
+		}
+	})
+
+	t.Run("Verify admin cashback routes", func(t *testing.T) {
+		expected := map[string]string{
+			"/v1/admin/cashback/campaigns":                http.MethodPost,
+			"/v1/admin/cashback/campaigns/:id/report":     http.MethodGet,
+			"/v1/admin/cashback/campaigns/:id/deactivate": http.MethodPost,
+		}
+
+		for path, method := range expected {
+			found := false
+			for _, r := range e.Routes() {
+				if r.Path == path && r.Method == method {
+					found = true
+				}
+			}
+			assert.True(t, found, "%s %s route not found", method, path)
+		}
 	})
 }
+
+// routePermissions is the permission every /v1 route requires, or "" for routes open to any
+// caller the route's own authentication accepts. Every route must be listed.
+var routePermissions = map[string]string{
+	"POST /v1/auth/register":               "",
+	"POST /v1/auth/login":                  "",
+	"POST /v1/auth/mfa/verify":             "",
+	"POST /v1/auth/activate":               "",
+	"POST /v1/auth/activation/resend":      "",
+	"POST /v1/auth/password/forgot":        "",
+	"POST /v1/auth/password/reset":         "",
+	"POST /v1/auth/refresh":                "",
+	"POST /v1/auth/logout":                 "",
+	"GET /v1/auth/sessions":                "",
+	"DELETE /v1/auth/sessions":             "",
+	"DELETE /v1/auth/sessions/:session_id": "",
+
+	"GET /v1/me/wallet/balance":                       "",
+	"POST /v1/me/wallet/withdraw":                     "",
+	"GET /v1/me/wallet/transactions":                  "",
+	"GET /v1/me/wallet/insights":                      "",
+	"GET /v1/me/wallet/pockets":                       "",
+	"POST /v1/me/wallet/pockets":                      "",
+	"PUT /v1/me/wallet/pockets/:pocket_id":            "",
+	"DELETE /v1/me/wallet/pockets/:pocket_id":         "",
+	"POST /v1/me/wallet/pockets/:pocket_id/transfers": "",
+	"GET /v1/me/wallet/budgets":                       "",
+	"POST /v1/me/wallet/budgets":                      "",
+	"GET /v1/me/wallet/budgets/status":                "",
+	"PUT /v1/me/wallet/budgets/:budget_id":            "",
+	"DELETE /v1/me/wallet/budgets/:budget_id":         "",
+	"GET /v1/me/wallet/notification-preferences":      "",
+	"PUT /v1/me/wallet/notification-preferences":      "",
+	"POST /v1/me/pin":                                 "",
+	"PUT /v1/me/pin":                                  "",
+	"POST /v1/me/pin/reset":                           "",
+	"GET /v1/me/mfa":                                  "",
+	"DELETE /v1/me/mfa":                               "",
+	"POST /v1/me/mfa/enroll":                          "",
+	"POST /v1/me/mfa/enroll/verify":                   "",
+	"POST /v1/me/mfa/recovery-codes":                  "",
+	"PUT /v1/me/mfa/settings":                         "",
+	"GET /v1/me/devices":                              "",
+	"DELETE /v1/me/devices/:device_id":                "",
+	"GET /v1/me/kyc":                                  "",
+	"POST /v1/me/kyc/submissions":                     "",
+
+	"GET /v1/wallet/balance/:user_id":                       auth.PermissionWalletRead,
+	"POST /v1/wallet/withdraw":                              auth.PermissionWalletWithdraw,
+	"GET /v1/wallet/:user_id/transactions":                  auth.PermissionWalletRead,
+	"GET /v1/wallet/:user_id/insights":                      auth.PermissionWalletRead,
+	"GET /v1/wallet/:user_id/pockets":                       auth.PermissionWalletRead,
+	"POST /v1/wallet/:user_id/pockets":                      auth.PermissionWalletManage,
+	"PUT /v1/wallet/:user_id/pockets/:pocket_id":            auth.PermissionWalletManage,
+	"DELETE /v1/wallet/:user_id/pockets/:pocket_id":         auth.PermissionWalletManage,
+	"POST /v1/wallet/:user_id/pockets/:pocket_id/transfers": auth.PermissionWalletManage,
+	"GET /v1/wallet/:user_id/budgets":                       auth.PermissionWalletRead,
+	"POST /v1/wallet/:user_id/budgets":                      auth.PermissionWalletManage,
+	"GET /v1/wallet/:user_id/budgets/status":                auth.PermissionWalletRead,
+	"PUT /v1/wallet/:user_id/budgets/:budget_id":            auth.PermissionWalletManage,
+	"DELETE /v1/wallet/:user_id/budgets/:budget_id":         auth.PermissionWalletManage,
+	"GET /v1/wallet/:user_id/notification-preferences":      auth.PermissionWalletRead,
+	"PUT /v1/wallet/:user_id/notification-preferences":      auth.PermissionWalletManage,
+	"POST /v1/wallet/:user_id/merchants":                    auth.PermissionWalletManage,
+	"POST /v1/wallet/:user_id/checkout/:id/confirm":         auth.PermissionWalletWithdraw,
+	"POST /v1/wallet/:user_id/promos/redeem":                auth.PermissionWalletManage,
+	"POST /v1/wallet/:user_id/escrows":                      auth.PermissionWalletWithdraw,
+	"GET /v1/wallet/:user_id/escrows/:id":                   auth.PermissionWalletRead,
+	"POST /v1/wallet/:user_id/escrows/:id/release":          auth.PermissionWalletManage,
+	"POST /v1/wallet/:user_id/escrows/:id/refund":           auth.PermissionWalletManage,
+
+	"POST /v1/merchants":                  "",
+	"GET /v1/merchants/:id":               "",
+	"PUT /v1/merchants/:id":               "",
+	"POST /v1/merchants/:id/rotate-key":   "",
+	"POST /v1/payment-intents":            "",
+	"GET /v1/payment-intents/:id":         "",
+	"POST /v1/payment-intents/:id/cancel": "",
+	"GET /v1/checkout/:id":                "",
+	"POST /v1/checkout/:id/confirm":       "",
+	"POST /v1/promos/redeem":              "",
+	"POST /v1/escrows":                    "",
+	"GET /v1/escrows/:id":                 "",
+	"POST /v1/escrows/:id/release":        "",
+	"POST /v1/escrows/:id/refund":         "",
+
+	"POST /v1/admin/cashback/campaigns":                   auth.PermissionCashbackManage,
+	"GET /v1/admin/cashback/campaigns":                    auth.PermissionCashbackRead,
+	"POST /v1/admin/cashback/campaigns/:id/deactivate":    auth.PermissionCashbackManage,
+	"GET /v1/admin/cashback/campaigns/:id/report":         auth.PermissionCashbackRead,
+	"GET /v1/admin/users":                                 auth.PermissionUserRead,
+	"POST /v1/admin/users/:user_id/unlock":                auth.PermissionUserUnlock,
+	"GET /v1/admin/users/:user_id/transactions/export":    auth.PermissionWalletExport,
+	"GET /v1/admin/wallets/:wallet_id":                    auth.PermissionWalletRead,
+	"POST /v1/admin/wallets/:wallet_id/adjustments":       auth.PermissionWalletAdjust,
+	"POST /v1/admin/wallets/:wallet_id/freeze":            auth.PermissionWalletFreeze,
+	"POST /v1/admin/wallets/:wallet_id/unfreeze":          auth.PermissionWalletFreeze,
+	"POST /v1/admin/transactions/:transaction_id/reverse": auth.PermissionWalletReverse,
+	"GET /v1/admin/approvals":                             auth.PermissionApprovalReview,
+	"GET /v1/admin/approvals/:id":                         auth.PermissionApprovalReview,
+	"POST /v1/admin/approvals/:id/approve":                auth.PermissionApprovalReview,
+	"POST /v1/admin/approvals/:id/reject":                 auth.PermissionApprovalReview,
+	"GET /v1/admin/kyc/submissions":                       auth.PermissionKYCReview,
+	"GET /v1/admin/kyc/submissions/:id":                   auth.PermissionKYCReview,
+	"GET /v1/admin/kyc/submissions/:id/document":          auth.PermissionKYCReview,
+	"POST /v1/admin/kyc/submissions/:id/approve":          auth.PermissionKYCReview,
+	"POST /v1/admin/kyc/submissions/:id/reject":           auth.PermissionKYCReview,
+	"GET /v1/admin/audit-events":                          auth.PermissionAuditRead,
+	"POST /v1/admin/api-keys":                             auth.PermissionAPIKeyManage,
+	"GET /v1/admin/api-keys":                              auth.PermissionAPIKeyManage,
+	"POST /v1/admin/api-keys/:id/rotate":                  auth.PermissionAPIKeyManage,
+	"POST /v1/admin/api-keys/:id/revoke":                  auth.PermissionAPIKeyManage,
+}
+
+func TestRoutePermissions(t *testing.T) {
+	cfg := &configs.Config{}
+	keyring := auth.NewHMACKeyring("test-signing-key", "digital-wallet", "digital-wallet")
+
+	authService := mocks.NewAuthService(t)
+	authService.On("TouchSession", mock.Anything, "staff-1", "session-1").Return(&models.Session{ID: "session-1"}, nil).Maybe()
+	accountService := mocks.NewAccountService(t)
+	accountService.On("CheckActive", mock.Anything, "staff-1").Return(nil).Maybe()
+	auditService := mocks.NewAuditService(t)
+	auditService.On("RecordSecurityEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	auditService.On("List", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&dto.PaginatedAuditEventResponse{}, nil).Maybe()
+
+	e := echo.New()
+	e.HTTPErrorHandler = response.CustomHTTPErrorHandler
+	// Handlers reached with the right permission have no services behind them
+	e.Use(echomiddleware.Recover())
+	SetupRouter(e, &di.Container{Config: cfg, Keyring: keyring, AuthService: authService, AccountService: accountService, AuditService: auditService})
+
+	var allPermissions []string
+	for _, permission := range routePermissions {
+		if permission != "" && !slices.Contains(allPermissions, permission) {
+			allPermissions = append(allPermissions, permission)
+		}
+	}
+
+	request := func(t *testing.T, method, path string, permissions []string) *httptest.ResponseRecorder {
+		token, err := auth.NewToken(auth.UserAuth{ID: "staff-1", Role: auth.RoleAdmin, SessionID: "session-1", Permissions: permissions},
+			auth.TokenTypeAccess, "token-1", time.Now().Add(time.Hour), keyring)
+		require.NoError(t, err)
+
+		target := regexp.MustCompile(`:[a-z_]+`).ReplaceAllString(path, "id-1")
+		req := httptest.NewRequest(method, target, strings.NewReader("{}"))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, r := range e.Routes() {
+		if !strings.HasPrefix(r.Path, "/v1/") || r.Method == echo.RouteNotFound {
+			continue
+		}
+
+		route := r.Method + " " + r.Path
+		permission, ok := routePermissions[route]
+		if !assert.True(t, ok, "%s has no entry in routePermissions", route) || permission == "" {
+			continue
+		}
+
+		t.Run(route, func(t *testing.T) {
+			others := slices.DeleteFunc(slices.Clone(allPermissions), func(p string) bool { return p == permission })
+
+			rec := request(t, r.Method, r.Path, others)
+			assert.Equal(t, http.StatusForbidden, rec.Code)
+			assert.Contains(t, rec.Body.String(), response.ErrInsufficientPermissions.Code)
+
+			rec = request(t, r.Method, r.Path, []string{permission})
+			assert.NotContains(t, rec.Body.String(), response.ErrInsufficientPermissions.Code)
+		})
+	}
+}
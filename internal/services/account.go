@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	response "digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultActivationTokenExpiration    = 24 * time.Hour
+	defaultPasswordResetTokenExpiration = time.Hour
+
+	accountTokenBytes = 32
+)
+
+type AccountService struct {
+	repo   interfaces.RegistryRepository
+	cfg    *configs.Config
+	sender interfaces.AccountSender
+}
+
+// Ensure AccountService implements interfaces.AccountService
+var _ interfaces.AccountService = (*AccountService)(nil)
+
+func NewAccountService(repo interfaces.RegistryRepository, config *configs.Config, sender interfaces.AccountSender) interfaces.AccountService {
+	return &AccountService{
+		repo:   repo,
+		cfg:    config,
+		sender: sender,
+	}
+}
+
+// ResendActivation sends a new activation token, replacing the previous one. It succeeds
+// whether or not the account exists, so it cannot be used to find registered phone numbers.
+func (s *AccountService) ResendActivation(ctx context.Context, req dto.AccountLookupRequest) error {
+	user, err := s.repo.GetUserRepository().GetByPhoneNumber(ctx, req.PhoneNumber)
+	if err != nil {
+		return response.Wrap(err, "error retrieving user")
+	}
+
+	if user == nil || user.IsActivated() {
+		return nil
+	}
+
+	return sendAccountToken(ctx, s.repo, s.cfg, s.sender, user, models.AccountTokenActivation)
+}
+
+// Activate activates the account the token was issued for
+func (s *AccountService) Activate(ctx context.Context, req dto.ActivateAccountRequest) error {
+	user, err := consumeAccountToken(ctx, s.repo, models.AccountTokenActivation, req.Token)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if _, err := txRepo.GetUserRepository().Activate(ctx, user.ID, time.Now()); err != nil {
+			return nil, response.Wrap(err, "error activating account")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionUserActivate, models.AuditResourceUser, user.ID,
+			map[string]interface{}{"is_active": user.IsActive},
+			map[string]interface{}{"is_active": true},
+		)
+	})
+	return err
+}
+
+// ForgotPassword sends a password reset token. Like ResendActivation, it does not reveal
+// whether the account exists.
+func (s *AccountService) ForgotPassword(ctx context.Context, req dto.AccountLookupRequest) error {
+	user, err := s.repo.GetUserRepository().GetByPhoneNumber(ctx, req.PhoneNumber)
+	if err != nil {
+		return response.Wrap(err, "error retrieving user")
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	return sendAccountToken(ctx, s.repo, s.cfg, s.sender, user, models.AccountTokenPasswordReset)
+}
+
+// ResetPassword sets a new password and revokes every session of the user, since whoever
+// knew the old password may be logged in
+func (s *AccountService) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
+	user, err := consumeAccountToken(ctx, s.repo, models.AccountTokenPasswordReset, req.Token)
+	if err != nil {
+		return err
+	}
+
+	hashed, err := models.HashAndSalt([]byte(req.NewPassword))
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetUserRepository().UpdatePassword(ctx, user.ID, hashed); err != nil {
+			return nil, response.Wrap(err, "error updating password")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionPasswordReset, models.AuditResourceUser, user.ID, nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.repo.GetSessionRepository().DeleteByUserID(ctx, user.ID); err != nil {
+		return response.Wrap(err, "error revoking sessions")
+	}
+
+	return nil
+}
+
+// CheckActive returns ErrAccountNotActivated or ErrAccountDeactivated unless the user is active
+func (s *AccountService) CheckActive(ctx context.Context, userID string) error {
+	user, err := s.repo.GetUserRepository().GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NewUnauthorizedError("")
+		}
+		return response.Wrap(err, "error retrieving user")
+	}
+
+	return checkAccountActive(user)
+}
+
+// checkAccountActive tells accounts that were never activated apart from deactivated ones
+func checkAccountActive(user *models.User) error {
+	if user.IsActive {
+		return nil
+	}
+
+	if !user.IsActivated() {
+		return response.ErrAccountNotActivated
+	}
+	return response.ErrAccountDeactivated
+}
+
+// sendAccountToken issues a single-use token for the purpose and sends it to the user. Only
+// the token's hash is stored.
+func sendAccountToken(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, sender interfaces.AccountSender, user *models.User, purpose string) error {
+	token, err := utils.RandomToken(accountTokenBytes)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.GetAccountTokenRepository().Save(ctx, purpose, user.ID, utils.HashToken(token), accountTokenExpiration(cfg, purpose)); err != nil {
+		return response.Wrap(err, "error saving token")
+	}
+
+	if purpose == models.AccountTokenActivation {
+		err = sender.SendActivation(ctx, user, token)
+	} else {
+		err = sender.SendPasswordReset(ctx, user, token)
+	}
+	if err != nil {
+		return response.Wrap(err, "error sending token")
+	}
+
+	return nil
+}
+
+// consumeAccountToken uses the token up and returns the user it was issued for
+func consumeAccountToken(ctx context.Context, repo interfaces.RegistryRepository, purpose, token string) (*models.User, error) {
+	userID, err := repo.GetAccountTokenRepository().Consume(ctx, purpose, utils.HashToken(token))
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving token")
+	}
+
+	if userID == "" {
+		return nil, response.ErrInvalidToken
+	}
+
+	user, err := repo.GetUserRepository().GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.ErrInvalidToken
+		}
+		return nil, response.Wrap(err, "error retrieving user")
+	}
+
+	return user, nil
+}
+
+func accountTokenExpiration(cfg *configs.Config, purpose string) time.Duration {
+	if purpose == models.AccountTokenActivation {
+		if cfg != nil {
+			if d, err := time.ParseDuration(cfg.JWT.AccountActivationTokenExpiration); err == nil && d > 0 {
+				return d
+			}
+		}
+		return defaultActivationTokenExpiration
+	}
+
+	if cfg != nil && cfg.JWT.ForgotPasswordTokenExpiration > 0 {
+		return time.Duration(cfg.JWT.ForgotPasswordTokenExpiration) * time.Second
+	}
+	return defaultPasswordResetTokenExpiration
+}
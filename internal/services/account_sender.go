@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/notifier"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+// LogAccountSender writes activation and password reset tokens to the log instead of
+// delivering them. It is meant for development only.
+type LogAccountSender struct {
+	logger *slog.Logger
+}
+
+// Ensure LogAccountSender implements interfaces.AccountSender
+var _ interfaces.AccountSender = (*LogAccountSender)(nil)
+
+func NewLogAccountSender(logger *slog.Logger) interfaces.AccountSender {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LogAccountSender{logger: logger}
+}
+
+func (s *LogAccountSender) SendActivation(ctx context.Context, user *models.User, token string) error {
+	s.logger.InfoContext(ctx, "Account activation token", "user_id", user.ID, "token", token)
+	return nil
+}
+
+func (s *LogAccountSender) SendPasswordReset(ctx context.Context, user *models.User, token string) error {
+	s.logger.InfoContext(ctx, "Password reset token", "user_id", user.ID, "token", token)
+	return nil
+}
+
+// NotifierAccountSender emails tokens as links into the app, or texts them to users who
+// have no email address
+type NotifierAccountSender struct {
+	notifiers notifier.Registry
+	baseURL   string
+}
+
+// Ensure NotifierAccountSender implements interfaces.AccountSender
+var _ interfaces.AccountSender = (*NotifierAccountSender)(nil)
+
+func NewNotifierAccountSender(notifiers notifier.Registry, baseURL string) interfaces.AccountSender {
+	return &NotifierAccountSender{notifiers: notifiers, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (s *NotifierAccountSender) SendActivation(ctx context.Context, user *models.User, token string) error {
+	return s.send(ctx, user, "Activate your account",
+		fmt.Sprintf("Welcome, %s. Activate your account at %s", user.FullName, s.link("/activate", token)))
+}
+
+func (s *NotifierAccountSender) SendPasswordReset(ctx context.Context, user *models.User, token string) error {
+	return s.send(ctx, user, "Reset your password",
+		fmt.Sprintf("Reset your password at %s. If you did not ask for this, ignore this message.", s.link("/reset-password", token)))
+}
+
+func (s *NotifierAccountSender) send(ctx context.Context, user *models.User, subject, body string) error {
+	if user.Email != nil && *user.Email != "" {
+		return s.notifiers.Send(ctx, notifier.ChannelEmail, notifier.Message{To: *user.Email, Subject: subject, Body: body})
+	}
+	return s.notifiers.Send(ctx, notifier.ChannelSMS, notifier.Message{To: user.PhoneNumber, Subject: subject, Body: body})
+}
+
+func (s *NotifierAccountSender) link(path, token string) string {
+	return s.baseURL + path + "?token=" + url.QueryEscape(token)
+}
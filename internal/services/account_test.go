@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountActivate(t *testing.T) {
+	t.Run("token activates the account", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockTokenRepo := mocks.NewAccountTokenRepository(t)
+
+		user := newTestUser(t, "secret123")
+		user.IsActive = false
+
+		mockTokenRepo.On("Consume", mock.Anything, models.AccountTokenActivation, utils.HashToken("token")).Return("user-1", nil)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(user, nil)
+		mockUserRepo.On("Activate", mock.Anything, "user-1", mock.AnythingOfType("time.Time")).Return(true, nil)
+
+		service := NewAccountService(&testRegistry{ur: mockUserRepo, akr: mockTokenRepo}, nil, nil)
+		err := service.Activate(context.Background(), dto.ActivateAccountRequest{Token: "token"})
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown or used token is rejected", func(t *testing.T) {
+		mockTokenRepo := mocks.NewAccountTokenRepository(t)
+		mockTokenRepo.On("Consume", mock.Anything, models.AccountTokenActivation, utils.HashToken("token")).Return("", nil)
+
+		service := NewAccountService(&testRegistry{akr: mockTokenRepo}, nil, nil)
+		err := service.Activate(context.Background(), dto.ActivateAccountRequest{Token: "token"})
+		assert.Equal(t, response.ErrInvalidToken, err)
+	})
+}
+
+func TestAccountResendActivation(t *testing.T) {
+	t.Run("pending account gets a new token", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockTokenRepo := mocks.NewAccountTokenRepository(t)
+		mockSender := mocks.NewAccountSender(t)
+
+		user := newTestUser(t, "secret123")
+		user.IsActive = false
+
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, user.PhoneNumber).Return(user, nil)
+		mockTokenRepo.On("Save", mock.Anything, models.AccountTokenActivation, "user-1", mock.AnythingOfType("string"), 24*time.Hour).Return(nil)
+		mockSender.On("SendActivation", mock.Anything, user, mock.AnythingOfType("string")).Return(nil)
+
+		service := NewAccountService(&testRegistry{ur: mockUserRepo, akr: mockTokenRepo}, nil, mockSender)
+		err := service.ResendActivation(context.Background(), dto.AccountLookupRequest{PhoneNumber: user.PhoneNumber})
+		require.NoError(t, err)
+	})
+
+	t.Run("activated account is skipped", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+
+		user := newTestUser(t, "secret123")
+		activatedAt := time.Now()
+		user.ActivatedAt = &activatedAt
+
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, user.PhoneNumber).Return(user, nil)
+
+		service := NewAccountService(&testRegistry{ur: mockUserRepo}, nil, mocks.NewAccountSender(t))
+		err := service.ResendActivation(context.Background(), dto.AccountLookupRequest{PhoneNumber: user.PhoneNumber})
+		require.NoError(t, err)
+	})
+}
+
+func TestAccountForgotPassword(t *testing.T) {
+	t.Run("unknown phone number succeeds without sending", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, "089999999999").Return(nil, nil)
+
+		service := NewAccountService(&testRegistry{ur: mockUserRepo}, nil, mocks.NewAccountSender(t))
+		err := service.ForgotPassword(context.Background(), dto.AccountLookupRequest{PhoneNumber: "089999999999"})
+		require.NoError(t, err)
+	})
+
+	t.Run("sent token is the one stored", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockTokenRepo := mocks.NewAccountTokenRepository(t)
+		mockSender := mocks.NewAccountSender(t)
+
+		user := newTestUser(t, "secret123")
+		var stored, sent string
+
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, user.PhoneNumber).Return(user, nil)
+		mockTokenRepo.On("Save", mock.Anything, models.AccountTokenPasswordReset, "user-1", mock.AnythingOfType("string"), time.Hour).
+			Run(func(args mock.Arguments) { stored = args.String(3) }).Return(nil)
+		mockSender.On("SendPasswordReset", mock.Anything, user, mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { sent = args.String(2) }).Return(nil)
+
+		service := NewAccountService(&testRegistry{ur: mockUserRepo, akr: mockTokenRepo}, nil, mockSender)
+		err := service.ForgotPassword(context.Background(), dto.AccountLookupRequest{PhoneNumber: user.PhoneNumber})
+		require.NoError(t, err)
+		assert.Equal(t, utils.HashToken(sent), stored)
+	})
+}
+
+func TestAccountResetPassword(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockTokenRepo := mocks.NewAccountTokenRepository(t)
+	mockSessionRepo := mocks.NewSessionRepository(t)
+
+	var hashed string
+	mockTokenRepo.On("Consume", mock.Anything, models.AccountTokenPasswordReset, utils.HashToken("token")).Return("user-1", nil)
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newTestUser(t, "secret123"), nil)
+	mockUserRepo.On("UpdatePassword", mock.Anything, "user-1", mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { hashed = args.String(2) }).Return(nil)
+	mockSessionRepo.On("DeleteByUserID", mock.Anything, "user-1").Return(2, nil)
+
+	service := NewAccountService(&testRegistry{ur: mockUserRepo, akr: mockTokenRepo, sr: mockSessionRepo}, nil, nil)
+	err := service.ResetPassword(context.Background(), dto.ResetPasswordRequest{Token: "token", NewPassword: "newsecret"})
+	require.NoError(t, err)
+	assert.True(t, utils.ComparePasswords(hashed, []byte("newsecret")))
+}
+
+func TestAccountCheckActive(t *testing.T) {
+	activatedAt := time.Now()
+
+	tests := []struct {
+		name        string
+		isActive    bool
+		activatedAt *time.Time
+		expected    error
+	}{
+		{name: "active", isActive: true, activatedAt: &activatedAt},
+		{name: "never activated", expected: response.ErrAccountNotActivated},
+		{name: "deactivated", activatedAt: &activatedAt, expected: response.ErrAccountDeactivated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewUserRepository(t)
+
+			user := newTestUser(t, "secret123")
+			user.IsActive = tt.isActive
+			user.ActivatedAt = tt.activatedAt
+			mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(user, nil)
+
+			service := NewAccountService(&testRegistry{ur: mockUserRepo}, nil, nil)
+			err := service.CheckActive(context.Background(), "user-1")
+			if tt.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
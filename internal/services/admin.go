@@ -0,0 +1,547 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	response "digital-wallet/pkg/response"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	adminSearchLimit  = 20
+	adminActionsLimit = 50
+
+	// maxExportPeriod bounds a single export so it stays small enough to build in memory
+	maxExportPeriod = 366 * 24 * time.Hour
+)
+
+type AdminService struct {
+	repo   interfaces.RegistryRepository
+	cfg    *configs.Config
+	ledger ledger
+}
+
+// Ensure AdminService implements interfaces.AdminService
+var _ interfaces.AdminService = (*AdminService)(nil)
+
+func NewAdminService(repo interfaces.RegistryRepository, config *configs.Config, listeners ...interfaces.TransactionListener) interfaces.AdminService {
+	return &AdminService{
+		repo:   repo,
+		cfg:    config,
+		ledger: newLedger(config, listeners),
+	}
+}
+
+// SearchUsers finds users by ID, email or phone number prefix, and the owner of the wallet
+// when the query is a wallet ID
+func (s *AdminService) SearchUsers(ctx context.Context, query string) ([]dto.AdminUserResult, error) {
+	if query == "" {
+		return nil, response.NewValidationError("Search query is required")
+	}
+
+	users, err := s.repo.GetUserRepository().Search(ctx, query, adminSearchLimit)
+	if err != nil {
+		return nil, response.Wrap(err, "error searching users")
+	}
+
+	wallet, err := s.repo.GetWalletRepository().GetByID(ctx, query)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, response.Wrap(err, "error retrieving wallet")
+	}
+
+	if wallet != nil {
+		owner, err := s.repo.GetUserRepository().GetByID(ctx, wallet.UserID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.Wrap(err, "error retrieving user")
+		}
+		if owner != nil {
+			users = append(users, *owner)
+		}
+	}
+
+	results := make([]dto.AdminUserResult, 0, len(users))
+	for i := range users {
+		userWallet, err := s.repo.GetWalletRepository().GetByUserID(ctx, users[i].ID)
+		if err != nil {
+			return nil, response.Wrap(err, "error retrieving wallet")
+		}
+		results = append(results, dto.AdminUserResult{User: &users[i], Wallet: userWallet})
+	}
+
+	return results, nil
+}
+
+// GetWallet returns the wallet with its owner, a page of its transactions and its latest
+// back-office actions
+func (s *AdminService) GetWallet(ctx context.Context, walletID string, limit, offset int) (*dto.AdminWalletResponse, error) {
+	wallet, err := getWalletByID(ctx, s.repo, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserRepository().GetByID(ctx, wallet.UserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, response.Wrap(err, "error retrieving user")
+	}
+
+	transactionRepo := s.repo.GetWalletTransactionRepository()
+
+	total, err := transactionRepo.CountByWalletID(ctx, wallet.ID)
+	if err != nil {
+		return nil, response.Wrap(err, "error counting transactions")
+	}
+
+	transactions, err := transactionRepo.GetByWalletID(ctx, wallet.ID, limit, offset)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving transactions")
+	}
+
+	actions, err := s.repo.GetAdminActionRepository().GetByWalletID(ctx, wallet.ID, adminActionsLimit)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving admin actions")
+	}
+
+	return &dto.AdminWalletResponse{
+		Wallet:       wallet,
+		User:         user,
+		Transactions: transactions,
+		Meta:         dto.PaginationMeta{Total: total, Limit: limit, Offset: offset},
+		Actions:      actions,
+	}, nil
+}
+
+// adjustmentPayload is the stored form of an AdjustmentRequest waiting for approval
+type adjustmentPayload struct {
+	WalletID  string  `json:"wallet_id"`
+	Direction string  `json:"direction"`
+	Amount    float64 `json:"amount"`
+	Reason    string  `json:"reason"`
+}
+
+// reversalPayload is the stored form of a ReversalRequest waiting for approval
+type reversalPayload struct {
+	TransactionID string `json:"transaction_id"`
+	Reason        string `json:"reason"`
+}
+
+// Adjust submits a manual credit or debit of the wallet. It only moves money once a second
+// person approves it.
+func (s *AdminService) Adjust(ctx context.Context, req dto.AdjustmentRequest) (*dto.AdminActionResponse, error) {
+	wallet, err := getWalletByID(ctx, s.repo, req.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !wallet.IsActive {
+		return nil, response.NewValidationError("Wallet is frozen, unfreeze it before adjusting the balance")
+	}
+
+	approval, err := submitApproval(ctx, s.repo, s.cfg, auth.PermissionWalletAdjust, req.ActorID, adjustmentPayload{
+		WalletID:  wallet.ID,
+		Direction: req.Direction,
+		Amount:    req.Amount,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AdminActionResponse{Approval: approval}, nil
+}
+
+// Reverse moves the amount of a settled transaction back in the opposite direction. Reversals
+// of at least the configured threshold wait for a second person's approval.
+func (s *AdminService) Reverse(ctx context.Context, req dto.ReversalRequest) (*dto.AdminActionResponse, error) {
+	transaction, err := s.getReversible(ctx, s.repo, req.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := reversalPayload{TransactionID: transaction.ID, Reason: req.Reason}
+
+	if transaction.Amount >= s.cfg.Approval.ReversalThreshold {
+		approval, err := submitApproval(ctx, s.repo, s.cfg, auth.PermissionWalletReverse, req.ActorID, payload)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.AdminActionResponse{Approval: approval}, nil
+	}
+
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		return s.reverse(ctx, txRepo, req.ActorID, nil, payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*dto.AdminActionResponse), nil
+}
+
+// ApprovalOperations lists the back-office operations that can wait for approval
+func (s *AdminService) ApprovalOperations() []string {
+	return []string{auth.PermissionWalletAdjust, auth.PermissionWalletReverse}
+}
+
+// ExecuteApproval runs an approved adjustment or reversal on behalf of its maker
+func (s *AdminService) ExecuteApproval(ctx context.Context, repo interfaces.RegistryRepository, approval *models.ApprovalRequest) (interface{}, error) {
+	switch approval.Operation {
+	case auth.PermissionWalletAdjust:
+		var payload adjustmentPayload
+		if err := json.Unmarshal(approval.Payload, &payload); err != nil {
+			return nil, response.Wrap(err, "error decoding approval payload")
+		}
+		return s.adjust(ctx, repo, approval.MakerID, &approval.ID, payload)
+	case auth.PermissionWalletReverse:
+		var payload reversalPayload
+		if err := json.Unmarshal(approval.Payload, &payload); err != nil {
+			return nil, response.Wrap(err, "error decoding approval payload")
+		}
+		return s.reverse(ctx, repo, approval.MakerID, &approval.ID, payload)
+	default:
+		return nil, response.NewValidationError("Operation " + approval.Operation + " cannot be approved")
+	}
+}
+
+// adjust credits or debits the wallet. The ledger transaction and the record of who made it
+// commit together, so it must run inside DoInTransaction.
+func (s *AdminService) adjust(ctx context.Context, repo interfaces.RegistryRepository, actorID string, approvalID *string, req adjustmentPayload) (*dto.AdminActionResponse, error) {
+	wallet, err := getWalletByID(ctx, repo, req.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !wallet.IsActive {
+		return nil, response.NewValidationError("Wallet is frozen, unfreeze it before adjusting the balance")
+	}
+
+	entry := ledgerEntry{
+		WalletID:    wallet.ID,
+		Amount:      req.Amount,
+		Type:        models.TransactionTypeAdjustment,
+		Description: "Manual adjustment: " + req.Reason,
+		Metadata: map[string]interface{}{
+			"actor_id": actorID,
+			"reason":   req.Reason,
+		},
+	}
+
+	action := models.AdminActionCredit
+	move := s.ledger.credit
+	if req.Direction == models.TransactionDirectionDebit {
+		if wallet.Balance < req.Amount {
+			return nil, response.NewValidationError("Insufficient balance")
+		}
+		action = models.AdminActionDebit
+		move = s.ledger.debit
+	}
+	entry.CapBalance = action == models.AdminActionCredit
+
+	transaction, wallet, err := move(ctx, repo, entry)
+	if err != nil {
+		if errors.Is(err, response.ErrBalanceLimitExceeded) {
+			return nil, err
+		}
+		return nil, response.Wrap(err, "adjustment failed")
+	}
+
+	record, err := recordAdminAction(ctx, repo, actorID, action, wallet, &transaction.ID, approvalID, req.Amount, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AdminActionResponse{Wallet: wallet, Action: record, Transaction: transaction}, nil
+}
+
+// reverse records an adjustment opposite to the original transaction. Like adjust it must run
+// inside DoInTransaction.
+func (s *AdminService) reverse(ctx context.Context, repo interfaces.RegistryRepository, actorID string, approvalID *string, req reversalPayload) (*dto.AdminActionResponse, error) {
+	original, err := s.getReversible(ctx, repo, req.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := getWalletByID(ctx, repo, original.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !wallet.IsActive {
+		return nil, response.NewValidationError("Wallet is frozen, unfreeze it before reversing a transaction")
+	}
+
+	entry := ledgerEntry{
+		WalletID:    wallet.ID,
+		Amount:      original.Amount,
+		Type:        models.TransactionTypeAdjustment,
+		Description: "Reversal: " + req.Reason,
+		Metadata: map[string]interface{}{
+			"actor_id": actorID,
+			"reason":   req.Reason,
+		},
+		ReversalOf: &original.ID,
+	}
+
+	move := s.ledger.debit
+	if original.Direction == models.TransactionDirectionDebit {
+		move = s.ledger.credit
+	} else if wallet.Balance < original.Amount {
+		return nil, response.NewValidationError("Insufficient balance")
+	}
+
+	transaction, wallet, err := move(ctx, repo, entry)
+	if err != nil {
+		return nil, response.Wrap(err, "reversal failed")
+	}
+
+	record, err := recordAdminAction(ctx, repo, actorID, models.AdminActionReverse, wallet, &transaction.ID, approvalID, original.Amount, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AdminActionResponse{Wallet: wallet, Action: record, Transaction: transaction}, nil
+}
+
+// getReversible returns a completed transaction that has not been reversed and is not itself
+// a reversal
+func (s *AdminService) getReversible(ctx context.Context, repo interfaces.RegistryRepository, transactionID string) (*models.WalletTransaction, error) {
+	transactionRepo := repo.GetWalletTransactionRepository()
+
+	transaction, err := transactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("Transaction")
+		}
+		return nil, response.Wrap(err, "error retrieving transaction")
+	}
+
+	if transaction.Status != models.TransactionStatusCompleted {
+		return nil, response.NewValidationError("Only completed transactions can be reversed")
+	}
+
+	if transaction.ReversalOf != nil {
+		return nil, response.NewValidationError("A reversal cannot be reversed")
+	}
+
+	reversed, err := transactionRepo.IsReversed(ctx, transaction.ID)
+	if err != nil {
+		return nil, response.Wrap(err, "error checking reversals")
+	}
+
+	if reversed {
+		return nil, response.NewValidationError("Transaction has already been reversed")
+	}
+
+	return transaction, nil
+}
+
+// Freeze stops every balance movement on the wallet until it is unfrozen
+func (s *AdminService) Freeze(ctx context.Context, req dto.FreezeWalletRequest) (*dto.AdminActionResponse, error) {
+	return s.setActive(ctx, req, false)
+}
+
+// Unfreeze lets a frozen wallet move money again
+func (s *AdminService) Unfreeze(ctx context.Context, req dto.FreezeWalletRequest) (*dto.AdminActionResponse, error) {
+	return s.setActive(ctx, req, true)
+}
+
+func (s *AdminService) setActive(ctx context.Context, req dto.FreezeWalletRequest, active bool) (*dto.AdminActionResponse, error) {
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		wallet, err := getWalletByID(ctx, txRepo, req.WalletID)
+		if err != nil {
+			return nil, err
+		}
+
+		changed, err := txRepo.GetWalletRepository().SetActive(ctx, wallet.ID, active)
+		if err != nil {
+			return nil, response.Wrap(err, "error updating wallet")
+		}
+
+		if !changed {
+			if active {
+				return nil, response.NewValidationError("Wallet is not frozen")
+			}
+			return nil, response.NewValidationError("Wallet is already frozen")
+		}
+		wallet.IsActive = active
+
+		action := models.AdminActionFreeze
+		if active {
+			action = models.AdminActionUnfreeze
+		}
+
+		record, err := recordAdminAction(ctx, txRepo, req.ActorID, action, wallet, nil, nil, 0, req.Reason)
+		if err != nil {
+			return nil, err
+		}
+
+		auditAction := models.AuditActionWalletFreeze
+		if active {
+			auditAction = models.AuditActionWalletUnfreeze
+		}
+
+		if err := recordAudit(ctx, txRepo, auditAction, models.AuditResourceWallet, wallet.ID,
+			map[string]interface{}{"is_active": !active},
+			map[string]interface{}{"is_active": active, "reason": req.Reason},
+		); err != nil {
+			return nil, err
+		}
+
+		return &dto.AdminActionResponse{Wallet: wallet, Action: record}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*dto.AdminActionResponse), nil
+}
+
+// UnlockLogin lets a user whose phone number was locked by failed logins try again at once
+func (s *AdminService) UnlockLogin(ctx context.Context, req dto.UnlockLoginRequest) error {
+	user, err := s.repo.GetUserRepository().GetByID(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NewNotFoundError("User")
+		}
+		return response.Wrap(err, "error retrieving user")
+	}
+
+	if err := unlockLogin(ctx, s.repo, user.PhoneNumber); err != nil {
+		return err
+	}
+
+	return recordSecurityEvent(ctx, s.repo, auth.SecurityEventLoginUnlocked, models.AuditResourceUser, user.ID, map[string]interface{}{
+		"actor_id": req.ActorID, "reason": req.Reason,
+	})
+}
+
+// ExportTransactions builds a CSV of the transactions the user's wallet made in the period and
+// records who exported them
+func (s *AdminService) ExportTransactions(ctx context.Context, req dto.ExportTransactionsRequest) (*dto.TransactionExport, error) {
+	if !req.To.After(req.From) {
+		return nil, response.NewValidationError("The end of the period must be after its start")
+	}
+
+	if req.To.Sub(req.From) > maxExportPeriod {
+		return nil, response.NewValidationError("The period cannot be longer than 366 days")
+	}
+
+	wallet, err := s.repo.GetWalletRepository().GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving wallet")
+	}
+
+	if wallet == nil {
+		return nil, response.NewNotFoundError("Wallet")
+	}
+
+	transactions, err := s.repo.GetWalletTransactionRepository().GetByWalletIDBetween(ctx, wallet.ID, req.From, req.To)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving transactions")
+	}
+
+	content, err := transactionsCSV(transactions)
+	if err != nil {
+		return nil, response.Wrap(err, "error writing export")
+	}
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if _, err := recordAdminAction(ctx, txRepo, req.ActorID, models.AdminActionExport, wallet, nil, nil, 0, req.Reason); err != nil {
+			return nil, err
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionWalletExport, models.AuditResourceWallet, wallet.ID, nil, map[string]interface{}{
+			"from": req.From, "to": req.To, "transactions": len(transactions), "reason": req.Reason,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TransactionExport{
+		FileName: fmt.Sprintf("transactions-%s-%s-%s.csv", req.UserID, req.From.Format("20060102"), req.To.Format("20060102")),
+		Content:  content,
+	}, nil
+}
+
+// getWalletByID returns the wallet or a not found error
+func getWalletByID(ctx context.Context, repo interfaces.RegistryRepository, walletID string) (*models.Wallet, error) {
+	wallet, err := repo.GetWalletRepository().GetByID(ctx, walletID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("Wallet")
+		}
+		return nil, response.Wrap(err, "error retrieving wallet")
+	}
+	return wallet, nil
+}
+
+func recordAdminAction(ctx context.Context, repo interfaces.RegistryRepository, actorID, action string, wallet *models.Wallet, transactionID, approvalID *string, amount float64, reason string) (*models.AdminAction, error) {
+	record := &models.AdminAction{
+		ID:            uuid.New().String(),
+		ActorID:       actorID,
+		Action:        action,
+		UserID:        wallet.UserID,
+		WalletID:      wallet.ID,
+		TransactionID: transactionID,
+		ApprovalID:    approvalID,
+		Amount:        amount,
+		Reason:        reason,
+	}
+
+	if err := repo.GetAdminActionRepository().Create(ctx, record); err != nil {
+		return nil, response.Wrap(err, "error recording admin action")
+	}
+
+	return record, nil
+}
+
+func transactionsCSV(transactions []models.WalletTransaction) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{"id", "created_at", "type", "direction", "status", "category", "amount", "description"}); err != nil {
+		return nil, err
+	}
+
+	for _, tx := range transactions {
+		row := []string{
+			tx.ID,
+			tx.CreatedAt.Format(time.RFC3339),
+			tx.Type,
+			tx.Direction,
+			tx.Status,
+			tx.Category,
+			strconv.FormatFloat(tx.Amount, 'f', 2, 64),
+			csvSafe(tx.Description),
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// csvSafe stops spreadsheet applications from evaluating user-supplied text as a formula
+func csvSafe(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
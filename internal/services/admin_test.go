@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func TestAdminService_SearchUsers(t *testing.T) {
+	t.Run("phone number prefix", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockWalletRepo := mocks.NewWalletRepository(t)
+
+		mockUserRepo.On("Search", mock.Anything, "0812", adminSearchLimit).Return([]models.User{{ID: "user-1"}}, nil)
+		mockWalletRepo.On("GetByID", mock.Anything, "0812").Return(nil, gorm.ErrRecordNotFound)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(&models.Wallet{ID: "wallet-1", UserID: "user-1"}, nil)
+
+		svc := NewAdminService(&testRegistry{ur: mockUserRepo, wr: mockWalletRepo}, &configs.Config{})
+
+		res, err := svc.SearchUsers(context.Background(), "0812")
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, "wallet-1", res[0].Wallet.ID)
+	})
+
+	t.Run("wallet ID finds its owner", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockWalletRepo := mocks.NewWalletRepository(t)
+
+		wallet := &models.Wallet{ID: "wallet-1", UserID: "user-1"}
+		mockUserRepo.On("Search", mock.Anything, "wallet-1", adminSearchLimit).Return([]models.User{}, nil)
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(wallet, nil)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&models.User{ID: "user-1"}, nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
+
+		svc := NewAdminService(&testRegistry{ur: mockUserRepo, wr: mockWalletRepo}, &configs.Config{})
+
+		res, err := svc.SearchUsers(context.Background(), "wallet-1")
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, "user-1", res[0].User.ID)
+	})
+}
+
+func TestAdminService_Adjust(t *testing.T) {
+	cfg := &configs.Config{}
+	cfg.Approval.Expiration = 3600
+
+	t.Run("adjustment waits for approval", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockApprovalRepo := mocks.NewApprovalRequestRepository(t)
+
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(&models.Wallet{ID: "wallet-1", UserID: "user-1", IsActive: true}, nil)
+		mockApprovalRepo.On("Create", mock.Anything, mock.MatchedBy(func(a *models.ApprovalRequest) bool {
+			return a.Operation == auth.PermissionWalletAdjust && a.MakerID == "ops-1" && a.Status == models.ApprovalStatusPending &&
+				strings.Contains(string(a.Payload), `"amount":5000`) && a.ExpiresAt.After(time.Now())
+		})).Return(nil)
+
+		svc := NewAdminService(&testRegistry{wr: mockWalletRepo, apr: mockApprovalRepo}, cfg)
+
+		res, err := svc.Adjust(context.Background(), dto.AdjustmentRequest{
+			WalletID: "wallet-1", ActorID: "ops-1", Direction: models.TransactionDirectionCredit, Amount: 5000, Reason: "Missing top up",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, res.Approval)
+		assert.Nil(t, res.Transaction)
+	})
+
+	t.Run("frozen wallets are not adjusted", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(&models.Wallet{ID: "wallet-1", Balance: 10000}, nil)
+
+		svc := NewAdminService(&testRegistry{wr: mockWalletRepo}, cfg)
+
+		_, err := svc.Adjust(context.Background(), dto.AdjustmentRequest{
+			WalletID: "wallet-1", ActorID: "ops-1", Direction: models.TransactionDirectionDebit, Amount: 5000, Reason: "Duplicate top up",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "frozen")
+	})
+}
+
+func TestAdminService_ExecuteApproval(t *testing.T) {
+	approvalOf := func(operation, payload string) *models.ApprovalRequest {
+		return &models.ApprovalRequest{ID: "approval-1", Operation: operation, Payload: datatypes.JSON(payload), MakerID: "ops-1"}
+	}
+
+	t.Run("approved credit records the maker and the approval", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockActionRepo := mocks.NewAdminActionRepository(t)
+
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(&models.Wallet{ID: "wallet-1", UserID: "user-1", IsActive: true}, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.MatchedBy(func(tx *models.WalletTransaction) bool {
+			return tx.Type == models.TransactionTypeAdjustment && tx.Direction == models.TransactionDirectionCredit &&
+				strings.Contains(string(tx.Metadata), `"actor_id":"ops-1"`)
+		})).Return(nil)
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-1", 5000.0).Return(&models.Wallet{ID: "wallet-1", UserID: "user-1", Balance: 5000, IsActive: true}, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		mockActionRepo.On("Create", mock.Anything, mock.MatchedBy(func(a *models.AdminAction) bool {
+			return a.ActorID == "ops-1" && a.Action == models.AdminActionCredit && a.UserID == "user-1" &&
+				a.TransactionID != nil && a.ApprovalID != nil && *a.ApprovalID == "approval-1" && a.Amount == 5000 && a.Reason == "Missing top up"
+		})).Return(nil)
+
+		reg := withinKYCLimits(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, aar: mockActionRepo})
+		svc := NewAdminService(reg, &configs.Config{})
+
+		res, err := svc.ExecuteApproval(context.Background(), reg,
+			approvalOf(auth.PermissionWalletAdjust, `{"wallet_id":"wallet-1","direction":"CREDIT","amount":5000,"reason":"Missing top up"}`))
+		require.NoError(t, err)
+
+		action := res.(*dto.AdminActionResponse)
+		assert.Equal(t, 5000.0, action.Wallet.Balance)
+		assert.Equal(t, action.Transaction.ID, *action.Action.TransactionID)
+	})
+
+	t.Run("approved debit cannot exceed the balance", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(&models.Wallet{ID: "wallet-1", Balance: 100, IsActive: true}, nil)
+
+		svc := NewAdminService(&testRegistry{wr: mockWalletRepo}, &configs.Config{})
+
+		_, err := svc.ExecuteApproval(context.Background(), &testRegistry{wr: mockWalletRepo},
+			approvalOf(auth.PermissionWalletAdjust, `{"wallet_id":"wallet-1","direction":"DEBIT","amount":5000,"reason":"Duplicate top up"}`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Insufficient balance")
+	})
+}
+
+func TestAdminService_Reverse(t *testing.T) {
+	cfg := &configs.Config{}
+	cfg.Approval.Expiration = 3600
+	cfg.Approval.ReversalThreshold = 1000000
+
+	t.Run("small reversal is executed at once", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockActionRepo := mocks.NewAdminActionRepository(t)
+
+		mockTxRepo.On("GetByID", mock.Anything, "tx-1").Return(&models.WalletTransaction{
+			ID: "tx-1", WalletID: "wallet-1", Amount: 25000, Direction: models.TransactionDirectionDebit, Status: models.TransactionStatusCompleted,
+		}, nil)
+		mockTxRepo.On("IsReversed", mock.Anything, "tx-1").Return(false, nil)
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(&models.Wallet{ID: "wallet-1", UserID: "user-1", IsActive: true}, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.MatchedBy(func(tx *models.WalletTransaction) bool {
+			return tx.Direction == models.TransactionDirectionCredit && tx.ReversalOf != nil && *tx.ReversalOf == "tx-1"
+		})).Return(nil)
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-1", 25000.0).Return(&models.Wallet{ID: "wallet-1", UserID: "user-1", Balance: 25000, IsActive: true}, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		mockActionRepo.On("Create", mock.Anything, mock.MatchedBy(func(a *models.AdminAction) bool {
+			return a.Action == models.AdminActionReverse && a.ActorID == "ops-1" && a.ApprovalID == nil
+		})).Return(nil)
+
+		svc := NewAdminService(withinKYCLimits(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, aar: mockActionRepo}), cfg)
+
+		res, err := svc.Reverse(context.Background(), dto.ReversalRequest{TransactionID: "tx-1", ActorID: "ops-1", Reason: "Merchant refund"})
+		require.NoError(t, err)
+		assert.Nil(t, res.Approval)
+		assert.Equal(t, 25000.0, res.Wallet.Balance)
+	})
+
+	t.Run("large reversal waits for approval", func(t *testing.T) {
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockApprovalRepo := mocks.NewApprovalRequestRepository(t)
+
+		mockTxRepo.On("GetByID", mock.Anything, "tx-1").Return(&models.WalletTransaction{
+			ID: "tx-1", WalletID: "wallet-1", Amount: 1000000, Direction: models.TransactionDirectionCredit, Status: models.TransactionStatusCompleted,
+		}, nil)
+		mockTxRepo.On("IsReversed", mock.Anything, "tx-1").Return(false, nil)
+		mockApprovalRepo.On("Create", mock.Anything, mock.MatchedBy(func(a *models.ApprovalRequest) bool {
+			return a.Operation == auth.PermissionWalletReverse && strings.Contains(string(a.Payload), `"transaction_id":"tx-1"`)
+		})).Return(nil)
+
+		svc := NewAdminService(&testRegistry{tr: mockTxRepo, apr: mockApprovalRepo}, cfg)
+
+		res, err := svc.Reverse(context.Background(), dto.ReversalRequest{TransactionID: "tx-1", ActorID: "ops-1", Reason: "Fraudulent top up"})
+		require.NoError(t, err)
+		require.NotNil(t, res.Approval)
+		assert.Nil(t, res.Transaction)
+	})
+
+	t.Run("a reversal cannot be reversed", func(t *testing.T) {
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		reversalOf := "tx-0"
+		mockTxRepo.On("GetByID", mock.Anything, "tx-1").Return(&models.WalletTransaction{
+			ID: "tx-1", Amount: 100, Status: models.TransactionStatusCompleted, ReversalOf: &reversalOf,
+		}, nil)
+
+		svc := NewAdminService(&testRegistry{tr: mockTxRepo}, cfg)
+
+		_, err := svc.Reverse(context.Background(), dto.ReversalRequest{TransactionID: "tx-1", ActorID: "ops-1", Reason: "Undo"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be reversed")
+	})
+
+	t.Run("a transaction is reversed only once", func(t *testing.T) {
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockTxRepo.On("GetByID", mock.Anything, "tx-1").Return(&models.WalletTransaction{ID: "tx-1", Amount: 100, Status: models.TransactionStatusCompleted}, nil)
+		mockTxRepo.On("IsReversed", mock.Anything, "tx-1").Return(true, nil)
+
+		svc := NewAdminService(&testRegistry{tr: mockTxRepo}, cfg)
+
+		_, err := svc.Reverse(context.Background(), dto.ReversalRequest{TransactionID: "tx-1", ActorID: "ops-1", Reason: "Merchant refund"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already been reversed")
+	})
+}
+
+func TestAdminService_Freeze(t *testing.T) {
+	t.Run("freezing records the actor and reason", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockActionRepo := mocks.NewAdminActionRepository(t)
+
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(&models.Wallet{ID: "wallet-1", UserID: "user-1", IsActive: true}, nil)
+		mockWalletRepo.On("SetActive", mock.Anything, "wallet-1", false).Return(true, nil)
+		mockActionRepo.On("Create", mock.Anything, mock.MatchedBy(func(a *models.AdminAction) bool {
+			return a.ActorID == "compliance-1" && a.Action == models.AdminActionFreeze && a.Reason == "Fraud report"
+		})).Return(nil)
+
+		svc := NewAdminService(&testRegistry{wr: mockWalletRepo, aar: mockActionRepo}, &configs.Config{})
+
+		res, err := svc.Freeze(context.Background(), dto.FreezeWalletRequest{WalletID: "wallet-1", ActorID: "compliance-1", Reason: "Fraud report"})
+		require.NoError(t, err)
+		assert.False(t, res.Wallet.IsActive)
+	})
+
+	t.Run("frozen wallet cannot be frozen again", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(&models.Wallet{ID: "wallet-1", UserID: "user-1"}, nil)
+		mockWalletRepo.On("SetActive", mock.Anything, "wallet-1", false).Return(false, nil)
+
+		svc := NewAdminService(&testRegistry{wr: mockWalletRepo}, &configs.Config{})
+
+		_, err := svc.Freeze(context.Background(), dto.FreezeWalletRequest{WalletID: "wallet-1", ActorID: "compliance-1", Reason: "Fraud report"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already frozen")
+	})
+}
+
+func TestAdminService_ExportTransactions(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	t.Run("writes a CSV and records the export", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockActionRepo := mocks.NewAdminActionRepository(t)
+
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(&models.Wallet{ID: "wallet-1", UserID: "user-1"}, nil)
+		mockTxRepo.On("GetByWalletIDBetween", mock.Anything, "wallet-1", from, to).Return([]models.WalletTransaction{
+			{ID: "tx-1", Amount: 25000, Type: models.TransactionTypePayment, Direction: models.TransactionDirectionDebit, Status: models.TransactionStatusCompleted, Description: "=HYPERLINK()", CreatedAt: from},
+		}, nil)
+		mockActionRepo.On("Create", mock.Anything, mock.MatchedBy(func(a *models.AdminAction) bool {
+			return a.ActorID == "compliance-1" && a.Action == models.AdminActionExport && a.WalletID == "wallet-1"
+		})).Return(nil)
+
+		svc := NewAdminService(withinKYCLimits(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, aar: mockActionRepo}), &configs.Config{})
+
+		res, err := svc.ExportTransactions(context.Background(), dto.ExportTransactionsRequest{UserID: "user-1", ActorID: "compliance-1", From: from, To: to})
+		require.NoError(t, err)
+		assert.Equal(t, "transactions-user-1-20261001-20261101.csv", res.FileName)
+
+		lines := strings.Split(strings.TrimSpace(string(res.Content)), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, "id,created_at,type,direction,status,category,amount,description", lines[0])
+		assert.Equal(t, "tx-1,2026-10-01T00:00:00Z,PAYMENT,DEBIT,COMPLETED,,25000.00,'=HYPERLINK()", lines[1])
+	})
+
+	t.Run("period longer than a year is refused", func(t *testing.T) {
+		svc := NewAdminService(&testRegistry{}, &configs.Config{})
+
+		_, err := svc.ExportTransactions(context.Background(), dto.ExportTransactionsRequest{UserID: "user-1", From: from, To: from.AddDate(2, 0, 0)})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "366 days")
+	})
+}
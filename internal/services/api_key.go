@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	response "digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// serviceAPIKeyPrefix marks service API keys; the part before the dot is stored in clear for lookup
+const serviceAPIKeyPrefix = "sk_"
+
+type APIKeyService struct {
+	repo interfaces.RegistryRepository
+	cfg  *configs.Config
+}
+
+// Ensure APIKeyService implements interfaces.APIKeyService
+var _ interfaces.APIKeyService = (*APIKeyService)(nil)
+
+func NewAPIKeyService(repo interfaces.RegistryRepository, config *configs.Config) interfaces.APIKeyService {
+	return &APIKeyService{
+		repo: repo,
+		cfg:  config,
+	}
+}
+
+// Create issues a key with the scopes. The secret is returned once and only its hash is kept.
+func (s *APIKeyService) Create(ctx context.Context, req dto.CreateAPIKeyRequest) (*dto.APIKeyCredentialsResponse, error) {
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	maxDays := s.cfg.APIKey.MaxLifetimeDay
+	days := req.ExpiresInDays
+	if days == 0 {
+		days = maxDays
+	}
+	if days > maxDays {
+		return nil, response.NewValidationError("API keys cannot live longer than the maximum lifetime")
+	}
+
+	key, secret, err := newAPIKey(req.Name, scopes, req.CreatedBy, time.Now().AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetAPIKeyRepository().Create(ctx, key); err != nil {
+			return nil, response.Wrap(err, "error creating API key")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionAPIKeyCreate, models.AuditResourceAPIKey, key.ID, nil, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.APIKeyCredentialsResponse{APIKey: key, Key: secret}, nil
+}
+
+func (s *APIKeyService) List(ctx context.Context, limit, offset int) (*dto.PaginatedAPIKeyResponse, error) {
+	keys, total, err := s.repo.GetAPIKeyRepository().List(ctx, limit, offset)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving API keys")
+	}
+
+	return &dto.PaginatedAPIKeyResponse{
+		Data: keys,
+		Meta: dto.PaginationMeta{Total: total, Limit: limit, Offset: offset},
+	}, nil
+}
+
+// Rotate issues a replacement key with the same name, scopes and lifetime. The old key keeps
+// working for the rotation grace period so clients can switch over without downtime.
+func (s *APIKeyService) Rotate(ctx context.Context, id, actorID string) (*dto.APIKeyCredentialsResponse, error) {
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		keyRepo := txRepo.GetAPIKeyRepository()
+
+		old, err := s.lockKey(ctx, txRepo, id)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		if !old.IsUsable(now) {
+			return nil, response.NewValidationError("Only active API keys can be rotated")
+		}
+
+		if old.ReplacedBy != nil {
+			return nil, response.NewValidationError("API key has already been rotated")
+		}
+
+		key, secret, err := newAPIKey(old.Name, old.Scopes, actorID, now.Add(old.ExpiresAt.Sub(old.CreatedAt)))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := keyRepo.Create(ctx, key); err != nil {
+			return nil, response.Wrap(err, "error creating API key")
+		}
+
+		before := *old
+		if grace := now.Add(time.Duration(s.cfg.APIKey.RotationGrace) * time.Second); grace.Before(old.ExpiresAt) {
+			old.ExpiresAt = grace
+		}
+		old.ReplacedBy = &key.ID
+
+		if err := keyRepo.Update(ctx, old); err != nil {
+			return nil, response.Wrap(err, "error updating API key")
+		}
+
+		if err := recordAudit(ctx, txRepo, models.AuditActionAPIKeyRotate, models.AuditResourceAPIKey, old.ID, before, old); err != nil {
+			return nil, err
+		}
+
+		if err := recordAudit(ctx, txRepo, models.AuditActionAPIKeyCreate, models.AuditResourceAPIKey, key.ID, nil, key); err != nil {
+			return nil, err
+		}
+
+		return &dto.APIKeyCredentialsResponse{APIKey: key, Key: secret}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*dto.APIKeyCredentialsResponse), nil
+}
+
+// Revoke stops the key from authenticating immediately
+func (s *APIKeyService) Revoke(ctx context.Context, id string) (*models.APIKey, error) {
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		key, err := s.lockKey(ctx, txRepo, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if key.RevokedAt != nil {
+			return nil, response.NewValidationError("API key has already been revoked")
+		}
+
+		before := *key
+		now := time.Now()
+		key.RevokedAt = &now
+
+		if err := txRepo.GetAPIKeyRepository().Update(ctx, key); err != nil {
+			return nil, response.Wrap(err, "error revoking API key")
+		}
+
+		if err := recordAudit(ctx, txRepo, models.AuditActionAPIKeyRevoke, models.AuditResourceAPIKey, key.ID, before, key); err != nil {
+			return nil, err
+		}
+
+		return key, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*models.APIKey), nil
+}
+
+// Authenticate resolves an API key to the principal of its service, with the permissions its
+// scopes grant
+func (s *APIKeyService) Authenticate(ctx context.Context, apiKey string) (*auth.Principal, error) {
+	prefix, _, ok := strings.Cut(apiKey, ".")
+	if !ok || !strings.HasPrefix(prefix, serviceAPIKeyPrefix) {
+		return nil, response.ErrInvalidCredentials
+	}
+
+	keyRepo := s.repo.GetAPIKeyRepository()
+
+	key, err := keyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving API key")
+	}
+
+	if key == nil || !utils.CompareTokenHash(key.KeyHash, apiKey) {
+		return nil, response.ErrInvalidCredentials
+	}
+
+	now := time.Now()
+	if !key.IsUsable(now) {
+		return nil, response.ErrInvalidCredentials
+	}
+
+	// last use is only written once per touch interval to keep busy keys from writing on every call
+	since := now.Add(-time.Duration(s.cfg.APIKey.TouchInterval) * time.Second)
+	if err := keyRepo.TouchLastUsed(ctx, key.ID, now, since); err != nil {
+		return nil, response.Wrap(err, "error updating API key")
+	}
+
+	permissions := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		if permission, ok := auth.ScopePermission(scope); ok {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return &auth.Principal{
+		Type:        auth.PrincipalTypeAPIKey,
+		ID:          key.ID,
+		Name:        key.Name,
+		Role:        auth.RoleService,
+		Permissions: permissions,
+	}, nil
+}
+
+func (s *APIKeyService) lockKey(ctx context.Context, repo interfaces.RegistryRepository, id string) (*models.APIKey, error) {
+	key, err := repo.GetAPIKeyRepository().GetByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("API key")
+		}
+		return nil, response.Wrap(err, "error retrieving API key")
+	}
+	return key, nil
+}
+
+// normalizeScopes refuses unknown scopes and drops duplicates
+func normalizeScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool, len(scopes))
+	normalized := make([]string, 0, len(scopes))
+
+	for _, scope := range scopes {
+		if _, ok := auth.ScopePermission(scope); !ok {
+			return nil, response.NewValidationError("Unknown scope " + scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+
+	return normalized, nil
+}
+
+// newAPIKey builds a key record and returns it with the full key handed to the service
+func newAPIKey(name string, scopes []string, createdBy string, expiresAt time.Time) (*models.APIKey, string, error) {
+	id, err := utils.RandomHex(6)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret, err := utils.RandomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	prefix := serviceAPIKeyPrefix + id
+	apiKey := prefix + "." + secret
+
+	return &models.APIKey{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   utils.HashToken(apiKey),
+		Scopes:    datatypes.JSONSlice[string](scopes),
+		CreatedBy: createdBy,
+		ExpiresAt: expiresAt,
+	}, apiKey, nil
+}
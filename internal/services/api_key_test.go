@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func apiKeyConfig() *configs.Config {
+	cfg := &configs.Config{}
+	cfg.APIKey.MaxLifetimeDay = 365
+	cfg.APIKey.RotationGrace = 3600
+	cfg.APIKey.TouchInterval = 60
+	return cfg
+}
+
+func TestAPIKeyService_Create(t *testing.T) {
+	t.Run("stores only the hash of the key", func(t *testing.T) {
+		mockKeyRepo := mocks.NewAPIKeyRepository(t)
+
+		var stored *models.APIKey
+		mockKeyRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*models.APIKey)
+		}).Return(nil)
+
+		svc := NewAPIKeyService(&testRegistry{akey: mockKeyRepo}, apiKeyConfig())
+
+		res, err := svc.Create(context.Background(), dto.CreateAPIKeyRequest{
+			CreatedBy: "admin-1", Name: "payroll", Scopes: []string{auth.ScopeWalletRead, auth.ScopeWalletWithdraw, auth.ScopeWalletRead}, ExpiresInDays: 90,
+		})
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(res.Key, stored.Prefix+"."))
+		assert.NotContains(t, stored.KeyHash, res.Key)
+		assert.True(t, utils.CompareTokenHash(stored.KeyHash, res.Key))
+		assert.Equal(t, []string{auth.ScopeWalletRead, auth.ScopeWalletWithdraw}, []string(stored.Scopes))
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, 90), stored.ExpiresAt, time.Minute)
+	})
+
+	t.Run("unknown scope is refused", func(t *testing.T) {
+		svc := NewAPIKeyService(&testRegistry{}, apiKeyConfig())
+
+		_, err := svc.Create(context.Background(), dto.CreateAPIKeyRequest{Name: "payroll", Scopes: []string{"wallet:adjust"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Unknown scope")
+	})
+
+	t.Run("lifetime is bounded", func(t *testing.T) {
+		svc := NewAPIKeyService(&testRegistry{}, apiKeyConfig())
+
+		_, err := svc.Create(context.Background(), dto.CreateAPIKeyRequest{Name: "payroll", Scopes: []string{auth.ScopeWalletRead}, ExpiresInDays: 400})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "maximum lifetime")
+	})
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	issue := func(t *testing.T) (*models.APIKey, string) {
+		key, secret, err := newAPIKey("checkout", []string{auth.ScopeWalletRead}, "admin-1", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		return key, secret
+	}
+
+	t.Run("valid key resolves to its scopes' permissions", func(t *testing.T) {
+		mockKeyRepo := mocks.NewAPIKeyRepository(t)
+		key, secret := issue(t)
+
+		mockKeyRepo.On("GetByPrefix", mock.Anything, key.Prefix).Return(key, nil)
+		mockKeyRepo.On("TouchLastUsed", mock.Anything, key.ID, mock.Anything, mock.Anything).Return(nil)
+
+		svc := NewAPIKeyService(&testRegistry{akey: mockKeyRepo}, apiKeyConfig())
+
+		principal, err := svc.Authenticate(context.Background(), secret)
+		require.NoError(t, err)
+		assert.Equal(t, auth.PrincipalTypeAPIKey, principal.Type)
+		assert.Equal(t, key.ID, principal.ID)
+		assert.Equal(t, []string{auth.PermissionWalletRead}, principal.Permissions)
+	})
+
+	t.Run("wrong secret is refused", func(t *testing.T) {
+		mockKeyRepo := mocks.NewAPIKeyRepository(t)
+		key, _ := issue(t)
+		mockKeyRepo.On("GetByPrefix", mock.Anything, key.Prefix).Return(key, nil)
+
+		svc := NewAPIKeyService(&testRegistry{akey: mockKeyRepo}, apiKeyConfig())
+
+		_, err := svc.Authenticate(context.Background(), key.Prefix+".not-the-secret")
+		assert.Equal(t, response.ErrInvalidCredentials, err)
+	})
+
+	t.Run("revoked and expired keys are refused", func(t *testing.T) {
+		revoked, revokedSecret := issue(t)
+		now := time.Now()
+		revoked.RevokedAt = &now
+
+		expired, expiredSecret := issue(t)
+		expired.ExpiresAt = now.Add(-time.Second)
+
+		mockKeyRepo := mocks.NewAPIKeyRepository(t)
+		mockKeyRepo.On("GetByPrefix", mock.Anything, revoked.Prefix).Return(revoked, nil)
+		mockKeyRepo.On("GetByPrefix", mock.Anything, expired.Prefix).Return(expired, nil)
+
+		svc := NewAPIKeyService(&testRegistry{akey: mockKeyRepo}, apiKeyConfig())
+
+		_, err := svc.Authenticate(context.Background(), revokedSecret)
+		assert.Equal(t, response.ErrInvalidCredentials, err)
+
+		_, err = svc.Authenticate(context.Background(), expiredSecret)
+		assert.Equal(t, response.ErrInvalidCredentials, err)
+	})
+
+	t.Run("merchant keys are not service keys", func(t *testing.T) {
+		svc := NewAPIKeyService(&testRegistry{}, apiKeyConfig())
+
+		_, err := svc.Authenticate(context.Background(), "mk_abc.secret")
+		assert.Equal(t, response.ErrInvalidCredentials, err)
+	})
+}
+
+func TestAPIKeyService_Rotate(t *testing.T) {
+	t.Run("old key keeps working for the grace period", func(t *testing.T) {
+		mockKeyRepo := mocks.NewAPIKeyRepository(t)
+
+		createdAt := time.Now().Add(-24 * time.Hour)
+		old := &models.APIKey{ID: "key-1", Name: "payroll", Scopes: []string{auth.ScopeWalletRead}, CreatedAt: createdAt, ExpiresAt: createdAt.AddDate(0, 0, 90)}
+
+		mockKeyRepo.On("GetByIDForUpdate", mock.Anything, "key-1").Return(old, nil)
+		mockKeyRepo.On("Create", mock.Anything, mock.MatchedBy(func(k *models.APIKey) bool {
+			return k.Name == "payroll" && k.CreatedBy == "admin-2" && k.ExpiresAt.After(time.Now().AddDate(0, 0, 89))
+		})).Return(nil)
+		mockKeyRepo.On("Update", mock.Anything, mock.MatchedBy(func(k *models.APIKey) bool {
+			return k.ID == "key-1" && k.ReplacedBy != nil && k.ExpiresAt.Before(time.Now().Add(2*time.Hour))
+		})).Return(nil)
+
+		svc := NewAPIKeyService(&testRegistry{akey: mockKeyRepo}, apiKeyConfig())
+
+		res, err := svc.Rotate(context.Background(), "key-1", "admin-2")
+		require.NoError(t, err)
+		assert.Equal(t, res.APIKey.ID, *old.ReplacedBy)
+	})
+
+	t.Run("rotated key cannot be rotated again", func(t *testing.T) {
+		mockKeyRepo := mocks.NewAPIKeyRepository(t)
+
+		replacedBy := "key-2"
+		mockKeyRepo.On("GetByIDForUpdate", mock.Anything, "key-1").Return(&models.APIKey{ID: "key-1", ExpiresAt: time.Now().Add(time.Hour), ReplacedBy: &replacedBy}, nil)
+
+		svc := NewAPIKeyService(&testRegistry{akey: mockKeyRepo}, apiKeyConfig())
+
+		_, err := svc.Rotate(context.Background(), "key-1", "admin-2")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already been rotated")
+	})
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	response "digital-wallet/pkg/response"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ApprovalService lets a checker approve or reject the operations makers submitted. Approved
+// operations run through the executor registered for them.
+type ApprovalService struct {
+	repo      interfaces.RegistryRepository
+	cfg       *configs.Config
+	executors map[string]interfaces.ApprovalExecutor
+}
+
+// Ensure ApprovalService implements interfaces.ApprovalService
+var _ interfaces.ApprovalService = (*ApprovalService)(nil)
+
+func NewApprovalService(repo interfaces.RegistryRepository, config *configs.Config, executors ...interfaces.ApprovalExecutor) interfaces.ApprovalService {
+	byOperation := make(map[string]interfaces.ApprovalExecutor)
+	for _, executor := range executors {
+		for _, operation := range executor.ApprovalOperations() {
+			byOperation[operation] = executor
+		}
+	}
+
+	return &ApprovalService{
+		repo:      repo,
+		cfg:       config,
+		executors: byOperation,
+	}
+}
+
+// List returns a page of requests, filtered by status when one is given
+func (s *ApprovalService) List(ctx context.Context, status string, limit, offset int) (*dto.PaginatedApprovalResponse, error) {
+	switch status {
+	case "", models.ApprovalStatusPending, models.ApprovalStatusApproved, models.ApprovalStatusRejected, models.ApprovalStatusExpired:
+	default:
+		return nil, response.NewValidationError("status must be one of PENDING, APPROVED, REJECTED or EXPIRED")
+	}
+
+	approvals, total, err := s.repo.GetApprovalRequestRepository().List(ctx, status, limit, offset)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving approval requests")
+	}
+
+	return &dto.PaginatedApprovalResponse{
+		Data: approvals,
+		Meta: dto.PaginationMeta{Total: total, Limit: limit, Offset: offset},
+	}, nil
+}
+
+func (s *ApprovalService) Get(ctx context.Context, id string) (*models.ApprovalRequest, error) {
+	approval, err := s.repo.GetApprovalRequestRepository().GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("Approval request")
+		}
+		return nil, response.Wrap(err, "error retrieving approval request")
+	}
+	return approval, nil
+}
+
+// Approve runs the operation of a pending request and marks it approved in the same database
+// transaction, so either both happen or neither does. The checker must be someone other than
+// the maker and must hold the permission the operation needs.
+func (s *ApprovalService) Approve(ctx context.Context, req dto.ApprovalDecisionRequest) (*dto.ApprovalDecisionResponse, error) {
+	var expired bool
+
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		approval, err := s.lockPending(ctx, txRepo, req)
+		if err != nil {
+			return nil, err
+		}
+
+		// the expiry is committed and only reported once the transaction ends
+		if approval.IsExpired(time.Now()) {
+			expired = true
+			approval.Status = models.ApprovalStatusExpired
+			if err := txRepo.GetApprovalRequestRepository().Update(ctx, approval); err != nil {
+				return nil, response.Wrap(err, "error updating approval request")
+			}
+			return nil, nil
+		}
+
+		executor, ok := s.executors[approval.Operation]
+		if !ok {
+			return nil, response.NewValidationError("Operation " + approval.Operation + " cannot be approved")
+		}
+
+		if err := auth.RequirePermission(ctx, approval.Operation); err != nil {
+			return nil, err
+		}
+
+		s.decide(approval, req, models.ApprovalStatusApproved)
+
+		res, err := executor.ExecuteApproval(ctx, txRepo, approval)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := txRepo.GetApprovalRequestRepository().Update(ctx, approval); err != nil {
+			return nil, response.Wrap(err, "error updating approval request")
+		}
+
+		if err := auditDecision(ctx, txRepo, models.AuditActionApprovalApprove, approval); err != nil {
+			return nil, err
+		}
+
+		return &dto.ApprovalDecisionResponse{Approval: approval, Result: res}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if expired {
+		return nil, response.ErrApprovalExpired
+	}
+
+	return result.(*dto.ApprovalDecisionResponse), nil
+}
+
+// Reject closes a pending request without running its operation
+func (s *ApprovalService) Reject(ctx context.Context, req dto.ApprovalDecisionRequest) (*dto.ApprovalDecisionResponse, error) {
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		approval, err := s.lockPending(ctx, txRepo, req)
+		if err != nil {
+			return nil, err
+		}
+
+		s.decide(approval, req, models.ApprovalStatusRejected)
+
+		if err := txRepo.GetApprovalRequestRepository().Update(ctx, approval); err != nil {
+			return nil, response.Wrap(err, "error updating approval request")
+		}
+
+		if err := auditDecision(ctx, txRepo, models.AuditActionApprovalReject, approval); err != nil {
+			return nil, err
+		}
+
+		return &dto.ApprovalDecisionResponse{Approval: approval}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*dto.ApprovalDecisionResponse), nil
+}
+
+// ExpirePending expires up to limit pending requests past their expiry time
+func (s *ApprovalService) ExpirePending(ctx context.Context, limit int) (int, error) {
+	n, err := s.repo.GetApprovalRequestRepository().ExpirePending(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, response.Wrap(err, "error expiring approval requests")
+	}
+	return int(n), nil
+}
+
+// lockPending loads the request under a row lock and checks it can still be decided by the checker
+func (s *ApprovalService) lockPending(ctx context.Context, repo interfaces.RegistryRepository, req dto.ApprovalDecisionRequest) (*models.ApprovalRequest, error) {
+	approval, err := repo.GetApprovalRequestRepository().GetByIDForUpdate(ctx, req.ApprovalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("Approval request")
+		}
+		return nil, response.Wrap(err, "error retrieving approval request")
+	}
+
+	if approval.Status != models.ApprovalStatusPending {
+		return nil, response.NewValidationError("Approval request is already " + approval.Status)
+	}
+
+	if approval.MakerID == req.CheckerID {
+		return nil, response.ErrSelfApproval
+	}
+
+	return approval, nil
+}
+
+func (s *ApprovalService) decide(approval *models.ApprovalRequest, req dto.ApprovalDecisionRequest, status string) {
+	now := time.Now()
+	checkerID := req.CheckerID
+
+	approval.Status = status
+	approval.CheckerID = &checkerID
+	approval.Note = req.Note
+	approval.DecidedAt = &now
+}
+
+// submitApproval stores an operation for a second person to approve. operation is the
+// permission the operation needs and payload holds the arguments its executor reads back.
+func submitApproval(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, operation, makerID string, payload interface{}) (*models.ApprovalRequest, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, response.Wrap(err, "error encoding approval payload")
+	}
+
+	approval := &models.ApprovalRequest{
+		ID:        uuid.New().String(),
+		Operation: operation,
+		Payload:   datatypes.JSON(data),
+		MakerID:   makerID,
+		Status:    models.ApprovalStatusPending,
+		ExpiresAt: time.Now().Add(time.Duration(cfg.Approval.Expiration) * time.Second),
+	}
+
+	_, err = repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetApprovalRequestRepository().Create(ctx, approval); err != nil {
+			return nil, response.Wrap(err, "error creating approval request")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionApprovalRequest, models.AuditResourceApproval, approval.ID, nil, approval)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+// auditDecision records the decision on a request that was pending in the audit log
+func auditDecision(ctx context.Context, repo interfaces.RegistryRepository, action string, approval *models.ApprovalRequest) error {
+	return recordAudit(ctx, repo, action, models.AuditResourceApproval, approval.ID,
+		map[string]interface{}{"status": models.ApprovalStatusPending}, approval)
+}
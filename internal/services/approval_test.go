@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func checkerContext(checkerID string, permissions ...interface{}) context.Context {
+	token := &jwt.Token{Claims: jwt.MapClaims{"user_id": checkerID, "role": auth.RoleOps, "permissions": permissions}}
+	return context.WithValue(context.Background(), auth.ContextKeyUser, token)
+}
+
+func pendingApproval() *models.ApprovalRequest {
+	return &models.ApprovalRequest{
+		ID:        "approval-1",
+		Operation: auth.PermissionWalletAdjust,
+		MakerID:   "ops-1",
+		Status:    models.ApprovalStatusPending,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func adjustExecutor(t *testing.T) *mocks.ApprovalExecutor {
+	executor := mocks.NewApprovalExecutor(t)
+	executor.On("ApprovalOperations").Return([]string{auth.PermissionWalletAdjust})
+	return executor
+}
+
+func TestApprovalService_Approve(t *testing.T) {
+	t.Run("runs the operation and records the checker", func(t *testing.T) {
+		mockApprovalRepo := mocks.NewApprovalRequestRepository(t)
+		executor := adjustExecutor(t)
+
+		mockApprovalRepo.On("GetByIDForUpdate", mock.Anything, "approval-1").Return(pendingApproval(), nil)
+		executor.On("ExecuteApproval", mock.Anything, mock.Anything, mock.MatchedBy(func(a *models.ApprovalRequest) bool {
+			return a.Status == models.ApprovalStatusApproved && *a.CheckerID == "ops-2"
+		})).Return(&dto.AdminActionResponse{}, nil)
+		mockApprovalRepo.On("Update", mock.Anything, mock.MatchedBy(func(a *models.ApprovalRequest) bool {
+			return a.Status == models.ApprovalStatusApproved && a.DecidedAt != nil && a.Note == "Checked the bank statement"
+		})).Return(nil)
+
+		svc := NewApprovalService(&testRegistry{apr: mockApprovalRepo}, &configs.Config{}, executor)
+
+		res, err := svc.Approve(checkerContext("ops-2", auth.PermissionApprovalReview, auth.PermissionWalletAdjust),
+			dto.ApprovalDecisionRequest{ApprovalID: "approval-1", CheckerID: "ops-2", Note: "Checked the bank statement"})
+		require.NoError(t, err)
+		assert.Equal(t, models.ApprovalStatusApproved, res.Approval.Status)
+		assert.NotNil(t, res.Result)
+	})
+
+	t.Run("maker cannot approve their own request", func(t *testing.T) {
+		mockApprovalRepo := mocks.NewApprovalRequestRepository(t)
+		mockApprovalRepo.On("GetByIDForUpdate", mock.Anything, "approval-1").Return(pendingApproval(), nil)
+
+		svc := NewApprovalService(&testRegistry{apr: mockApprovalRepo}, &configs.Config{}, adjustExecutor(t))
+
+		_, err := svc.Approve(checkerContext("ops-1", auth.PermissionApprovalReview, auth.PermissionWalletAdjust),
+			dto.ApprovalDecisionRequest{ApprovalID: "approval-1", CheckerID: "ops-1"})
+		assert.Equal(t, response.ErrSelfApproval, err)
+	})
+
+	t.Run("checker needs the permission of the operation", func(t *testing.T) {
+		mockApprovalRepo := mocks.NewApprovalRequestRepository(t)
+		mockApprovalRepo.On("GetByIDForUpdate", mock.Anything, "approval-1").Return(pendingApproval(), nil)
+
+		svc := NewApprovalService(&testRegistry{apr: mockApprovalRepo}, &configs.Config{}, adjustExecutor(t))
+
+		_, err := svc.Approve(checkerContext("compliance-1", auth.PermissionApprovalReview),
+			dto.ApprovalDecisionRequest{ApprovalID: "approval-1", CheckerID: "compliance-1"})
+		assert.Equal(t, response.ErrInsufficientPermissions, err)
+	})
+
+	t.Run("expired request is marked expired", func(t *testing.T) {
+		mockApprovalRepo := mocks.NewApprovalRequestRepository(t)
+
+		approval := pendingApproval()
+		approval.ExpiresAt = time.Now().Add(-time.Minute)
+		mockApprovalRepo.On("GetByIDForUpdate", mock.Anything, "approval-1").Return(approval, nil)
+		mockApprovalRepo.On("Update", mock.Anything, mock.MatchedBy(func(a *models.ApprovalRequest) bool {
+			return a.Status == models.ApprovalStatusExpired
+		})).Return(nil)
+
+		svc := NewApprovalService(&testRegistry{apr: mockApprovalRepo}, &configs.Config{}, adjustExecutor(t))
+
+		_, err := svc.Approve(checkerContext("ops-2", auth.PermissionApprovalReview, auth.PermissionWalletAdjust),
+			dto.ApprovalDecisionRequest{ApprovalID: "approval-1", CheckerID: "ops-2"})
+		assert.Equal(t, response.ErrApprovalExpired, err)
+	})
+
+	t.Run("decided request cannot be approved again", func(t *testing.T) {
+		mockApprovalRepo := mocks.NewApprovalRequestRepository(t)
+
+		approval := pendingApproval()
+		approval.Status = models.ApprovalStatusRejected
+		mockApprovalRepo.On("GetByIDForUpdate", mock.Anything, "approval-1").Return(approval, nil)
+
+		svc := NewApprovalService(&testRegistry{apr: mockApprovalRepo}, &configs.Config{}, adjustExecutor(t))
+
+		_, err := svc.Approve(checkerContext("ops-2", auth.PermissionApprovalReview, auth.PermissionWalletAdjust),
+			dto.ApprovalDecisionRequest{ApprovalID: "approval-1", CheckerID: "ops-2"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already REJECTED")
+	})
+}
+
+func TestApprovalService_Reject(t *testing.T) {
+	mockApprovalRepo := mocks.NewApprovalRequestRepository(t)
+	mockApprovalRepo.On("GetByIDForUpdate", mock.Anything, "approval-1").Return(pendingApproval(), nil)
+	mockApprovalRepo.On("Update", mock.Anything, mock.MatchedBy(func(a *models.ApprovalRequest) bool {
+		return a.Status == models.ApprovalStatusRejected && *a.CheckerID == "ops-2"
+	})).Return(nil)
+
+	// the executor is never asked to run a rejected operation
+	svc := NewApprovalService(&testRegistry{apr: mockApprovalRepo}, &configs.Config{}, adjustExecutor(t))
+
+	res, err := svc.Reject(checkerContext("ops-2", auth.PermissionApprovalReview),
+		dto.ApprovalDecisionRequest{ApprovalID: "approval-1", CheckerID: "ops-2", Note: "No supporting ticket"})
+	require.NoError(t, err)
+	assert.Nil(t, res.Result)
+}
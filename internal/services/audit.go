@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/audit"
+	"digital-wallet/pkg/auth"
+	response "digital-wallet/pkg/response"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// AuditService answers compliance queries on the audit log. Events are written by the
+// services making the changes, through recordAudit.
+type AuditService struct {
+	repo interfaces.RegistryRepository
+	cfg  *configs.Config
+}
+
+// Ensure AuditService implements interfaces.AuditService
+var _ interfaces.AuditService = (*AuditService)(nil)
+
+func NewAuditService(repo interfaces.RegistryRepository, config *configs.Config) interfaces.AuditService {
+	return &AuditService{
+		repo: repo,
+		cfg:  config,
+	}
+}
+
+// List returns a page of the events matching the query, newest first
+func (s *AuditService) List(ctx context.Context, query dto.AuditEventQuery, limit, offset int) (*dto.PaginatedAuditEventResponse, error) {
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return nil, response.NewValidationError("from must be before to")
+	}
+
+	events, total, err := s.repo.GetAuditEventRepository().List(ctx, models.AuditEventFilter{
+		ActorID:       query.ActorID,
+		PrincipalType: query.PrincipalType,
+		Action:        query.Action,
+		ResourceType:  query.ResourceType,
+		ResourceID:    query.ResourceID,
+		RequestID:     query.RequestID,
+		From:          query.From,
+		To:            query.To,
+	}, limit, offset)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving audit events")
+	}
+
+	return &dto.PaginatedAuditEventResponse{
+		Data: events,
+		Meta: dto.PaginationMeta{Total: total, Limit: limit, Offset: offset},
+	}, nil
+}
+
+// recordAudit writes an audit event for a change to a resource, with the principal and HTTP
+// request of ctx. Given the repository of a DoInTransaction callback, the event commits or
+// rolls back together with the change. before and after are snapshots of the resource,
+// encoded as JSON, and are left out when nil.
+func recordAudit(ctx context.Context, repo interfaces.RegistryRepository, action, resourceType, resourceID string, before, after interface{}) error {
+	event := &models.AuditEvent{
+		ID:           uuid.New().String(),
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+	}
+
+	request, inRequest := audit.RequestFrom(ctx)
+	event.RequestID = request.ID
+	event.IPAddress = request.IPAddress
+
+	principal := auth.GetPrincipal(ctx)
+	switch {
+	case principal.ID != "":
+		event.ActorID = principal.ID
+		event.PrincipalType = principal.Type
+	case inRequest:
+		event.PrincipalType = models.AuditPrincipalAnonymous
+	default:
+		event.PrincipalType = models.AuditPrincipalSystem
+	}
+
+	var err error
+	if event.Before, err = auditSnapshot(before); err != nil {
+		return err
+	}
+	if event.After, err = auditSnapshot(after); err != nil {
+		return err
+	}
+
+	if err := repo.GetAuditEventRepository().Create(ctx, event); err != nil {
+		return response.Wrap(err, "error recording audit event")
+	}
+	return nil
+}
+
+// recordSecurityEvent writes a security event to the application log, like
+// auth.LogSecurityEvent, and to the audit log against the resource it concerns
+func recordSecurityEvent(ctx context.Context, repo interfaces.RegistryRepository, event, resourceType, resourceID string, details map[string]interface{}) error {
+	attrs := make([]any, 0, 2*len(details)+2)
+	attrs = append(attrs, resourceType, resourceID)
+	for key, value := range details {
+		attrs = append(attrs, key, value)
+	}
+	auth.LogSecurityEvent(event, attrs...)
+
+	return recordAudit(ctx, repo, "security."+event, resourceType, resourceID, nil, details)
+}
+
+// RecordSecurityEvent writes a security event to the application log and the audit log, for
+// callers outside the services such as middleware
+func (s *AuditService) RecordSecurityEvent(ctx context.Context, event, resourceType, resourceID string, details map[string]interface{}) error {
+	return recordSecurityEvent(ctx, s.repo, event, resourceType, resourceID, details)
+}
+
+// authorizeUser lets the principal of the request act for the user when it is that user, or when
+// its role or API key scopes grant the permission. Work without a principal, such as cron jobs,
+// is not checked. A refusal is recorded as a cross_user_access security event, so repo must not
+// be the registry of a transaction that the refusal rolls back.
+func authorizeUser(ctx context.Context, repo interfaces.RegistryRepository, userID, permission string) error {
+	principal := auth.GetPrincipal(ctx)
+	if principal.ID == "" || principal.ID == userID || principal.HasPermission(permission) {
+		return nil
+	}
+
+	if err := recordSecurityEvent(ctx, repo, auth.SecurityEventCrossUserAccess, models.AuditResourceUser, userID, map[string]interface{}{
+		"principal_id":   principal.ID,
+		"principal_type": principal.Type,
+		"role":           principal.Role,
+		"permission":     permission,
+	}); err != nil {
+		return err
+	}
+
+	return response.ErrInsufficientPermissions
+}
+
+func auditSnapshot(v interface{}) (datatypes.JSON, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, response.Wrap(err, "error encoding audit snapshot")
+	}
+	return datatypes.JSON(data), nil
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/audit"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAudit(t *testing.T) {
+	request := audit.Request{ID: "request-1", IPAddress: "10.0.0.1"}
+
+	t.Run("records the principal and request of the change", func(t *testing.T) {
+		mockAuditRepo := mocks.NewAuditEventRepository(t)
+		mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.AuditEvent) bool {
+			return e.ActorID == "ops-1" && e.PrincipalType == auth.PrincipalTypeUser && e.RequestID == "request-1" &&
+				e.IPAddress == "10.0.0.1" && e.Action == models.AuditActionWalletFreeze && e.ResourceID == "wallet-1" &&
+				string(e.Before) == `{"is_active":true}` && string(e.After) == `{"is_active":false}`
+		})).Return(nil)
+
+		ctx := audit.WithRequest(checkerContext("ops-1"), request)
+		err := recordAudit(ctx, &testRegistry{aur: mockAuditRepo}, models.AuditActionWalletFreeze, models.AuditResourceWallet, "wallet-1",
+			map[string]interface{}{"is_active": true}, map[string]interface{}{"is_active": false})
+		require.NoError(t, err)
+	})
+
+	t.Run("requests before signing in are anonymous", func(t *testing.T) {
+		mockAuditRepo := mocks.NewAuditEventRepository(t)
+		mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.AuditEvent) bool {
+			return e.ActorID == "" && e.PrincipalType == models.AuditPrincipalAnonymous && e.Before == nil
+		})).Return(nil)
+
+		ctx := audit.WithRequest(context.Background(), request)
+		require.NoError(t, recordAudit(ctx, &testRegistry{aur: mockAuditRepo}, models.AuditActionPasswordReset, models.AuditResourceUser, "user-1", nil, nil))
+	})
+
+	t.Run("changes outside a request are made by the system", func(t *testing.T) {
+		mockAuditRepo := mocks.NewAuditEventRepository(t)
+		mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.AuditEvent) bool {
+			return e.PrincipalType == models.AuditPrincipalSystem && e.RequestID == ""
+		})).Return(nil)
+
+		require.NoError(t, recordAudit(context.Background(), &testRegistry{aur: mockAuditRepo}, models.AuditActionWalletCredit, models.AuditResourceWallet, "wallet-1", nil, nil))
+	})
+}
+
+func TestAuthorizeUser(t *testing.T) {
+	t.Run("users act for themselves", func(t *testing.T) {
+		require.NoError(t, authorizeUser(checkerContext("user-1"), &testRegistry{}, "user-1", auth.PermissionWalletWithdraw))
+	})
+
+	t.Run("principals with the permission act for anyone", func(t *testing.T) {
+		require.NoError(t, authorizeUser(checkerContext("ops-1", auth.PermissionWalletWithdraw), &testRegistry{}, "user-1", auth.PermissionWalletWithdraw))
+	})
+
+	t.Run("acting for another user is refused and recorded", func(t *testing.T) {
+		mockAuditRepo := mocks.NewAuditEventRepository(t)
+		mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.AuditEvent) bool {
+			return e.Action == "security."+auth.SecurityEventCrossUserAccess && e.ActorID == "user-2" &&
+				e.ResourceType == models.AuditResourceUser && e.ResourceID == "user-1"
+		})).Return(nil)
+
+		err := authorizeUser(checkerContext("user-2"), &testRegistry{aur: mockAuditRepo}, "user-1", auth.PermissionWalletWithdraw)
+		require.ErrorIs(t, err, response.ErrInsufficientPermissions)
+	})
+}
+
+func TestLedger_Audit(t *testing.T) {
+	newRegistry := func(t *testing.T, mockAuditRepo *mocks.AuditEventRepository) *testRegistry {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+
+		mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-1", 5000.0).Return(&models.Wallet{ID: "wallet-1", Balance: 15000}, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		return &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, aur: mockAuditRepo}
+	}
+
+	t.Run("a credit records the balance before and after", func(t *testing.T) {
+		mockAuditRepo := mocks.NewAuditEventRepository(t)
+		mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.AuditEvent) bool {
+			return e.Action == models.AuditActionWalletCredit && e.ResourceType == models.AuditResourceWallet && e.ResourceID == "wallet-1" &&
+				string(e.Before) == `{"balance":10000}`
+		})).Return(nil)
+
+		_, _, err := newLedger(&configs.Config{}, nil).credit(context.Background(), newRegistry(t, mockAuditRepo), ledgerEntry{
+			WalletID: "wallet-1", Amount: 5000, Type: models.TransactionTypeAdjustment,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("a failed audit write fails the credit, rolling it back", func(t *testing.T) {
+		mockAuditRepo := mocks.NewAuditEventRepository(t)
+		mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+
+		_, _, err := newLedger(&configs.Config{}, nil).credit(context.Background(), newRegistry(t, mockAuditRepo), ledgerEntry{
+			WalletID: "wallet-1", Amount: 5000, Type: models.TransactionTypeAdjustment,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error recording audit event")
+	})
+}
+
+func TestAdminService_UnlockLoginAudit(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockAttemptRepo := mocks.NewAttemptRepository(t)
+	mockAuditRepo := mocks.NewAuditEventRepository(t)
+
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newTestUser(t, "secret123"), nil)
+	mockAttemptRepo.On("Reset", mock.Anything, "login:phone:081234567890").Return(nil)
+	mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.AuditEvent) bool {
+		return e.Action == "security."+auth.SecurityEventLoginUnlocked && e.ResourceID == "user-1" && e.ActorID == "support-1"
+	})).Return(nil)
+
+	svc := NewAdminService(&testRegistry{ur: mockUserRepo, atr: mockAttemptRepo, aur: mockAuditRepo}, newLoginConfig())
+
+	require.NoError(t, svc.UnlockLogin(checkerContext("support-1"), dto.UnlockLoginRequest{UserID: "user-1", ActorID: "support-1", Reason: "Verified by phone"}))
+}
+
+func TestAuditService_List(t *testing.T) {
+	t.Run("passes the filters to the repository", func(t *testing.T) {
+		mockAuditRepo := mocks.NewAuditEventRepository(t)
+		from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+		mockAuditRepo.On("List", mock.Anything, models.AuditEventFilter{ResourceType: models.AuditResourceWallet, ResourceID: "wallet-1", From: from}, 10, 0).
+			Return([]models.AuditEvent{{ID: "event-1"}}, int64(1), nil)
+
+		svc := NewAuditService(&testRegistry{aur: mockAuditRepo}, &configs.Config{})
+
+		res, err := svc.List(context.Background(), dto.AuditEventQuery{ResourceType: models.AuditResourceWallet, ResourceID: "wallet-1", From: from}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), res.Meta.Total)
+	})
+
+	t.Run("an empty period is refused", func(t *testing.T) {
+		day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+		svc := NewAuditService(&testRegistry{}, &configs.Config{})
+
+		_, err := svc.List(context.Background(), dto.AuditEventQuery{From: day, To: day}, 10, 0)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,562 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	response "digital-wallet/pkg/response"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAccessTokenExpiration  = time.Hour
+	defaultRefreshTokenExpiration = 7 * 24 * time.Hour
+	defaultSessionMaxLifetime     = 30 * 24 * time.Hour
+	defaultSessionTouchInterval   = time.Minute
+)
+
+type AuthService struct {
+	repo            interfaces.RegistryRepository
+	cfg             *configs.Config
+	keyring         *auth.Keyring
+	sender          interfaces.AccountSender
+	deviceListeners []interfaces.DeviceListener
+}
+
+// Ensure AuthService implements interfaces.AuthService
+var _ interfaces.AuthService = (*AuthService)(nil)
+
+func NewAuthService(repo interfaces.RegistryRepository, config *configs.Config, keyring *auth.Keyring, sender interfaces.AccountSender, deviceListeners ...interfaces.DeviceListener) interfaces.AuthService {
+	return &AuthService{
+		repo:            repo,
+		cfg:             config,
+		keyring:         keyring,
+		sender:          sender,
+		deviceListeners: deviceListeners,
+	}
+}
+
+// Register creates the user together with their wallet. The account starts inactive, and
+// an activation token is sent to the user.
+func (s *AuthService) Register(ctx context.Context, req dto.RegisterRequest) (*dto.AuthResponse, error) {
+	userRepo := s.repo.GetUserRepository()
+
+	existing, err := userRepo.GetByPhoneNumber(ctx, req.PhoneNumber)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving user")
+	}
+
+	if existing != nil {
+		return nil, response.NewDuplicateEntryError("Phone number is already registered")
+	}
+
+	var email *string
+	if req.Email != nil && *req.Email != "" {
+		normalized := strings.ToLower(strings.TrimSpace(*req.Email))
+		email = &normalized
+
+		existing, err := userRepo.GetByEmail(ctx, normalized)
+		if err != nil {
+			return nil, response.Wrap(err, "error retrieving user")
+		}
+
+		if existing != nil {
+			return nil, response.NewDuplicateEntryError("Email is already registered")
+		}
+	}
+
+	hashed, err := models.HashAndSalt([]byte(req.Password))
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		ID:          uuid.New().String(),
+		FullName:    req.FullName,
+		Email:       email,
+		Password:    hashed,
+		IsActive:    false,
+		Role:        auth.RoleUser,
+		PhoneNumber: req.PhoneNumber,
+	}
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetUserRepository().Create(ctx, user); err != nil {
+			return nil, response.Wrap(err, "error creating user")
+		}
+
+		if err := recordAudit(ctx, txRepo, models.AuditActionUserRegister, models.AuditResourceUser, user.ID, nil, user); err != nil {
+			return nil, err
+		}
+
+		return getOrCreateWallet(ctx, txRepo.GetWalletRepository(), user.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the account exists either way; the user can ask for another token
+	if err := sendAccountToken(ctx, s.repo, s.cfg, s.sender, user, models.AccountTokenActivation); err != nil {
+		slog.Warn("Failed to send activation token", "user_id", user.ID, "error", err)
+	}
+
+	return &dto.AuthResponse{ActivationRequired: true}, nil
+}
+
+// Login checks the phone number and password and starts a new session. Failed logins are
+// counted per phone number and IP address, and slow down then lock further attempts.
+func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (*dto.AuthResponse, error) {
+	failures, err := checkLoginAllowed(ctx, s.repo, s.cfg, req.PhoneNumber, req.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserRepository().GetByPhoneNumber(ctx, req.PhoneNumber)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving user")
+	}
+
+	if user == nil || user.CheckPassword(req.Password) != nil {
+		userID := ""
+		if user != nil {
+			userID = user.ID
+		}
+		return nil, recordLoginFailure(ctx, s.repo, s.cfg, req.PhoneNumber, req.IPAddress, userID)
+	}
+
+	if failures > 0 {
+		if err := unlockLogin(ctx, s.repo, req.PhoneNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkAccountActive(user); err != nil {
+		return nil, err
+	}
+
+	mfa, err := s.repo.GetMFARepository().GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving two-factor authentication")
+	}
+
+	if mfa.IsEnabled() {
+		return s.issueMFAToken(user)
+	}
+
+	return s.startSession(ctx, user, req.DeviceInfo)
+}
+
+// VerifyMFA completes a login that is waiting for the second factor. Recovery codes are
+// accepted in place of a TOTP code.
+func (s *AuthService) VerifyMFA(ctx context.Context, req dto.VerifyMFARequest) (*dto.AuthResponse, error) {
+	claims, err := s.parseToken(req.MFAToken, auth.TokenTypeMFAPending)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserRepository().GetByID(ctx, claims.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewUnauthorizedError("Invalid MFA token")
+		}
+		return nil, response.Wrap(err, "error retrieving user")
+	}
+
+	if err := checkAccountActive(user); err != nil {
+		return nil, err
+	}
+
+	mfa, err := s.repo.GetMFARepository().GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving two-factor authentication")
+	}
+
+	if !mfa.IsEnabled() {
+		return nil, response.NewUnauthorizedError("Invalid MFA token")
+	}
+
+	if err := verifyMFACode(ctx, s.repo, s.cfg, mfa, req.Code, true); err != nil {
+		return nil, err
+	}
+
+	return s.startSession(ctx, user, req.DeviceInfo)
+}
+
+// Refresh exchanges a refresh token for a new token pair. Refresh tokens are single use:
+// presenting one that was already exchanged ends the session, since it may have been stolen.
+func (s *AuthService) Refresh(ctx context.Context, req dto.RefreshTokenRequest) (*dto.AuthResponse, error) {
+	claims, err := s.parseRefreshToken(req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionRepo := s.repo.GetSessionRepository()
+
+	session, err := sessionRepo.Get(ctx, claims.ID, claims.SessionID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving session")
+	}
+
+	if session == nil {
+		return nil, response.NewUnauthorizedError("Session has expired")
+	}
+
+	if session.RefreshTokenID != claims.tokenID {
+		if err := sessionRepo.Delete(ctx, session.UserID, session.ID); err != nil {
+			return nil, response.Wrap(err, "error revoking session")
+		}
+		return nil, response.NewUnauthorizedError("Refresh token has already been used")
+	}
+
+	user, err := s.repo.GetUserRepository().GetByID(ctx, claims.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewUnauthorizedError("")
+		}
+		return nil, response.Wrap(err, "error retrieving user")
+	}
+
+	if err := checkAccountActive(user); err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, user, session)
+}
+
+// Logout ends the session the refresh token belongs to
+func (s *AuthService) Logout(ctx context.Context, req dto.RefreshTokenRequest) error {
+	claims, err := s.parseRefreshToken(req.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.GetSessionRepository().Delete(ctx, claims.ID, claims.SessionID); err != nil {
+		return response.Wrap(err, "error revoking session")
+	}
+
+	return nil
+}
+
+// TouchSession checks that the session is still active and slides its expiry. To spare Redis
+// a write on every request, a session used within the touch interval is left as it is.
+func (s *AuthService) TouchSession(ctx context.Context, userID, sessionID string) (*models.Session, error) {
+	sessionRepo := s.repo.GetSessionRepository()
+
+	session, err := sessionRepo.Get(ctx, userID, sessionID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving session")
+	}
+
+	if session == nil {
+		return nil, response.ErrSessionExpiredType
+	}
+
+	now := time.Now()
+	if now.Sub(session.LastSeenAt) < s.sessionTouchInterval() {
+		return session, nil
+	}
+
+	session.Slide(now, s.refreshTokenExpiration(), s.sessionMaxLifetime())
+	if !session.ExpiresAt.After(now) {
+		return nil, response.ErrSessionExpiredType
+	}
+
+	if err := sessionRepo.Save(ctx, session); err != nil {
+		return nil, response.Wrap(err, "error saving session")
+	}
+
+	return session, nil
+}
+
+// LocalPrincipal builds the principal of a user from their record here rather than from token
+// claims, for tokens of a trusted issuer whose role and permissions this service does not vouch for
+func (s *AuthService) LocalPrincipal(ctx context.Context, userID string) (*auth.Principal, error) {
+	user, err := s.repo.GetUserRepository().GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewUnauthorizedError(response.ErrUnauthorizedType.Message)
+		}
+		return nil, response.Wrap(err, "error retrieving user")
+	}
+
+	permissions, err := s.repo.GetRoleRepository().GetPermissions(ctx, user.Role)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving permissions")
+	}
+
+	principal := auth.UserAuth{ID: user.ID, Role: user.Role, Permissions: permissions}
+	if user.Email != nil {
+		principal.Email = *user.Email
+	}
+
+	return auth.PrincipalFromUser(principal), nil
+}
+
+// ListSessions returns the user's active sessions, flagging the one the request was made with
+func (s *AuthService) ListSessions(ctx context.Context, userID, currentSessionID string) ([]dto.SessionResponse, error) {
+	sessions, err := s.repo.GetSessionRepository().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving sessions")
+	}
+
+	res := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		res = append(res, dto.SessionResponse{
+			ID:         session.ID,
+			DeviceID:   session.DeviceID,
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			Current:    session.ID == currentSessionID,
+			CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+			LastSeenAt: session.LastSeenAt.Format(time.RFC3339),
+			ExpiresAt:  session.ExpiresAt.Format(time.RFC3339),
+		})
+	}
+
+	return res, nil
+}
+
+// RevokeSession ends one of the user's sessions
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	sessionRepo := s.repo.GetSessionRepository()
+
+	session, err := sessionRepo.Get(ctx, userID, sessionID)
+	if err != nil {
+		return response.Wrap(err, "error retrieving session")
+	}
+
+	if session == nil {
+		return response.NewNotFoundError("Session")
+	}
+
+	// sessions live in Redis; the session is only deleted once its event is written, and a
+	// failed delete rolls the event back
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := recordAudit(ctx, txRepo, models.AuditActionSessionRevoke, models.AuditResourceSession, session.ID, session, nil); err != nil {
+			return nil, err
+		}
+
+		if err := sessionRepo.Delete(ctx, userID, sessionID); err != nil {
+			return nil, response.Wrap(err, "error revoking session")
+		}
+
+		return nil, nil
+	})
+
+	return err
+}
+
+// RevokeAllSessions ends every session of the user, including the one making the request
+func (s *AuthService) RevokeAllSessions(ctx context.Context, userID string) (*dto.RevokeSessionsResponse, error) {
+	sessionRepo := s.repo.GetSessionRepository()
+
+	sessions, err := sessionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving sessions")
+	}
+
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := recordAudit(ctx, txRepo, models.AuditActionSessionsRevoke, models.AuditResourceUser, userID, sessions, nil); err != nil {
+			return nil, err
+		}
+
+		revoked, err := sessionRepo.DeleteByUserID(ctx, userID)
+		if err != nil {
+			return nil, response.Wrap(err, "error revoking sessions")
+		}
+
+		return revoked, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RevokeSessionsResponse{Revoked: result.(int)}, nil
+}
+
+// startSession records the device and opens a session for it. A device holds at most one
+// session, so logging in again from a known device ID replaces the session it had.
+func (s *AuthService) startSession(ctx context.Context, user *models.User, device dto.DeviceInfo) (*dto.AuthResponse, error) {
+	if err := recordDevice(ctx, s.repo, s.cfg, user.ID, device, s.deviceListeners); err != nil {
+		return nil, err
+	}
+
+	sessionRepo := s.repo.GetSessionRepository()
+
+	if device.DeviceID != "" {
+		existing, err := sessionRepo.GetByUserID(ctx, user.ID)
+		if err != nil {
+			return nil, response.Wrap(err, "error retrieving sessions")
+		}
+
+		for _, session := range existing {
+			if session.DeviceID != device.DeviceID {
+				continue
+			}
+			if err := sessionRepo.Delete(ctx, user.ID, session.ID); err != nil {
+				return nil, response.Wrap(err, "error revoking session")
+			}
+		}
+	}
+
+	session := &models.Session{
+		ID:         uuid.New().String(),
+		UserID:     user.ID,
+		DeviceID:   device.DeviceID,
+		DeviceName: device.DeviceName,
+		UserAgent:  device.UserAgent,
+		IPAddress:  device.IPAddress,
+		CreatedAt:  time.Now(),
+	}
+
+	return s.issueTokens(ctx, user, session)
+}
+
+// issueTokens signs a new access and refresh token for the session and records the refresh
+// token as the only one the session accepts
+func (s *AuthService) issueTokens(ctx context.Context, user *models.User, session *models.Session) (*dto.AuthResponse, error) {
+	now := time.Now()
+	accessExpiresAt := now.Add(s.accessTokenExpiration())
+
+	session.RefreshTokenID = uuid.New().String()
+	session.Slide(now, s.refreshTokenExpiration(), s.sessionMaxLifetime())
+	if !session.ExpiresAt.After(now) {
+		return nil, response.NewUnauthorizedError("Session has expired")
+	}
+
+	permissions, err := s.repo.GetRoleRepository().GetPermissions(ctx, user.Role)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving permissions")
+	}
+
+	principal := auth.UserAuth{ID: user.ID, Role: user.Role, SessionID: session.ID, Permissions: permissions}
+	if user.Email != nil {
+		principal.Email = *user.Email
+	}
+
+	accessToken, err := auth.NewToken(principal, auth.TokenTypeAccess, uuid.New().String(), accessExpiresAt, s.keyring)
+	if err != nil {
+		return nil, response.Wrap(err, "error signing access token")
+	}
+
+	refreshToken, err := auth.NewToken(principal, auth.TokenTypeRefresh, session.RefreshTokenID, session.ExpiresAt, s.keyring)
+	if err != nil {
+		return nil, response.Wrap(err, "error signing refresh token")
+	}
+
+	if err := s.repo.GetSessionRepository().Save(ctx, session); err != nil {
+		return nil, response.Wrap(err, "error saving session")
+	}
+
+	return &dto.AuthResponse{
+		AccessToken:  accessToken,
+		ExpiresAt:    accessExpiresAt.Format(time.RFC3339),
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// issueMFAToken returns the short-lived token that stands in for a session until the
+// second factor is verified. It cannot be used as an access or refresh token.
+func (s *AuthService) issueMFAToken(user *models.User) (*dto.AuthResponse, error) {
+	expiresAt := time.Now().Add(mfaPendingTokenExpiration(s.cfg))
+
+	token, err := auth.NewToken(auth.UserAuth{ID: user.ID, Role: user.Role}, auth.TokenTypeMFAPending, uuid.New().String(), expiresAt, s.keyring)
+	if err != nil {
+		return nil, response.Wrap(err, "error signing MFA token")
+	}
+
+	return &dto.AuthResponse{
+		ExpiresAt:   expiresAt.Format(time.RFC3339),
+		MFARequired: true,
+		MFAToken:    token,
+	}, nil
+}
+
+// tokenClaims are the claims of a verified refresh or MFA token
+type tokenClaims struct {
+	auth.UserAuth
+	tokenID string
+}
+
+func (s *AuthService) parseRefreshToken(tokenString string) (*tokenClaims, error) {
+	claims, err := s.parseToken(tokenString, auth.TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.SessionID == "" {
+		return nil, response.NewUnauthorizedError("Invalid refresh token")
+	}
+
+	return claims, nil
+}
+
+// parseToken verifies tokenString and checks that it is of tokenType. Refresh and MFA tokens
+// are only ever issued by this service, so tokens of a trusted issuer are refused.
+func (s *AuthService) parseToken(tokenString, tokenType string) (*tokenClaims, error) {
+	invalid := response.NewUnauthorizedError("Invalid refresh token")
+	if tokenType == auth.TokenTypeMFAPending {
+		invalid = response.NewUnauthorizedError("Invalid MFA token")
+	}
+
+	token, err := auth.VerifyToken(tokenString, s.keyring)
+	if err != nil || !token.Valid {
+		return nil, invalid
+	}
+
+	if issuer, _ := token.Claims.GetIssuer(); issuer != s.keyring.Issuer() {
+		return nil, invalid
+	}
+
+	mapClaims, _ := token.Claims.(jwt.MapClaims)
+	claims := &tokenClaims{}
+	claims.ID, _ = mapClaims["user_id"].(string)
+	claims.SessionID, _ = mapClaims["session_id"].(string)
+	claims.Type, _ = mapClaims["type"].(string)
+	claims.tokenID, _ = mapClaims["jti"].(string)
+
+	if claims.Type != tokenType || claims.ID == "" {
+		return nil, invalid
+	}
+
+	return claims, nil
+}
+
+func (s *AuthService) accessTokenExpiration() time.Duration {
+	if s.cfg != nil && s.cfg.JWT.TokenExpiration > 0 {
+		return time.Duration(s.cfg.JWT.TokenExpiration) * time.Second
+	}
+	return defaultAccessTokenExpiration
+}
+
+func (s *AuthService) refreshTokenExpiration() time.Duration {
+	if s.cfg != nil && s.cfg.JWT.RefreshTokenExpirationDay > 0 {
+		return time.Duration(s.cfg.JWT.RefreshTokenExpirationDay) * 24 * time.Hour
+	}
+	return defaultRefreshTokenExpiration
+}
+
+func (s *AuthService) sessionMaxLifetime() time.Duration {
+	if s.cfg != nil && s.cfg.Session.MaxLifetimeDay > 0 {
+		return time.Duration(s.cfg.Session.MaxLifetimeDay) * 24 * time.Hour
+	}
+	return defaultSessionMaxLifetime
+}
+
+func (s *AuthService) sessionTouchInterval() time.Duration {
+	if s.cfg != nil && s.cfg.Session.TouchInterval > 0 {
+		return time.Duration(s.cfg.Session.TouchInterval) * time.Second
+	}
+	return defaultSessionTouchInterval
+}
@@ -0,0 +1,449 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var testKeyring = auth.NewHMACKeyring("test-signing-key", "digital-wallet", "digital-wallet")
+
+func newAuthConfig() *configs.Config {
+	cfg := &configs.Config{}
+	cfg.JWT.TokenExpiration = 3600
+	cfg.JWT.RefreshTokenExpirationDay = 7
+	return cfg
+}
+
+func newTestUser(t *testing.T, password string) *models.User {
+	hashed, err := models.HashAndSalt([]byte(password))
+	require.NoError(t, err)
+
+	email := "user@example.com"
+	return &models.User{ID: "user-1", FullName: "Test User", Email: &email, Password: hashed, IsActive: true, Role: auth.RoleUser, PhoneNumber: "081234567890"}
+}
+
+// withPermissions returns a role repository granting the permissions to role
+func withPermissions(t *testing.T, role string, permissions ...string) *mocks.RoleRepository {
+	mockRoleRepo := mocks.NewRoleRepository(t)
+	mockRoleRepo.On("GetPermissions", mock.Anything, role).Return(permissions, nil).Maybe()
+	return mockRoleRepo
+}
+
+// withoutLoginFailures returns an attempt repository with no failed logins recorded
+func withoutLoginFailures(t *testing.T) *mocks.AttemptRepository {
+	mockAttemptRepo := mocks.NewAttemptRepository(t)
+	mockAttemptRepo.On("Get", mock.Anything, mock.Anything).Return(0, nil).Maybe()
+	mockAttemptRepo.On("BlockedFor", mock.Anything, mock.Anything).Return(time.Duration(0), nil).Maybe()
+	return mockAttemptRepo
+}
+
+func claimsOf(t *testing.T, tokenString string) jwt.MapClaims {
+	token, err := auth.VerifyToken(tokenString, testKeyring)
+	require.NoError(t, err)
+	return token.Claims.(jwt.MapClaims)
+}
+
+func TestAuthService_Register(t *testing.T) {
+	t.Run("creates the inactive user and their wallet and sends an activation token", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTokenRepo := mocks.NewAccountTokenRepository(t)
+		mockSender := mocks.NewAccountSender(t)
+
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, "081234567890").Return(nil, nil)
+		mockUserRepo.On("GetByEmail", mock.Anything, "user@example.com").Return(nil, nil)
+		mockUserRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *models.User) bool {
+			return u.CheckPassword("secret123") == nil && *u.Email == "user@example.com" && !u.IsActive
+		})).Return(nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, mock.Anything).Return(nil, nil)
+		mockWalletRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		var token string
+		mockSender.On("SendActivation", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			token = args.String(2)
+		}).Return(nil)
+		mockTokenRepo.On("Save", mock.Anything, models.AccountTokenActivation, mock.Anything, mock.Anything, 24*time.Hour).Return(nil)
+
+		reg := &testRegistry{ur: mockUserRepo, wr: mockWalletRepo, akr: mockTokenRepo}
+		svc := NewAuthService(reg, newAuthConfig(), testKeyring, mockSender)
+
+		email := " User@Example.com"
+		res, err := svc.Register(context.Background(), dto.RegisterRequest{FullName: "Test User", Email: &email, Password: "secret123", PhoneNumber: "081234567890"})
+		require.NoError(t, err)
+		assert.True(t, res.ActivationRequired)
+		assert.Empty(t, res.AccessToken)
+
+		mockTokenRepo.AssertCalled(t, "Save", mock.Anything, models.AccountTokenActivation, mock.Anything, utils.HashToken(token), 24*time.Hour)
+	})
+
+	t.Run("duplicate phone number", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, "081234567890").Return(&models.User{ID: "user-1"}, nil)
+
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo}, newAuthConfig(), testKeyring, nil)
+
+		_, err := svc.Register(context.Background(), dto.RegisterRequest{FullName: "Test User", Password: "secret123", PhoneNumber: "081234567890"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already registered")
+	})
+}
+
+func TestAuthService_Login(t *testing.T) {
+	user := newTestUser(t, "secret123")
+
+	t.Run("issues a token pair bound to a new session", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockSessionRepo := mocks.NewSessionRepository(t)
+
+		var saved *models.Session
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, "081234567890").Return(user, nil)
+		mockSessionRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*models.Session)
+		}).Return(nil)
+
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo, sr: mockSessionRepo, mfr: withoutMFA(t, "user-1"), atr: withoutLoginFailures(t), rlr: withPermissions(t, auth.RoleUser)}, newAuthConfig(), testKeyring, nil)
+
+		res, err := svc.Login(context.Background(), dto.LoginRequest{PhoneNumber: "081234567890", Password: "secret123"})
+		require.NoError(t, err)
+
+		access := claimsOf(t, res.AccessToken)
+		refresh := claimsOf(t, res.RefreshToken)
+		assert.Equal(t, "user-1", access["user_id"])
+		assert.Equal(t, "user@example.com", access["email"])
+		assert.Equal(t, auth.RoleUser, access["role"])
+		assert.Equal(t, auth.TokenTypeRefresh, refresh["type"])
+		assert.Equal(t, saved.ID, access["session_id"])
+		assert.Equal(t, saved.ID, refresh["session_id"])
+		assert.Equal(t, saved.RefreshTokenID, refresh["jti"])
+		assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), saved.ExpiresAt, time.Minute)
+	})
+
+	t.Run("access token carries the permissions of the role", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockSessionRepo := mocks.NewSessionRepository(t)
+
+		staff := newTestUser(t, "secret123")
+		staff.Role = auth.RoleSupport
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, "081234567890").Return(staff, nil)
+		mockSessionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		roles := withPermissions(t, auth.RoleSupport, auth.PermissionUserRead, auth.PermissionWalletRead)
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo, sr: mockSessionRepo, mfr: withoutMFA(t, "user-1"), atr: withoutLoginFailures(t), rlr: roles}, newAuthConfig(), testKeyring, nil)
+
+		res, err := svc.Login(context.Background(), dto.LoginRequest{PhoneNumber: "081234567890", Password: "secret123"})
+		require.NoError(t, err)
+
+		access := claimsOf(t, res.AccessToken)
+		assert.Equal(t, auth.RoleSupport, access["role"])
+		assert.Equal(t, []interface{}{auth.PermissionUserRead, auth.PermissionWalletRead}, access["permissions"])
+	})
+
+	t.Run("logging in again from a device replaces its session", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockSessionRepo := mocks.NewSessionRepository(t)
+		mockDeviceRepo := mocks.NewDeviceRepository(t)
+
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, "081234567890").Return(user, nil)
+		mockDeviceRepo.On("GetByDeviceID", mock.Anything, "user-1", "phone-1").Return(&models.Device{ID: "device-1", DeviceID: "phone-1"}, nil)
+		mockDeviceRepo.On("Touch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockSessionRepo.On("GetByUserID", mock.Anything, "user-1").Return([]models.Session{
+			{ID: "session-1", UserID: "user-1", DeviceID: "phone-1"},
+			{ID: "session-2", UserID: "user-1", DeviceID: "laptop-1"},
+		}, nil)
+		mockSessionRepo.On("Delete", mock.Anything, "user-1", "session-1").Return(nil)
+		mockSessionRepo.On("Save", mock.Anything, mock.MatchedBy(func(s *models.Session) bool {
+			return s.DeviceID == "phone-1" && s.DeviceName == "Pixel" && s.UserAgent == "wallet-app/1.0" && s.IPAddress == "10.0.0.1"
+		})).Return(nil)
+
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo, sr: mockSessionRepo, dvr: mockDeviceRepo, mfr: withoutMFA(t, "user-1"), atr: withoutLoginFailures(t), rlr: withPermissions(t, auth.RoleUser)}, newAuthConfig(), testKeyring, nil)
+
+		_, err := svc.Login(context.Background(), dto.LoginRequest{
+			PhoneNumber: "081234567890",
+			Password:    "secret123",
+			DeviceInfo:  dto.DeviceInfo{DeviceID: "phone-1", DeviceName: "Pixel", UserAgent: "wallet-app/1.0", IPAddress: "10.0.0.1"},
+		})
+		require.NoError(t, err)
+		mockSessionRepo.AssertNotCalled(t, "Delete", mock.Anything, "user-1", "session-2")
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockAttemptRepo := withoutLoginFailures(t)
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, "081234567890").Return(user, nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "login:phone:081234567890", 15*time.Minute).Return(1, nil)
+
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo, atr: mockAttemptRepo}, newAuthConfig(), testKeyring, nil)
+
+		_, err := svc.Login(context.Background(), dto.LoginRequest{PhoneNumber: "081234567890", Password: "wrong"})
+		assert.Equal(t, response.ErrInvalidCredentials, err)
+	})
+
+	t.Run("unknown phone number", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockAttemptRepo := withoutLoginFailures(t)
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, "080000000000").Return(nil, nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "login:phone:080000000000", 15*time.Minute).Return(1, nil)
+
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo, atr: mockAttemptRepo}, newAuthConfig(), testKeyring, nil)
+
+		_, err := svc.Login(context.Background(), dto.LoginRequest{PhoneNumber: "080000000000", Password: "secret123"})
+		assert.Equal(t, response.ErrInvalidCredentials, err)
+	})
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	user := newTestUser(t, "secret123")
+	cfg := newAuthConfig()
+
+	refreshToken := func(t *testing.T, tokenID string) string {
+		token, err := auth.NewToken(auth.UserAuth{ID: "user-1", SessionID: "session-1"}, auth.TokenTypeRefresh, tokenID, time.Now().Add(time.Hour), testKeyring)
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("rotates the refresh token", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockSessionRepo := mocks.NewSessionRepository(t)
+
+		mockSessionRepo.On("Get", mock.Anything, "user-1", "session-1").Return(&models.Session{ID: "session-1", UserID: "user-1", RefreshTokenID: "token-1", CreatedAt: time.Now().Add(-time.Hour)}, nil)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(user, nil)
+		mockSessionRepo.On("Save", mock.Anything, mock.MatchedBy(func(s *models.Session) bool {
+			return s.ID == "session-1" && s.RefreshTokenID != "token-1"
+		})).Return(nil)
+
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo, sr: mockSessionRepo, rlr: withPermissions(t, auth.RoleUser)}, cfg, testKeyring, nil)
+
+		res, err := svc.Refresh(context.Background(), dto.RefreshTokenRequest{RefreshToken: refreshToken(t, "token-1")})
+		require.NoError(t, err)
+		assert.Equal(t, "session-1", claimsOf(t, res.AccessToken)["session_id"])
+	})
+
+	t.Run("sessions past their maximum lifetime are not renewed", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockSessionRepo := mocks.NewSessionRepository(t)
+
+		mockSessionRepo.On("Get", mock.Anything, "user-1", "session-1").Return(&models.Session{ID: "session-1", UserID: "user-1", RefreshTokenID: "token-1", CreatedAt: time.Now().Add(-31 * 24 * time.Hour)}, nil)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(user, nil)
+
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo, sr: mockSessionRepo, rlr: withPermissions(t, auth.RoleUser)}, cfg, testKeyring, nil)
+
+		_, err := svc.Refresh(context.Background(), dto.RefreshTokenRequest{RefreshToken: refreshToken(t, "token-1")})
+		require.Error(t, err)
+		mockSessionRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reused refresh token revokes the session", func(t *testing.T) {
+		mockSessionRepo := mocks.NewSessionRepository(t)
+
+		mockSessionRepo.On("Get", mock.Anything, "user-1", "session-1").Return(&models.Session{ID: "session-1", UserID: "user-1", RefreshTokenID: "token-2"}, nil)
+		mockSessionRepo.On("Delete", mock.Anything, "user-1", "session-1").Return(nil)
+
+		svc := NewAuthService(&testRegistry{sr: mockSessionRepo}, cfg, testKeyring, nil)
+
+		_, err := svc.Refresh(context.Background(), dto.RefreshTokenRequest{RefreshToken: refreshToken(t, "token-1")})
+		require.Error(t, err)
+	})
+
+	t.Run("access tokens are rejected", func(t *testing.T) {
+		token, err := auth.NewToken(auth.UserAuth{ID: "user-1", SessionID: "session-1"}, auth.TokenTypeAccess, "token-1", time.Now().Add(time.Hour), testKeyring)
+		require.NoError(t, err)
+
+		svc := NewAuthService(&testRegistry{}, cfg, testKeyring, nil)
+
+		_, err = svc.Refresh(context.Background(), dto.RefreshTokenRequest{RefreshToken: token})
+		require.Error(t, err)
+	})
+
+	t.Run("refresh tokens of a trusted issuer are rejected", func(t *testing.T) {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		keyFile := filepath.Join(dir, "idp.pem")
+		require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+		idp, err := auth.NewKeyring(auth.KeyringConfig{Issuer: "https://idp.example.com", Audience: "digital-wallet", SigningKeyID: "idp-1", SigningKeyFile: keyFile})
+		require.NoError(t, err)
+
+		jwks, err := json.Marshal(idp.JWKS())
+		require.NoError(t, err)
+		jwksFile := filepath.Join(dir, "jwks.json")
+		require.NoError(t, os.WriteFile(jwksFile, jwks, 0o600))
+
+		keyring, err := auth.NewKeyring(auth.KeyringConfig{
+			Issuer: "digital-wallet", Audience: "digital-wallet", HMACSecret: "test-signing-key",
+			TrustedIssuer: "https://idp.example.com", TrustedJWKS: jwksFile,
+		})
+		require.NoError(t, err)
+
+		token, err := auth.NewToken(auth.UserAuth{ID: "user-1", SessionID: "session-1"}, auth.TokenTypeRefresh, "token-1", time.Now().Add(time.Hour), idp)
+		require.NoError(t, err)
+		_, err = keyring.Verify(token)
+		require.NoError(t, err)
+
+		svc := NewAuthService(&testRegistry{}, cfg, keyring, nil)
+
+		_, err = svc.Refresh(context.Background(), dto.RefreshTokenRequest{RefreshToken: token})
+		assert.Equal(t, response.NewUnauthorizedError("Invalid refresh token"), err)
+	})
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	cfg := newAuthConfig()
+	token, err := auth.NewToken(auth.UserAuth{ID: "user-1", SessionID: "session-1"}, auth.TokenTypeRefresh, "token-1", time.Now().Add(time.Hour), testKeyring)
+	require.NoError(t, err)
+
+	mockSessionRepo := mocks.NewSessionRepository(t)
+	mockSessionRepo.On("Delete", mock.Anything, "user-1", "session-1").Return(nil)
+
+	svc := NewAuthService(&testRegistry{sr: mockSessionRepo}, cfg, testKeyring, nil)
+
+	require.NoError(t, svc.Logout(context.Background(), dto.RefreshTokenRequest{RefreshToken: token}))
+}
+
+func TestAuthService_TouchSession(t *testing.T) {
+	cfg := newAuthConfig()
+
+	t.Run("slides the expiry of an idle session", func(t *testing.T) {
+		mockSessionRepo := mocks.NewSessionRepository(t)
+
+		session := &models.Session{ID: "session-1", UserID: "user-1", CreatedAt: time.Now().Add(-24 * time.Hour), LastSeenAt: time.Now().Add(-time.Hour), ExpiresAt: time.Now().Add(time.Hour)}
+		mockSessionRepo.On("Get", mock.Anything, "user-1", "session-1").Return(session, nil)
+		mockSessionRepo.On("Save", mock.Anything, session).Return(nil)
+
+		svc := NewAuthService(&testRegistry{sr: mockSessionRepo}, cfg, testKeyring, nil)
+
+		_, err := svc.TouchSession(context.Background(), "user-1", "session-1")
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), session.LastSeenAt, time.Minute)
+		assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), session.ExpiresAt, time.Minute)
+	})
+
+	t.Run("recently used sessions are not rewritten", func(t *testing.T) {
+		mockSessionRepo := mocks.NewSessionRepository(t)
+
+		mockSessionRepo.On("Get", mock.Anything, "user-1", "session-1").Return(&models.Session{ID: "session-1", UserID: "user-1", CreatedAt: time.Now(), LastSeenAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+		svc := NewAuthService(&testRegistry{sr: mockSessionRepo}, cfg, testKeyring, nil)
+
+		_, err := svc.TouchSession(context.Background(), "user-1", "session-1")
+		require.NoError(t, err)
+		mockSessionRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("expired session", func(t *testing.T) {
+		mockSessionRepo := mocks.NewSessionRepository(t)
+		mockSessionRepo.On("Get", mock.Anything, "user-1", "session-1").Return(nil, nil)
+
+		svc := NewAuthService(&testRegistry{sr: mockSessionRepo}, cfg, testKeyring, nil)
+
+		_, err := svc.TouchSession(context.Background(), "user-1", "session-1")
+		assert.Equal(t, response.ErrSessionExpiredType, err)
+	})
+}
+
+func TestAuthService_LocalPrincipal(t *testing.T) {
+	t.Run("role and permissions come from the user record", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newTestUser(t, "secret123"), nil)
+
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo, rlr: withPermissions(t, auth.RoleUser, auth.PermissionWalletRead)}, newAuthConfig(), testKeyring, nil)
+
+		principal, err := svc.LocalPrincipal(context.Background(), "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, auth.RoleUser, principal.Role)
+		assert.Equal(t, []string{auth.PermissionWalletRead}, principal.Permissions)
+	})
+
+	t.Run("unknown users are not authenticated", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockUserRepo.On("GetByID", mock.Anything, "user-9").Return(nil, gorm.ErrRecordNotFound)
+
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo}, newAuthConfig(), testKeyring, nil)
+
+		_, err := svc.LocalPrincipal(context.Background(), "user-9")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), response.ErrUnauthorizedType.Message)
+	})
+}
+
+func TestAuthService_Sessions(t *testing.T) {
+	cfg := newAuthConfig()
+
+	t.Run("lists sessions and flags the current one", func(t *testing.T) {
+		mockSessionRepo := mocks.NewSessionRepository(t)
+		mockSessionRepo.On("GetByUserID", mock.Anything, "user-1").Return([]models.Session{
+			{ID: "session-1", UserID: "user-1", DeviceName: "Pixel"},
+			{ID: "session-2", UserID: "user-1", DeviceName: "Laptop"},
+		}, nil)
+
+		svc := NewAuthService(&testRegistry{sr: mockSessionRepo}, cfg, testKeyring, nil)
+
+		res, err := svc.ListSessions(context.Background(), "user-1", "session-2")
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.False(t, res[0].Current)
+		assert.True(t, res[1].Current)
+		assert.Equal(t, "Laptop", res[1].DeviceName)
+	})
+
+	t.Run("revoking an unknown session", func(t *testing.T) {
+		mockSessionRepo := mocks.NewSessionRepository(t)
+		mockSessionRepo.On("Get", mock.Anything, "user-1", "session-9").Return(nil, nil)
+
+		svc := NewAuthService(&testRegistry{sr: mockSessionRepo}, cfg, testKeyring, nil)
+
+		err := svc.RevokeSession(context.Background(), "user-1", "session-9")
+		assert.Equal(t, response.NewNotFoundError("Session"), err)
+	})
+
+	t.Run("revokes one session", func(t *testing.T) {
+		mockSessionRepo := mocks.NewSessionRepository(t)
+		mockSessionRepo.On("Get", mock.Anything, "user-1", "session-1").Return(&models.Session{ID: "session-1", UserID: "user-1"}, nil)
+		mockSessionRepo.On("Delete", mock.Anything, "user-1", "session-1").Return(nil)
+		mockAuditRepo := mocks.NewAuditEventRepository(t)
+		mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.AuditEvent) bool {
+			return e.Action == models.AuditActionSessionRevoke && e.ResourceID == "session-1" && e.Before != nil && e.After == nil
+		})).Return(nil)
+
+		svc := NewAuthService(&testRegistry{sr: mockSessionRepo, aur: mockAuditRepo}, cfg, testKeyring, nil)
+
+		require.NoError(t, svc.RevokeSession(context.Background(), "user-1", "session-1"))
+	})
+
+	t.Run("revokes every session", func(t *testing.T) {
+		mockSessionRepo := mocks.NewSessionRepository(t)
+		mockSessionRepo.On("GetByUserID", mock.Anything, "user-1").Return([]models.Session{{ID: "session-1", UserID: "user-1"}}, nil)
+		mockSessionRepo.On("DeleteByUserID", mock.Anything, "user-1").Return(3, nil)
+
+		svc := NewAuthService(&testRegistry{sr: mockSessionRepo}, cfg, testKeyring, nil)
+
+		res, err := svc.RevokeAllSessions(context.Background(), "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, 3, res.Revoked)
+	})
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	response "digital-wallet/pkg/response"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BudgetService struct {
+	repo interfaces.RegistryRepository
+	cfg  *configs.Config
+}
+
+// Ensure BudgetService implements interfaces.BudgetService
+var _ interfaces.BudgetService = (*BudgetService)(nil)
+
+func NewBudgetService(repo interfaces.RegistryRepository, config *configs.Config) interfaces.BudgetService {
+	return &BudgetService{
+		repo: repo,
+		cfg:  config,
+	}
+}
+
+// CreateBudget sets a monthly budget for one spending category of the user's wallet
+func (s *BudgetService) CreateBudget(ctx context.Context, userID string, req dto.CreateBudgetRequest) (*models.Budget, error) {
+	wallet, err := getOrCreateWallet(ctx, s.repo.GetWalletRepository(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	budgetRepo := s.repo.GetBudgetRepository()
+
+	existing, err := budgetRepo.GetByWalletIDAndCategory(ctx, wallet.ID, req.Category)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving budget")
+	}
+
+	if existing != nil {
+		return nil, response.NewDuplicateEntryError("Budget for this category already exists")
+	}
+
+	budget := &models.Budget{
+		ID:       uuid.New().String(),
+		WalletID: wallet.ID,
+		Category: req.Category,
+		Amount:   req.Amount,
+	}
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetBudgetRepository().Create(ctx, budget); err != nil {
+			return nil, response.Wrap(err, "error creating budget")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionBudgetCreate, models.AuditResourceBudget, budget.ID, nil, budget)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+// ListBudgets is
+func (s *BudgetService) ListBudgets(ctx context.Context, userID string) ([]models.Budget, error) {
+	wallet, err := s.repo.GetWalletRepository().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving wallet")
+	}
+
+	if wallet == nil {
+		return []models.Budget{}, nil
+	}
+
+	budgets, err := s.repo.GetBudgetRepository().GetByWalletID(ctx, wallet.ID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving budgets")
+	}
+
+	return budgets, nil
+}
+
+// UpdateBudget changes the budget amount. Thresholds that already fired this month stay fired.
+func (s *BudgetService) UpdateBudget(ctx context.Context, userID, budgetID string, req dto.UpdateBudgetRequest) (*models.Budget, error) {
+	budget, err := s.getOwnedBudget(ctx, userID, budgetID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *budget
+	budget.Amount = req.Amount
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetBudgetRepository().Update(ctx, budget); err != nil {
+			return nil, response.Wrap(err, "error updating budget")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionBudgetUpdate, models.AuditResourceBudget, budget.ID, before, budget)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+// DeleteBudget removes a budget together with its alerts
+func (s *BudgetService) DeleteBudget(ctx context.Context, userID, budgetID string) error {
+	budget, err := s.getOwnedBudget(ctx, userID, budgetID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetBudgetRepository().Delete(ctx, budget.ID); err != nil {
+			return nil, response.Wrap(err, "error deleting budget")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionBudgetDelete, models.AuditResourceBudget, budget.ID, budget, nil)
+	})
+
+	return err
+}
+
+// GetStatus returns the spending against every budget of the user in the given month
+func (s *BudgetService) GetStatus(ctx context.Context, userID, month string) ([]dto.BudgetStatus, error) {
+	period, err := parsePeriod(month)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := s.ListBudgets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(budgets) == 0 {
+		return []dto.BudgetStatus{}, nil
+	}
+
+	budgetIDs := make([]string, len(budgets))
+	for i, budget := range budgets {
+		budgetIDs[i] = budget.ID
+	}
+
+	alerts, err := s.repo.GetBudgetAlertRepository().GetByBudgetIDs(ctx, budgetIDs, period)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving budget alerts")
+	}
+
+	alertsByBudget := make(map[string][]dto.BudgetAlertResponse)
+	for _, alert := range alerts {
+		alertsByBudget[alert.BudgetID] = append(alertsByBudget[alert.BudgetID], dto.BudgetAlertResponse{
+			Threshold:   alert.Threshold,
+			SpentAmount: alert.SpentAmount,
+			TriggeredAt: alert.CreatedAt.String(),
+		})
+	}
+
+	transactionRepo := s.repo.GetWalletTransactionRepository()
+	statuses := make([]dto.BudgetStatus, 0, len(budgets))
+
+	for _, budget := range budgets {
+		spent, err := transactionRepo.SumSpent(ctx, budget.WalletID, budget.Category, period, period.AddDate(0, 1, 0))
+		if err != nil {
+			return nil, response.Wrap(err, "error calculating spending")
+		}
+
+		budgetAlerts := alertsByBudget[budget.ID]
+		if budgetAlerts == nil {
+			budgetAlerts = []dto.BudgetAlertResponse{}
+		}
+
+		statuses = append(statuses, dto.BudgetStatus{
+			BudgetID:       budget.ID,
+			Category:       budget.Category,
+			Period:         period.Format(insightPeriodLayout),
+			Amount:         budget.Amount,
+			Spent:          spent,
+			Remaining:      math.Max(roundCents(budget.Amount-spent), 0),
+			PercentageUsed: roundCents(spent / budget.Amount * 100),
+			Status:         budget.StatusFor(spent),
+			Alerts:         budgetAlerts,
+		})
+	}
+
+	return statuses, nil
+}
+
+// OnTransactionSettled checks the budget of the category a completed debit falls in, and
+// records an alert for each threshold the month's spending has reached for the first time
+func (s *BudgetService) OnTransactionSettled(ctx context.Context, repo interfaces.RegistryRepository, transaction *models.WalletTransaction) error {
+	if transaction.Direction != models.TransactionDirectionDebit || transaction.Status != models.TransactionStatusCompleted {
+		return nil
+	}
+
+	budget, err := repo.GetBudgetRepository().GetByWalletIDAndCategory(ctx, transaction.WalletID, transaction.Category)
+	if err != nil {
+		return response.Wrap(err, "error retrieving budget")
+	}
+
+	if budget == nil {
+		return nil
+	}
+
+	createdAt := transaction.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	period := models.PeriodOf(createdAt)
+
+	// the transaction is already written in this database transaction, so the sum includes it
+	spent, err := repo.GetWalletTransactionRepository().SumSpent(ctx, budget.WalletID, budget.Category, period, period.AddDate(0, 1, 0))
+	if err != nil {
+		return response.Wrap(err, "error calculating spending")
+	}
+
+	alertRepo := repo.GetBudgetAlertRepository()
+	for _, threshold := range budget.CrossedThresholds(spent) {
+		_, err := alertRepo.CreateIfAbsent(ctx, &models.BudgetAlert{
+			ID:            uuid.New().String(),
+			BudgetID:      budget.ID,
+			Period:        period,
+			Threshold:     threshold,
+			BudgetAmount:  budget.Amount,
+			SpentAmount:   spent,
+			TransactionID: transaction.ID,
+		})
+		if err != nil {
+			return response.Wrap(err, "error recording budget alert")
+		}
+	}
+
+	return nil
+}
+
+func (s *BudgetService) getOwnedBudget(ctx context.Context, userID, budgetID string) (*models.Budget, error) {
+	wallet, err := s.repo.GetWalletRepository().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving wallet")
+	}
+
+	if wallet == nil {
+		return nil, response.NewNotFoundError("Wallet")
+	}
+
+	budget, err := s.repo.GetBudgetRepository().GetByID(ctx, budgetID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("Budget")
+		}
+		return nil, response.Wrap(err, "error retrieving budget")
+	}
+
+	if budget.WalletID != wallet.ID {
+		return nil, response.NewNotFoundError("Budget")
+	}
+
+	return budget, nil
+}
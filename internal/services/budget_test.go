@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetService_OnTransactionSettled(t *testing.T) {
+	createdAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	period := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	budget := &models.Budget{ID: "budget-1", WalletID: "wallet-1", Category: models.CategoryFoodAndDrink, Amount: 1000}
+
+	newDebit := func() *models.WalletTransaction {
+		return &models.WalletTransaction{
+			ID:        "tx-1",
+			WalletID:  "wallet-1",
+			Amount:    300,
+			Category:  models.CategoryFoodAndDrink,
+			Direction: models.TransactionDirectionDebit,
+			Status:    models.TransactionStatusCompleted,
+			CreatedAt: createdAt,
+		}
+	}
+
+	t.Run("records the warning threshold once reached", func(t *testing.T) {
+		mockBudgetRepo := mocks.NewBudgetRepository(t)
+		mockAlertRepo := mocks.NewBudgetAlertRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+
+		mockBudgetRepo.On("GetByWalletIDAndCategory", mock.Anything, "wallet-1", models.CategoryFoodAndDrink).Return(budget, nil)
+		mockTxRepo.On("SumSpent", mock.Anything, "wallet-1", models.CategoryFoodAndDrink, period, period.AddDate(0, 1, 0)).Return(850.0, nil)
+		mockAlertRepo.On("CreateIfAbsent", mock.Anything, mock.MatchedBy(func(a *models.BudgetAlert) bool {
+			return a.Threshold == models.BudgetThresholdWarning && a.Period.Equal(period) && a.SpentAmount == 850 && a.TransactionID == "tx-1"
+		})).Return(true, nil).Once()
+
+		reg := &testRegistry{br: mockBudgetRepo, bar: mockAlertRepo, tr: mockTxRepo}
+		svc := NewBudgetService(reg, &configs.Config{})
+
+		err := svc.OnTransactionSettled(context.Background(), reg, newDebit())
+		require.NoError(t, err)
+	})
+
+	t.Run("records every threshold crossed by one transaction", func(t *testing.T) {
+		mockBudgetRepo := mocks.NewBudgetRepository(t)
+		mockAlertRepo := mocks.NewBudgetAlertRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+
+		mockBudgetRepo.On("GetByWalletIDAndCategory", mock.Anything, "wallet-1", models.CategoryFoodAndDrink).Return(budget, nil)
+		mockTxRepo.On("SumSpent", mock.Anything, "wallet-1", models.CategoryFoodAndDrink, period, period.AddDate(0, 1, 0)).Return(1200.0, nil)
+		mockAlertRepo.On("CreateIfAbsent", mock.Anything, mock.MatchedBy(func(a *models.BudgetAlert) bool {
+			return a.Threshold == models.BudgetThresholdWarning
+		})).Return(false, nil).Once()
+		mockAlertRepo.On("CreateIfAbsent", mock.Anything, mock.MatchedBy(func(a *models.BudgetAlert) bool {
+			return a.Threshold == models.BudgetThresholdExceeded
+		})).Return(true, nil).Once()
+
+		reg := &testRegistry{br: mockBudgetRepo, bar: mockAlertRepo, tr: mockTxRepo}
+		svc := NewBudgetService(reg, &configs.Config{})
+
+		err := svc.OnTransactionSettled(context.Background(), reg, newDebit())
+		require.NoError(t, err)
+	})
+
+	t.Run("ignores credits", func(t *testing.T) {
+		reg := &testRegistry{}
+		svc := NewBudgetService(reg, &configs.Config{})
+
+		credit := newDebit()
+		credit.Direction = models.TransactionDirectionCredit
+
+		err := svc.OnTransactionSettled(context.Background(), reg, credit)
+		require.NoError(t, err)
+	})
+
+	t.Run("no budget for the category", func(t *testing.T) {
+		mockBudgetRepo := mocks.NewBudgetRepository(t)
+		mockBudgetRepo.On("GetByWalletIDAndCategory", mock.Anything, "wallet-1", models.CategoryFoodAndDrink).Return(nil, nil)
+
+		reg := &testRegistry{br: mockBudgetRepo}
+		svc := NewBudgetService(reg, &configs.Config{})
+
+		err := svc.OnTransactionSettled(context.Background(), reg, newDebit())
+		require.NoError(t, err)
+	})
+}
+
+func TestBudgetService_CreateBudget(t *testing.T) {
+	t.Run("rejects a second budget for the same category", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockBudgetRepo := mocks.NewBudgetRepository(t)
+
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(&models.Wallet{ID: "wallet-1"}, nil)
+		mockBudgetRepo.On("GetByWalletIDAndCategory", mock.Anything, "wallet-1", models.CategoryTransport).Return(&models.Budget{ID: "budget-1"}, nil)
+
+		svc := NewBudgetService(&testRegistry{wr: mockWalletRepo, br: mockBudgetRepo}, &configs.Config{})
+
+		_, err := svc.CreateBudget(context.Background(), "user-1", dto.CreateBudgetRequest{Category: models.CategoryTransport, Amount: 500})
+		require.Error(t, err)
+	})
+
+	t.Run("creates the budget", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockBudgetRepo := mocks.NewBudgetRepository(t)
+
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(&models.Wallet{ID: "wallet-1"}, nil)
+		mockBudgetRepo.On("GetByWalletIDAndCategory", mock.Anything, "wallet-1", models.CategoryTransport).Return(nil, nil)
+		mockBudgetRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockAuditRepo := mocks.NewAuditEventRepository(t)
+		mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.AuditEvent) bool {
+			return e.Action == models.AuditActionBudgetCreate && e.ActorID == "user-1" && e.Before == nil && e.After != nil
+		})).Return(nil)
+
+		svc := NewBudgetService(&testRegistry{wr: mockWalletRepo, br: mockBudgetRepo, aur: mockAuditRepo}, &configs.Config{})
+
+		budget, err := svc.CreateBudget(checkerContext("user-1"), "user-1", dto.CreateBudgetRequest{Category: models.CategoryTransport, Amount: 500})
+		require.NoError(t, err)
+		assert.Equal(t, "wallet-1", budget.WalletID)
+		assert.Equal(t, 500.0, budget.Amount)
+	})
+}
+
+func TestBudgetService_GetStatus(t *testing.T) {
+	mockWalletRepo := mocks.NewWalletRepository(t)
+	mockBudgetRepo := mocks.NewBudgetRepository(t)
+	mockAlertRepo := mocks.NewBudgetAlertRepository(t)
+	mockTxRepo := mocks.NewWalletTransactionRepository(t)
+
+	period := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(&models.Wallet{ID: "wallet-1"}, nil)
+	mockBudgetRepo.On("GetByWalletID", mock.Anything, "wallet-1").Return([]models.Budget{
+		{ID: "budget-1", WalletID: "wallet-1", Category: models.CategoryFoodAndDrink, Amount: 1000},
+		{ID: "budget-2", WalletID: "wallet-1", Category: models.CategoryTransport, Amount: 400},
+	}, nil)
+	mockAlertRepo.On("GetByBudgetIDs", mock.Anything, []string{"budget-1", "budget-2"}, period).Return([]models.BudgetAlert{
+		{BudgetID: "budget-1", Threshold: models.BudgetThresholdWarning, SpentAmount: 820},
+	}, nil)
+	mockTxRepo.On("SumSpent", mock.Anything, "wallet-1", models.CategoryFoodAndDrink, period, period.AddDate(0, 1, 0)).Return(900.0, nil)
+	mockTxRepo.On("SumSpent", mock.Anything, "wallet-1", models.CategoryTransport, period, period.AddDate(0, 1, 0)).Return(500.0, nil)
+
+	reg := &testRegistry{wr: mockWalletRepo, br: mockBudgetRepo, bar: mockAlertRepo, tr: mockTxRepo}
+	svc := NewBudgetService(reg, &configs.Config{})
+
+	statuses, err := svc.GetStatus(context.Background(), "user-1", "2026-10")
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+
+	assert.Equal(t, models.BudgetStatusWarning, statuses[0].Status)
+	assert.Equal(t, 100.0, statuses[0].Remaining)
+	assert.Equal(t, 90.0, statuses[0].PercentageUsed)
+	assert.Len(t, statuses[0].Alerts, 1)
+
+	assert.Equal(t, models.BudgetStatusExceeded, statuses[1].Status)
+	assert.Equal(t, 0.0, statuses[1].Remaining)
+	assert.Equal(t, 125.0, statuses[1].PercentageUsed)
+	assert.Empty(t, statuses[1].Alerts)
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"digital-wallet/configs"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Headers of a payment intent callback. The signature is the hex HMAC-SHA256 of the timestamp, a
+// dot and the body, keyed with the merchant's callback secret.
+const (
+	callbackTimestampHeader = "X-Callback-Timestamp"
+	callbackSignatureHeader = "X-Callback-Signature"
+)
+
+var errCallbackAddressBlocked = errors.New("callback address is not public")
+
+// callbackBlockedNets are the non-public ranges the net.IP predicates do not cover
+var callbackBlockedNets = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+}
+
+// newCallbackClient returns the client callbacks are sent with. Merchants choose the URL, so the
+// client only connects to public addresses. The address is checked when it is dialled, after DNS
+// resolution, so a host that resolves to an internal address later on is still refused. Proxies
+// are not used and redirects are not followed, so neither can take the request elsewhere.
+func newCallbackClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: callbackDialControl}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// callbackDialControl refuses connections to loopback, private, link-local and other non-public addresses
+func callbackDialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("%w: %s", errCallbackAddressBlocked, host)
+	}
+
+	return nil
+}
+
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+
+	for _, blocked := range callbackBlockedNets {
+		if blocked.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// signCallback returns the signature of a callback body sent at timestamp
+func signCallback(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// callbackEncryptionKey is the key merchant callback secrets are sealed with
+func callbackEncryptionKey(cfg *configs.Config) string {
+	if cfg != nil {
+		return cfg.JWT.EncryptionKey
+	}
+	return ""
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
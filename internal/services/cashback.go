@@ -0,0 +1,399 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	response "digital-wallet/pkg/response"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var errCashbackFundingNotConfigured = errors.New("cashback funding wallet is not configured")
+
+type CashbackService struct {
+	repo   interfaces.RegistryRepository
+	cfg    *configs.Config
+	ledger ledger
+}
+
+// Ensure CashbackService implements interfaces.CashbackService
+var _ interfaces.CashbackService = (*CashbackService)(nil)
+
+func NewCashbackService(repo interfaces.RegistryRepository, config *configs.Config, listeners ...interfaces.TransactionListener) interfaces.CashbackService {
+	return &CashbackService{
+		repo:   repo,
+		cfg:    config,
+		ledger: newLedger(config, listeners),
+	}
+}
+
+// CreateCampaign is
+func (s *CashbackService) CreateCampaign(ctx context.Context, req dto.CreateCashbackCampaignRequest) (*models.CashbackCampaign, error) {
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, response.NewValidationError("Campaign must end after it starts")
+	}
+
+	if req.PerUserBudget > req.TotalBudget {
+		return nil, response.NewValidationError("Per-user budget cannot exceed the total budget")
+	}
+
+	if s.fundingWalletID() == "" {
+		return nil, errCashbackFundingNotConfigured
+	}
+
+	campaign := &models.CashbackCampaign{
+		ID:            uuid.New().String(),
+		Name:          req.Name,
+		Percentage:    req.Percentage,
+		MaxCashback:   req.MaxCashback,
+		MinSpend:      req.MinSpend,
+		StartsAt:      req.StartsAt,
+		EndsAt:        req.EndsAt,
+		PerUserBudget: req.PerUserBudget,
+		TotalBudget:   req.TotalBudget,
+		HoldingDays:   req.HoldingDays,
+		IsActive:      true,
+		CreatedBy:     req.CreatedBy,
+	}
+
+	_, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetCashbackCampaignRepository().Create(ctx, campaign); err != nil {
+			return nil, response.Wrap(err, "error creating cashback campaign")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionCampaignCreate, models.AuditResourceCampaign, campaign.ID, nil, campaign)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+// ListCampaigns is
+func (s *CashbackService) ListCampaigns(ctx context.Context, limit, offset int) ([]models.CashbackCampaign, int64, error) {
+	campaigns, total, err := s.repo.GetCashbackCampaignRepository().List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, response.Wrap(err, "error retrieving cashback campaigns")
+	}
+
+	return campaigns, total, nil
+}
+
+// DeactivateCampaign stops a campaign from rewarding new payments; held rewards are still released
+func (s *CashbackService) DeactivateCampaign(ctx context.Context, id string) (*models.CashbackCampaign, error) {
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		campaignRepo := txRepo.GetCashbackCampaignRepository()
+
+		// locked so the budget in the audit event is the one the campaign stopped at
+		campaign, err := campaignRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, response.NewNotFoundError("Cashback campaign")
+			}
+			return nil, response.Wrap(err, "error retrieving cashback campaign")
+		}
+
+		if !campaign.IsActive {
+			return campaign, nil
+		}
+
+		before := *campaign
+		if _, err := campaignRepo.SetActive(ctx, id, false); err != nil {
+			return nil, response.Wrap(err, "error updating cashback campaign")
+		}
+		campaign.IsActive = false
+
+		if err := recordAudit(ctx, txRepo, models.AuditActionCampaignStop, models.AuditResourceCampaign, campaign.ID, before, campaign); err != nil {
+			return nil, err
+		}
+
+		return campaign, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*models.CashbackCampaign), nil
+}
+
+// GetCampaignReport summarizes budget consumption and rewards for a campaign
+func (s *CashbackService) GetCampaignReport(ctx context.Context, id string) (*dto.CashbackCampaignReport, error) {
+	campaign, err := s.getCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rewardRepo := s.repo.GetCashbackRewardRepository()
+
+	summaries, err := rewardRepo.SummarizeByCampaign(ctx, id)
+	if err != nil {
+		return nil, response.Wrap(err, "error summarizing cashback rewards")
+	}
+
+	users, err := rewardRepo.CountUsersByCampaign(ctx, id)
+	if err != nil {
+		return nil, response.Wrap(err, "error counting cashback users")
+	}
+
+	report := &dto.CashbackCampaignReport{
+		CampaignID:      campaign.ID,
+		Name:            campaign.Name,
+		IsActive:        campaign.IsActive,
+		TotalBudget:     campaign.TotalBudget,
+		UsedBudget:      campaign.UsedBudget,
+		RemainingBudget: campaign.RemainingBudget(),
+		UniqueUsers:     users,
+	}
+
+	for _, summary := range summaries {
+		switch summary.Status {
+		case models.CashbackRewardStatusHeld:
+			report.HeldRewards = summary.Rewards
+			report.HeldAmount = summary.Amount
+		case models.CashbackRewardStatusCredited:
+			report.CreditedRewards = summary.Rewards
+			report.CreditedAmount = summary.Amount
+		}
+	}
+
+	return report, nil
+}
+
+// OnTransactionSettled evaluates running campaigns when a payment completes. At most one
+// campaign rewards a payment: the first one, by highest percentage, with budget left for it.
+func (s *CashbackService) OnTransactionSettled(ctx context.Context, repo interfaces.RegistryRepository, transaction *models.WalletTransaction) error {
+	if transaction.Type != models.TransactionTypePayment || transaction.Status != models.TransactionStatusCompleted {
+		return nil
+	}
+
+	// campaigns reward nothing while there is no wallet to pay them from
+	if s.fundingWalletID() == "" {
+		return nil
+	}
+
+	now := time.Now()
+
+	campaigns, err := repo.GetCashbackCampaignRepository().GetRunning(ctx, now, transaction.Amount)
+	if err != nil {
+		return response.Wrap(err, "error retrieving cashback campaigns")
+	}
+
+	if len(campaigns) == 0 {
+		return nil
+	}
+
+	wallet, err := repo.GetWalletRepository().GetByID(ctx, transaction.WalletID)
+	if err != nil {
+		return response.Wrap(err, "error retrieving wallet")
+	}
+
+	for _, candidate := range campaigns {
+		rewarded, err := s.applyCampaign(ctx, repo, candidate.ID, wallet, transaction, now)
+		if err != nil {
+			return err
+		}
+
+		if rewarded {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// ReleaseHeldRewards credits up to limit rewards whose holding period has ended
+func (s *CashbackService) ReleaseHeldRewards(ctx context.Context, limit int) (int, error) {
+	rewards, err := s.repo.GetCashbackRewardRepository().GetDueHeld(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, response.Wrap(err, "error retrieving held cashback rewards")
+	}
+
+	released := 0
+	for _, candidate := range rewards {
+		result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+			reward, err := txRepo.GetCashbackRewardRepository().GetByIDForUpdate(ctx, candidate.ID)
+			if err != nil {
+				return nil, response.Wrap(err, "error retrieving cashback reward")
+			}
+
+			// another run may have released it since it was listed
+			if reward.Status != models.CashbackRewardStatusHeld {
+				return false, nil
+			}
+
+			campaign, err := txRepo.GetCashbackCampaignRepository().GetByID(ctx, reward.CampaignID)
+			if err != nil {
+				return nil, response.Wrap(err, "error retrieving cashback campaign")
+			}
+
+			if err := s.creditReward(ctx, txRepo, reward, campaign, time.Now()); err != nil {
+				return nil, err
+			}
+
+			return true, nil
+		})
+
+		// a wallet at the maximum balance of its KYC level keeps the reward held; it is paid by
+		// a later run once there is room
+		if errors.Is(err, response.ErrBalanceLimitExceeded) {
+			continue
+		}
+
+		if err != nil {
+			return released, err
+		}
+
+		if result.(bool) {
+			released++
+		}
+	}
+
+	return released, nil
+}
+
+// applyCampaign rewards the payment from one campaign. The campaign row stays locked until
+// the payment commits, so concurrent payments consume its budgets one after another.
+func (s *CashbackService) applyCampaign(ctx context.Context, repo interfaces.RegistryRepository, campaignID string, wallet *models.Wallet, transaction *models.WalletTransaction, now time.Time) (bool, error) {
+	campaignRepo := repo.GetCashbackCampaignRepository()
+	rewardRepo := repo.GetCashbackRewardRepository()
+
+	campaign, err := campaignRepo.GetByIDForUpdate(ctx, campaignID)
+	if err != nil {
+		return false, response.Wrap(err, "error locking cashback campaign")
+	}
+
+	if !campaign.IsRunning(now) || transaction.Amount < campaign.MinSpend {
+		return false, nil
+	}
+
+	amount := transaction.Amount * campaign.Percentage / 100
+	if campaign.MaxCashback > 0 {
+		amount = math.Min(amount, campaign.MaxCashback)
+	}
+	amount = math.Min(amount, campaign.RemainingBudget())
+
+	if campaign.PerUserBudget > 0 {
+		used, err := rewardRepo.SumByCampaignAndUser(ctx, campaign.ID, wallet.UserID)
+		if err != nil {
+			return false, response.Wrap(err, "error retrieving cashback usage")
+		}
+		amount = math.Min(amount, campaign.PerUserBudget-used)
+	}
+
+	// round down to whole cents so the budget is never overspent
+	amount = math.Floor(amount*100) / 100
+	if amount <= 0 {
+		return false, nil
+	}
+
+	if err := campaignRepo.AddUsedBudget(ctx, campaign.ID, amount); err != nil {
+		return false, response.Wrap(err, "error consuming cashback budget")
+	}
+
+	reward := &models.CashbackReward{
+		ID:                  uuid.New().String(),
+		CampaignID:          campaign.ID,
+		UserID:              wallet.UserID,
+		WalletID:            wallet.ID,
+		SourceTransactionID: transaction.ID,
+		Amount:              amount,
+		Status:              models.CashbackRewardStatusHeld,
+		ReleaseAt:           now.AddDate(0, 0, campaign.HoldingDays),
+	}
+
+	if err := rewardRepo.Create(ctx, reward); err != nil {
+		return false, response.Wrap(err, "error creating cashback reward")
+	}
+
+	if err := recordAudit(ctx, repo, models.AuditActionRewardGrant, models.AuditResourceReward, reward.ID, nil, reward); err != nil {
+		return false, err
+	}
+
+	if campaign.HoldingDays == 0 {
+		if err := s.creditReward(ctx, repo, reward, campaign, now); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// creditReward pays a held reward into the user's wallet. The money is taken from the cashback
+// funding wallet, the same way promos are paid from theirs, and the credit is refused when it
+// takes the wallet past the maximum balance of its owner's KYC level.
+func (s *CashbackService) creditReward(ctx context.Context, repo interfaces.RegistryRepository, reward *models.CashbackReward, campaign *models.CashbackCampaign, now time.Time) error {
+	fundingWalletID := s.fundingWalletID()
+	if fundingWalletID == "" {
+		return errCashbackFundingNotConfigured
+	}
+
+	metadata := map[string]interface{}{
+		"cashback_reward_id":    reward.ID,
+		"campaign_id":           campaign.ID,
+		"source_transaction_id": reward.SourceTransactionID,
+	}
+
+	if _, _, err := s.ledger.debit(ctx, repo, ledgerEntry{
+		WalletID:    fundingWalletID,
+		Amount:      reward.Amount,
+		Type:        models.TransactionTypeTransfer,
+		Description: fmt.Sprintf("Funding for cashback from %s", campaign.Name),
+		Metadata:    metadata,
+	}); err != nil {
+		return response.Wrap(err, "cashback funding failed")
+	}
+
+	transaction, _, err := s.ledger.credit(ctx, repo, ledgerEntry{
+		WalletID:    reward.WalletID,
+		Amount:      reward.Amount,
+		Type:        models.TransactionTypeDeposit,
+		Description: fmt.Sprintf("Cashback from %s", campaign.Name),
+		Metadata:    metadata,
+		CapBalance:  true,
+	})
+	if err != nil {
+		if errors.Is(err, response.ErrBalanceLimitExceeded) {
+			return err
+		}
+		return response.Wrap(err, "error crediting cashback")
+	}
+
+	before := *reward
+	reward.Status = models.CashbackRewardStatusCredited
+	reward.CreditedTransactionID = &transaction.ID
+	reward.CreditedAt = &now
+
+	if err := repo.GetCashbackRewardRepository().Update(ctx, reward); err != nil {
+		return response.Wrap(err, "error updating cashback reward")
+	}
+
+	return recordAudit(ctx, repo, models.AuditActionRewardCredit, models.AuditResourceReward, reward.ID, before, reward)
+}
+
+func (s *CashbackService) fundingWalletID() string {
+	if s.cfg == nil {
+		return ""
+	}
+	return s.cfg.Cashback.FundingWalletID
+}
+
+func (s *CashbackService) getCampaign(ctx context.Context, id string) (*models.CashbackCampaign, error) {
+	campaign, err := s.repo.GetCashbackCampaignRepository().GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("Cashback campaign")
+		}
+		return nil, response.Wrap(err, "error retrieving cashback campaign")
+	}
+
+	return campaign, nil
+}
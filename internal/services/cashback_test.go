@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func cashbackConfig() *configs.Config {
+	cfg := &configs.Config{}
+	cfg.Cashback.FundingWalletID = "wallet-cashback"
+	return cfg
+}
+
+func TestCashbackService_OnTransactionSettled(t *testing.T) {
+	now := time.Now()
+	wallet := &models.Wallet{ID: "wallet-1", UserID: "user-1", IsActive: true}
+	payment := &models.WalletTransaction{ID: "tx-1", WalletID: "wallet-1", Amount: 200, Type: models.TransactionTypePayment, Status: models.TransactionStatusCompleted}
+
+	newCampaign := func() *models.CashbackCampaign {
+		return &models.CashbackCampaign{
+			ID:          "campaign-1",
+			Name:        "Launch",
+			Percentage:  10,
+			StartsAt:    now.Add(-time.Hour),
+			EndsAt:      now.Add(time.Hour),
+			TotalBudget: 1000,
+			IsActive:    true,
+		}
+	}
+
+	t.Run("ignores transactions that are not payments", func(t *testing.T) {
+		svc := NewCashbackService(&testRegistry{}, &configs.Config{})
+
+		err := svc.OnTransactionSettled(context.Background(), &testRegistry{}, &models.WalletTransaction{Type: models.TransactionTypeWithdrawal, Status: models.TransactionStatusCompleted})
+		require.NoError(t, err)
+	})
+
+	t.Run("credits cashback immediately without a holding period", func(t *testing.T) {
+		campaign := newCampaign()
+		campaign.MaxCashback = 15
+
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockCampaignRepo := mocks.NewCashbackCampaignRepository(t)
+		mockRewardRepo := mocks.NewCashbackRewardRepository(t)
+
+		mockCampaignRepo.On("GetRunning", mock.Anything, mock.Anything, 200.0).Return([]models.CashbackCampaign{*campaign}, nil)
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(wallet, nil)
+		mockCampaignRepo.On("GetByIDForUpdate", mock.Anything, "campaign-1").Return(campaign, nil)
+		mockCampaignRepo.On("AddUsedBudget", mock.Anything, "campaign-1", 15.0).Return(nil)
+		mockRewardRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *models.CashbackReward) bool {
+			return r.Amount == 15 && r.SourceTransactionID == "tx-1" && r.UserID == "user-1"
+		})).Return(nil)
+		mockTxRepo.On("Create", mock.Anything, mock.MatchedBy(func(tx *models.WalletTransaction) bool {
+			return tx.Type == models.TransactionTypeTransfer && tx.WalletID == "wallet-cashback" && tx.Amount == 15
+		})).Return(nil)
+		mockWalletRepo.On("Withdraw", mock.Anything, "wallet-cashback", 15.0).Return(&models.Wallet{ID: "wallet-cashback", Balance: 985}, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.MatchedBy(func(tx *models.WalletTransaction) bool {
+			return tx.Type == models.TransactionTypeDeposit && tx.WalletID == "wallet-1" && tx.Amount == 15
+		})).Return(nil)
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-1", 15.0).Return(wallet, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Twice()
+		mockRewardRepo.On("Update", mock.Anything, mock.MatchedBy(func(r *models.CashbackReward) bool {
+			return r.Status == models.CashbackRewardStatusCredited && r.CreditedTransactionID != nil
+		})).Return(nil)
+
+		reg := withinKYCLimits(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, ccr: mockCampaignRepo, crr: mockRewardRepo})
+		svc := NewCashbackService(reg, cashbackConfig())
+
+		require.NoError(t, svc.OnTransactionSettled(context.Background(), reg, payment))
+	})
+
+	t.Run("holds cashback until the holding period ends", func(t *testing.T) {
+		campaign := newCampaign()
+		campaign.HoldingDays = 7
+
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockCampaignRepo := mocks.NewCashbackCampaignRepository(t)
+		mockRewardRepo := mocks.NewCashbackRewardRepository(t)
+
+		mockCampaignRepo.On("GetRunning", mock.Anything, mock.Anything, 200.0).Return([]models.CashbackCampaign{*campaign}, nil)
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(wallet, nil)
+		mockCampaignRepo.On("GetByIDForUpdate", mock.Anything, "campaign-1").Return(campaign, nil)
+		mockCampaignRepo.On("AddUsedBudget", mock.Anything, "campaign-1", 20.0).Return(nil)
+		mockRewardRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *models.CashbackReward) bool {
+			return r.Status == models.CashbackRewardStatusHeld && r.ReleaseAt.After(now.AddDate(0, 0, 6))
+		})).Return(nil)
+
+		reg := &testRegistry{wr: mockWalletRepo, ccr: mockCampaignRepo, crr: mockRewardRepo}
+		svc := NewCashbackService(reg, cashbackConfig())
+
+		require.NoError(t, svc.OnTransactionSettled(context.Background(), reg, payment))
+	})
+
+	t.Run("caps cashback at the remaining per-user budget", func(t *testing.T) {
+		campaign := newCampaign()
+		campaign.HoldingDays = 1
+		campaign.PerUserBudget = 50
+
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockCampaignRepo := mocks.NewCashbackCampaignRepository(t)
+		mockRewardRepo := mocks.NewCashbackRewardRepository(t)
+
+		mockCampaignRepo.On("GetRunning", mock.Anything, mock.Anything, 200.0).Return([]models.CashbackCampaign{*campaign}, nil)
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(wallet, nil)
+		mockCampaignRepo.On("GetByIDForUpdate", mock.Anything, "campaign-1").Return(campaign, nil)
+		mockRewardRepo.On("SumByCampaignAndUser", mock.Anything, "campaign-1", "user-1").Return(42.5, nil)
+		mockCampaignRepo.On("AddUsedBudget", mock.Anything, "campaign-1", 7.5).Return(nil)
+		mockRewardRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		reg := &testRegistry{wr: mockWalletRepo, ccr: mockCampaignRepo, crr: mockRewardRepo}
+		svc := NewCashbackService(reg, cashbackConfig())
+
+		require.NoError(t, svc.OnTransactionSettled(context.Background(), reg, payment))
+	})
+
+	t.Run("falls through to the next campaign when the budget ran out under lock", func(t *testing.T) {
+		exhausted := newCampaign()
+		exhausted.UsedBudget = exhausted.TotalBudget
+		next := newCampaign()
+		next.ID = "campaign-2"
+		next.Percentage = 5
+		next.HoldingDays = 1
+
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockCampaignRepo := mocks.NewCashbackCampaignRepository(t)
+		mockRewardRepo := mocks.NewCashbackRewardRepository(t)
+
+		mockCampaignRepo.On("GetRunning", mock.Anything, mock.Anything, 200.0).Return([]models.CashbackCampaign{*newCampaign(), *next}, nil)
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(wallet, nil)
+		mockCampaignRepo.On("GetByIDForUpdate", mock.Anything, "campaign-1").Return(exhausted, nil)
+		mockCampaignRepo.On("GetByIDForUpdate", mock.Anything, "campaign-2").Return(next, nil)
+		mockCampaignRepo.On("AddUsedBudget", mock.Anything, "campaign-2", 10.0).Return(nil)
+		mockRewardRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *models.CashbackReward) bool {
+			return r.CampaignID == "campaign-2"
+		})).Return(nil)
+
+		reg := &testRegistry{wr: mockWalletRepo, ccr: mockCampaignRepo, crr: mockRewardRepo}
+		svc := NewCashbackService(reg, cashbackConfig())
+
+		require.NoError(t, svc.OnTransactionSettled(context.Background(), reg, payment))
+	})
+}
+
+func TestCashbackService_CreateCampaign(t *testing.T) {
+	t.Run("rejects a window that ends before it starts", func(t *testing.T) {
+		svc := NewCashbackService(&testRegistry{}, &configs.Config{})
+
+		now := time.Now()
+		_, err := svc.CreateCampaign(context.Background(), dto.CreateCashbackCampaignRequest{
+			Name:        "Broken",
+			Percentage:  5,
+			StartsAt:    now,
+			EndsAt:      now.Add(-time.Hour),
+			TotalBudget: 100,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "end after it starts")
+	})
+}
+
+func TestCashbackService_DeactivateCampaign(t *testing.T) {
+	mockCampaignRepo := mocks.NewCashbackCampaignRepository(t)
+
+	mockAuditRepo := mocks.NewAuditEventRepository(t)
+
+	mockCampaignRepo.On("GetByIDForUpdate", mock.Anything, "campaign-1").Return(&models.CashbackCampaign{ID: "campaign-1", UsedBudget: 150, IsActive: true}, nil)
+	mockCampaignRepo.On("SetActive", mock.Anything, "campaign-1", false).Return(true, nil)
+	mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.AuditEvent) bool {
+		return e.Action == models.AuditActionCampaignStop && e.ActorID == "admin-1" && e.ResourceID == "campaign-1"
+	})).Return(nil)
+
+	svc := NewCashbackService(&testRegistry{ccr: mockCampaignRepo, aur: mockAuditRepo}, &configs.Config{})
+
+	campaign, err := svc.DeactivateCampaign(checkerContext("admin-1"), "campaign-1")
+	require.NoError(t, err)
+	assert.False(t, campaign.IsActive)
+	assert.Equal(t, 150.0, campaign.UsedBudget)
+}
+
+func TestCashbackService_ReleaseHeldRewards(t *testing.T) {
+	t.Run("a wallet at its maximum balance keeps the reward held", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockCampaignRepo := mocks.NewCashbackCampaignRepository(t)
+		mockRewardRepo := mocks.NewCashbackRewardRepository(t)
+
+		reward := models.CashbackReward{ID: "reward-1", CampaignID: "campaign-1", UserID: "user-1", WalletID: "wallet-1", Amount: 20, Status: models.CashbackRewardStatusHeld}
+		mockRewardRepo.On("GetDueHeld", mock.Anything, mock.Anything, 10).Return([]models.CashbackReward{reward}, nil)
+		mockRewardRepo.On("GetByIDForUpdate", mock.Anything, "reward-1").Return(&reward, nil)
+		mockCampaignRepo.On("GetByID", mock.Anything, "campaign-1").Return(&models.CashbackCampaign{ID: "campaign-1", Name: "Launch"}, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockWalletRepo.On("Withdraw", mock.Anything, "wallet-cashback", 20.0).Return(&models.Wallet{ID: "wallet-cashback", Balance: 980}, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-1", 20.0).Return(&models.Wallet{ID: "wallet-1", UserID: "user-1", Balance: 2000010}, nil)
+
+		cfg := newKYCConfig()
+		cfg.Cashback.FundingWalletID = "wallet-cashback"
+
+		reg := withinKYCLimits(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, ccr: mockCampaignRepo, crr: mockRewardRepo})
+		svc := NewCashbackService(reg, cfg)
+
+		released, err := svc.ReleaseHeldRewards(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, 0, released)
+		mockRewardRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
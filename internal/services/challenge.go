@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/notifier"
+	response "digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultStepUpAmountThreshold = 5000000
+	defaultStepUpNewDeviceWindow = 24 * time.Hour
+	defaultStepUpCodeExpiration  = 5 * time.Minute
+	defaultStepUpMaxAttempts     = 3
+	defaultStepUpMaxChallenges   = 5
+	defaultStepUpRateWindow      = 15 * time.Minute
+
+	challengeCodeLength = 6
+)
+
+// NotifierOTPSender sends step-up codes through a notification channel
+type NotifierOTPSender struct {
+	notifiers notifier.Registry
+	channel   string
+}
+
+// Ensure NotifierOTPSender implements interfaces.OTPSender
+var _ interfaces.OTPSender = (*NotifierOTPSender)(nil)
+
+func NewNotifierOTPSender(notifiers notifier.Registry, channel string) interfaces.OTPSender {
+	if channel == "" {
+		channel = notifier.ChannelSMS
+	}
+	return &NotifierOTPSender{notifiers: notifiers, channel: channel}
+}
+
+// SendOTP sends the code to the user's phone number, or their email address on the email channel
+func (s *NotifierOTPSender) SendOTP(ctx context.Context, user *models.User, code string) error {
+	to := user.PhoneNumber
+	if s.channel == notifier.ChannelEmail {
+		if user.Email == nil {
+			return errors.New("user has no email address")
+		}
+		to = *user.Email
+	}
+
+	return s.notifiers.Send(ctx, s.channel, notifier.Message{
+		To:      to,
+		Subject: "Transaction verification code",
+		Body:    fmt.Sprintf("Your transaction verification code is %s. Do not share it with anyone.", code),
+	})
+}
+
+// withdrawalRisks returns why a withdrawal needs a step-up challenge, or nothing when it does not
+func (s *WalletService) withdrawalRisks(ctx context.Context, req dto.WithdrawRequest, wallet *models.Wallet, newDevice bool) ([]string, error) {
+	reasons := outgoingRisks(s.cfg, req.Amount, newDevice)
+
+	if req.Beneficiary != "" {
+		known, err := s.repo.GetWalletTransactionRepository().HasCompletedWithdrawalTo(ctx, wallet.ID, req.Beneficiary)
+		if err != nil {
+			return nil, response.Wrap(err, "error checking beneficiary")
+		}
+
+		if !known {
+			reasons = append(reasons, models.ChallengeReasonNewBeneficiary)
+		}
+	}
+
+	return reasons, nil
+}
+
+// outgoingRisks returns why money leaving a wallet needs a step-up challenge: a large amount,
+// or a device the user was only recently seen on
+func outgoingRisks(cfg *configs.Config, amount float64, newDevice bool) []string {
+	var reasons []string
+
+	if amount >= stepUpAmountThreshold(cfg) {
+		reasons = append(reasons, models.ChallengeReasonLargeAmount)
+	}
+
+	if newDevice {
+		reasons = append(reasons, models.ChallengeReasonNewDevice)
+	}
+
+	return reasons
+}
+
+// issueChallenge sends a one-time code to the user and stores the challenge it answers
+func issueChallenge(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, otp interfaces.OTPSender, userID, payloadHash string, reasons []string) (*dto.StepUpChallenge, error) {
+	attemptRepo := repo.GetAttemptRepository()
+
+	issued, err := attemptRepo.Increment(ctx, stepUpRateKey(userID), stepUpRateWindow(cfg))
+	if err != nil {
+		return nil, response.Wrap(err, "error recording challenge")
+	}
+
+	if issued > stepUpMaxChallenges(cfg) {
+		return nil, response.ErrChallengeRateLimited
+	}
+
+	user, err := repo.GetUserRepository().GetByID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving user")
+	}
+
+	code, err := utils.RandomDigits(challengeCodeLength)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	challenge := &models.Challenge{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		PayloadHash: payloadHash,
+		Reasons:     reasons,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(stepUpCodeExpiration(cfg)),
+	}
+	challenge.CodeHash = challengeCodeHash(challenge.ID, code)
+
+	challengeRepo := repo.GetChallengeRepository()
+	if err := challengeRepo.Save(ctx, challenge); err != nil {
+		return nil, response.Wrap(err, "error saving challenge")
+	}
+
+	if err := otp.SendOTP(ctx, user, code); err != nil {
+		if _, err := challengeRepo.Delete(ctx, challenge.ID); err != nil {
+			return nil, response.Wrap(err, "error deleting challenge")
+		}
+		return nil, response.Wrap(err, "error sending challenge code")
+	}
+
+	return &dto.StepUpChallenge{
+		ChallengeID: challenge.ID,
+		Reasons:     reasons,
+		ExpiresAt:   challenge.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// verifyChallenge checks the code of the named challenge and uses the challenge up. A
+// challenge only confirms the debit it was issued for, and is dropped after too many wrong codes.
+func verifyChallenge(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, userID, payloadHash, challengeID, code string) error {
+	challengeRepo := repo.GetChallengeRepository()
+
+	challenge, err := challengeRepo.Get(ctx, challengeID)
+	if err != nil {
+		return response.Wrap(err, "error retrieving challenge")
+	}
+
+	if challenge == nil || challenge.UserID != userID {
+		return response.ErrChallengeExpired
+	}
+
+	if challenge.PayloadHash != payloadHash {
+		return response.ErrChallengeMismatch
+	}
+
+	attemptRepo := repo.GetAttemptRepository()
+	key := challengeAttemptKey(challenge.ID)
+	maxAttempts := stepUpMaxAttempts(cfg)
+
+	// every attempt is counted before the code is compared, so concurrent guesses each use one up
+	attempts, err := attemptRepo.Increment(ctx, key, time.Until(challenge.ExpiresAt))
+	if err != nil {
+		return response.Wrap(err, "error recording challenge attempt")
+	}
+
+	if attempts > maxAttempts {
+		return dropChallenge(ctx, challengeRepo, challenge.ID)
+	}
+
+	if !utils.CompareTokenHash(challenge.CodeHash, challenge.ID+":"+code) {
+		if attempts == maxAttempts {
+			return dropChallenge(ctx, challengeRepo, challenge.ID)
+		}
+		return response.ErrInvalidChallengeCode
+	}
+
+	deleted, err := challengeRepo.Delete(ctx, challenge.ID)
+	if err != nil {
+		return response.Wrap(err, "error deleting challenge")
+	}
+
+	// another request confirmed the same challenge first
+	if !deleted {
+		return response.ErrChallengeExpired
+	}
+
+	if err := attemptRepo.Reset(ctx, key); err != nil {
+		return response.Wrap(err, "error resetting challenge attempts")
+	}
+
+	return nil
+}
+
+// dropChallenge deletes a challenge that had too many wrong codes; it then answers like an
+// expired one
+func dropChallenge(ctx context.Context, challengeRepo interfaces.ChallengeRepository, id string) error {
+	if _, err := challengeRepo.Delete(ctx, id); err != nil {
+		return response.Wrap(err, "error deleting challenge")
+	}
+	return response.ErrChallengeExpired
+}
+
+// withdrawalPayloadHash identifies what a withdrawal does, so that a challenge cannot be
+// used to confirm a different one
+func withdrawalPayloadHash(req dto.WithdrawRequest) string {
+	return utils.HashToken(fmt.Sprintf("%s|%s|%s|%s",
+		req.UserID, strconv.FormatFloat(req.Amount, 'f', 2, 64), req.Beneficiary, req.Description))
+}
+
+// paymentPayloadHash identifies a payment of a checkout by the payer, for the amount they saw
+func paymentPayloadHash(userID, intentID string, amountDue float64) string {
+	return utils.HashToken(fmt.Sprintf("payment|%s|%s|%s",
+		userID, intentID, strconv.FormatFloat(amountDue, 'f', 2, 64)))
+}
+
+// escrowPayloadHash identifies the funding of an escrow by the buyer
+func escrowPayloadHash(buyerUserID string, req dto.CreateEscrowRequest) string {
+	return utils.HashToken(fmt.Sprintf("escrow|%s|%s|%s|%s|%s|%d",
+		buyerUserID, req.SellerUserID, strconv.FormatFloat(req.Amount, 'f', 2, 64), req.Reference, req.Description, req.AutoReleaseInHours))
+}
+
+func challengeCodeHash(challengeID, code string) string {
+	return utils.HashToken(challengeID + ":" + code)
+}
+
+func stepUpRateKey(userID string) string {
+	return "step-up:" + userID
+}
+
+func challengeAttemptKey(challengeID string) string {
+	return "challenge:" + challengeID
+}
+
+func stepUpAmountThreshold(cfg *configs.Config) float64 {
+	if cfg != nil && cfg.StepUp.AmountThreshold > 0 {
+		return cfg.StepUp.AmountThreshold
+	}
+	return defaultStepUpAmountThreshold
+}
+
+func stepUpCodeExpiration(cfg *configs.Config) time.Duration {
+	if cfg != nil && cfg.StepUp.CodeExpiration > 0 {
+		return time.Duration(cfg.StepUp.CodeExpiration) * time.Second
+	}
+	return defaultStepUpCodeExpiration
+}
+
+func stepUpMaxAttempts(cfg *configs.Config) int {
+	if cfg != nil && cfg.StepUp.MaxAttempts > 0 {
+		return cfg.StepUp.MaxAttempts
+	}
+	return defaultStepUpMaxAttempts
+}
+
+func stepUpMaxChallenges(cfg *configs.Config) int {
+	if cfg != nil && cfg.StepUp.MaxChallenges > 0 {
+		return cfg.StepUp.MaxChallenges
+	}
+	return defaultStepUpMaxChallenges
+}
+
+func stepUpRateWindow(cfg *configs.Config) time.Duration {
+	if cfg != nil && cfg.StepUp.RateWindow > 0 {
+		return time.Duration(cfg.StepUp.RateWindow) * time.Second
+	}
+	return defaultStepUpRateWindow
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/response"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newStepUpConfig() *configs.Config {
+	cfg := &configs.Config{}
+	cfg.StepUp.AmountThreshold = 1000
+	cfg.StepUp.NewDeviceWindow = 3600
+	cfg.StepUp.CodeExpiration = 300
+	cfg.StepUp.MaxAttempts = 3
+	cfg.StepUp.MaxChallenges = 5
+	cfg.StepUp.RateWindow = 900
+	return cfg
+}
+
+func newActiveWalletRepo(t *testing.T) *mocks.WalletRepository {
+	mockWalletRepo := mocks.NewWalletRepository(t)
+	mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(&models.Wallet{ID: "wallet-1", UserID: "user-1", Balance: 10000, IsActive: true}, nil)
+	return mockWalletRepo
+}
+
+func TestWalletService_WithdrawChallenge(t *testing.T) {
+	cfg := newStepUpConfig()
+	large := dto.WithdrawRequest{UserID: "user-1", Amount: 5000, Description: "rent", PIN: testPIN}
+
+	t.Run("large amount issues a challenge and sends the code", func(t *testing.T) {
+		mockChallengeRepo := mocks.NewChallengeRepository(t)
+		mockSender := mocks.NewOTPSender(t)
+
+		reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: newActiveWalletRepo(t), chr: mockChallengeRepo}, "user-1"))
+		reg.atr.(*mocks.AttemptRepository).On("Increment", mock.Anything, "step-up:user-1", 15*time.Minute).Return(1, nil)
+
+		var saved *models.Challenge
+		var sent string
+		mockChallengeRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*models.Challenge)
+		}).Return(nil)
+		mockSender.On("SendOTP", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			sent = args.String(2)
+		}).Return(nil)
+
+		svc := NewWalletService(reg, cfg, mockSender)
+
+		res, err := svc.Withdraw(context.Background(), large)
+		require.NoError(t, err)
+		assert.Equal(t, dto.WithdrawStatusChallengeRequired, res.Status)
+		assert.Equal(t, saved.ID, res.Challenge.ChallengeID)
+		assert.Equal(t, []string{models.ChallengeReasonLargeAmount}, res.Challenge.Reasons)
+		assert.Regexp(t, `^[0-9]{6}$`, sent)
+		assert.Equal(t, challengeCodeHash(saved.ID, sent), saved.CodeHash)
+		assert.Equal(t, withdrawalPayloadHash(large), saved.PayloadHash)
+	})
+
+	t.Run("too many challenges are refused", func(t *testing.T) {
+		reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: newActiveWalletRepo(t)}, "user-1"))
+		reg.atr.(*mocks.AttemptRepository).On("Increment", mock.Anything, "step-up:user-1", 15*time.Minute).Return(6, nil)
+
+		svc := NewWalletService(reg, cfg, mocks.NewOTPSender(t))
+
+		_, err := svc.Withdraw(context.Background(), large)
+		assert.Equal(t, response.ErrChallengeRateLimited, err)
+	})
+
+	t.Run("new device and new beneficiary need a challenge", func(t *testing.T) {
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockTxRepo.On("HasCompletedWithdrawalTo", mock.Anything, "wallet-1", "bank:123").Return(false, nil)
+
+		svc := &WalletService{repo: &testRegistry{tr: mockTxRepo}, cfg: cfg}
+
+		reasons, err := svc.withdrawalRisks(context.Background(), dto.WithdrawRequest{UserID: "user-1", Amount: 10, Beneficiary: "bank:123", SessionID: "session-1"}, &models.Wallet{ID: "wallet-1"}, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{models.ChallengeReasonNewDevice, models.ChallengeReasonNewBeneficiary}, reasons)
+	})
+
+	t.Run("known device and beneficiary need none", func(t *testing.T) {
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockTxRepo.On("HasCompletedWithdrawalTo", mock.Anything, "wallet-1", "bank:123").Return(true, nil)
+
+		svc := &WalletService{repo: &testRegistry{tr: mockTxRepo}, cfg: cfg}
+
+		reasons, err := svc.withdrawalRisks(context.Background(), dto.WithdrawRequest{UserID: "user-1", Amount: 10, Beneficiary: "bank:123", SessionID: "session-1"}, &models.Wallet{ID: "wallet-1"}, false)
+		require.NoError(t, err)
+		assert.Empty(t, reasons)
+	})
+}
+
+func TestVerifyChallenge(t *testing.T) {
+	cfg := newStepUpConfig()
+	req := dto.WithdrawRequest{UserID: "user-1", Amount: 5000, Description: "rent", ChallengeID: "challenge-1", ChallengeCode: "123456"}
+
+	newChallenge := func() *models.Challenge {
+		return &models.Challenge{
+			ID:          "challenge-1",
+			UserID:      "user-1",
+			PayloadHash: withdrawalPayloadHash(req),
+			CodeHash:    challengeCodeHash("challenge-1", "123456"),
+			ExpiresAt:   time.Now().Add(5 * time.Minute),
+		}
+	}
+
+	t.Run("correct code uses the challenge up", func(t *testing.T) {
+		mockChallengeRepo := mocks.NewChallengeRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mockChallengeRepo.On("Get", mock.Anything, "challenge-1").Return(newChallenge(), nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "challenge:challenge-1", mock.Anything).Return(1, nil)
+		mockChallengeRepo.On("Delete", mock.Anything, "challenge-1").Return(true, nil)
+		mockAttemptRepo.On("Reset", mock.Anything, "challenge:challenge-1").Return(nil)
+
+		reg := &testRegistry{chr: mockChallengeRepo, atr: mockAttemptRepo}
+		require.NoError(t, verifyChallenge(context.Background(), reg, cfg, req.UserID, withdrawalPayloadHash(req), req.ChallengeID, req.ChallengeCode))
+	})
+
+	t.Run("a challenge confirmed concurrently is refused", func(t *testing.T) {
+		mockChallengeRepo := mocks.NewChallengeRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mockChallengeRepo.On("Get", mock.Anything, "challenge-1").Return(newChallenge(), nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "challenge:challenge-1", mock.Anything).Return(1, nil)
+		mockChallengeRepo.On("Delete", mock.Anything, "challenge-1").Return(false, nil)
+
+		reg := &testRegistry{chr: mockChallengeRepo, atr: mockAttemptRepo}
+		assert.Equal(t, response.ErrChallengeExpired, verifyChallenge(context.Background(), reg, cfg, req.UserID, withdrawalPayloadHash(req), req.ChallengeID, req.ChallengeCode))
+	})
+
+	t.Run("a different payload is refused", func(t *testing.T) {
+		mockChallengeRepo := mocks.NewChallengeRepository(t)
+		mockChallengeRepo.On("Get", mock.Anything, "challenge-1").Return(newChallenge(), nil)
+
+		changed := req
+		changed.Amount = 9000
+
+		reg := &testRegistry{chr: mockChallengeRepo}
+		assert.Equal(t, response.ErrChallengeMismatch, verifyChallenge(context.Background(), reg, cfg, changed.UserID, withdrawalPayloadHash(changed), changed.ChallengeID, changed.ChallengeCode))
+	})
+
+	t.Run("another user's challenge is refused", func(t *testing.T) {
+		mockChallengeRepo := mocks.NewChallengeRepository(t)
+		mockChallengeRepo.On("Get", mock.Anything, "challenge-1").Return(newChallenge(), nil)
+
+		other := req
+		other.UserID = "user-2"
+
+		reg := &testRegistry{chr: mockChallengeRepo}
+		assert.Equal(t, response.ErrChallengeExpired, verifyChallenge(context.Background(), reg, cfg, other.UserID, withdrawalPayloadHash(other), other.ChallengeID, other.ChallengeCode))
+	})
+
+	t.Run("wrong code is counted and drops the challenge at the limit", func(t *testing.T) {
+		mockChallengeRepo := mocks.NewChallengeRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mockChallengeRepo.On("Get", mock.Anything, "challenge-1").Return(newChallenge(), nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "challenge:challenge-1", mock.Anything).Return(1, nil).Once()
+		mockAttemptRepo.On("Increment", mock.Anything, "challenge:challenge-1", mock.Anything).Return(3, nil).Once()
+		mockChallengeRepo.On("Delete", mock.Anything, "challenge-1").Return(true, nil)
+
+		wrong := req
+		wrong.ChallengeCode = "654321"
+
+		reg := &testRegistry{chr: mockChallengeRepo, atr: mockAttemptRepo}
+		assert.Equal(t, response.ErrInvalidChallengeCode, verifyChallenge(context.Background(), reg, cfg, wrong.UserID, withdrawalPayloadHash(wrong), wrong.ChallengeID, wrong.ChallengeCode))
+		assert.Equal(t, response.ErrChallengeExpired, verifyChallenge(context.Background(), reg, cfg, wrong.UserID, withdrawalPayloadHash(wrong), wrong.ChallengeID, wrong.ChallengeCode))
+	})
+
+	t.Run("attempts past the limit do not check the code", func(t *testing.T) {
+		mockChallengeRepo := mocks.NewChallengeRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mockChallengeRepo.On("Get", mock.Anything, "challenge-1").Return(newChallenge(), nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "challenge:challenge-1", mock.Anything).Return(4, nil)
+		mockChallengeRepo.On("Delete", mock.Anything, "challenge-1").Return(true, nil)
+
+		reg := &testRegistry{chr: mockChallengeRepo, atr: mockAttemptRepo}
+		assert.Equal(t, response.ErrChallengeExpired, verifyChallenge(context.Background(), reg, cfg, req.UserID, withdrawalPayloadHash(req), req.ChallengeID, req.ChallengeCode))
+		mockAttemptRepo.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+	})
+
+	t.Run("expired challenge", func(t *testing.T) {
+		mockChallengeRepo := mocks.NewChallengeRepository(t)
+		mockChallengeRepo.On("Get", mock.Anything, "challenge-1").Return(nil, nil)
+
+		reg := &testRegistry{chr: mockChallengeRepo}
+		assert.Equal(t, response.ErrChallengeExpired, verifyChallenge(context.Background(), reg, cfg, req.UserID, withdrawalPayloadHash(req), req.ChallengeID, req.ChallengeCode))
+	})
+}
+
+func TestPaymentIntentService_ConfirmChallenge(t *testing.T) {
+	cfg := newStepUpConfig()
+
+	t.Run("large payment issues a challenge before any money moves", func(t *testing.T) {
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+		mockChallengeRepo := mocks.NewChallengeRepository(t)
+		mockSender := mocks.NewOTPSender(t)
+
+		intent, _ := newPaymentIntentFixture()
+		intent.Amount = 5000
+		mockIntentRepo.On("GetByID", mock.Anything, "pi-1").Return(intent, nil)
+
+		reg := withPIN(t, &testRegistry{pir: mockIntentRepo, chr: mockChallengeRepo}, "user-1")
+		reg.atr.(*mocks.AttemptRepository).On("Increment", mock.Anything, "step-up:user-1", 15*time.Minute).Return(1, nil)
+
+		var saved *models.Challenge
+		mockChallengeRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*models.Challenge)
+		}).Return(nil)
+		mockSender.On("SendOTP", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		svc := NewPaymentIntentService(reg, cfg, mockSender)
+
+		res, err := svc.ConfirmIntent(context.Background(), "user-1", "pi-1", dto.ConfirmPaymentIntentRequest{PIN: testPIN})
+		require.NoError(t, err)
+		require.NotNil(t, res.Challenge)
+		assert.Equal(t, []string{models.ChallengeReasonLargeAmount}, res.Challenge.Reasons)
+		assert.Equal(t, paymentPayloadHash("user-1", "pi-1", 5000), saved.PayloadHash)
+		mockIntentRepo.AssertNotCalled(t, "GetByIDForUpdate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a withdrawal challenge cannot confirm a payment", func(t *testing.T) {
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+		mockChallengeRepo := mocks.NewChallengeRepository(t)
+
+		intent, _ := newPaymentIntentFixture()
+		intent.Amount = 5000
+		mockIntentRepo.On("GetByID", mock.Anything, "pi-1").Return(intent, nil)
+		mockChallengeRepo.On("Get", mock.Anything, "challenge-1").Return(&models.Challenge{
+			ID:          "challenge-1",
+			UserID:      "user-1",
+			PayloadHash: withdrawalPayloadHash(dto.WithdrawRequest{UserID: "user-1", Amount: 5000}),
+			ExpiresAt:   time.Now().Add(5 * time.Minute),
+		}, nil)
+
+		reg := withPIN(t, &testRegistry{pir: mockIntentRepo, chr: mockChallengeRepo}, "user-1")
+		svc := NewPaymentIntentService(reg, cfg, nil)
+
+		_, err := svc.ConfirmIntent(context.Background(), "user-1", "pi-1", dto.ConfirmPaymentIntentRequest{PIN: testPIN, ChallengeID: "challenge-1", ChallengeCode: "123456"})
+		assert.Equal(t, response.ErrChallengeMismatch, err)
+	})
+}
+
+func TestEscrowService_CreateChallenge(t *testing.T) {
+	mockWalletRepo := mocks.NewWalletRepository(t)
+	mockSessionRepo := mocks.NewSessionRepository(t)
+	mockChallengeRepo := mocks.NewChallengeRepository(t)
+	mockSender := mocks.NewOTPSender(t)
+
+	mockSessionRepo.On("Get", mock.Anything, "buyer", "session-1").Return(&models.Session{ID: "session-1", UserID: "buyer", CreatedAt: time.Now().Add(-time.Minute)}, nil)
+	mockChallengeRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	mockSender.On("SendOTP", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	reg := withPIN(t, &testRegistry{wr: mockWalletRepo, sr: mockSessionRepo, chr: mockChallengeRepo}, "buyer")
+	reg.atr.(*mocks.AttemptRepository).On("Increment", mock.Anything, "step-up:buyer", 15*time.Minute).Return(1, nil)
+
+	svc := NewEscrowService(reg, newStepUpConfig(), mockSender)
+
+	res, err := svc.CreateEscrow(context.Background(), "buyer", dto.CreateEscrowRequest{SellerUserID: "seller", Amount: 10, PIN: testPIN, SessionID: "session-1"})
+	require.NoError(t, err)
+	require.NotNil(t, res.Challenge)
+	assert.Equal(t, []string{models.ChallengeReasonNewDevice}, res.Challenge.Reasons)
+	mockWalletRepo.AssertNotCalled(t, "Withdraw", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	response "digital-wallet/pkg/response"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultDeviceNewWithdrawalLimit = 1000000
+	defaultDeviceTouchInterval      = time.Minute
+)
+
+type DeviceService struct {
+	repo      interfaces.RegistryRepository
+	cfg       *configs.Config
+	listeners []interfaces.DeviceListener
+}
+
+// Ensure DeviceService implements interfaces.DeviceService
+var _ interfaces.DeviceService = (*DeviceService)(nil)
+
+func NewDeviceService(repo interfaces.RegistryRepository, config *configs.Config, listeners ...interfaces.DeviceListener) interfaces.DeviceService {
+	return &DeviceService{
+		repo:      repo,
+		cfg:       config,
+		listeners: listeners,
+	}
+}
+
+// Record registers the device the user is calling from, or marks it as seen again
+func (s *DeviceService) Record(ctx context.Context, userID string, device dto.DeviceInfo) error {
+	return recordDevice(ctx, s.repo, s.cfg, userID, device, s.listeners)
+}
+
+// List returns the user's devices, flagging the one the request was made from
+func (s *DeviceService) List(ctx context.Context, userID, currentDeviceID string) ([]dto.DeviceResponse, error) {
+	devices, err := s.repo.GetDeviceRepository().ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving devices")
+	}
+
+	now := time.Now()
+	window := newDeviceWindow(s.cfg)
+
+	res := make([]dto.DeviceResponse, 0, len(devices))
+	for i := range devices {
+		device := &devices[i]
+		res = append(res, dto.DeviceResponse{
+			Device:    device,
+			Current:   currentDeviceID != "" && device.DeviceID == currentDeviceID,
+			Trusted:   !device.IsNew(now, window),
+			TrustedAt: device.FirstSeenAt.Add(window).Format(time.RFC3339),
+		})
+	}
+
+	return res, nil
+}
+
+// Remove forgets the device and ends the sessions started from it. Seeing the device again
+// registers it as a new device.
+func (s *DeviceService) Remove(ctx context.Context, userID, id string) (*dto.RevokeSessionsResponse, error) {
+	deviceRepo := s.repo.GetDeviceRepository()
+
+	device, err := deviceRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("Device")
+		}
+		return nil, response.Wrap(err, "error retrieving device")
+	}
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetDeviceRepository().Delete(ctx, device.ID); err != nil {
+			return nil, response.Wrap(err, "error removing device")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionDeviceRemove, models.AuditResourceDevice, device.ID, device, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sessionRepo := s.repo.GetSessionRepository()
+
+	sessions, err := sessionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving sessions")
+	}
+
+	revoked := 0
+	for _, session := range sessions {
+		if session.DeviceID != device.DeviceID {
+			continue
+		}
+		if err := sessionRepo.Delete(ctx, userID, session.ID); err != nil {
+			return nil, response.Wrap(err, "error revoking session")
+		}
+		revoked++
+	}
+
+	return &dto.RevokeSessionsResponse{Revoked: revoked}, nil
+}
+
+// recordDevice registers a device the first time the user is seen on it and tells the
+// listeners, except for the user's first device, which there is nothing to warn about. A
+// known device only has its last sighting updated, at most once per touch interval. Requests
+// without a device ID are not recorded.
+func recordDevice(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, userID string, info dto.DeviceInfo, listeners []interfaces.DeviceListener) error {
+	if info.DeviceID == "" {
+		return nil
+	}
+
+	now := time.Now()
+
+	existing, err := repo.GetDeviceRepository().GetByDeviceID(ctx, userID, info.DeviceID)
+	if err != nil {
+		return response.Wrap(err, "error retrieving device")
+	}
+
+	if existing != nil {
+		existing.UserAgent = info.UserAgent
+		existing.IPAddress = info.IPAddress
+		if err := repo.GetDeviceRepository().Touch(ctx, existing, now, now.Add(-deviceTouchInterval(cfg))); err != nil {
+			return response.Wrap(err, "error updating device")
+		}
+		return nil
+	}
+
+	device := &models.Device{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		DeviceID:    info.DeviceID,
+		DeviceName:  info.DeviceName,
+		UserAgent:   info.UserAgent,
+		IPAddress:   info.IPAddress,
+		FirstSeenAt: now,
+		LastSeenAt:  now,
+	}
+
+	_, err = repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		deviceRepo := txRepo.GetDeviceRepository()
+
+		known, err := deviceRepo.ListByUserID(ctx, userID)
+		if err != nil {
+			return nil, response.Wrap(err, "error retrieving devices")
+		}
+
+		// a concurrent request from the same device may have recorded it first
+		created, err := deviceRepo.CreateIfAbsent(ctx, device)
+		if err != nil {
+			return nil, response.Wrap(err, "error recording device")
+		}
+
+		if !created || len(known) == 0 {
+			return nil, nil
+		}
+
+		for _, listener := range listeners {
+			if err := listener.OnNewDevice(ctx, txRepo, device); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+
+	return err
+}
+
+// deviceFirstSeen returns when the user was first seen on the device of the session moving
+// money out of their wallet and whether that is recent enough for the device to count as new.
+// Sessions without a device ID count from when they started. Debits made outside the user's
+// own session, by staff or a service, have no device.
+func deviceFirstSeen(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, userID, sessionID string) (time.Time, bool, error) {
+	if sessionID == "" {
+		return time.Time{}, false, nil
+	}
+
+	session, err := repo.GetSessionRepository().Get(ctx, userID, sessionID)
+	if err != nil {
+		return time.Time{}, false, response.Wrap(err, "error retrieving session")
+	}
+
+	if session == nil {
+		return time.Time{}, false, nil
+	}
+
+	firstSeen := session.CreatedAt
+	if session.DeviceID != "" {
+		device, err := repo.GetDeviceRepository().GetByDeviceID(ctx, userID, session.DeviceID)
+		if err != nil {
+			return time.Time{}, false, response.Wrap(err, "error retrieving device")
+		}
+		if device != nil {
+			firstSeen = device.FirstSeenAt
+		}
+	}
+
+	return firstSeen, time.Since(firstSeen) < newDeviceWindow(cfg), nil
+}
+
+// checkNewDeviceLimit refuses a debit that would take what the wallet sent out since the
+// device was first seen past the new device limit. It runs while the wallet row is locked, so
+// concurrent debits are counted one after the other and the refusal rolls the debit back.
+func checkNewDeviceLimit(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, wallet *models.Wallet, amount float64, firstSeen time.Time) error {
+	withdrawn, err := repo.GetWalletTransactionRepository().SumOutgoing(ctx, wallet.ID, firstSeen)
+	if err != nil {
+		return response.Wrap(err, "error retrieving withdrawals")
+	}
+
+	if withdrawn+amount > newDeviceWithdrawalLimit(cfg) {
+		return response.ErrNewDeviceLimit
+	}
+
+	return nil
+}
+
+// newDeviceWindow is how long a device counts as new after it is first seen
+func newDeviceWindow(cfg *configs.Config) time.Duration {
+	if cfg != nil && cfg.StepUp.NewDeviceWindow > 0 {
+		return time.Duration(cfg.StepUp.NewDeviceWindow) * time.Second
+	}
+	return defaultStepUpNewDeviceWindow
+}
+
+func newDeviceWithdrawalLimit(cfg *configs.Config) float64 {
+	if cfg != nil && cfg.Device.NewWithdrawalLimit > 0 {
+		return cfg.Device.NewWithdrawalLimit
+	}
+	return defaultDeviceNewWithdrawalLimit
+}
+
+func deviceTouchInterval(cfg *configs.Config) time.Duration {
+	if cfg != nil && cfg.Device.TouchInterval > 0 {
+		return time.Duration(cfg.Device.TouchInterval) * time.Second
+	}
+	return defaultDeviceTouchInterval
+}
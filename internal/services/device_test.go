@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/notifier"
+	"digital-wallet/pkg/response"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newDeviceConfig() *configs.Config {
+	cfg := &configs.Config{}
+	cfg.StepUp.NewDeviceWindow = 86400
+	cfg.Device.NewWithdrawalLimit = 1000000
+	cfg.Device.TouchInterval = 60
+	return cfg
+}
+
+func TestDeviceService_Record(t *testing.T) {
+	phone := dto.DeviceInfo{DeviceID: "phone-1", DeviceName: "Pixel", UserAgent: "wallet-app/1.0", IPAddress: "10.0.0.1"}
+
+	t.Run("another device is announced to the listeners", func(t *testing.T) {
+		mockDeviceRepo := mocks.NewDeviceRepository(t)
+		listener := mocks.NewDeviceListener(t)
+
+		mockDeviceRepo.On("GetByDeviceID", mock.Anything, "user-1", "phone-1").Return(nil, nil)
+		mockDeviceRepo.On("ListByUserID", mock.Anything, "user-1").Return([]models.Device{{ID: "device-0", DeviceID: "laptop-1"}}, nil)
+		mockDeviceRepo.On("CreateIfAbsent", mock.Anything, mock.MatchedBy(func(d *models.Device) bool {
+			return d.UserID == "user-1" && d.DeviceID == "phone-1" && d.DeviceName == "Pixel" && !d.FirstSeenAt.IsZero()
+		})).Return(true, nil)
+		listener.On("OnNewDevice", mock.Anything, mock.Anything, mock.MatchedBy(func(d *models.Device) bool {
+			return d.DeviceID == "phone-1"
+		})).Return(nil)
+
+		svc := NewDeviceService(&testRegistry{dvr: mockDeviceRepo}, newDeviceConfig(), listener)
+
+		require.NoError(t, svc.Record(context.Background(), "user-1", phone))
+	})
+
+	t.Run("the first device is not announced", func(t *testing.T) {
+		mockDeviceRepo := mocks.NewDeviceRepository(t)
+
+		mockDeviceRepo.On("GetByDeviceID", mock.Anything, "user-1", "phone-1").Return(nil, nil)
+		mockDeviceRepo.On("ListByUserID", mock.Anything, "user-1").Return(nil, nil)
+		mockDeviceRepo.On("CreateIfAbsent", mock.Anything, mock.Anything).Return(true, nil)
+
+		// the listener mock fails the test if it is called
+		svc := NewDeviceService(&testRegistry{dvr: mockDeviceRepo}, newDeviceConfig(), mocks.NewDeviceListener(t))
+
+		require.NoError(t, svc.Record(context.Background(), "user-1", phone))
+	})
+
+	t.Run("a known device is only touched", func(t *testing.T) {
+		mockDeviceRepo := mocks.NewDeviceRepository(t)
+
+		mockDeviceRepo.On("GetByDeviceID", mock.Anything, "user-1", "phone-1").Return(&models.Device{ID: "device-1", DeviceID: "phone-1"}, nil)
+		mockDeviceRepo.On("Touch", mock.Anything, mock.MatchedBy(func(d *models.Device) bool {
+			return d.ID == "device-1" && d.IPAddress == "10.0.0.1"
+		}), mock.Anything, mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since) >= time.Minute
+		})).Return(nil)
+
+		svc := NewDeviceService(&testRegistry{dvr: mockDeviceRepo}, newDeviceConfig(), mocks.NewDeviceListener(t))
+
+		require.NoError(t, svc.Record(context.Background(), "user-1", phone))
+	})
+
+	t.Run("requests without a device ID are not recorded", func(t *testing.T) {
+		svc := NewDeviceService(&testRegistry{}, newDeviceConfig())
+
+		require.NoError(t, svc.Record(context.Background(), "user-1", dto.DeviceInfo{UserAgent: "curl/8.0"}))
+	})
+}
+
+func TestDeviceService_List(t *testing.T) {
+	mockDeviceRepo := mocks.NewDeviceRepository(t)
+	mockDeviceRepo.On("ListByUserID", mock.Anything, "user-1").Return([]models.Device{
+		{ID: "device-1", DeviceID: "phone-1", FirstSeenAt: time.Now().Add(-time.Hour)},
+		{ID: "device-2", DeviceID: "laptop-1", FirstSeenAt: time.Now().AddDate(0, -1, 0)},
+	}, nil)
+
+	svc := NewDeviceService(&testRegistry{dvr: mockDeviceRepo}, newDeviceConfig())
+
+	devices, err := svc.List(context.Background(), "user-1", "laptop-1")
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.False(t, devices[0].Trusted)
+	assert.False(t, devices[0].Current)
+	assert.True(t, devices[1].Trusted)
+	assert.True(t, devices[1].Current)
+}
+
+func TestDeviceService_Remove(t *testing.T) {
+	t.Run("revokes the sessions of the device", func(t *testing.T) {
+		mockDeviceRepo := mocks.NewDeviceRepository(t)
+		mockSessionRepo := mocks.NewSessionRepository(t)
+
+		mockDeviceRepo.On("GetByID", mock.Anything, "user-1", "device-1").Return(&models.Device{ID: "device-1", UserID: "user-1", DeviceID: "phone-1"}, nil)
+		mockDeviceRepo.On("Delete", mock.Anything, "device-1").Return(nil)
+		mockSessionRepo.On("GetByUserID", mock.Anything, "user-1").Return([]models.Session{
+			{ID: "session-1", UserID: "user-1", DeviceID: "phone-1"},
+			{ID: "session-2", UserID: "user-1", DeviceID: "laptop-1"},
+		}, nil)
+		mockSessionRepo.On("Delete", mock.Anything, "user-1", "session-1").Return(nil)
+
+		svc := NewDeviceService(&testRegistry{dvr: mockDeviceRepo, sr: mockSessionRepo}, newDeviceConfig())
+
+		res, err := svc.Remove(context.Background(), "user-1", "device-1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.Revoked)
+		mockSessionRepo.AssertNotCalled(t, "Delete", mock.Anything, "user-1", "session-2")
+	})
+
+	t.Run("devices of other users are not found", func(t *testing.T) {
+		mockDeviceRepo := mocks.NewDeviceRepository(t)
+		mockDeviceRepo.On("GetByID", mock.Anything, "user-2", "device-1").Return(nil, gorm.ErrRecordNotFound)
+
+		svc := NewDeviceService(&testRegistry{dvr: mockDeviceRepo}, newDeviceConfig())
+
+		_, err := svc.Remove(context.Background(), "user-2", "device-1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestDeviceFirstSeen(t *testing.T) {
+	t.Run("a new session on a known device is not new", func(t *testing.T) {
+		mockSessionRepo := mocks.NewSessionRepository(t)
+		mockDeviceRepo := mocks.NewDeviceRepository(t)
+
+		firstSeen := time.Now().AddDate(0, -1, 0)
+		mockSessionRepo.On("Get", mock.Anything, "user-1", "session-1").Return(&models.Session{ID: "session-1", DeviceID: "phone-1", CreatedAt: time.Now().Add(-time.Minute)}, nil)
+		mockDeviceRepo.On("GetByDeviceID", mock.Anything, "user-1", "phone-1").Return(&models.Device{DeviceID: "phone-1", FirstSeenAt: firstSeen}, nil)
+
+		reg := &testRegistry{sr: mockSessionRepo, dvr: mockDeviceRepo}
+
+		since, isNew, err := deviceFirstSeen(context.Background(), reg, newDeviceConfig(), "user-1", "session-1")
+		require.NoError(t, err)
+		assert.False(t, isNew)
+		assert.Equal(t, firstSeen, since)
+	})
+
+	t.Run("a session without a device counts from when it started", func(t *testing.T) {
+		mockSessionRepo := mocks.NewSessionRepository(t)
+		mockSessionRepo.On("Get", mock.Anything, "user-1", "session-1").Return(&models.Session{ID: "session-1", CreatedAt: time.Now().Add(-time.Minute)}, nil)
+
+		reg := &testRegistry{sr: mockSessionRepo}
+
+		_, isNew, err := deviceFirstSeen(context.Background(), reg, newDeviceConfig(), "user-1", "session-1")
+		require.NoError(t, err)
+		assert.True(t, isNew)
+	})
+
+	t.Run("debits outside the user's session have no device", func(t *testing.T) {
+		_, isNew, err := deviceFirstSeen(context.Background(), &testRegistry{}, newDeviceConfig(), "user-1", "")
+		require.NoError(t, err)
+		assert.False(t, isNew)
+	})
+}
+
+func TestCheckNewDeviceLimit(t *testing.T) {
+	firstSeen := time.Now().Add(-time.Hour)
+	wallet := &models.Wallet{ID: "wallet-1"}
+
+	mockTxRepo := mocks.NewWalletTransactionRepository(t)
+	mockTxRepo.On("SumOutgoing", mock.Anything, "wallet-1", firstSeen).Return(float64(800000), nil)
+
+	reg := &testRegistry{tr: mockTxRepo}
+
+	assert.NoError(t, checkNewDeviceLimit(context.Background(), reg, newDeviceConfig(), wallet, 200000, firstSeen))
+	assert.Equal(t, response.ErrNewDeviceLimit, checkNewDeviceLimit(context.Background(), reg, newDeviceConfig(), wallet, 200001, firstSeen))
+}
+
+func TestNotificationService_OnNewDevice(t *testing.T) {
+	mockPreferenceRepo := mocks.NewNotificationPreferenceRepository(t)
+	mockNotificationRepo := mocks.NewNotificationRepository(t)
+
+	mockPreferenceRepo.On("GetByUserID", mock.Anything, "user-1").Return(&models.NotificationPreference{
+		UserID: "user-1", Locale: "en", Email: "user@example.com", EmailEnabled: true,
+	}, nil)
+	mockNotificationRepo.On("CreateIfAbsent", mock.Anything, mock.MatchedBy(func(n *models.Notification) bool {
+		return n.Channel == notifier.ChannelEmail && n.Event == models.NotificationEventNewDevice && n.ReferenceID == "device-1" &&
+			strings.Contains(n.Body, "Device: Pixel") && strings.Contains(n.Body, "IP address: 10.0.0.1")
+	})).Return(true, nil)
+
+	reg := &testRegistry{npr: mockPreferenceRepo, nr: mockNotificationRepo}
+	svc := NewNotificationService(reg, &configs.Config{}, notifier.NewRegistry())
+
+	require.NoError(t, svc.OnNewDevice(context.Background(), reg, &models.Device{
+		ID: "device-1", UserID: "user-1", DeviceID: "phone-1", DeviceName: "Pixel", IPAddress: "10.0.0.1", FirstSeenAt: time.Now(),
+	}))
+}
+
+func TestLedger_DebitFromNewDevice(t *testing.T) {
+	mockWalletRepo := mocks.NewWalletRepository(t)
+	mockTxRepo := mocks.NewWalletTransactionRepository(t)
+	mockSessionRepo := mocks.NewSessionRepository(t)
+
+	started := time.Now().Add(-time.Minute)
+	mockSessionRepo.On("Get", mock.Anything, "user-1", "session-1").Return(&models.Session{ID: "session-1", UserID: "user-1", CreatedAt: started}, nil)
+	mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockWalletRepo.On("Withdraw", mock.Anything, "wallet-1", 300000.0).Return(&models.Wallet{ID: "wallet-1", UserID: "user-1"}, nil)
+	mockTxRepo.On("SumOutgoing", mock.Anything, "wallet-1", started).Return(float64(800000), nil)
+
+	l := newLedger(newDeviceConfig(), nil)
+
+	_, _, err := l.debit(context.Background(), &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, sr: mockSessionRepo}, ledgerEntry{
+		WalletID:      "wallet-1",
+		Amount:        300000,
+		Type:          models.TransactionTypePayment,
+		LimitOutgoing: true,
+		SessionID:     "session-1",
+	})
+	assert.Equal(t, response.ErrNewDeviceLimit, err)
+	mockTxRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
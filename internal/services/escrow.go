@@ -0,0 +1,433 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	response "digital-wallet/pkg/response"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const defaultEscrowAutoRelease = 7 * 24 * time.Hour
+
+type EscrowService struct {
+	repo   interfaces.RegistryRepository
+	cfg    *configs.Config
+	ledger ledger
+	otp    interfaces.OTPSender
+}
+
+// Ensure EscrowService implements interfaces.EscrowService
+var _ interfaces.EscrowService = (*EscrowService)(nil)
+
+func NewEscrowService(repo interfaces.RegistryRepository, config *configs.Config, otp interfaces.OTPSender, listeners ...interfaces.TransactionListener) interfaces.EscrowService {
+	return &EscrowService{
+		repo:   repo,
+		cfg:    config,
+		ledger: newLedger(config, listeners),
+		otp:    otp,
+	}
+}
+
+// CreateEscrow takes the amount from the buyer's balance and holds it until the escrow is
+// released to the seller or refunded. The debit and the escrow row commit together.
+func (s *EscrowService) CreateEscrow(ctx context.Context, buyerUserID string, req dto.CreateEscrowRequest) (*dto.EscrowResponse, error) {
+	if err := authorizeUser(ctx, s.repo, buyerUserID, auth.PermissionWalletWithdraw); err != nil {
+		return nil, err
+	}
+
+	if buyerUserID == req.SellerUserID {
+		return nil, response.NewValidationError("Buyer and seller must be different users")
+	}
+
+	if err := verifyPIN(ctx, s.repo, s.cfg, buyerUserID, req.PIN); err != nil {
+		return nil, err
+	}
+
+	if err := verifyOutgoingMFA(ctx, s.repo, s.cfg, buyerUserID, req.Amount, req.MFACode); err != nil {
+		return nil, err
+	}
+
+	// like withdrawals, large escrows and escrows funded from a new device need a one-time code
+	payloadHash := escrowPayloadHash(buyerUserID, req)
+	if req.ChallengeID != "" {
+		if err := verifyChallenge(ctx, s.repo, s.cfg, buyerUserID, payloadHash, req.ChallengeID, req.ChallengeCode); err != nil {
+			return nil, err
+		}
+	} else {
+		_, newDevice, err := deviceFirstSeen(ctx, s.repo, s.cfg, buyerUserID, req.SessionID)
+		if err != nil {
+			return nil, err
+		}
+
+		if reasons := outgoingRisks(s.cfg, req.Amount, newDevice); len(reasons) > 0 {
+			challenge, err := issueChallenge(ctx, s.repo, s.cfg, s.otp, buyerUserID, payloadHash, reasons)
+			if err != nil {
+				return nil, err
+			}
+			return &dto.EscrowResponse{Challenge: challenge}, nil
+		}
+	}
+
+	now := time.Now()
+
+	autoRelease := s.autoReleaseAfter()
+	if req.AutoReleaseInHours > 0 {
+		autoRelease = time.Duration(req.AutoReleaseInHours) * time.Hour
+	}
+
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		walletRepo := txRepo.GetWalletRepository()
+
+		buyerWallet, err := walletRepo.GetByUserID(ctx, buyerUserID)
+		if err != nil {
+			return nil, response.Wrap(err, "error retrieving wallet")
+		}
+
+		if buyerWallet == nil {
+			return nil, response.NewNotFoundError("Wallet")
+		}
+
+		if !buyerWallet.IsActive {
+			return nil, response.NewValidationError("Wallet is not active")
+		}
+
+		sellerWallet, err := getOrCreateWallet(ctx, walletRepo, req.SellerUserID)
+		if err != nil {
+			return nil, err
+		}
+
+		if sellerWallet.Currency != buyerWallet.Currency {
+			return nil, response.NewValidationError("Buyer and seller wallets use different currencies")
+		}
+
+		escrow := &models.Escrow{
+			ID:             uuid.New().String(),
+			BuyerUserID:    buyerUserID,
+			BuyerWalletID:  buyerWallet.ID,
+			SellerUserID:   req.SellerUserID,
+			SellerWalletID: sellerWallet.ID,
+			Amount:         req.Amount,
+			FeeAmount:      s.feeFor(req.Amount),
+			Currency:       buyerWallet.Currency,
+			Reference:      req.Reference,
+			Description:    req.Description,
+			Status:         models.EscrowStatusFunded,
+			AutoReleaseAt:  now.Add(autoRelease),
+		}
+
+		funding, _, err := s.ledger.debit(ctx, txRepo, ledgerEntry{
+			WalletID:      buyerWallet.ID,
+			Amount:        escrow.Amount,
+			Type:          models.TransactionTypeEscrow,
+			Description:   fmt.Sprintf("Funds held in escrow %s", escrow.ID),
+			Metadata:      escrowMetadata(escrow),
+			LimitOutgoing: true,
+			SessionID:     req.SessionID,
+		})
+		if err != nil {
+			if errors.Is(err, response.ErrWithdrawalLimitExceeded) || errors.Is(err, response.ErrNewDeviceLimit) {
+				return nil, err
+			}
+			return nil, response.Wrap(err, "escrow funding failed")
+		}
+
+		escrow.FundingTransactionID = &funding.ID
+
+		if err := txRepo.GetEscrowRepository().Create(ctx, escrow); err != nil {
+			return nil, response.Wrap(err, "error creating escrow")
+		}
+
+		if err := s.recordEvent(ctx, txRepo, nil, escrow, models.EscrowActionFund, buyerUserID, &funding.ID, ""); err != nil {
+			return nil, err
+		}
+
+		return escrow, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(ctx, result.(*models.Escrow))
+}
+
+// GetEscrow returns an escrow the user is the buyer or seller of
+func (s *EscrowService) GetEscrow(ctx context.Context, userID, id string) (*dto.EscrowResponse, error) {
+	if err := authorizeUser(ctx, s.repo, userID, auth.PermissionWalletRead); err != nil {
+		return nil, err
+	}
+
+	escrow, err := s.repo.GetEscrowRepository().GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("Escrow")
+		}
+		return nil, response.Wrap(err, "error retrieving escrow")
+	}
+
+	if escrow.BuyerUserID != userID && escrow.SellerUserID != userID {
+		return nil, response.NewNotFoundError("Escrow")
+	}
+
+	return s.toResponse(ctx, escrow)
+}
+
+// Release pays the escrow to the seller minus fees. Only the buyer can confirm delivery.
+func (s *EscrowService) Release(ctx context.Context, userID, id string, req dto.EscrowActionRequest) (*dto.EscrowResponse, error) {
+	if err := authorizeUser(ctx, s.repo, userID, auth.PermissionWalletManage); err != nil {
+		return nil, err
+	}
+
+	return s.settle(ctx, id, func(escrow *models.Escrow) error {
+		if escrow.BuyerUserID != userID {
+			return response.NewForbiddenError("Only the buyer can release this escrow")
+		}
+		return nil
+	}, models.EscrowActionRelease, userID, req.Note)
+}
+
+// Refund returns the escrow to the buyer. Only the seller can give up the funds.
+func (s *EscrowService) Refund(ctx context.Context, userID, id string, req dto.EscrowActionRequest) (*dto.EscrowResponse, error) {
+	if err := authorizeUser(ctx, s.repo, userID, auth.PermissionWalletManage); err != nil {
+		return nil, err
+	}
+
+	return s.settle(ctx, id, func(escrow *models.Escrow) error {
+		if escrow.SellerUserID != userID {
+			return response.NewForbiddenError("Only the seller can refund this escrow")
+		}
+		return nil
+	}, models.EscrowActionRefund, userID, req.Note)
+}
+
+// AutoRelease releases up to limit funded escrows whose auto-release time has passed. An escrow
+// that cannot be released, such as one that would take the seller past their balance limit, is
+// logged and left for the next run without holding up the rest of the batch.
+func (s *EscrowService) AutoRelease(ctx context.Context, limit int) (int, error) {
+	escrows, err := s.repo.GetEscrowRepository().GetDueForAutoRelease(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, response.Wrap(err, "error retrieving escrows due for release")
+	}
+
+	released := 0
+	for _, candidate := range escrows {
+		_, err := s.settle(ctx, candidate.ID, func(escrow *models.Escrow) error {
+			// the buyer or seller may have acted since it was listed
+			if escrow.Status != models.EscrowStatusFunded || time.Now().Before(escrow.AutoReleaseAt) {
+				return errEscrowSkipped
+			}
+			return nil
+		}, models.EscrowActionAutoRelease, models.EscrowActorSystem, "auto-released after timeout")
+
+		if errors.Is(err, errEscrowSkipped) {
+			continue
+		}
+
+		if err != nil {
+			slog.Error("Failed to auto-release escrow", "escrow_id", candidate.ID, "error", err)
+			continue
+		}
+
+		released++
+	}
+
+	return released, nil
+}
+
+// errEscrowSkipped tells AutoRelease that an escrow no longer needs releasing
+var errEscrowSkipped = errors.New("escrow skipped")
+
+// settle locks the escrow, lets authorize reject the caller, then moves the held funds
+// according to action and appends the status change to the history.
+func (s *EscrowService) settle(ctx context.Context, id string, authorize func(*models.Escrow) error, action, actor, note string) (*dto.EscrowResponse, error) {
+	now := time.Now()
+
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		escrow, err := txRepo.GetEscrowRepository().GetByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, response.NewNotFoundError("Escrow")
+			}
+			return nil, response.Wrap(err, "error retrieving escrow")
+		}
+
+		if err := authorize(escrow); err != nil {
+			return nil, err
+		}
+
+		target := models.EscrowStatusReleased
+		if action == models.EscrowActionRefund {
+			target = models.EscrowStatusRefunded
+		}
+
+		if !escrow.CanTransitionTo(target) {
+			return nil, response.NewValidationError(fmt.Sprintf("Escrow cannot be moved to %s in status %s", target, escrow.Status))
+		}
+
+		before := *escrow
+
+		var transactionID *string
+		if target == models.EscrowStatusReleased {
+			transactionID, err = s.releaseFunds(ctx, txRepo, escrow)
+			escrow.ReleasedAt = &now
+		} else {
+			transactionID, err = s.refundFunds(ctx, txRepo, escrow)
+			escrow.RefundedAt = &now
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		escrow.Status = target
+		if err := txRepo.GetEscrowRepository().Update(ctx, escrow); err != nil {
+			return nil, response.Wrap(err, "error updating escrow")
+		}
+
+		if err := s.recordEvent(ctx, txRepo, &before, escrow, action, actor, transactionID, note); err != nil {
+			return nil, err
+		}
+
+		return escrow, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(ctx, result.(*models.Escrow))
+}
+
+func (s *EscrowService) releaseFunds(ctx context.Context, repo interfaces.RegistryRepository, escrow *models.Escrow) (*string, error) {
+	release, _, err := s.ledger.credit(ctx, repo, ledgerEntry{
+		WalletID:    escrow.SellerWalletID,
+		Amount:      escrow.SellerAmount(),
+		Type:        models.TransactionTypeEscrow,
+		Description: fmt.Sprintf("Release of escrow %s", escrow.ID),
+		Metadata:    escrowMetadata(escrow),
+		CapBalance:  true,
+	})
+	if err != nil {
+		if errors.Is(err, response.ErrBalanceLimitExceeded) {
+			return nil, err
+		}
+		return nil, response.Wrap(err, "escrow release failed")
+	}
+
+	escrow.ReleaseTransactionID = &release.ID
+
+	if escrow.FeeAmount > 0 {
+		fee, _, err := s.ledger.credit(ctx, repo, ledgerEntry{
+			WalletID:    s.cfg.Escrow.FeeWalletID,
+			Amount:      escrow.FeeAmount,
+			Type:        models.TransactionTypeFee,
+			Description: fmt.Sprintf("Fee for escrow %s", escrow.ID),
+			Metadata:    escrowMetadata(escrow),
+		})
+		if err != nil {
+			return nil, response.Wrap(err, "escrow fee collection failed")
+		}
+
+		escrow.FeeTransactionID = &fee.ID
+	}
+
+	return &release.ID, nil
+}
+
+func (s *EscrowService) refundFunds(ctx context.Context, repo interfaces.RegistryRepository, escrow *models.Escrow) (*string, error) {
+	refund, _, err := s.ledger.credit(ctx, repo, ledgerEntry{
+		WalletID:    escrow.BuyerWalletID,
+		Amount:      escrow.Amount,
+		Type:        models.TransactionTypeRefund,
+		Description: fmt.Sprintf("Refund of escrow %s", escrow.ID),
+		Metadata:    escrowMetadata(escrow),
+	})
+	if err != nil {
+		return nil, response.Wrap(err, "escrow refund failed")
+	}
+
+	escrow.RefundTransactionID = &refund.ID
+
+	return &refund.ID, nil
+}
+
+// recordEvent appends a status change to the escrow history and records it in the audit log.
+// before is the escrow ahead of the change, nil when it was just created.
+func (s *EscrowService) recordEvent(ctx context.Context, repo interfaces.RegistryRepository, before, escrow *models.Escrow, action, actor string, transactionID *string, note string) error {
+	from := ""
+	var snapshot interface{}
+	if before != nil {
+		from = before.Status
+		snapshot = before
+	}
+
+	event := &models.EscrowEvent{
+		ID:            uuid.New().String(),
+		EscrowID:      escrow.ID,
+		Action:        action,
+		FromStatus:    from,
+		ToStatus:      escrow.Status,
+		Actor:         actor,
+		TransactionID: transactionID,
+		Note:          note,
+	}
+
+	if err := repo.GetEscrowEventRepository().Create(ctx, event); err != nil {
+		return response.Wrap(err, "error recording escrow history")
+	}
+
+	return recordAudit(ctx, repo, escrowAuditActions[action], models.AuditResourceEscrow, escrow.ID, snapshot, escrow)
+}
+
+// escrowAuditActions maps the actions of the escrow history to the audit log
+var escrowAuditActions = map[string]string{
+	models.EscrowActionFund:        models.AuditActionEscrowFund,
+	models.EscrowActionRelease:     models.AuditActionEscrowRelease,
+	models.EscrowActionAutoRelease: models.AuditActionEscrowAutoRel,
+	models.EscrowActionRefund:      models.AuditActionEscrowRefund,
+}
+
+func (s *EscrowService) toResponse(ctx context.Context, escrow *models.Escrow) (*dto.EscrowResponse, error) {
+	events, err := s.repo.GetEscrowEventRepository().GetByEscrowID(ctx, escrow.ID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving escrow history")
+	}
+
+	return &dto.EscrowResponse{Escrow: escrow, History: events}, nil
+}
+
+// feeFor returns the platform fee for an escrow amount, rounded down to whole cents.
+// Fees are only charged when there is a wallet configured to collect them.
+func (s *EscrowService) feeFor(amount float64) float64 {
+	if s.cfg == nil || s.cfg.Escrow.FeeWalletID == "" || s.cfg.Escrow.FeePercentage <= 0 {
+		return 0
+	}
+	return math.Floor(amount*s.cfg.Escrow.FeePercentage) / 100
+}
+
+func (s *EscrowService) autoReleaseAfter() time.Duration {
+	if s.cfg != nil && s.cfg.Escrow.AutoReleaseHours > 0 {
+		return time.Duration(s.cfg.Escrow.AutoReleaseHours) * time.Hour
+	}
+	return defaultEscrowAutoRelease
+}
+
+func escrowMetadata(escrow *models.Escrow) map[string]interface{} {
+	return map[string]interface{}{
+		"escrow_id":      escrow.ID,
+		"buyer_user_id":  escrow.BuyerUserID,
+		"seller_user_id": escrow.SellerUserID,
+		"reference":      escrow.Reference,
+	}
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/response"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newEscrowFixture() *models.Escrow {
+	return &models.Escrow{
+		ID:             "escrow-1",
+		BuyerUserID:    "buyer",
+		BuyerWalletID:  "wallet-buyer",
+		SellerUserID:   "seller",
+		SellerWalletID: "wallet-seller",
+		Amount:         1000,
+		FeeAmount:      25,
+		Status:         models.EscrowStatusFunded,
+		AutoReleaseAt:  time.Now().Add(time.Hour),
+	}
+}
+
+func TestEscrowService_CreateEscrow(t *testing.T) {
+	t.Run("locks buyer funds and records the funding", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockEscrowRepo := mocks.NewEscrowRepository(t)
+		mockEventRepo := mocks.NewEscrowEventRepository(t)
+
+		mockWalletRepo.On("GetByUserID", mock.Anything, "buyer").Return(&models.Wallet{ID: "wallet-buyer", Currency: "IDR", IsActive: true}, nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "seller").Return(&models.Wallet{ID: "wallet-seller", Currency: "IDR", IsActive: true}, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.MatchedBy(func(tx *models.WalletTransaction) bool {
+			return tx.Type == models.TransactionTypeEscrow && tx.Amount == 1000
+		})).Return(nil)
+		mockWalletRepo.On("Withdraw", mock.Anything, "wallet-buyer", 1000.0).Return(&models.Wallet{ID: "wallet-buyer"}, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		mockEscrowRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.Escrow) bool {
+			return e.FeeAmount == 25 && e.FundingTransactionID != nil && e.Status == models.EscrowStatusFunded
+		})).Return(nil)
+		mockEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.EscrowEvent) bool {
+			return e.Action == models.EscrowActionFund && e.ToStatus == models.EscrowStatusFunded && e.TransactionID != nil
+		})).Return(nil)
+		mockEventRepo.On("GetByEscrowID", mock.Anything, mock.Anything).Return([]models.EscrowEvent{{Action: models.EscrowActionFund}}, nil)
+
+		cfg := &configs.Config{}
+		cfg.Escrow.FeePercentage = 2.5
+		cfg.Escrow.FeeWalletID = "wallet-fees"
+
+		reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, er: mockEscrowRepo, eer: mockEventRepo}, "buyer"))
+		svc := NewEscrowService(reg, cfg, nil)
+
+		res, err := svc.CreateEscrow(context.Background(), "buyer", dto.CreateEscrowRequest{SellerUserID: "seller", Amount: 1000, PIN: testPIN})
+		require.NoError(t, err)
+		assert.Equal(t, models.EscrowStatusFunded, res.Status)
+		assert.Len(t, res.History, 1)
+	})
+
+	t.Run("escrow funding counts towards the daily withdrawal limit", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+
+		mockWalletRepo.On("GetByUserID", mock.Anything, "buyer").Return(&models.Wallet{ID: "wallet-buyer", UserID: "buyer", Currency: "IDR", IsActive: true}, nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "seller").Return(&models.Wallet{ID: "wallet-seller", Currency: "IDR", IsActive: true}, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockWalletRepo.On("Withdraw", mock.Anything, "wallet-buyer", 1000.0).Return(&models.Wallet{ID: "wallet-buyer", UserID: "buyer"}, nil)
+		mockTxRepo.On("SumOutgoing", mock.Anything, "wallet-buyer", mock.Anything).Return(float64(999500), nil)
+
+		reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}, "buyer"))
+		svc := NewEscrowService(reg, newKYCConfig(), nil)
+
+		_, err := svc.CreateEscrow(context.Background(), "buyer", dto.CreateEscrowRequest{SellerUserID: "seller", Amount: 1000, PIN: testPIN})
+		assert.Equal(t, response.ErrWithdrawalLimitExceeded, err)
+	})
+
+	t.Run("funding above the two-factor threshold needs the code", func(t *testing.T) {
+		mockMFARepo := mocks.NewMFARepository(t)
+		mockWalletRepo := mocks.NewWalletRepository(t)
+
+		mfa, _ := newTestMFA(t)
+		threshold := 500.0
+		mfa.WithdrawalThreshold = &threshold
+		mockMFARepo.On("GetByUserID", mock.Anything, "buyer").Return(mfa, nil)
+
+		reg := withPIN(t, &testRegistry{wr: mockWalletRepo, mfr: mockMFARepo}, "buyer")
+		svc := NewEscrowService(reg, newMFAConfig(), nil)
+
+		_, err := svc.CreateEscrow(context.Background(), "buyer", dto.CreateEscrowRequest{SellerUserID: "seller", Amount: 1000, PIN: testPIN})
+		assert.Equal(t, response.ErrMFARequired, err)
+		mockWalletRepo.AssertNotCalled(t, "Withdraw", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("insufficient buyer balance", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+
+		mockWalletRepo.On("GetByUserID", mock.Anything, "buyer").Return(&models.Wallet{ID: "wallet-buyer", Currency: "IDR", IsActive: true}, nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "seller").Return(&models.Wallet{ID: "wallet-seller", Currency: "IDR", IsActive: true}, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockWalletRepo.On("Withdraw", mock.Anything, "wallet-buyer", 1000.0).Return(nil, assert.AnError)
+
+		reg := withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}, "buyer")
+		svc := NewEscrowService(reg, &configs.Config{}, nil)
+
+		_, err := svc.CreateEscrow(context.Background(), "buyer", dto.CreateEscrowRequest{SellerUserID: "seller", Amount: 1000, PIN: testPIN})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "escrow funding failed")
+	})
+}
+
+func TestEscrowService_GetEscrow(t *testing.T) {
+	t.Run("only the buyer and seller can see the escrow", func(t *testing.T) {
+		mockEscrowRepo := mocks.NewEscrowRepository(t)
+		mockEscrowRepo.On("GetByID", mock.Anything, "escrow-1").Return(newEscrowFixture(), nil)
+
+		svc := NewEscrowService(&testRegistry{er: mockEscrowRepo}, &configs.Config{}, nil)
+
+		_, err := svc.GetEscrow(context.Background(), "stranger", "escrow-1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestEscrowService_Release(t *testing.T) {
+	t.Run("buyer releases to the seller minus fees", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockEscrowRepo := mocks.NewEscrowRepository(t)
+		mockEventRepo := mocks.NewEscrowEventRepository(t)
+
+		mockEscrowRepo.On("GetByIDForUpdate", mock.Anything, "escrow-1").Return(newEscrowFixture(), nil)
+		mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Twice()
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-seller", 975.0).Return(&models.Wallet{}, nil)
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-fees", 25.0).Return(&models.Wallet{}, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Twice()
+		mockEscrowRepo.On("Update", mock.Anything, mock.MatchedBy(func(e *models.Escrow) bool {
+			return e.Status == models.EscrowStatusReleased && e.ReleaseTransactionID != nil && e.FeeTransactionID != nil
+		})).Return(nil)
+		mockEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.EscrowEvent) bool {
+			return e.FromStatus == models.EscrowStatusFunded && e.ToStatus == models.EscrowStatusReleased && e.Actor == "buyer"
+		})).Return(nil)
+		mockEventRepo.On("GetByEscrowID", mock.Anything, "escrow-1").Return([]models.EscrowEvent{}, nil)
+
+		cfg := &configs.Config{}
+		cfg.Escrow.FeeWalletID = "wallet-fees"
+
+		reg := withinKYCLimits(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, er: mockEscrowRepo, eer: mockEventRepo})
+		svc := NewEscrowService(reg, cfg, nil)
+
+		res, err := svc.Release(context.Background(), "buyer", "escrow-1", dto.EscrowActionRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, models.EscrowStatusReleased, res.Status)
+	})
+
+	t.Run("seller cannot release", func(t *testing.T) {
+		mockEscrowRepo := mocks.NewEscrowRepository(t)
+		mockEscrowRepo.On("GetByIDForUpdate", mock.Anything, "escrow-1").Return(newEscrowFixture(), nil)
+
+		svc := NewEscrowService(&testRegistry{er: mockEscrowRepo}, &configs.Config{}, nil)
+
+		_, err := svc.Release(context.Background(), "seller", "escrow-1", dto.EscrowActionRequest{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Only the buyer")
+	})
+
+	t.Run("refunded escrow cannot be released", func(t *testing.T) {
+		escrow := newEscrowFixture()
+		escrow.Status = models.EscrowStatusRefunded
+
+		mockEscrowRepo := mocks.NewEscrowRepository(t)
+		mockEscrowRepo.On("GetByIDForUpdate", mock.Anything, "escrow-1").Return(escrow, nil)
+
+		svc := NewEscrowService(&testRegistry{er: mockEscrowRepo}, &configs.Config{}, nil)
+
+		_, err := svc.Release(context.Background(), "buyer", "escrow-1", dto.EscrowActionRequest{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be moved")
+	})
+}
+
+func TestEscrowService_Refund(t *testing.T) {
+	t.Run("seller refunds the full amount to the buyer", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockEscrowRepo := mocks.NewEscrowRepository(t)
+		mockEventRepo := mocks.NewEscrowEventRepository(t)
+
+		mockEscrowRepo.On("GetByIDForUpdate", mock.Anything, "escrow-1").Return(newEscrowFixture(), nil)
+		mockTxRepo.On("Create", mock.Anything, mock.MatchedBy(func(tx *models.WalletTransaction) bool {
+			return tx.Type == models.TransactionTypeRefund
+		})).Return(nil)
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-buyer", 1000.0).Return(&models.Wallet{}, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		mockEscrowRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		mockEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.EscrowEvent) bool {
+			return e.Action == models.EscrowActionRefund && e.ToStatus == models.EscrowStatusRefunded
+		})).Return(nil)
+		mockEventRepo.On("GetByEscrowID", mock.Anything, "escrow-1").Return([]models.EscrowEvent{}, nil)
+
+		reg := withinKYCLimits(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, er: mockEscrowRepo, eer: mockEventRepo})
+		svc := NewEscrowService(reg, &configs.Config{}, nil)
+
+		res, err := svc.Refund(context.Background(), "seller", "escrow-1", dto.EscrowActionRequest{Note: "out of stock"})
+		require.NoError(t, err)
+		assert.Equal(t, models.EscrowStatusRefunded, res.Status)
+	})
+}
+
+func TestEscrowService_AutoRelease(t *testing.T) {
+	t.Run("skips escrows settled since they were listed", func(t *testing.T) {
+		due := newEscrowFixture()
+		due.AutoReleaseAt = time.Now().Add(-time.Minute)
+		settled := newEscrowFixture()
+		settled.Status = models.EscrowStatusRefunded
+
+		mockEscrowRepo := mocks.NewEscrowRepository(t)
+		mockEscrowRepo.On("GetDueForAutoRelease", mock.Anything, mock.Anything, 10).Return([]models.Escrow{*due}, nil)
+		mockEscrowRepo.On("GetByIDForUpdate", mock.Anything, "escrow-1").Return(settled, nil)
+
+		svc := NewEscrowService(&testRegistry{er: mockEscrowRepo}, &configs.Config{}, nil)
+
+		n, err := svc.AutoRelease(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("a failed release does not stop the batch", func(t *testing.T) {
+		first := newEscrowFixture()
+		first.AutoReleaseAt = time.Now().Add(-time.Minute)
+		first.FeeAmount = 0
+		second := newEscrowFixture()
+		second.ID = "escrow-2"
+		second.SellerWalletID = "wallet-seller-2"
+		second.AutoReleaseAt = time.Now().Add(-time.Minute)
+		second.FeeAmount = 0
+
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockEscrowRepo := mocks.NewEscrowRepository(t)
+		mockEventRepo := mocks.NewEscrowEventRepository(t)
+
+		mockEscrowRepo.On("GetDueForAutoRelease", mock.Anything, mock.Anything, 10).Return([]models.Escrow{*first, *second}, nil)
+		mockEscrowRepo.On("GetByIDForUpdate", mock.Anything, "escrow-1").Return(first, nil)
+		mockEscrowRepo.On("GetByIDForUpdate", mock.Anything, "escrow-2").Return(second, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-seller", 1000.0).Return(nil, errors.New("deadlock"))
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-seller-2", 1000.0).Return(&models.Wallet{ID: "wallet-seller-2", Balance: 1000}, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		mockEscrowRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		mockEventRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockEventRepo.On("GetByEscrowID", mock.Anything, "escrow-2").Return(nil, nil)
+
+		reg := withinKYCLimits(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, er: mockEscrowRepo, eer: mockEventRepo})
+		svc := NewEscrowService(reg, &configs.Config{}, nil)
+
+		n, err := svc.AutoRelease(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+}
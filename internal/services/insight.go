@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	response "digital-wallet/pkg/response"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// insightPeriodLayout formats summary periods in requests and responses
+	insightPeriodLayout = "2006-01"
+	maxInsightMonths    = 12
+
+	// summaryLag keeps the summary job behind transactions that may still be uncommitted,
+	// since a transaction can commit after one created later than it
+	summaryLag = 5 * time.Minute
+)
+
+type InsightService struct {
+	repo interfaces.RegistryRepository
+	cfg  *configs.Config
+}
+
+// Ensure InsightService implements interfaces.InsightService
+var _ interfaces.InsightService = (*InsightService)(nil)
+
+func NewInsightService(repo interfaces.RegistryRepository, config *configs.Config) interfaces.InsightService {
+	return &InsightService{
+		repo: repo,
+		cfg:  config,
+	}
+}
+
+// GetInsights returns category totals for the given number of months up to and including
+// month, each compared with the month before it. Totals come from the summary table only.
+func (s *InsightService) GetInsights(ctx context.Context, userID, month string, months int) (*dto.InsightsResponse, error) {
+	if months < 1 || months > maxInsightMonths {
+		return nil, response.NewValidationError("Months must be between 1 and 12")
+	}
+
+	last, err := parsePeriod(month)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.repo.GetWalletRepository().GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, response.Wrap(err, "error retrieving wallet")
+	}
+
+	if wallet == nil {
+		return nil, response.NewNotFoundError("Wallet")
+	}
+
+	// one extra month so the first month shown has something to compare with
+	first := last.AddDate(0, -months, 0)
+
+	summaries, err := s.repo.GetTransactionSummaryRepository().GetByWalletID(ctx, wallet.ID, first, last)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving transaction summaries")
+	}
+
+	byPeriod := make(map[string][]models.TransactionSummary)
+	for _, summary := range summaries {
+		key := summary.Period.Format(insightPeriodLayout)
+		byPeriod[key] = append(byPeriod[key], summary)
+	}
+
+	res := &dto.InsightsResponse{
+		WalletID: wallet.ID,
+		Currency: wallet.Currency,
+		Months:   make([]dto.MonthlyInsight, 0, months),
+	}
+
+	for period := first.AddDate(0, 1, 0); !period.After(last); period = period.AddDate(0, 1, 0) {
+		current := byPeriod[period.Format(insightPeriodLayout)]
+		previous := byPeriod[period.AddDate(0, -1, 0).Format(insightPeriodLayout)]
+		res.Months = append(res.Months, compareMonths(period, current, previous))
+	}
+
+	return res, nil
+}
+
+// SummarizeTransactions folds transactions created since the watermark into the monthly
+// summaries, batch by batch, and returns how many transactions it read
+func (s *InsightService) SummarizeTransactions(ctx context.Context, batchSize int) (int, error) {
+	processed := 0
+
+	for {
+		result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+			summaryRepo := txRepo.GetTransactionSummaryRepository()
+
+			watermark, err := summaryRepo.GetWatermarkForUpdate(ctx, models.TransactionSummaryWatermark)
+			if err != nil {
+				return nil, response.Wrap(err, "error retrieving summary watermark")
+			}
+
+			transactions, err := txRepo.GetWalletTransactionRepository().GetCreatedAfter(ctx,
+				watermark.LastCreatedAt, watermark.LastTransactionID, time.Now().Add(-summaryLag), batchSize)
+			if err != nil {
+				return nil, response.Wrap(err, "error retrieving transactions")
+			}
+
+			if len(transactions) == 0 {
+				return 0, nil
+			}
+
+			if err := summaryRepo.Accumulate(ctx, summarizeTransactions(transactions)); err != nil {
+				return nil, response.Wrap(err, "error updating transaction summaries")
+			}
+
+			lastTransaction := transactions[len(transactions)-1]
+			watermark.LastCreatedAt = lastTransaction.CreatedAt
+			watermark.LastTransactionID = lastTransaction.ID
+			watermark.ProcessedCount += int64(len(transactions))
+
+			if err := summaryRepo.SaveWatermark(ctx, watermark); err != nil {
+				return nil, response.Wrap(err, "error saving summary watermark")
+			}
+
+			return len(transactions), nil
+		})
+
+		if err != nil {
+			return processed, err
+		}
+
+		n := result.(int)
+		processed += n
+
+		if n < batchSize {
+			return processed, nil
+		}
+	}
+}
+
+// summarizeTransactions totals completed transactions per wallet, month, category and direction.
+// Failed and pending transactions moved no money and are skipped.
+func summarizeTransactions(transactions []models.WalletTransaction) []models.TransactionSummary {
+	type key struct {
+		walletID  string
+		period    time.Time
+		category  string
+		direction string
+	}
+
+	totals := make(map[key]*models.TransactionSummary)
+	var order []key
+
+	for _, transaction := range transactions {
+		if transaction.Status != models.TransactionStatusCompleted {
+			continue
+		}
+
+		category := transaction.Category
+		if category == "" {
+			category = models.CategoryOther
+		}
+
+		direction := transaction.Direction
+		if direction == "" {
+			direction = models.DefaultDirection(transaction.Type)
+		}
+
+		k := key{transaction.WalletID, models.PeriodOf(transaction.CreatedAt), category, direction}
+		summary, ok := totals[k]
+		if !ok {
+			summary = &models.TransactionSummary{
+				WalletID:  k.walletID,
+				Period:    k.period,
+				Category:  k.category,
+				Direction: k.direction,
+			}
+			totals[k] = summary
+			order = append(order, k)
+		}
+
+		summary.TotalAmount += transaction.Amount
+		summary.TransactionCount++
+	}
+
+	summaries := make([]models.TransactionSummary, 0, len(order))
+	for _, k := range order {
+		summaries = append(summaries, *totals[k])
+	}
+
+	return summaries
+}
+
+// compareMonths builds the insight for one month, listing every category seen in either month
+func compareMonths(period time.Time, current, previous []models.TransactionSummary) dto.MonthlyInsight {
+	type key struct {
+		category  string
+		direction string
+	}
+
+	categories := make(map[key]*dto.CategoryInsight)
+	get := func(summary models.TransactionSummary) *dto.CategoryInsight {
+		k := key{summary.Category, summary.Direction}
+		if categories[k] == nil {
+			categories[k] = &dto.CategoryInsight{Category: summary.Category, Direction: summary.Direction}
+		}
+		return categories[k]
+	}
+
+	insight := dto.MonthlyInsight{Period: period.Format(insightPeriodLayout)}
+
+	for _, summary := range current {
+		category := get(summary)
+		category.Total += summary.TotalAmount
+		category.Count += summary.TransactionCount
+
+		if summary.Direction == models.TransactionDirectionDebit {
+			insight.TotalSpent += summary.TotalAmount
+		} else {
+			insight.TotalReceived += summary.TotalAmount
+		}
+	}
+
+	for _, summary := range previous {
+		get(summary).PreviousTotal += summary.TotalAmount
+
+		if summary.Direction == models.TransactionDirectionDebit {
+			insight.PreviousTotalSpent += summary.TotalAmount
+		}
+	}
+
+	insight.SpentChange = roundCents(insight.TotalSpent - insight.PreviousTotalSpent)
+	insight.SpentChangePercentage = changePercentage(insight.TotalSpent, insight.PreviousTotalSpent)
+
+	insight.Categories = make([]dto.CategoryInsight, 0, len(categories))
+	for _, category := range categories {
+		category.Change = roundCents(category.Total - category.PreviousTotal)
+		category.ChangePercentage = changePercentage(category.Total, category.PreviousTotal)
+		insight.Categories = append(insight.Categories, *category)
+	}
+
+	sort.Slice(insight.Categories, func(i, j int) bool {
+		a, b := insight.Categories[i], insight.Categories[j]
+		if a.Direction != b.Direction {
+			return a.Direction == models.TransactionDirectionDebit
+		}
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return a.Category < b.Category
+	})
+
+	return insight
+}
+
+// parsePeriod reads a YYYY-MM month, defaulting to the current month when it is empty
+func parsePeriod(month string) (time.Time, error) {
+	if month == "" {
+		return models.PeriodOf(time.Now()), nil
+	}
+
+	parsed, err := time.Parse(insightPeriodLayout, month)
+	if err != nil {
+		return time.Time{}, response.NewValidationError("Month must be formatted as YYYY-MM")
+	}
+
+	return models.PeriodOf(parsed), nil
+}
+
+// changePercentage is nil when there is nothing to compare against
+func changePercentage(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := roundCents((current - previous) / previous * 100)
+	return &change
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
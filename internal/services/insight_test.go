@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsightService_SummarizeTransactions(t *testing.T) {
+	t.Run("aggregates completed transactions and advances the watermark", func(t *testing.T) {
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockSummaryRepo := mocks.NewTransactionSummaryRepository(t)
+
+		start := time.Date(2026, 9, 30, 23, 0, 0, 0, time.UTC)
+		watermark := &models.SummaryWatermark{Name: models.TransactionSummaryWatermark, LastCreatedAt: start}
+		transactions := []models.WalletTransaction{
+			{ID: "t1", WalletID: "w1", Amount: 100, Category: models.CategoryFoodAndDrink, Direction: models.TransactionDirectionDebit, Status: models.TransactionStatusCompleted, CreatedAt: start.Add(10 * time.Minute)},
+			{ID: "t2", WalletID: "w1", Amount: 50, Category: models.CategoryFoodAndDrink, Direction: models.TransactionDirectionDebit, Status: models.TransactionStatusCompleted, CreatedAt: start.Add(2 * time.Hour)},
+			{ID: "t3", WalletID: "w1", Amount: 70, Category: models.CategoryFoodAndDrink, Direction: models.TransactionDirectionDebit, Status: models.TransactionStatusCompleted, CreatedAt: start.Add(3 * time.Hour)},
+			{ID: "t4", WalletID: "w1", Amount: 999, Category: models.CategoryCashOut, Direction: models.TransactionDirectionDebit, Status: "FAILED", CreatedAt: start.Add(4 * time.Hour)},
+		}
+
+		mockSummaryRepo.On("GetWatermarkForUpdate", mock.Anything, models.TransactionSummaryWatermark).Return(watermark, nil)
+		mockTxRepo.On("GetCreatedAfter", mock.Anything, start, "", mock.Anything, 10).Return(transactions, nil)
+		mockSummaryRepo.On("Accumulate", mock.Anything, mock.MatchedBy(func(summaries []models.TransactionSummary) bool {
+			return len(summaries) == 2 &&
+				summaries[0].Period.Equal(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)) && summaries[0].TotalAmount == 100 &&
+				summaries[1].Period.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) && summaries[1].TotalAmount == 120 && summaries[1].TransactionCount == 2
+		})).Return(nil)
+		mockSummaryRepo.On("SaveWatermark", mock.Anything, mock.MatchedBy(func(w *models.SummaryWatermark) bool {
+			return w.LastTransactionID == "t4" && w.ProcessedCount == 4
+		})).Return(nil)
+
+		reg := &testRegistry{tr: mockTxRepo, tsr: mockSummaryRepo}
+		svc := NewInsightService(reg, &configs.Config{})
+
+		n, err := svc.SummarizeTransactions(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, 4, n)
+	})
+
+	t.Run("nothing new", func(t *testing.T) {
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockSummaryRepo := mocks.NewTransactionSummaryRepository(t)
+
+		mockSummaryRepo.On("GetWatermarkForUpdate", mock.Anything, models.TransactionSummaryWatermark).Return(&models.SummaryWatermark{}, nil)
+		mockTxRepo.On("GetCreatedAfter", mock.Anything, mock.Anything, mock.Anything, mock.Anything, 10).Return([]models.WalletTransaction{}, nil)
+
+		reg := &testRegistry{tr: mockTxRepo, tsr: mockSummaryRepo}
+		svc := NewInsightService(reg, &configs.Config{})
+
+		n, err := svc.SummarizeTransactions(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+		mockSummaryRepo.AssertNotCalled(t, "SaveWatermark", mock.Anything, mock.Anything)
+	})
+}
+
+func TestInsightService_GetInsights(t *testing.T) {
+	t.Run("compares each month with the previous one", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockSummaryRepo := mocks.NewTransactionSummaryRepository(t)
+
+		aug := time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)
+		sep := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+		oct := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(&models.Wallet{ID: "w1", Currency: "IDR"}, nil)
+		mockSummaryRepo.On("GetByWalletID", mock.Anything, "w1", aug, oct).Return([]models.TransactionSummary{
+			{Period: sep, Category: models.CategoryFoodAndDrink, Direction: models.TransactionDirectionDebit, TotalAmount: 200, TransactionCount: 4},
+			{Period: sep, Category: models.CategoryTransport, Direction: models.TransactionDirectionDebit, TotalAmount: 100, TransactionCount: 2},
+			{Period: oct, Category: models.CategoryFoodAndDrink, Direction: models.TransactionDirectionDebit, TotalAmount: 300, TransactionCount: 5},
+			{Period: oct, Category: models.CategoryTopUp, Direction: models.TransactionDirectionCredit, TotalAmount: 1000, TransactionCount: 1},
+		}, nil)
+
+		reg := &testRegistry{wr: mockWalletRepo, tsr: mockSummaryRepo}
+		svc := NewInsightService(reg, &configs.Config{})
+
+		res, err := svc.GetInsights(context.Background(), "user-1", "2026-10", 2)
+		require.NoError(t, err)
+		require.Len(t, res.Months, 2)
+
+		september := res.Months[0]
+		assert.Equal(t, "2026-09", september.Period)
+		assert.Equal(t, 300.0, september.TotalSpent)
+		assert.Nil(t, september.SpentChangePercentage)
+
+		october := res.Months[1]
+		assert.Equal(t, "2026-10", october.Period)
+		assert.Equal(t, 300.0, october.TotalSpent)
+		assert.Equal(t, 1000.0, october.TotalReceived)
+		assert.Equal(t, 0.0, october.SpentChange)
+		require.Len(t, october.Categories, 3)
+
+		food := october.Categories[0]
+		assert.Equal(t, models.CategoryFoodAndDrink, food.Category)
+		assert.Equal(t, 100.0, food.Change)
+		require.NotNil(t, food.ChangePercentage)
+		assert.Equal(t, 50.0, *food.ChangePercentage)
+
+		transport := october.Categories[1]
+		assert.Equal(t, models.CategoryTransport, transport.Category)
+		assert.Equal(t, 0.0, transport.Total)
+		assert.Equal(t, -100.0, *transport.ChangePercentage)
+
+		assert.Equal(t, models.TransactionDirectionCredit, october.Categories[2].Direction)
+	})
+
+	t.Run("invalid month", func(t *testing.T) {
+		svc := NewInsightService(&testRegistry{}, &configs.Config{})
+
+		_, err := svc.GetInsights(context.Background(), "user-1", "October", 3)
+		require.Error(t, err)
+	})
+
+	t.Run("wallet not found", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(nil, nil)
+
+		svc := NewInsightService(&testRegistry{wr: mockWalletRepo}, &configs.Config{})
+
+		_, err := svc.GetInsights(context.Background(), "user-1", "", 3)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,377 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	response "digital-wallet/pkg/response"
+	"digital-wallet/pkg/storage"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxKYCFileSize matches the limit ErrMaxFileSizeExceed reports
+	maxKYCFileSize = 10 << 20
+
+	defaultKYCBasicMaxBalance              = 2000000
+	defaultKYCBasicDailyWithdrawalLimit    = 1000000
+	defaultKYCVerifiedMaxBalance           = 20000000
+	defaultKYCVerifiedDailyWithdrawalLimit = 20000000
+)
+
+// kycFileTypes maps the extensions accepted for identity documents to the content type their
+// content must have
+var kycFileTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".pdf":  "application/pdf",
+}
+
+// KYCService takes the identity documents users upload and lets reviewers approve or reject
+// them. An approved document raises the user to the KYC level it was submitted for.
+type KYCService struct {
+	repo    interfaces.RegistryRepository
+	cfg     *configs.Config
+	storage storage.FileStorage
+}
+
+// Ensure KYCService implements interfaces.KYCService
+var _ interfaces.KYCService = (*KYCService)(nil)
+
+func NewKYCService(repo interfaces.RegistryRepository, config *configs.Config, fileStorage storage.FileStorage) interfaces.KYCService {
+	return &KYCService{
+		repo:    repo,
+		cfg:     config,
+		storage: fileStorage,
+	}
+}
+
+// GetStatus returns the user's KYC level, the limits it sets and their latest submission
+func (s *KYCService) GetStatus(ctx context.Context, userID string) (*dto.KYCStatusResponse, error) {
+	user, err := getUser(ctx, s.repo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := s.repo.GetKYCSubmissionRepository().GetLatestByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving KYC submission")
+	}
+
+	level := kycLevel(user)
+	return &dto.KYCStatusResponse{
+		Level:                level,
+		MaxBalance:           kycMaxBalance(s.cfg, level),
+		DailyWithdrawalLimit: kycDailyWithdrawalLimit(s.cfg, level),
+		Submission:           submission,
+	}, nil
+}
+
+// Submit stores an identity document and queues it for review. Only JPEG, PNG and PDF files
+// up to 10MB are accepted, and the content must match the extension. A user has at most one
+// submission waiting for review.
+func (s *KYCService) Submit(ctx context.Context, req dto.KYCSubmitRequest) (*models.KYCSubmission, error) {
+	ext := strings.ToLower(filepath.Ext(req.FileName))
+	contentType, ok := kycFileTypes[ext]
+	if !ok {
+		return nil, response.ErrFileExtNotAllowed
+	}
+
+	if req.Size > maxKYCFileSize {
+		return nil, response.ErrMaxFileSizeExceed
+	}
+
+	user, err := getUser(ctx, s.repo, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if kycLevel(user) == models.KYCLevelVerified {
+		return nil, response.NewValidationError("Account is already verified")
+	}
+
+	latest, err := s.repo.GetKYCSubmissionRepository().GetLatestByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving KYC submission")
+	}
+
+	if latest != nil && latest.Status == models.KYCStatusPending {
+		return nil, response.NewValidationError("A submission is already waiting for review")
+	}
+
+	// the extension is only a claim; the first bytes tell what the file really is
+	head := make([]byte, 512)
+	n, err := io.ReadFull(req.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, response.NewValidationError("Document is empty")
+	}
+	head = head[:n]
+
+	if http.DetectContentType(head) != contentType {
+		return nil, response.ErrFileExtNotAllowed
+	}
+
+	submission := &models.KYCSubmission{
+		ID:           uuid.New().String(),
+		UserID:       req.UserID,
+		Level:        models.KYCLevelVerified,
+		DocumentType: req.DocumentType,
+		FileName:     filepath.Base(req.FileName),
+		ContentType:  contentType,
+		Status:       models.KYCStatusPending,
+	}
+	submission.FileKey = fmt.Sprintf("kyc/%s/%s%s", req.UserID, submission.ID, ext)
+
+	// the declared size is not trusted either; one byte past the limit is enough to refuse it
+	content := &countingReader{r: io.LimitReader(io.MultiReader(bytes.NewReader(head), req.Content), maxKYCFileSize+1)}
+	if err := s.storage.Save(ctx, submission.FileKey, content); err != nil {
+		return nil, response.Wrap(err, "error storing document")
+	}
+
+	if content.n > maxKYCFileSize {
+		s.deleteDocument(ctx, submission.FileKey)
+		return nil, response.ErrMaxFileSizeExceed
+	}
+	submission.Size = content.n
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetKYCSubmissionRepository().Create(ctx, submission); err != nil {
+			return nil, response.Wrap(err, "error creating KYC submission")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionKYCSubmit, models.AuditResourceKYC, submission.ID, nil, submission)
+	})
+	if err != nil {
+		s.deleteDocument(ctx, submission.FileKey)
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+// List returns a page of submissions, filtered by status when one is given, oldest first
+func (s *KYCService) List(ctx context.Context, status string, limit, offset int) (*dto.PaginatedKYCSubmissionResponse, error) {
+	switch status {
+	case "", models.KYCStatusPending, models.KYCStatusApproved, models.KYCStatusRejected:
+	default:
+		return nil, response.NewValidationError("status must be one of PENDING, APPROVED or REJECTED")
+	}
+
+	submissions, total, err := s.repo.GetKYCSubmissionRepository().List(ctx, status, limit, offset)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving KYC submissions")
+	}
+
+	return &dto.PaginatedKYCSubmissionResponse{
+		Data: submissions,
+		Meta: dto.PaginationMeta{Total: total, Limit: limit, Offset: offset},
+	}, nil
+}
+
+func (s *KYCService) Get(ctx context.Context, id string) (*models.KYCSubmission, error) {
+	return getKYCSubmission(ctx, s.repo, id)
+}
+
+// OpenDocument returns the uploaded file of a submission
+func (s *KYCService) OpenDocument(ctx context.Context, id string) (*dto.KYCDocument, error) {
+	submission, err := getKYCSubmission(ctx, s.repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.storage.Open(ctx, submission.FileKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, response.NewNotFoundError("Document")
+		}
+		return nil, response.Wrap(err, "error opening document")
+	}
+
+	return &dto.KYCDocument{
+		FileName:    submission.FileName,
+		ContentType: submission.ContentType,
+		Content:     content,
+	}, nil
+}
+
+// Approve raises the user to the level of the submission, in the same database transaction
+// as the decision. Reviewers cannot decide their own submission.
+func (s *KYCService) Approve(ctx context.Context, req dto.KYCReviewRequest) (*models.KYCSubmission, error) {
+	return s.review(ctx, req, models.KYCStatusApproved)
+}
+
+// Reject closes the submission without changing the user's level. The note tells the user
+// what to fix before submitting again.
+func (s *KYCService) Reject(ctx context.Context, req dto.KYCReviewRequest) (*models.KYCSubmission, error) {
+	if strings.TrimSpace(req.Note) == "" {
+		return nil, response.NewValidationError("A note is required to reject a submission")
+	}
+	return s.review(ctx, req, models.KYCStatusRejected)
+}
+
+func (s *KYCService) review(ctx context.Context, req dto.KYCReviewRequest, status string) (*models.KYCSubmission, error) {
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		submission, err := getKYCSubmission(ctx, txRepo, req.SubmissionID)
+		if err != nil {
+			return nil, err
+		}
+
+		if submission.UserID == req.ReviewerID {
+			return nil, response.ErrSelfApproval
+		}
+
+		now := time.Now()
+		submission.Status = status
+		submission.ReviewerID = &req.ReviewerID
+		submission.ReviewNote = req.Note
+		submission.ReviewedAt = &now
+
+		reviewed, err := txRepo.GetKYCSubmissionRepository().Review(ctx, submission)
+		if err != nil {
+			return nil, response.Wrap(err, "error updating KYC submission")
+		}
+
+		if !reviewed {
+			return nil, response.NewValidationError("Submission has already been reviewed")
+		}
+
+		action := models.AuditActionKYCReject
+		if status == models.KYCStatusApproved {
+			action = models.AuditActionKYCApprove
+			if err := txRepo.GetUserRepository().UpdateKYCLevel(ctx, submission.UserID, submission.Level); err != nil {
+				return nil, response.Wrap(err, "error updating KYC level")
+			}
+		}
+
+		if err := recordAudit(ctx, txRepo, action, models.AuditResourceKYC, submission.ID,
+			map[string]interface{}{"status": models.KYCStatusPending}, submission); err != nil {
+			return nil, err
+		}
+
+		return submission, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*models.KYCSubmission), nil
+}
+
+// deleteDocument removes a stored file the submission was not saved for. A failure only
+// leaves an orphaned file behind, so it is logged rather than returned.
+func (s *KYCService) deleteDocument(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		slog.Warn("Failed to delete KYC document", "key", key, "error", err)
+	}
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func getKYCSubmission(ctx context.Context, repo interfaces.RegistryRepository, id string) (*models.KYCSubmission, error) {
+	submission, err := repo.GetKYCSubmissionRepository().GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("KYC submission")
+		}
+		return nil, response.Wrap(err, "error retrieving KYC submission")
+	}
+	return submission, nil
+}
+
+// checkBalanceLimit refuses a credit that left the wallet, together with its pockets, above
+// the maximum balance of its owner's KYC level. It runs after the balance changed, while the
+// wallet row is locked, so the refusal rolls the credit back.
+func checkBalanceLimit(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, wallet *models.Wallet) error {
+	user, err := getUser(ctx, repo, wallet.UserID)
+	if err != nil {
+		return err
+	}
+
+	pockets, err := repo.GetPocketRepository().GetByWalletID(ctx, wallet.ID)
+	if err != nil {
+		return response.Wrap(err, "error retrieving pockets")
+	}
+
+	total := wallet.Balance
+	for _, p := range pockets {
+		total += p.Balance
+	}
+
+	if total > kycMaxBalance(cfg, kycLevel(user)) {
+		return response.ErrBalanceLimitExceeded
+	}
+
+	return nil
+}
+
+func getUser(ctx context.Context, repo interfaces.RegistryRepository, userID string) (*models.User, error) {
+	user, err := repo.GetUserRepository().GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("User")
+		}
+		return nil, response.Wrap(err, "error retrieving user")
+	}
+	return user, nil
+}
+
+// kycLevel treats users from before KYC levels existed as BASIC
+func kycLevel(user *models.User) string {
+	if user.KYCLevel == "" {
+		return models.KYCLevelBasic
+	}
+	return user.KYCLevel
+}
+
+func kycMaxBalance(cfg *configs.Config, level string) float64 {
+	if level == models.KYCLevelVerified {
+		if cfg != nil && cfg.KYC.VerifiedMaxBalance > 0 {
+			return cfg.KYC.VerifiedMaxBalance
+		}
+		return defaultKYCVerifiedMaxBalance
+	}
+
+	if cfg != nil && cfg.KYC.BasicMaxBalance > 0 {
+		return cfg.KYC.BasicMaxBalance
+	}
+	return defaultKYCBasicMaxBalance
+}
+
+func kycDailyWithdrawalLimit(cfg *configs.Config, level string) float64 {
+	if level == models.KYCLevelVerified {
+		if cfg != nil && cfg.KYC.VerifiedDailyWithdrawalLimit > 0 {
+			return cfg.KYC.VerifiedDailyWithdrawalLimit
+		}
+		return defaultKYCVerifiedDailyWithdrawalLimit
+	}
+
+	if cfg != nil && cfg.KYC.BasicDailyWithdrawalLimit > 0 {
+		return cfg.KYC.BasicDailyWithdrawalLimit
+	}
+	return defaultKYCBasicDailyWithdrawalLimit
+}
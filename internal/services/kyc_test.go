@@ -0,0 +1,260 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/response"
+	"digital-wallet/pkg/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// pngHeader is enough of a PNG file for its content type to be detected
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newKYCConfig() *configs.Config {
+	cfg := &configs.Config{}
+	cfg.KYC.BasicMaxBalance = 2000000
+	cfg.KYC.BasicDailyWithdrawalLimit = 1000000
+	cfg.KYC.VerifiedMaxBalance = 20000000
+	cfg.KYC.VerifiedDailyWithdrawalLimit = 20000000
+	return cfg
+}
+
+// withinKYCLimits sets up reg so that its users are BASIC, their wallets have no pockets and
+// nothing was withdrawn today, keeping the KYC limits out of tests about something else.
+// Expectations already set on reg's mocks take precedence.
+func withinKYCLimits(t *testing.T, reg *testRegistry) *testRegistry {
+	mockUserRepo, ok := reg.ur.(*mocks.UserRepository)
+	if !ok {
+		mockUserRepo = mocks.NewUserRepository(t)
+		reg.ur = mockUserRepo
+	}
+	mockUserRepo.On("GetByID", mock.Anything, mock.Anything).Return(&models.User{ID: "user-1", KYCLevel: models.KYCLevelBasic, IsActive: true}, nil).Maybe()
+
+	mockPocketRepo, ok := reg.pkr.(*mocks.PocketRepository)
+	if !ok {
+		mockPocketRepo = mocks.NewPocketRepository(t)
+		reg.pkr = mockPocketRepo
+	}
+	mockPocketRepo.On("GetByWalletID", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+
+	mockTxRepo, ok := reg.tr.(*mocks.WalletTransactionRepository)
+	if !ok {
+		mockTxRepo = mocks.NewWalletTransactionRepository(t)
+		reg.tr = mockTxRepo
+	}
+	mockTxRepo.On("SumOutgoing", mock.Anything, mock.Anything, mock.Anything).Return(float64(0), nil).Maybe()
+
+	return reg
+}
+
+func TestKYCService_Submit(t *testing.T) {
+	basicUser := &models.User{ID: "user-1", KYCLevel: models.KYCLevelBasic, IsActive: true}
+
+	t.Run("stores the document and queues it for review", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockKYCRepo := mocks.NewKYCSubmissionRepository(t)
+		fileStorage := storage.NewLocal(t.TempDir())
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(basicUser, nil)
+		mockKYCRepo.On("GetLatestByUserID", mock.Anything, "user-1").Return(&models.KYCSubmission{Status: models.KYCStatusRejected}, nil)
+		mockKYCRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *models.KYCSubmission) bool {
+			return s.UserID == "user-1" && s.Status == models.KYCStatusPending && s.Level == models.KYCLevelVerified &&
+				s.ContentType == "image/png" && s.Size == int64(len(pngHeader)) && strings.HasPrefix(s.FileKey, "kyc/user-1/")
+		})).Return(nil)
+
+		svc := NewKYCService(&testRegistry{ur: mockUserRepo, ksr: mockKYCRepo}, newKYCConfig(), fileStorage)
+
+		submission, err := svc.Submit(context.Background(), dto.KYCSubmitRequest{
+			UserID: "user-1", DocumentType: models.KYCDocumentKTP, FileName: "ktp.PNG", Size: int64(len(pngHeader)), Content: bytes.NewReader(pngHeader),
+		})
+		require.NoError(t, err)
+
+		stored, err := fileStorage.Open(context.Background(), submission.FileKey)
+		require.NoError(t, err)
+		defer stored.Close()
+
+		content, err := io.ReadAll(stored)
+		require.NoError(t, err)
+		assert.Equal(t, pngHeader, content)
+	})
+
+	t.Run("content not matching the extension is refused", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockKYCRepo := mocks.NewKYCSubmissionRepository(t)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(basicUser, nil)
+		mockKYCRepo.On("GetLatestByUserID", mock.Anything, "user-1").Return(nil, nil)
+
+		svc := NewKYCService(&testRegistry{ur: mockUserRepo, ksr: mockKYCRepo}, newKYCConfig(), storage.NewLocal(t.TempDir()))
+
+		_, err := svc.Submit(context.Background(), dto.KYCSubmitRequest{
+			UserID: "user-1", DocumentType: models.KYCDocumentKTP, FileName: "ktp.pdf", Size: 5, Content: strings.NewReader("<html>"),
+		})
+		assert.Equal(t, response.ErrFileExtNotAllowed, err)
+	})
+
+	t.Run("other extensions are refused", func(t *testing.T) {
+		svc := NewKYCService(&testRegistry{}, newKYCConfig(), storage.NewLocal(t.TempDir()))
+
+		_, err := svc.Submit(context.Background(), dto.KYCSubmitRequest{
+			UserID: "user-1", DocumentType: models.KYCDocumentKTP, FileName: "ktp.exe", Size: 5, Content: strings.NewReader("MZ"),
+		})
+		assert.Equal(t, response.ErrFileExtNotAllowed, err)
+	})
+
+	t.Run("content past the size limit is refused and removed", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockKYCRepo := mocks.NewKYCSubmissionRepository(t)
+		root := t.TempDir()
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(basicUser, nil)
+		mockKYCRepo.On("GetLatestByUserID", mock.Anything, "user-1").Return(nil, nil)
+
+		svc := NewKYCService(&testRegistry{ur: mockUserRepo, ksr: mockKYCRepo}, newKYCConfig(), storage.NewLocal(root))
+
+		// the declared size understates the content
+		content := io.MultiReader(bytes.NewReader(pngHeader), bytes.NewReader(make([]byte, maxKYCFileSize)))
+		_, err := svc.Submit(context.Background(), dto.KYCSubmitRequest{
+			UserID: "user-1", DocumentType: models.KYCDocumentKTP, FileName: "ktp.png", Size: 1024, Content: content,
+		})
+		assert.Equal(t, response.ErrMaxFileSizeExceed, err)
+	})
+
+	t.Run("a user with a pending submission cannot submit another", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockKYCRepo := mocks.NewKYCSubmissionRepository(t)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(basicUser, nil)
+		mockKYCRepo.On("GetLatestByUserID", mock.Anything, "user-1").Return(&models.KYCSubmission{Status: models.KYCStatusPending}, nil)
+
+		svc := NewKYCService(&testRegistry{ur: mockUserRepo, ksr: mockKYCRepo}, newKYCConfig(), storage.NewLocal(t.TempDir()))
+
+		_, err := svc.Submit(context.Background(), dto.KYCSubmitRequest{
+			UserID: "user-1", DocumentType: models.KYCDocumentKTP, FileName: "ktp.png", Size: int64(len(pngHeader)), Content: bytes.NewReader(pngHeader),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "waiting for review")
+	})
+}
+
+func TestKYCService_Review(t *testing.T) {
+	pending := func() *models.KYCSubmission {
+		return &models.KYCSubmission{ID: "kyc-1", UserID: "user-1", Level: models.KYCLevelVerified, Status: models.KYCStatusPending}
+	}
+
+	t.Run("approval raises the user's level", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockKYCRepo := mocks.NewKYCSubmissionRepository(t)
+
+		mockKYCRepo.On("GetByID", mock.Anything, "kyc-1").Return(pending(), nil)
+		mockKYCRepo.On("Review", mock.Anything, mock.MatchedBy(func(s *models.KYCSubmission) bool {
+			return s.Status == models.KYCStatusApproved && *s.ReviewerID == "compliance-1" && s.ReviewedAt != nil
+		})).Return(true, nil)
+		mockUserRepo.On("UpdateKYCLevel", mock.Anything, "user-1", models.KYCLevelVerified).Return(nil)
+
+		svc := NewKYCService(&testRegistry{ur: mockUserRepo, ksr: mockKYCRepo}, newKYCConfig(), storage.NewLocal(t.TempDir()))
+
+		submission, err := svc.Approve(context.Background(), dto.KYCReviewRequest{SubmissionID: "kyc-1", ReviewerID: "compliance-1"})
+		require.NoError(t, err)
+		assert.Equal(t, models.KYCStatusApproved, submission.Status)
+	})
+
+	t.Run("rejection leaves the level alone", func(t *testing.T) {
+		mockKYCRepo := mocks.NewKYCSubmissionRepository(t)
+
+		mockKYCRepo.On("GetByID", mock.Anything, "kyc-1").Return(pending(), nil)
+		mockKYCRepo.On("Review", mock.Anything, mock.Anything).Return(true, nil)
+
+		// the user repository mock fails the test if the level is changed
+		svc := NewKYCService(&testRegistry{ur: mocks.NewUserRepository(t), ksr: mockKYCRepo}, newKYCConfig(), storage.NewLocal(t.TempDir()))
+
+		submission, err := svc.Reject(context.Background(), dto.KYCReviewRequest{SubmissionID: "kyc-1", ReviewerID: "compliance-1", Note: "Photo is blurred"})
+		require.NoError(t, err)
+		assert.Equal(t, models.KYCStatusRejected, submission.Status)
+	})
+
+	t.Run("rejection needs a note", func(t *testing.T) {
+		svc := NewKYCService(&testRegistry{}, newKYCConfig(), storage.NewLocal(t.TempDir()))
+
+		_, err := svc.Reject(context.Background(), dto.KYCReviewRequest{SubmissionID: "kyc-1", ReviewerID: "compliance-1"})
+		require.Error(t, err)
+	})
+
+	t.Run("reviewers cannot decide their own submission", func(t *testing.T) {
+		mockKYCRepo := mocks.NewKYCSubmissionRepository(t)
+		mockKYCRepo.On("GetByID", mock.Anything, "kyc-1").Return(pending(), nil)
+
+		svc := NewKYCService(&testRegistry{ksr: mockKYCRepo}, newKYCConfig(), storage.NewLocal(t.TempDir()))
+
+		_, err := svc.Approve(context.Background(), dto.KYCReviewRequest{SubmissionID: "kyc-1", ReviewerID: "user-1"})
+		assert.Equal(t, response.ErrSelfApproval, err)
+	})
+
+	t.Run("a submission already reviewed is refused", func(t *testing.T) {
+		mockKYCRepo := mocks.NewKYCSubmissionRepository(t)
+
+		mockKYCRepo.On("GetByID", mock.Anything, "kyc-1").Return(pending(), nil)
+		mockKYCRepo.On("Review", mock.Anything, mock.Anything).Return(false, nil)
+
+		svc := NewKYCService(&testRegistry{ur: mocks.NewUserRepository(t), ksr: mockKYCRepo}, newKYCConfig(), storage.NewLocal(t.TempDir()))
+
+		_, err := svc.Approve(context.Background(), dto.KYCReviewRequest{SubmissionID: "kyc-1", ReviewerID: "compliance-1"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already been reviewed")
+	})
+}
+
+func TestCheckBalanceLimit(t *testing.T) {
+	wallet := &models.Wallet{ID: "wallet-1", UserID: "user-1", Balance: 1500000}
+
+	t.Run("pockets count towards the balance", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockPocketRepo := mocks.NewPocketRepository(t)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&models.User{ID: "user-1"}, nil)
+		mockPocketRepo.On("GetByWalletID", mock.Anything, "wallet-1").Return([]models.Pocket{{Balance: 600000}}, nil)
+
+		err := checkBalanceLimit(context.Background(), &testRegistry{ur: mockUserRepo, pkr: mockPocketRepo}, newKYCConfig(), wallet)
+		assert.Equal(t, response.ErrBalanceLimitExceeded, err)
+	})
+
+	t.Run("verified users have the higher limit", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockPocketRepo := mocks.NewPocketRepository(t)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&models.User{ID: "user-1", KYCLevel: models.KYCLevelVerified}, nil)
+		mockPocketRepo.On("GetByWalletID", mock.Anything, "wallet-1").Return([]models.Pocket{{Balance: 600000}}, nil)
+
+		require.NoError(t, checkBalanceLimit(context.Background(), &testRegistry{ur: mockUserRepo, pkr: mockPocketRepo}, newKYCConfig(), wallet))
+	})
+}
+
+func TestWalletService_WithdrawKYCLimit(t *testing.T) {
+	mockWalletRepo := mocks.NewWalletRepository(t)
+	mockTxRepo := mocks.NewWalletTransactionRepository(t)
+
+	mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(&models.Wallet{ID: "wallet-1", UserID: "user-1", Balance: 5000000, IsActive: true}, nil)
+	mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockWalletRepo.On("Withdraw", mock.Anything, "wallet-1", 200000.0).Return(&models.Wallet{ID: "wallet-1", UserID: "user-1", Balance: 4800000}, nil)
+	mockTxRepo.On("SumOutgoing", mock.Anything, "wallet-1", mock.Anything).Return(float64(900000), nil)
+
+	reg := withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}, "user-1")
+	svc := NewWalletService(reg, newKYCConfig(), nil)
+
+	// the limit is checked after the debit took the row lock, and refusing it rolls the debit back
+	_, err := svc.Withdraw(context.Background(), dto.WithdrawRequest{UserID: "user-1", Amount: 200000, PIN: testPIN})
+	assert.Equal(t, response.ErrWithdrawalLimitExceeded, err)
+	mockTxRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	response "digital-wallet/pkg/response"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// ledger moves money on wallets and tells the registered listeners about every
+// transaction it settles
+type ledger struct {
+	cfg       *configs.Config
+	listeners []interfaces.TransactionListener
+}
+
+func newLedger(cfg *configs.Config, listeners []interfaces.TransactionListener) ledger {
+	return ledger{cfg: cfg, listeners: listeners}
+}
+
+// ledgerEntry describes a single balance movement on one wallet
+type ledgerEntry struct {
+	WalletID    string
+	Amount      float64
+	Type        string
+	Description string
+	Metadata    map[string]interface{}
+	ReversalOf  *string
+	// CapBalance marks a credit of new money for the wallet, refused when it takes the wallet
+	// past the maximum balance of its owner's KYC level. Refunds, reversals, moves out of a
+	// pocket and settlements to merchant and fee wallets are not capped.
+	CapBalance bool
+	// LimitOutgoing marks a debit by which money leaves the user, refused when it takes what the
+	// wallet sent out today past the daily withdrawal limit of its owner's KYC level, or what it
+	// sent out from a new device past the new device limit
+	LimitOutgoing bool
+	// SessionID is the session of the wallet owner making an outgoing debit, empty when staff or
+	// a service makes it. Its device decides whether the new device limit applies.
+	SessionID string
+}
+
+// debit records a transaction for the entry and takes the amount from the wallet
+// balance under a row lock, the same way WalletService.Withdraw does. It must run inside
+// DoInTransaction so a failed debit rolls back together with the caller's other writes.
+func (l ledger) debit(ctx context.Context, repo interfaces.RegistryRepository, entry ledgerEntry) (*models.WalletTransaction, *models.Wallet, error) {
+	walletRepo := repo.GetWalletRepository()
+	transactionRepo := repo.GetWalletTransactionRepository()
+
+	transaction, err := newLedgerTransaction(entry, models.TransactionDirectionDebit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := transactionRepo.Create(ctx, transaction); err != nil {
+		return nil, nil, response.Wrap(err, "error creating transaction")
+	}
+
+	wallet, err := walletRepo.Withdraw(ctx, entry.WalletID, entry.Amount)
+	if err != nil {
+		return nil, nil, response.Wrap(err, "debit failed")
+	}
+
+	if entry.LimitOutgoing {
+		if err := checkOutgoingLimits(ctx, repo, l.cfg, wallet, entry.Amount, entry.SessionID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	transaction.Status = models.TransactionStatusCompleted
+	if err := chainTransaction(ctx, repo, transaction); err != nil {
+		return nil, nil, err
+	}
+	if err := transactionRepo.Update(ctx, transaction); err != nil {
+		return nil, nil, response.Wrap(err, "error updating transaction status")
+	}
+
+	if err := auditBalanceChange(ctx, repo, wallet, transaction); err != nil {
+		return nil, nil, err
+	}
+
+	if err := l.publish(ctx, repo, transaction); err != nil {
+		return nil, nil, err
+	}
+
+	return transaction, wallet, nil
+}
+
+// credit records a transaction for the entry and adds the amount to the wallet
+// balance under a row lock. Like debit it must run inside DoInTransaction.
+func (l ledger) credit(ctx context.Context, repo interfaces.RegistryRepository, entry ledgerEntry) (*models.WalletTransaction, *models.Wallet, error) {
+	walletRepo := repo.GetWalletRepository()
+	transactionRepo := repo.GetWalletTransactionRepository()
+
+	transaction, err := newLedgerTransaction(entry, models.TransactionDirectionCredit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := transactionRepo.Create(ctx, transaction); err != nil {
+		return nil, nil, response.Wrap(err, "error creating transaction")
+	}
+
+	wallet, err := walletRepo.Deposit(ctx, entry.WalletID, entry.Amount)
+	if err != nil {
+		return nil, nil, response.Wrap(err, "credit failed")
+	}
+
+	if entry.CapBalance {
+		if err := checkBalanceLimit(ctx, repo, l.cfg, wallet); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	transaction.Status = models.TransactionStatusCompleted
+	if err := chainTransaction(ctx, repo, transaction); err != nil {
+		return nil, nil, err
+	}
+	if err := transactionRepo.Update(ctx, transaction); err != nil {
+		return nil, nil, response.Wrap(err, "error updating transaction status")
+	}
+
+	if err := auditBalanceChange(ctx, repo, wallet, transaction); err != nil {
+		return nil, nil, err
+	}
+
+	if err := l.publish(ctx, repo, transaction); err != nil {
+		return nil, nil, err
+	}
+
+	return transaction, wallet, nil
+}
+
+// publish hands a settled transaction to every listener, inside the caller's database transaction
+func (l ledger) publish(ctx context.Context, repo interfaces.RegistryRepository, transaction *models.WalletTransaction) error {
+	for _, listener := range l.listeners {
+		if err := listener.OnTransactionSettled(ctx, repo, transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditBalanceChange records the balance of the wallet before and after a settled transaction
+// in the audit log. wallet is the wallet as the transaction left it.
+func auditBalanceChange(ctx context.Context, repo interfaces.RegistryRepository, wallet *models.Wallet, transaction *models.WalletTransaction) error {
+	action := models.AuditActionWalletCredit
+	before := wallet.Balance - transaction.Amount
+	if transaction.Direction == models.TransactionDirectionDebit {
+		action = models.AuditActionWalletDebit
+		before = wallet.Balance + transaction.Amount
+	}
+
+	return recordAudit(ctx, repo, action, models.AuditResourceWallet, wallet.ID,
+		map[string]interface{}{"balance": before},
+		map[string]interface{}{"balance": wallet.Balance, "transaction_id": transaction.ID, "type": transaction.Type, "amount": transaction.Amount},
+	)
+}
+
+func newLedgerTransaction(entry ledgerEntry, direction string) (*models.WalletTransaction, error) {
+	transaction := &models.WalletTransaction{
+		ID:          uuid.New().String(),
+		WalletID:    entry.WalletID,
+		Amount:      entry.Amount,
+		Type:        entry.Type,
+		Direction:   direction,
+		Status:      models.TransactionStatusPending,
+		Description: entry.Description,
+		ReversalOf:  entry.ReversalOf,
+	}
+
+	if entry.Metadata != nil {
+		metadata, err := json.Marshal(entry.Metadata)
+		if err != nil {
+			return nil, response.Wrap(err, "error encoding transaction metadata")
+		}
+		transaction.Metadata = datatypes.JSON(metadata)
+	}
+
+	return transaction, nil
+}
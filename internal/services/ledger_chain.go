@@ -0,0 +1,416 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	response "digital-wallet/pkg/response"
+	"digital-wallet/pkg/signing"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// genesisHash stands for the previous hash of the first transaction of every chain
+var genesisHash = strings.Repeat("0", 64)
+
+// LedgerService signs checkpoints of the wallets' hash chains and verifies the chains against
+// their transactions and checkpoints. The chains themselves are extended by chainTransaction
+// as transactions settle.
+type LedgerService struct {
+	repo interfaces.RegistryRepository
+	cfg  *configs.Config
+	keys *signing.Keyring
+}
+
+// Ensure LedgerService implements interfaces.LedgerService
+var _ interfaces.LedgerService = (*LedgerService)(nil)
+
+func NewLedgerService(repo interfaces.RegistryRepository, config *configs.Config, keys *signing.Keyring) interfaces.LedgerService {
+	return &LedgerService{
+		repo: repo,
+		cfg:  config,
+		keys: keys,
+	}
+}
+
+// chainPosition is a place in a chain, the sequence number and hash of a transaction, or the
+// genesis position before the first one
+type chainPosition struct {
+	Seq  int64
+	Hash string
+}
+
+// chainWalk is how far walkChain got along a chain
+type chainWalk struct {
+	Head         chainPosition
+	Transactions int
+	Checkpoints  int
+	// FirstCreatedAt is the creation time of the first transaction walked
+	FirstCreatedAt time.Time
+	Broken         *dto.BrokenLink
+}
+
+// chainLink is the canonical content of a transaction hashed into the chain. Timestamps are
+// left out, since the database rounds them; the order of the chain is its sequence numbers.
+type chainLink struct {
+	Seq         int64           `json:"seq"`
+	ID          string          `json:"id"`
+	WalletID    string          `json:"wallet_id"`
+	Amount      string          `json:"amount"`
+	Type        string          `json:"type"`
+	Direction   string          `json:"direction"`
+	Status      string          `json:"status"`
+	Category    string          `json:"category"`
+	Description string          `json:"description"`
+	Beneficiary *string         `json:"beneficiary"`
+	ReversalOf  *string         `json:"reversal_of"`
+	Metadata    json.RawMessage `json:"metadata"`
+}
+
+// chainTransaction links a settled transaction into the hash chain of its wallet, setting its
+// ChainSeq and ChainHash for the caller's following Update. It must run inside DoInTransaction
+// after the last change to the transaction, so the link commits with the settlement.
+func chainTransaction(ctx context.Context, repo interfaces.RegistryRepository, transaction *models.WalletTransaction) error {
+	head, err := repo.GetLedgerChainRepository().GetHead(ctx, transaction.WalletID)
+	if err != nil {
+		return response.Wrap(err, "error retrieving ledger chain head")
+	}
+
+	previous := chainPosition{Hash: genesisHash}
+	if head != nil {
+		previous = chainPosition{Seq: *head.ChainSeq, Hash: head.ChainHash}
+	}
+
+	seq := previous.Seq + 1
+	hash, err := chainHash(previous.Hash, seq, transaction)
+	if err != nil {
+		return err
+	}
+
+	transaction.ChainSeq = &seq
+	transaction.ChainHash = hash
+	return nil
+}
+
+// chainHash is the SHA-256 of the previous hash followed by the canonical content of the
+// transaction at position seq, hex encoded
+func chainHash(previous string, seq int64, transaction *models.WalletTransaction) (string, error) {
+	// amounts are stored with two decimals, rounded half away from zero like big.Rat does
+	amount, ok := new(big.Rat).SetString(strconv.FormatFloat(transaction.Amount, 'f', -1, 64))
+	if !ok {
+		return "", fmt.Errorf("transaction %s has an invalid amount", transaction.ID)
+	}
+
+	link := chainLink{
+		Seq:         seq,
+		ID:          transaction.ID,
+		WalletID:    transaction.WalletID,
+		Amount:      amount.FloatString(2),
+		Type:        transaction.Type,
+		Direction:   transaction.Direction,
+		Status:      transaction.Status,
+		Category:    transaction.Category,
+		Description: transaction.Description,
+		Beneficiary: transaction.Beneficiary,
+		ReversalOf:  transaction.ReversalOf,
+		Metadata:    json.RawMessage("null"),
+	}
+
+	// the database reformats JSON columns, so metadata is hashed re-encoded with sorted keys
+	if len(transaction.Metadata) > 0 {
+		var metadata interface{}
+		if err := json.Unmarshal(transaction.Metadata, &metadata); err != nil {
+			return "", response.Wrap(err, "error decoding transaction metadata")
+		}
+
+		canonical, err := json.Marshal(metadata)
+		if err != nil {
+			return "", response.Wrap(err, "error encoding transaction metadata")
+		}
+		link.Metadata = canonical
+	}
+
+	content, err := json.Marshal(link)
+	if err != nil {
+		return "", response.Wrap(err, "error encoding chain link")
+	}
+
+	sum := sha256.Sum256(append([]byte(previous+"\n"), content...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// checkpointMessage is what a checkpoint signs
+func checkpointMessage(walletID string, seq int64, hash string) []byte {
+	return []byte(fmt.Sprintf("%s\n%d\n%s", walletID, seq, hash))
+}
+
+// Checkpoint signs the head of every chain that grew since its latest checkpoint, once the
+// transactions added since check out. A chain that does not is left unsigned and recorded as
+// a security event. It returns the number of checkpoints written.
+func (s *LedgerService) Checkpoint(ctx context.Context, batchSize int) (int, error) {
+	if s.keys.KeyID() == "" {
+		return 0, signing.ErrNoSigningKey
+	}
+
+	chainRepo := s.repo.GetLedgerChainRepository()
+
+	written := 0
+	afterID := ""
+	for {
+		walletIDs, err := chainRepo.GetUncheckpointedWalletIDs(ctx, afterID, batchSize)
+		if err != nil {
+			return written, response.Wrap(err, "error retrieving wallets to checkpoint")
+		}
+
+		for _, walletID := range walletIDs {
+			signed, err := s.checkpointWallet(ctx, walletID, batchSize)
+			if err != nil {
+				return written, err
+			}
+			if signed {
+				written++
+			}
+		}
+
+		if len(walletIDs) < batchSize {
+			return written, nil
+		}
+		afterID = walletIDs[len(walletIDs)-1]
+	}
+}
+
+// checkpointWallet walks the wallet's chain from its latest checkpoint and signs the head
+// reached, reporting whether it did
+func (s *LedgerService) checkpointWallet(ctx context.Context, walletID string, batchSize int) (bool, error) {
+	chainRepo := s.repo.GetLedgerChainRepository()
+
+	latest, err := chainRepo.GetLatestCheckpoint(ctx, walletID)
+	if err != nil {
+		return false, response.Wrap(err, "error retrieving ledger checkpoint")
+	}
+
+	start := chainPosition{Hash: genesisHash}
+	if latest != nil {
+		if reason := s.checkCheckpoint(walletID, *latest, latest.ChainHash); reason != "" {
+			return false, s.reportBroken(ctx, dto.BrokenLink{WalletID: walletID, ChainSeq: latest.ChainSeq, Reason: reason})
+		}
+		start = chainPosition{Seq: latest.ChainSeq, Hash: latest.ChainHash}
+	}
+
+	walk, err := s.walkChain(ctx, walletID, start, nil, batchSize)
+	if err != nil {
+		return false, err
+	}
+
+	if walk.Broken != nil {
+		return false, s.reportBroken(ctx, *walk.Broken)
+	}
+
+	if walk.Head.Seq == start.Seq {
+		return false, nil
+	}
+
+	keyID, signature, err := s.keys.Sign(checkpointMessage(walletID, walk.Head.Seq, walk.Head.Hash))
+	if err != nil {
+		return false, response.Wrap(err, "error signing ledger checkpoint")
+	}
+
+	checkpoint := &models.LedgerCheckpoint{
+		ID:        uuid.New().String(),
+		WalletID:  walletID,
+		ChainSeq:  walk.Head.Seq,
+		ChainHash: walk.Head.Hash,
+		KeyID:     keyID,
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}
+
+	if err := chainRepo.CreateCheckpoint(ctx, checkpoint); err != nil {
+		return false, response.Wrap(err, "error creating ledger checkpoint")
+	}
+	return true, nil
+}
+
+func (s *LedgerService) reportBroken(ctx context.Context, link dto.BrokenLink) error {
+	return recordSecurityEvent(ctx, s.repo, auth.SecurityEventLedgerBroken, models.AuditResourceWallet, link.WalletID, map[string]interface{}{
+		"chain_seq":      link.ChainSeq,
+		"transaction_id": link.TransactionID,
+		"reason":         link.Reason,
+	})
+}
+
+// Verify walks the chain of the wallet, or of every wallet when walletID is empty, and reports
+// the first broken link of each
+func (s *LedgerService) Verify(ctx context.Context, walletID string, batchSize int) (*dto.LedgerVerification, error) {
+	result := &dto.LedgerVerification{Broken: []dto.BrokenLink{}}
+
+	if walletID != "" {
+		return result, s.verifyWallet(ctx, walletID, batchSize, result)
+	}
+
+	afterID := ""
+	for {
+		walletIDs, err := s.repo.GetLedgerChainRepository().GetChainedWalletIDs(ctx, afterID, batchSize)
+		if err != nil {
+			return result, response.Wrap(err, "error retrieving wallets to verify")
+		}
+
+		for _, id := range walletIDs {
+			if err := s.verifyWallet(ctx, id, batchSize, result); err != nil {
+				return result, err
+			}
+		}
+
+		if len(walletIDs) < batchSize {
+			return result, nil
+		}
+		afterID = walletIDs[len(walletIDs)-1]
+	}
+}
+
+// verifyWallet walks the whole chain of the wallet against its checkpoints, then looks for
+// transactions created since the chain started that are missing from it
+func (s *LedgerService) verifyWallet(ctx context.Context, walletID string, batchSize int, result *dto.LedgerVerification) error {
+	chainRepo := s.repo.GetLedgerChainRepository()
+
+	checkpoints, err := chainRepo.GetCheckpoints(ctx, walletID)
+	if err != nil {
+		return response.Wrap(err, "error retrieving ledger checkpoints")
+	}
+
+	walk, err := s.walkChain(ctx, walletID, chainPosition{Hash: genesisHash}, checkpoints, batchSize)
+	if err != nil {
+		return err
+	}
+
+	result.Wallets++
+	result.Transactions += walk.Transactions
+	result.Checkpoints += walk.Checkpoints
+
+	if walk.Broken == nil && walk.Transactions > 0 {
+		unchained, err := chainRepo.CountUnchainedSince(ctx, walletID, walk.FirstCreatedAt)
+		if err != nil {
+			return response.Wrap(err, "error counting unchained transactions")
+		}
+
+		if unchained > 0 {
+			walk.Broken = &dto.BrokenLink{
+				WalletID: walletID,
+				Reason:   fmt.Sprintf("%d transactions created since the chain started are not in it", unchained),
+			}
+		}
+	}
+
+	if walk.Broken != nil {
+		result.Broken = append(result.Broken, *walk.Broken)
+	}
+	return nil
+}
+
+// walkChain recomputes the hashes of the wallet's chain after start, comparing each with the
+// stored hash and with the checkpoints at its position, and stops at the first broken link.
+// checkpoints must be in chain order. A checkpoint past the end of the chain means
+// transactions were removed from its end.
+func (s *LedgerService) walkChain(ctx context.Context, walletID string, start chainPosition, checkpoints []models.LedgerCheckpoint, batchSize int) (chainWalk, error) {
+	chainRepo := s.repo.GetLedgerChainRepository()
+
+	walk := chainWalk{Head: start}
+	next := 0
+
+	for {
+		transactions, err := chainRepo.GetChain(ctx, walletID, walk.Head.Seq, batchSize)
+		if err != nil {
+			return walk, response.Wrap(err, "error retrieving ledger chain")
+		}
+
+		for i := range transactions {
+			transaction := &transactions[i]
+			seq := *transaction.ChainSeq
+
+			if walk.Transactions == 0 {
+				walk.FirstCreatedAt = transaction.CreatedAt
+			}
+
+			if seq != walk.Head.Seq+1 {
+				reason := fmt.Sprintf("transactions %d to %d of the chain are missing", walk.Head.Seq+1, seq-1)
+				if seq == walk.Head.Seq+2 {
+					reason = fmt.Sprintf("transaction %d of the chain is missing", seq-1)
+				}
+				walk.Broken = brokenLink(transaction, reason)
+				return walk, nil
+			}
+
+			if transaction.DeletedAt.Valid {
+				walk.Broken = brokenLink(transaction, "transaction was deleted")
+				return walk, nil
+			}
+
+			hash, err := chainHash(walk.Head.Hash, seq, transaction)
+			if err != nil {
+				return walk, err
+			}
+
+			if hash != transaction.ChainHash {
+				walk.Broken = brokenLink(transaction, "transaction does not match its chain hash")
+				return walk, nil
+			}
+
+			for ; next < len(checkpoints) && checkpoints[next].ChainSeq <= seq; next++ {
+				if reason := s.checkCheckpoint(walletID, checkpoints[next], hash); reason != "" {
+					walk.Broken = brokenLink(transaction, reason)
+					return walk, nil
+				}
+				walk.Checkpoints++
+			}
+
+			walk.Head = chainPosition{Seq: seq, Hash: hash}
+			walk.Transactions++
+		}
+
+		if len(transactions) < batchSize {
+			break
+		}
+	}
+
+	if next < len(checkpoints) {
+		walk.Broken = &dto.BrokenLink{
+			WalletID: walletID,
+			ChainSeq: walk.Head.Seq + 1,
+			Reason:   fmt.Sprintf("the chain ends at %d but checkpoint %s was signed at %d", walk.Head.Seq, checkpoints[next].ID, checkpoints[next].ChainSeq),
+		}
+	}
+
+	return walk, nil
+}
+
+func brokenLink(transaction *models.WalletTransaction, reason string) *dto.BrokenLink {
+	return &dto.BrokenLink{WalletID: transaction.WalletID, TransactionID: transaction.ID, ChainSeq: *transaction.ChainSeq, Reason: reason}
+}
+
+// checkCheckpoint checks the signature of the checkpoint and that it signed hash, returning
+// what is wrong or an empty string
+func (s *LedgerService) checkCheckpoint(walletID string, checkpoint models.LedgerCheckpoint, hash string) string {
+	signature, err := base64.StdEncoding.DecodeString(checkpoint.Signature)
+	if err == nil {
+		err = s.keys.Verify(checkpoint.KeyID, checkpointMessage(walletID, checkpoint.ChainSeq, checkpoint.ChainHash), signature)
+	}
+	if err != nil {
+		return fmt.Sprintf("checkpoint %s has an invalid signature: %v", checkpoint.ID, err)
+	}
+
+	if checkpoint.ChainHash != hash {
+		return fmt.Sprintf("transaction does not match checkpoint %s", checkpoint.ID)
+	}
+	return ""
+}
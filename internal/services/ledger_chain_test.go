@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/signing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testLedgerKeyring returns a keyring signing with a new Ed25519 key under keyID
+func testLedgerKeyring(t *testing.T, keyID string) *signing.Keyring {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), keyID+".pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	keyring, err := signing.NewKeyring(keyID, path, nil)
+	require.NoError(t, err)
+	return keyring
+}
+
+// testChain returns n settled transactions of wallet-1 linked into a chain, oldest first
+func testChain(t *testing.T, n int) []models.WalletTransaction {
+	created := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	chain := make([]models.WalletTransaction, n)
+	previous := genesisHash
+	for i := range chain {
+		seq := int64(i + 1)
+		chain[i] = models.WalletTransaction{
+			ID:        "transaction-" + string(rune('a'+i)),
+			WalletID:  "wallet-1",
+			Amount:    10000,
+			Type:      models.TransactionTypeDeposit,
+			Direction: models.TransactionDirectionCredit,
+			Status:    models.TransactionStatusCompleted,
+			Category:  "TOP_UP",
+			ChainSeq:  &seq,
+			CreatedAt: created.Add(time.Duration(i) * time.Minute),
+		}
+
+		hash, err := chainHash(previous, seq, &chain[i])
+		require.NoError(t, err)
+		chain[i].ChainHash = hash
+		previous = hash
+	}
+	return chain
+}
+
+// testCheckpoint signs the position of transaction in its chain
+func testCheckpoint(t *testing.T, keys *signing.Keyring, transaction models.WalletTransaction) models.LedgerCheckpoint {
+	keyID, signature, err := keys.Sign(checkpointMessage(transaction.WalletID, *transaction.ChainSeq, transaction.ChainHash))
+	require.NoError(t, err)
+
+	return models.LedgerCheckpoint{
+		ID:        "checkpoint-1",
+		WalletID:  transaction.WalletID,
+		ChainSeq:  *transaction.ChainSeq,
+		ChainHash: transaction.ChainHash,
+		KeyID:     keyID,
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}
+}
+
+func mustDecode(t *testing.T, s string) []byte {
+	data, err := base64.StdEncoding.DecodeString(s)
+	require.NoError(t, err)
+	return data
+}
+
+func TestChainTransaction(t *testing.T) {
+	t.Run("the first transaction follows the genesis hash", func(t *testing.T) {
+		transaction := &models.WalletTransaction{ID: "transaction-a", WalletID: "wallet-1", Amount: 10000, Status: models.TransactionStatusCompleted}
+
+		require.NoError(t, chainTransaction(context.Background(), &testRegistry{}, transaction))
+
+		expected, err := chainHash(genesisHash, 1, transaction)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), *transaction.ChainSeq)
+		assert.Equal(t, expected, transaction.ChainHash)
+	})
+
+	t.Run("later transactions follow the head of the chain", func(t *testing.T) {
+		head := testChain(t, 2)[1]
+
+		mockChainRepo := mocks.NewLedgerChainRepository(t)
+		mockChainRepo.On("GetHead", mock.Anything, "wallet-1").Return(&head, nil)
+
+		transaction := &models.WalletTransaction{ID: "transaction-c", WalletID: "wallet-1", Amount: 500, Status: models.TransactionStatusFailed}
+		require.NoError(t, chainTransaction(context.Background(), &testRegistry{lcr: mockChainRepo}, transaction))
+
+		expected, err := chainHash(head.ChainHash, 3, transaction)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), *transaction.ChainSeq)
+		assert.Equal(t, expected, transaction.ChainHash)
+	})
+}
+
+func TestChainHash(t *testing.T) {
+	transaction := &models.WalletTransaction{
+		ID:       "transaction-a",
+		WalletID: "wallet-1",
+		Amount:   1234.565,
+		Status:   models.TransactionStatusCompleted,
+		Metadata: []byte(`{"promo_code":"HEMAT","campaign":{"id":"c-1","rate":0.5}}`),
+	}
+	hash, err := chainHash(genesisHash, 1, transaction)
+	require.NoError(t, err)
+
+	t.Run("matches the transaction as the database returns it", func(t *testing.T) {
+		stored := *transaction
+		stored.Amount = 1234.57
+		stored.Metadata = []byte(`{"campaign": {"id": "c-1", "rate": 0.5}, "promo_code": "HEMAT"}`)
+
+		storedHash, err := chainHash(genesisHash, 1, &stored)
+		require.NoError(t, err)
+		assert.Equal(t, hash, storedHash)
+	})
+
+	t.Run("changes with the contents", func(t *testing.T) {
+		edited := *transaction
+		edited.Status = models.TransactionStatusFailed
+
+		editedHash, err := chainHash(genesisHash, 1, &edited)
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, editedHash)
+	})
+
+	t.Run("changes with the position and the previous hash", func(t *testing.T) {
+		moved, err := chainHash(genesisHash, 2, transaction)
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, moved)
+
+		relinked, err := chainHash(hash, 1, transaction)
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, relinked)
+	})
+}
+
+func TestLedgerService_Verify(t *testing.T) {
+	keys := testLedgerKeyring(t, "2026-10")
+
+	verify := func(t *testing.T, chain []models.WalletTransaction, checkpoints []models.LedgerCheckpoint) []string {
+		mockChainRepo := mocks.NewLedgerChainRepository(t)
+		mockChainRepo.On("GetCheckpoints", mock.Anything, "wallet-1").Return(checkpoints, nil)
+		mockChainRepo.On("GetChain", mock.Anything, "wallet-1", int64(0), 100).Return(chain, nil)
+		mockChainRepo.On("CountUnchainedSince", mock.Anything, "wallet-1", mock.Anything).Return(int64(0), nil).Maybe()
+
+		svc := NewLedgerService(&testRegistry{lcr: mockChainRepo}, &configs.Config{}, keys)
+
+		result, err := svc.Verify(context.Background(), "wallet-1", 100)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Wallets)
+
+		reasons := make([]string, 0, len(result.Broken))
+		for _, link := range result.Broken {
+			reasons = append(reasons, link.Reason)
+		}
+		return reasons
+	}
+
+	t.Run("an intact chain with its checkpoint", func(t *testing.T) {
+		chain := testChain(t, 3)
+		assert.Empty(t, verify(t, chain, []models.LedgerCheckpoint{testCheckpoint(t, keys, chain[2])}))
+	})
+
+	t.Run("an edited transaction", func(t *testing.T) {
+		chain := testChain(t, 3)
+		chain[1].Amount = 1000000
+
+		assert.Equal(t, []string{"transaction does not match its chain hash"}, verify(t, chain, nil))
+	})
+
+	t.Run("a removed transaction", func(t *testing.T) {
+		chain := testChain(t, 3)
+
+		assert.Equal(t, []string{"transaction 2 of the chain is missing"}, verify(t, []models.WalletTransaction{chain[0], chain[2]}, nil))
+	})
+
+	t.Run("a chain rehashed after an edit no longer matches its checkpoint", func(t *testing.T) {
+		chain := testChain(t, 3)
+		checkpoint := testCheckpoint(t, keys, chain[2])
+
+		chain[1].Amount = 1000000
+		for i := 1; i < len(chain); i++ {
+			hash, err := chainHash(chain[i-1].ChainHash, *chain[i].ChainSeq, &chain[i])
+			require.NoError(t, err)
+			chain[i].ChainHash = hash
+		}
+
+		assert.Equal(t, []string{"transaction does not match checkpoint checkpoint-1"}, verify(t, chain, []models.LedgerCheckpoint{checkpoint}))
+	})
+
+	t.Run("transactions removed from the end of a checkpointed chain", func(t *testing.T) {
+		chain := testChain(t, 3)
+		checkpoint := testCheckpoint(t, keys, chain[2])
+
+		assert.Equal(t, []string{"the chain ends at 2 but checkpoint checkpoint-1 was signed at 3"}, verify(t, chain[:2], []models.LedgerCheckpoint{checkpoint}))
+	})
+
+	t.Run("a checkpoint signed by an unknown key", func(t *testing.T) {
+		chain := testChain(t, 3)
+		checkpoint := testCheckpoint(t, testLedgerKeyring(t, "forged"), chain[2])
+
+		reasons := verify(t, chain, []models.LedgerCheckpoint{checkpoint})
+		require.Len(t, reasons, 1)
+		assert.Contains(t, reasons[0], "invalid signature")
+	})
+}
+
+func TestLedgerService_Checkpoint(t *testing.T) {
+	t.Run("signs the head of a grown chain", func(t *testing.T) {
+		keys := testLedgerKeyring(t, "2026-10")
+		chain := testChain(t, 3)
+
+		mockChainRepo := mocks.NewLedgerChainRepository(t)
+		mockChainRepo.On("GetUncheckpointedWalletIDs", mock.Anything, "", 100).Return([]string{"wallet-1"}, nil)
+		mockChainRepo.On("GetLatestCheckpoint", mock.Anything, "wallet-1").Return(nil, nil)
+		mockChainRepo.On("GetChain", mock.Anything, "wallet-1", int64(0), 100).Return(chain, nil)
+		mockChainRepo.On("CreateCheckpoint", mock.Anything, mock.MatchedBy(func(c *models.LedgerCheckpoint) bool {
+			return c.WalletID == "wallet-1" && c.ChainSeq == 3 && c.ChainHash == chain[2].ChainHash && c.KeyID == "2026-10" &&
+				keys.Verify(c.KeyID, checkpointMessage("wallet-1", 3, chain[2].ChainHash), mustDecode(t, c.Signature)) == nil
+		})).Return(nil)
+
+		svc := NewLedgerService(&testRegistry{lcr: mockChainRepo}, &configs.Config{}, keys)
+
+		n, err := svc.Checkpoint(context.Background(), 100)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("a broken chain is reported instead of signed", func(t *testing.T) {
+		keys := testLedgerKeyring(t, "2026-10")
+		chain := testChain(t, 3)
+		chain[2].Description = "edited"
+
+		mockChainRepo := mocks.NewLedgerChainRepository(t)
+		mockAuditRepo := mocks.NewAuditEventRepository(t)
+		mockChainRepo.On("GetUncheckpointedWalletIDs", mock.Anything, "", 100).Return([]string{"wallet-1"}, nil)
+		mockChainRepo.On("GetLatestCheckpoint", mock.Anything, "wallet-1").Return(nil, nil)
+		mockChainRepo.On("GetChain", mock.Anything, "wallet-1", int64(0), 100).Return(chain, nil)
+		mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.AuditEvent) bool {
+			return e.Action == "security.ledger_chain_broken" && e.ResourceID == "wallet-1" && e.PrincipalType == models.AuditPrincipalSystem
+		})).Return(nil)
+
+		svc := NewLedgerService(&testRegistry{lcr: mockChainRepo, aur: mockAuditRepo}, &configs.Config{}, keys)
+
+		n, err := svc.Checkpoint(context.Background(), 100)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("needs a signing key", func(t *testing.T) {
+		keys, err := signing.NewKeyring("", "", nil)
+		require.NoError(t, err)
+
+		svc := NewLedgerService(&testRegistry{}, &configs.Config{}, keys)
+
+		_, err = svc.Checkpoint(context.Background(), 100)
+		assert.ErrorIs(t, err, signing.ErrNoSigningKey)
+	})
+}
+
+func TestLedger_ChainsSettledTransactions(t *testing.T) {
+	mockWalletRepo := mocks.NewWalletRepository(t)
+	mockTxRepo := mocks.NewWalletTransactionRepository(t)
+
+	mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockWalletRepo.On("Withdraw", mock.Anything, "wallet-1", 5000.0).Return(&models.Wallet{ID: "wallet-1", Balance: 5000}, nil)
+	mockTxRepo.On("Update", mock.Anything, mock.MatchedBy(func(tx *models.WalletTransaction) bool {
+		return tx.Status == models.TransactionStatusCompleted && tx.ChainSeq != nil && *tx.ChainSeq == 1 && tx.ChainHash != ""
+	})).Return(nil)
+
+	_, _, err := newLedger(&configs.Config{}, nil).debit(context.Background(), &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}, ledgerEntry{
+		WalletID: "wallet-1", Amount: 5000, Type: models.TransactionTypePayment,
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	response "digital-wallet/pkg/response"
+	"time"
+)
+
+const (
+	defaultLoginMaxAttempts   = 10
+	defaultLoginIPMaxAttempts = 100
+	defaultLoginLockDuration  = 15 * time.Minute
+	defaultLoginDelayAfter    = 3
+	defaultLoginMaxDelay      = time.Minute
+)
+
+// loginLimit is a failed login counter and the number of failures that locks it
+type loginLimit struct {
+	key         string
+	maxAttempts int
+}
+
+// loginLimits returns the counters a login from the phone number and IP address is checked
+// against. The IP address is unknown when the request did not pass through the controller.
+func loginLimits(cfg *configs.Config, phoneNumber, ipAddress string) []loginLimit {
+	limits := []loginLimit{{key: loginPhoneKey(phoneNumber), maxAttempts: loginMaxAttempts(cfg)}}
+	if ipAddress != "" {
+		limits = append(limits, loginLimit{key: loginIPKey(ipAddress), maxAttempts: loginIPMaxAttempts(cfg)})
+	}
+	return limits
+}
+
+// checkLoginAllowed refuses a login from a phone number or IP address that is locked, or that
+// is still waiting out the delay after its last failure, before the password is looked at.
+// The refusal is ErrInvalidCredentials, the answer to a wrong password, so a caller cannot
+// tell a lock from a wrong password or learn whether the phone number is registered; the right
+// password does not get past it. It returns the failures of the phone number so far.
+func checkLoginAllowed(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, phoneNumber, ipAddress string) (int, error) {
+	attemptRepo := repo.GetAttemptRepository()
+
+	phoneFailures := 0
+	for i, limit := range loginLimits(cfg, phoneNumber, ipAddress) {
+		failures, err := attemptRepo.Get(ctx, limit.key)
+		if err != nil {
+			return 0, response.Wrap(err, "error retrieving login attempts")
+		}
+
+		if i == 0 {
+			phoneFailures = failures
+		}
+
+		if failures >= limit.maxAttempts {
+			return 0, response.ErrInvalidCredentials
+		}
+
+		wait, err := attemptRepo.BlockedFor(ctx, limit.key)
+		if err != nil {
+			return 0, response.Wrap(err, "error retrieving login attempts")
+		}
+
+		if wait > 0 {
+			return 0, response.ErrInvalidCredentials
+		}
+	}
+
+	return phoneFailures, nil
+}
+
+// recordLoginFailure counts a failed login against the phone number and IP address. From
+// the delay threshold on, the phone number has to wait before its next attempt, twice as long
+// after each failure. A counter reaching its maximum is locked until the lock duration has
+// passed since the last failure, or an admin unlocks the account. userID is empty when the
+// phone number is not registered.
+func recordLoginFailure(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, phoneNumber, ipAddress, userID string) error {
+	attemptRepo := repo.GetAttemptRepository()
+
+	for i, limit := range loginLimits(cfg, phoneNumber, ipAddress) {
+		failures, err := attemptRepo.Increment(ctx, limit.key, loginLockDuration(cfg))
+		if err != nil {
+			return response.Wrap(err, "error recording login attempt")
+		}
+
+		if failures >= limit.maxAttempts {
+			resourceType, resourceID := models.AuditResourceIP, ipAddress
+			if i == 0 {
+				resourceType, resourceID = models.AuditResourcePhone, phoneNumber
+				if userID != "" {
+					resourceType, resourceID = models.AuditResourceUser, userID
+				}
+			}
+
+			if err := recordSecurityEvent(ctx, repo, auth.SecurityEventLoginLocked, resourceType, resourceID, map[string]interface{}{
+				"ip_address": ipAddress, "by_ip": i > 0, "attempts": failures,
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// only the phone number is slowed down; one address may serve many honest users
+		if i == 0 && failures >= loginDelayAfter(cfg) {
+			if err := attemptRepo.Block(ctx, limit.key, loginDelay(cfg, failures)); err != nil {
+				return response.Wrap(err, "error recording login attempt")
+			}
+		}
+	}
+
+	return response.ErrInvalidCredentials
+}
+
+// unlockLogin lifts the lock and delay of the phone number. Locked IP addresses stay locked
+// until their lock expires.
+func unlockLogin(ctx context.Context, repo interfaces.RegistryRepository, phoneNumber string) error {
+	if err := repo.GetAttemptRepository().Reset(ctx, loginPhoneKey(phoneNumber)); err != nil {
+		return response.Wrap(err, "error resetting login attempts")
+	}
+	return nil
+}
+
+func loginPhoneKey(phoneNumber string) string {
+	return "login:phone:" + phoneNumber
+}
+
+func loginIPKey(ipAddress string) string {
+	return "login:ip:" + ipAddress
+}
+
+// loginDelay is how long the phone number waits after its failures-th failed login, doubling
+// with each failure past the threshold up to the maximum delay
+func loginDelay(cfg *configs.Config, failures int) time.Duration {
+	maxDelay := loginMaxDelay(cfg)
+
+	delay := time.Second
+	for i := loginDelayAfter(cfg); i < failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+func loginMaxAttempts(cfg *configs.Config) int {
+	if cfg != nil && cfg.Login.MaxAttempts > 0 {
+		return cfg.Login.MaxAttempts
+	}
+	return defaultLoginMaxAttempts
+}
+
+func loginIPMaxAttempts(cfg *configs.Config) int {
+	if cfg != nil && cfg.Login.IPMaxAttempts > 0 {
+		return cfg.Login.IPMaxAttempts
+	}
+	return defaultLoginIPMaxAttempts
+}
+
+func loginLockDuration(cfg *configs.Config) time.Duration {
+	if cfg != nil && cfg.Login.LockDuration > 0 {
+		return time.Duration(cfg.Login.LockDuration) * time.Second
+	}
+	return defaultLoginLockDuration
+}
+
+func loginDelayAfter(cfg *configs.Config) int {
+	if cfg != nil && cfg.Login.DelayAfter > 0 {
+		return cfg.Login.DelayAfter
+	}
+	return defaultLoginDelayAfter
+}
+
+func loginMaxDelay(cfg *configs.Config) time.Duration {
+	if cfg != nil && cfg.Login.MaxDelay > 0 {
+		return time.Duration(cfg.Login.MaxDelay) * time.Second
+	}
+	return defaultLoginMaxDelay
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newLoginConfig() *configs.Config {
+	cfg := newAuthConfig()
+	cfg.Login.MaxAttempts = 5
+	cfg.Login.IPMaxAttempts = 20
+	cfg.Login.LockDuration = 900
+	cfg.Login.DelayAfter = 3
+	cfg.Login.MaxDelay = 60
+	return cfg
+}
+
+func TestAuthService_LoginLockout(t *testing.T) {
+	user := newTestUser(t, "secret123")
+	phoneKey := "login:phone:081234567890"
+	ipKey := "login:ip:10.0.0.1"
+	req := dto.LoginRequest{PhoneNumber: "081234567890", Password: "secret123", DeviceInfo: dto.DeviceInfo{IPAddress: "10.0.0.1"}}
+
+	t.Run("a locked phone number is refused even with the right password", func(t *testing.T) {
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+		mockAttemptRepo.On("Get", mock.Anything, phoneKey).Return(5, nil)
+
+		// the user repository mock fails the test if the password is checked
+		svc := NewAuthService(&testRegistry{ur: mocks.NewUserRepository(t), atr: mockAttemptRepo}, newLoginConfig(), testKeyring, nil)
+
+		_, err := svc.Login(context.Background(), req)
+		assert.Equal(t, response.ErrInvalidCredentials, err)
+	})
+
+	t.Run("a locked IP address is refused", func(t *testing.T) {
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+		mockAttemptRepo.On("Get", mock.Anything, phoneKey).Return(0, nil)
+		mockAttemptRepo.On("BlockedFor", mock.Anything, phoneKey).Return(time.Duration(0), nil)
+		mockAttemptRepo.On("Get", mock.Anything, ipKey).Return(20, nil)
+
+		svc := NewAuthService(&testRegistry{ur: mocks.NewUserRepository(t), atr: mockAttemptRepo}, newLoginConfig(), testKeyring, nil)
+
+		_, err := svc.Login(context.Background(), req)
+		assert.Equal(t, response.ErrInvalidCredentials, err)
+	})
+
+	t.Run("attempts during the delay are refused", func(t *testing.T) {
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+		mockAttemptRepo.On("Get", mock.Anything, phoneKey).Return(3, nil)
+		mockAttemptRepo.On("BlockedFor", mock.Anything, phoneKey).Return(time.Second, nil)
+
+		svc := NewAuthService(&testRegistry{ur: mocks.NewUserRepository(t), atr: mockAttemptRepo}, newLoginConfig(), testKeyring, nil)
+
+		_, err := svc.Login(context.Background(), req)
+		assert.Equal(t, response.ErrInvalidCredentials, err)
+	})
+
+	t.Run("failures past the threshold delay the next attempt", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockAttemptRepo := withoutLoginFailures(t)
+
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, "081234567890").Return(user, nil)
+		mockAttemptRepo.On("Increment", mock.Anything, phoneKey, 15*time.Minute).Return(4, nil)
+		mockAttemptRepo.On("Increment", mock.Anything, ipKey, 15*time.Minute).Return(4, nil)
+		mockAttemptRepo.On("Block", mock.Anything, phoneKey, 2*time.Second).Return(nil)
+
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo, atr: mockAttemptRepo}, newLoginConfig(), testKeyring, nil)
+
+		_, err := svc.Login(context.Background(), dto.LoginRequest{PhoneNumber: "081234567890", Password: "wrong", DeviceInfo: dto.DeviceInfo{IPAddress: "10.0.0.1"}})
+		assert.Equal(t, response.ErrInvalidCredentials, err)
+		mockAttemptRepo.AssertNotCalled(t, "Block", mock.Anything, ipKey, mock.Anything)
+	})
+
+	t.Run("the failure reaching the maximum locks the phone number", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockAttemptRepo := withoutLoginFailures(t)
+
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, "081234567890").Return(user, nil)
+		mockAttemptRepo.On("Increment", mock.Anything, phoneKey, 15*time.Minute).Return(5, nil)
+		mockAttemptRepo.On("Increment", mock.Anything, ipKey, 15*time.Minute).Return(5, nil)
+
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo, atr: mockAttemptRepo}, newLoginConfig(), testKeyring, nil)
+
+		_, err := svc.Login(context.Background(), dto.LoginRequest{PhoneNumber: "081234567890", Password: "wrong", DeviceInfo: dto.DeviceInfo{IPAddress: "10.0.0.1"}})
+		assert.Equal(t, response.ErrInvalidCredentials, err)
+	})
+
+	t.Run("a successful login clears the failures of the phone number only", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockSessionRepo := mocks.NewSessionRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mockAttemptRepo.On("Get", mock.Anything, phoneKey).Return(2, nil)
+		mockAttemptRepo.On("Get", mock.Anything, ipKey).Return(2, nil)
+		mockAttemptRepo.On("BlockedFor", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+		mockAttemptRepo.On("Reset", mock.Anything, phoneKey).Return(nil)
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, "081234567890").Return(user, nil)
+		mockSessionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		reg := &testRegistry{ur: mockUserRepo, sr: mockSessionRepo, atr: mockAttemptRepo, mfr: withoutMFA(t, "user-1"), rlr: withPermissions(t, auth.RoleUser)}
+		svc := NewAuthService(reg, newLoginConfig(), testKeyring, nil)
+
+		_, err := svc.Login(context.Background(), req)
+		require.NoError(t, err)
+		mockAttemptRepo.AssertNotCalled(t, "Reset", mock.Anything, ipKey)
+	})
+}
+
+func TestLoginDelay(t *testing.T) {
+	cfg := newLoginConfig()
+
+	assert.Equal(t, time.Second, loginDelay(cfg, 3))
+	assert.Equal(t, 2*time.Second, loginDelay(cfg, 4))
+	assert.Equal(t, 32*time.Second, loginDelay(cfg, 8))
+	assert.Equal(t, time.Minute, loginDelay(cfg, 9))
+	assert.Equal(t, time.Minute, loginDelay(cfg, 50))
+}
+
+func TestAdminService_UnlockLogin(t *testing.T) {
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+	mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newTestUser(t, "secret123"), nil)
+	mockAttemptRepo.On("Reset", mock.Anything, "login:phone:081234567890").Return(nil)
+
+	svc := NewAdminService(&testRegistry{ur: mockUserRepo, atr: mockAttemptRepo}, newLoginConfig())
+
+	require.NoError(t, svc.UnlockLogin(context.Background(), dto.UnlockLoginRequest{UserID: "user-1", ActorID: "support-1", Reason: "Verified by phone"}))
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	response "digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// merchantAPIKeyPrefix marks merchant API keys; the part before the dot is stored in clear for lookup
+const merchantAPIKeyPrefix = "mk_"
+
+type MerchantService struct {
+	repo interfaces.RegistryRepository
+	cfg  *configs.Config
+}
+
+// Ensure MerchantService implements interfaces.MerchantService
+var _ interfaces.MerchantService = (*MerchantService)(nil)
+
+func NewMerchantService(repo interfaces.RegistryRepository, config *configs.Config) interfaces.MerchantService {
+	return &MerchantService{
+		repo: repo,
+		cfg:  config,
+	}
+}
+
+// CreateMerchant registers a merchant owned by an existing user, using that user's wallet for settlement
+func (s *MerchantService) CreateMerchant(ctx context.Context, req dto.CreateMerchantRequest) (*dto.MerchantCredentialsResponse, error) {
+	if err := authorizeUser(ctx, s.repo, req.UserID, auth.PermissionWalletManage); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		wallet, err := getOrCreateWallet(ctx, txRepo.GetWalletRepository(), req.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		prefix, apiKey, err := generateMerchantAPIKey()
+		if err != nil {
+			return nil, err
+		}
+
+		callbackSecret, sealedSecret, err := s.generateCallbackSecret()
+		if err != nil {
+			return nil, err
+		}
+
+		merchant := &models.Merchant{
+			ID:                 uuid.New().String(),
+			UserID:             req.UserID,
+			Name:               req.Name,
+			SettlementWalletID: wallet.ID,
+			APIKeyPrefix:       prefix,
+			APIKeyHash:         utils.HashToken(apiKey),
+			CallbackURL:        req.CallbackURL,
+			CallbackSecret:     sealedSecret,
+			IsActive:           true,
+		}
+
+		if err := txRepo.GetMerchantRepository().Create(ctx, merchant); err != nil {
+			return nil, response.Wrap(err, "error creating merchant")
+		}
+
+		if err := recordAudit(ctx, txRepo, models.AuditActionMerchantCreate, models.AuditResourceMerchant, merchant.ID, nil, merchant); err != nil {
+			return nil, err
+		}
+
+		return toMerchantCredentials(merchant, apiKey, callbackSecret), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*dto.MerchantCredentialsResponse), nil
+}
+
+// GetMerchant returns a merchant owned by the user
+func (s *MerchantService) GetMerchant(ctx context.Context, userID, id string) (*models.Merchant, error) {
+	merchant, err := s.repo.GetMerchantRepository().GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("Merchant")
+		}
+		return nil, response.Wrap(err, "error retrieving merchant")
+	}
+
+	if merchant.UserID != userID {
+		return nil, response.NewNotFoundError("Merchant")
+	}
+
+	return merchant, nil
+}
+
+// UpdateMerchant changes the merchant's display name and callback URL. Fields left out of the
+// request keep their value.
+func (s *MerchantService) UpdateMerchant(ctx context.Context, userID, id string, req dto.UpdateMerchantRequest) (*models.Merchant, error) {
+	merchant, err := s.GetMerchant(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *merchant
+	if req.Name != "" {
+		merchant.Name = req.Name
+	}
+	if req.CallbackURL != nil {
+		merchant.CallbackURL = *req.CallbackURL
+	}
+
+	if err := s.saveMerchant(ctx, models.AuditActionMerchantUpdate, &before, merchant); err != nil {
+		return nil, err
+	}
+
+	return merchant, nil
+}
+
+// RotateAPIKey issues a new API key and callback secret, and invalidates the previous ones
+// immediately
+func (s *MerchantService) RotateAPIKey(ctx context.Context, userID, id string) (*dto.MerchantCredentialsResponse, error) {
+	merchant, err := s.GetMerchant(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, apiKey, err := generateMerchantAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	callbackSecret, sealedSecret, err := s.generateCallbackSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	before := *merchant
+	merchant.APIKeyPrefix = prefix
+	merchant.APIKeyHash = utils.HashToken(apiKey)
+	merchant.CallbackSecret = sealedSecret
+
+	if err := s.saveMerchant(ctx, models.AuditActionMerchantRotate, &before, merchant); err != nil {
+		return nil, err
+	}
+
+	return toMerchantCredentials(merchant, apiKey, callbackSecret), nil
+}
+
+// saveMerchant updates the merchant and records the change in the audit log. The key hash is
+// left out of the snapshots like it is left out of responses.
+func (s *MerchantService) saveMerchant(ctx context.Context, action string, before, merchant *models.Merchant) error {
+	_, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetMerchantRepository().Update(ctx, merchant); err != nil {
+			return nil, response.Wrap(err, "error updating merchant")
+		}
+
+		return nil, recordAudit(ctx, txRepo, action, models.AuditResourceMerchant, merchant.ID, before, merchant)
+	})
+	return err
+}
+
+// Authenticate resolves an API key sent by a merchant to its active merchant record
+func (s *MerchantService) Authenticate(ctx context.Context, apiKey string) (*models.Merchant, error) {
+	prefix, _, ok := strings.Cut(apiKey, ".")
+	if !ok || !strings.HasPrefix(prefix, merchantAPIKeyPrefix) {
+		return nil, response.ErrInvalidCredentials
+	}
+
+	merchant, err := s.repo.GetMerchantRepository().GetByAPIKeyPrefix(ctx, prefix)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving merchant")
+	}
+
+	if merchant == nil || !utils.CompareTokenHash(merchant.APIKeyHash, apiKey) {
+		return nil, response.ErrInvalidCredentials
+	}
+
+	if !merchant.IsActive {
+		return nil, response.ErrAccountDeactivated
+	}
+
+	return merchant, nil
+}
+
+// generateMerchantAPIKey returns the lookup prefix and the full key handed to the merchant
+func generateMerchantAPIKey() (string, string, error) {
+	id, err := utils.RandomHex(6)
+	if err != nil {
+		return "", "", err
+	}
+
+	secret, err := utils.RandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	prefix := merchantAPIKeyPrefix + id
+	return prefix, prefix + "." + secret, nil
+}
+
+// generateCallbackSecret returns the secret handed to the merchant and the sealed copy stored to
+// sign its callbacks
+func (s *MerchantService) generateCallbackSecret() (string, string, error) {
+	secret, err := utils.RandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	sealed, err := utils.Seal(secret, callbackEncryptionKey(s.cfg))
+	if err != nil {
+		return "", "", response.Wrap(err, "error sealing callback secret")
+	}
+
+	return secret, sealed, nil
+}
+
+func toMerchantCredentials(merchant *models.Merchant, apiKey, callbackSecret string) *dto.MerchantCredentialsResponse {
+	return &dto.MerchantCredentialsResponse{
+		MerchantID:         merchant.ID,
+		Name:               merchant.Name,
+		SettlementWalletID: merchant.SettlementWalletID,
+		CallbackURL:        merchant.CallbackURL,
+		APIKey:             apiKey,
+		CallbackSecret:     callbackSecret,
+	}
+}
@@ -0,0 +1,424 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	response "digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+	"digital-wallet/pkg/totp"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultMFAIssuer                 = "Digital Wallet"
+	defaultMFAPendingTokenExpiration = 5 * time.Minute
+	defaultMFAMaxAttempts            = 5
+	defaultMFALockDuration           = 15 * time.Minute
+	defaultMFARecoveryCodes          = 10
+
+	// mfaSkew accepts codes of the time steps next to the current one, for clock drift
+	mfaSkew = 1
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type MFAService struct {
+	repo interfaces.RegistryRepository
+	cfg  *configs.Config
+}
+
+// Ensure MFAService implements interfaces.MFAService
+var _ interfaces.MFAService = (*MFAService)(nil)
+
+func NewMFAService(repo interfaces.RegistryRepository, config *configs.Config) interfaces.MFAService {
+	return &MFAService{
+		repo: repo,
+		cfg:  config,
+	}
+}
+
+// GetStatus returns whether the user has two-factor authentication enabled and its settings
+func (s *MFAService) GetStatus(ctx context.Context, userID string) (*dto.MFAStatusResponse, error) {
+	mfa, err := s.repo.GetMFARepository().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving two-factor authentication")
+	}
+
+	return s.toStatusResponse(ctx, mfa)
+}
+
+// Enroll generates a new secret for the user. It takes effect once ConfirmEnrollment has
+// verified a code from it; enrolling again before that replaces the secret.
+func (s *MFAService) Enroll(ctx context.Context, userID string) (*dto.MFAEnrollmentResponse, error) {
+	user, err := s.repo.GetUserRepository().GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("User")
+		}
+		return nil, response.Wrap(err, "error retrieving user")
+	}
+
+	mfaRepo := s.repo.GetMFARepository()
+
+	existing, err := mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving two-factor authentication")
+	}
+
+	if existing.IsEnabled() {
+		return nil, response.NewDuplicateEntryError("Two-factor authentication is already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, response.Wrap(err, "error generating secret")
+	}
+
+	sealed, err := utils.Seal(secret, mfaEncryptionKey(s.cfg))
+	if err != nil {
+		return nil, response.Wrap(err, "error encrypting secret")
+	}
+
+	if err := mfaRepo.Save(ctx, &models.UserMFA{UserID: userID, Secret: sealed}); err != nil {
+		return nil, response.Wrap(err, "error saving two-factor authentication")
+	}
+
+	account := user.PhoneNumber
+	if user.Email != nil {
+		account = *user.Email
+	}
+
+	return &dto.MFAEnrollmentResponse{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(s.issuer(), account, secret),
+	}, nil
+}
+
+// ConfirmEnrollment enables two-factor authentication with a code from the enrolled secret
+// and returns the recovery codes. They are shown only this once.
+func (s *MFAService) ConfirmEnrollment(ctx context.Context, userID string, req dto.MFACodeRequest) (*dto.MFARecoveryCodesResponse, error) {
+	mfa, err := s.repo.GetMFARepository().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving two-factor authentication")
+	}
+
+	if mfa == nil {
+		return nil, response.NewNotFoundError("Two-factor enrollment")
+	}
+
+	if mfa.IsEnabled() {
+		return nil, response.NewDuplicateEntryError("Two-factor authentication is already enabled")
+	}
+
+	if err := verifyMFACode(ctx, s.repo, s.cfg, mfa, req.Code, false); err != nil {
+		return nil, err
+	}
+
+	codes, hashed, err := s.generateRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		now := time.Now()
+		mfa.EnabledAt = &now
+
+		if err := txRepo.GetMFARepository().Save(ctx, mfa); err != nil {
+			return nil, response.Wrap(err, "error saving two-factor authentication")
+		}
+
+		if err := txRepo.GetMFARecoveryCodeRepository().Replace(ctx, userID, hashed); err != nil {
+			return nil, response.Wrap(err, "error saving recovery codes")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionMFAEnable, models.AuditResourceUser, userID, nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes, used or not
+func (s *MFAService) RegenerateRecoveryCodes(ctx context.Context, userID string, req dto.MFACodeRequest) (*dto.MFARecoveryCodesResponse, error) {
+	if _, err := s.getEnabled(ctx, userID, req.Code, false); err != nil {
+		return nil, err
+	}
+
+	codes, hashed, err := s.generateRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetMFARecoveryCodeRepository().Replace(ctx, userID, hashed); err != nil {
+			return nil, response.Wrap(err, "error saving recovery codes")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionMFARecovery, models.AuditResourceUser, userID, nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// UpdateSettings changes the withdrawal threshold. It needs a current code, since removing
+// the threshold weakens the protection of the wallet.
+func (s *MFAService) UpdateSettings(ctx context.Context, userID string, req dto.UpdateMFASettingsRequest) (*dto.MFAStatusResponse, error) {
+	mfa, err := s.getEnabled(ctx, userID, req.Code, false)
+	if err != nil {
+		return nil, err
+	}
+
+	before := mfa.WithdrawalThreshold
+	mfa.WithdrawalThreshold = req.WithdrawalThreshold
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetMFARepository().Save(ctx, mfa); err != nil {
+			return nil, response.Wrap(err, "error saving two-factor authentication")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionMFASettings, models.AuditResourceUser, userID,
+			map[string]interface{}{"withdrawal_threshold": before},
+			map[string]interface{}{"withdrawal_threshold": mfa.WithdrawalThreshold},
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toStatusResponse(ctx, mfa)
+}
+
+// Disable turns two-factor authentication off. A recovery code is accepted, for users who
+// lost their authenticator.
+func (s *MFAService) Disable(ctx context.Context, userID string, req dto.MFACodeRequest) error {
+	if _, err := s.getEnabled(ctx, userID, req.Code, true); err != nil {
+		return err
+	}
+
+	_, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetMFARecoveryCodeRepository().DeleteByUserID(ctx, userID); err != nil {
+			return nil, response.Wrap(err, "error deleting recovery codes")
+		}
+
+		if err := txRepo.GetMFARepository().Delete(ctx, userID); err != nil {
+			return nil, response.Wrap(err, "error deleting two-factor authentication")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionMFADisable, models.AuditResourceUser, userID, nil, nil)
+	})
+
+	return err
+}
+
+// getEnabled returns the user's second factor after verifying code against it
+func (s *MFAService) getEnabled(ctx context.Context, userID, code string, allowRecovery bool) (*models.UserMFA, error) {
+	mfa, err := s.repo.GetMFARepository().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving two-factor authentication")
+	}
+
+	if !mfa.IsEnabled() {
+		return nil, response.NewValidationError("Two-factor authentication is not enabled")
+	}
+
+	if err := verifyMFACode(ctx, s.repo, s.cfg, mfa, code, allowRecovery); err != nil {
+		return nil, err
+	}
+
+	return mfa, nil
+}
+
+// generateRecoveryCodes returns the codes to show the user and the records storing their hashes
+func (s *MFAService) generateRecoveryCodes(userID string) ([]string, []models.MFARecoveryCode, error) {
+	count := defaultMFARecoveryCodes
+	if s.cfg != nil && s.cfg.MFA.RecoveryCodes > 0 {
+		count = s.cfg.MFA.RecoveryCodes
+	}
+
+	codes := make([]string, count)
+	records := make([]models.MFARecoveryCode, count)
+	for i := range codes {
+		b := make([]byte, 6)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, response.Wrap(err, "error generating recovery codes")
+		}
+
+		// 48 random bits as ten base32 characters, shown as xxxxx-xxxxx
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		codes[i] = code[:5] + "-" + code[5:]
+		records[i] = models.MFARecoveryCode{
+			ID:       uuid.New().String(),
+			UserID:   userID,
+			CodeHash: utils.HashToken(code),
+		}
+	}
+
+	return codes, records, nil
+}
+
+func (s *MFAService) toStatusResponse(ctx context.Context, mfa *models.UserMFA) (*dto.MFAStatusResponse, error) {
+	res := &dto.MFAStatusResponse{Enabled: mfa.IsEnabled()}
+	if !res.Enabled {
+		return res, nil
+	}
+
+	remaining, err := s.repo.GetMFARecoveryCodeRepository().CountUnused(ctx, mfa.UserID)
+	if err != nil {
+		return nil, response.Wrap(err, "error counting recovery codes")
+	}
+
+	enabledAt := mfa.EnabledAt.Format(time.RFC3339)
+	res.EnabledAt = &enabledAt
+	res.WithdrawalThreshold = mfa.WithdrawalThreshold
+	res.RecoveryCodesRemaining = remaining
+
+	return res, nil
+}
+
+func (s *MFAService) issuer() string {
+	if s.cfg != nil && s.cfg.MFA.Issuer != "" {
+		return s.cfg.MFA.Issuer
+	}
+	return defaultMFAIssuer
+}
+
+// verifyOutgoingMFA asks for the user's two-factor code when money leaving their wallet is above
+// their threshold. Withdrawals, checkout payments and escrow funding all go through it, since
+// each sends money to a party the user picks.
+func verifyOutgoingMFA(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, userID string, amount float64, code string) error {
+	mfa, err := repo.GetMFARepository().GetByUserID(ctx, userID)
+	if err != nil {
+		return response.Wrap(err, "error retrieving two-factor authentication")
+	}
+
+	if !mfa.RequiresCodeForWithdrawal(amount) {
+		return nil
+	}
+
+	if code == "" {
+		return response.ErrMFARequired
+	}
+
+	return verifyMFACode(ctx, repo, cfg, mfa, code, false)
+}
+
+// verifyMFACode checks a TOTP code, or a recovery code when allowRecovery is set, against
+// the user's second factor. Like PINs, every attempt is counted in Redis before the code is
+// compared, so concurrent guesses each use one up, and verification locks once the count
+// passes the maximum. A correct code clears the count.
+func verifyMFACode(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, mfa *models.UserMFA, code string, allowRecovery bool) error {
+	attemptRepo := repo.GetAttemptRepository()
+	key := mfaAttemptKey(mfa.UserID)
+	maxAttempts := mfaMaxAttempts(cfg)
+
+	attempts, err := attemptRepo.Increment(ctx, key, mfaLockDuration(cfg))
+	if err != nil {
+		return response.Wrap(err, "error recording verification attempt")
+	}
+
+	if attempts > maxAttempts {
+		return response.ErrMFALocked
+	}
+
+	matched, err := matchMFACode(ctx, repo, cfg, mfa, code, allowRecovery)
+	if err != nil {
+		return err
+	}
+
+	if !matched {
+		if attempts == maxAttempts {
+			if err := recordSecurityEvent(ctx, repo, auth.SecurityEventMFALocked, models.AuditResourceUser, mfa.UserID, map[string]interface{}{"attempts": attempts}); err != nil {
+				return err
+			}
+			return response.ErrMFALocked
+		}
+		return response.ErrInvalidMFACode
+	}
+
+	if err := attemptRepo.Reset(ctx, key); err != nil {
+		return response.Wrap(err, "error resetting verification attempts")
+	}
+
+	return nil
+}
+
+// matchMFACode reports whether code is an unused TOTP code or, if allowed, an unused
+// recovery code. A matching code is used up.
+func matchMFACode(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, mfa *models.UserMFA, code string, allowRecovery bool) (bool, error) {
+	secret, err := utils.Open(mfa.Secret, mfaEncryptionKey(cfg))
+	if err != nil {
+		return false, response.Wrap(err, "error decrypting secret")
+	}
+
+	if step, ok := totp.Validate(secret, code, time.Now(), mfaSkew); ok {
+		used, err := repo.GetMFARepository().UseStep(ctx, mfa.UserID, step)
+		if err != nil {
+			return false, response.Wrap(err, "error recording verification code")
+		}
+		if used {
+			mfa.LastUsedStep = step
+		}
+		return used, nil
+	}
+
+	if !allowRecovery {
+		return false, nil
+	}
+
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	used, err := repo.GetMFARecoveryCodeRepository().Use(ctx, mfa.UserID, utils.HashToken(normalized))
+	if err != nil {
+		return false, response.Wrap(err, "error using recovery code")
+	}
+
+	return used, nil
+}
+
+func mfaAttemptKey(userID string) string {
+	return "mfa:" + userID
+}
+
+// mfaEncryptionKey is the key TOTP secrets are sealed with
+func mfaEncryptionKey(cfg *configs.Config) string {
+	if cfg != nil {
+		return cfg.JWT.EncryptionKey
+	}
+	return ""
+}
+
+func mfaMaxAttempts(cfg *configs.Config) int {
+	if cfg != nil && cfg.MFA.MaxAttempts > 0 {
+		return cfg.MFA.MaxAttempts
+	}
+	return defaultMFAMaxAttempts
+}
+
+func mfaLockDuration(cfg *configs.Config) time.Duration {
+	if cfg != nil && cfg.MFA.LockDuration > 0 {
+		return time.Duration(cfg.MFA.LockDuration) * time.Second
+	}
+	return defaultMFALockDuration
+}
+
+func mfaPendingTokenExpiration(cfg *configs.Config) time.Duration {
+	if cfg != nil && cfg.MFA.PendingTokenExpiration > 0 {
+		return time.Duration(cfg.MFA.PendingTokenExpiration) * time.Second
+	}
+	return defaultMFAPendingTokenExpiration
+}
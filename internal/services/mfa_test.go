@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+	"digital-wallet/pkg/totp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testEncryptionKey = "0123456789abcdef0123456789abcdef"
+
+// withoutMFA returns an MFA repository in which userID has no second factor
+func withoutMFA(t *testing.T, userID string) *mocks.MFARepository {
+	mockMFARepo := mocks.NewMFARepository(t)
+	mockMFARepo.On("GetByUserID", mock.Anything, userID).Return(nil, nil).Maybe()
+	return mockMFARepo
+}
+
+func newMFAConfig() *configs.Config {
+	cfg := newAuthConfig()
+	cfg.JWT.EncryptionKey = testEncryptionKey
+	cfg.MFA.MaxAttempts = 3
+	cfg.MFA.LockDuration = 900
+	cfg.MFA.RecoveryCodes = 4
+	return cfg
+}
+
+// newTestMFA returns an enabled second factor for user-1 and the plain secret behind it
+func newTestMFA(t *testing.T) (*models.UserMFA, string) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	sealed, err := utils.Seal(secret, testEncryptionKey)
+	require.NoError(t, err)
+
+	enabledAt := time.Now().Add(-time.Hour)
+	return &models.UserMFA{UserID: "user-1", Secret: sealed, EnabledAt: &enabledAt}, secret
+}
+
+func currentCode(t *testing.T, secret string) string {
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func TestMFAService_Enrollment(t *testing.T) {
+	cfg := newMFAConfig()
+
+	t.Run("enroll stores the secret encrypted and returns an otpauth URI", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockMFARepo := mocks.NewMFARepository(t)
+
+		var saved *models.UserMFA
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newTestUser(t, "secret123"), nil)
+		mockMFARepo.On("GetByUserID", mock.Anything, "user-1").Return(nil, nil)
+		mockMFARepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*models.UserMFA)
+		}).Return(nil)
+
+		svc := NewMFAService(&testRegistry{ur: mockUserRepo, mfr: mockMFARepo}, cfg)
+
+		res, err := svc.Enroll(context.Background(), "user-1")
+		require.NoError(t, err)
+
+		assert.Contains(t, res.OTPAuthURI, "otpauth://totp/Digital%20Wallet:user@example.com?")
+		assert.Contains(t, res.OTPAuthURI, "secret="+res.Secret)
+		assert.False(t, saved.IsEnabled())
+		assert.NotEqual(t, res.Secret, saved.Secret)
+
+		opened, err := utils.Open(saved.Secret, testEncryptionKey)
+		require.NoError(t, err)
+		assert.Equal(t, res.Secret, opened)
+	})
+
+	t.Run("enroll refuses when already enabled", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockMFARepo := mocks.NewMFARepository(t)
+
+		mfa, _ := newTestMFA(t)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newTestUser(t, "secret123"), nil)
+		mockMFARepo.On("GetByUserID", mock.Anything, "user-1").Return(mfa, nil)
+
+		svc := NewMFAService(&testRegistry{ur: mockUserRepo, mfr: mockMFARepo}, cfg)
+
+		_, err := svc.Enroll(context.Background(), "user-1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already enabled")
+	})
+
+	t.Run("confirming with a valid code enables it and issues recovery codes", func(t *testing.T) {
+		mockMFARepo := mocks.NewMFARepository(t)
+		mockRecoveryRepo := mocks.NewMFARecoveryCodeRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mfa, secret := newTestMFA(t)
+		mfa.EnabledAt = nil
+		mockMFARepo.On("GetByUserID", mock.Anything, "user-1").Return(mfa, nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "mfa:user-1", 15*time.Minute).Return(1, nil)
+		mockAttemptRepo.On("Reset", mock.Anything, "mfa:user-1").Return(nil)
+		mockMFARepo.On("UseStep", mock.Anything, "user-1", mock.Anything).Return(true, nil)
+		mockMFARepo.On("Save", mock.Anything, mock.MatchedBy(func(m *models.UserMFA) bool {
+			return m.IsEnabled() && m.LastUsedStep > 0
+		})).Return(nil)
+
+		var hashed []models.MFARecoveryCode
+		mockRecoveryRepo.On("Replace", mock.Anything, "user-1", mock.Anything).Run(func(args mock.Arguments) {
+			hashed = args.Get(2).([]models.MFARecoveryCode)
+		}).Return(nil)
+
+		svc := NewMFAService(&testRegistry{mfr: mockMFARepo, rcr: mockRecoveryRepo, atr: mockAttemptRepo}, cfg)
+
+		res, err := svc.ConfirmEnrollment(context.Background(), "user-1", dto.MFACodeRequest{Code: currentCode(t, secret)})
+		require.NoError(t, err)
+		require.Len(t, res.RecoveryCodes, 4)
+		require.Len(t, hashed, 4)
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, res.RecoveryCodes[0])
+		assert.NotContains(t, hashed[0].CodeHash, res.RecoveryCodes[0])
+	})
+}
+
+func TestVerifyMFACode(t *testing.T) {
+	cfg := newMFAConfig()
+
+	t.Run("a code that was already used is refused", func(t *testing.T) {
+		mockMFARepo := mocks.NewMFARepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mfa, secret := newTestMFA(t)
+		mockAttemptRepo.On("Increment", mock.Anything, "mfa:user-1", 15*time.Minute).Return(1, nil)
+		mockMFARepo.On("UseStep", mock.Anything, "user-1", mock.Anything).Return(false, nil)
+
+		err := verifyMFACode(context.Background(), &testRegistry{mfr: mockMFARepo, atr: mockAttemptRepo}, cfg, mfa, currentCode(t, secret), false)
+		assert.Equal(t, response.ErrInvalidMFACode, err)
+	})
+
+	t.Run("recovery code is accepted when allowed", func(t *testing.T) {
+		mockRecoveryRepo := mocks.NewMFARecoveryCodeRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mfa, _ := newTestMFA(t)
+		mockAttemptRepo.On("Increment", mock.Anything, "mfa:user-1", 15*time.Minute).Return(2, nil)
+		mockAttemptRepo.On("Reset", mock.Anything, "mfa:user-1").Return(nil)
+		mockRecoveryRepo.On("Use", mock.Anything, "user-1", utils.HashToken("abcdefghij")).Return(true, nil)
+
+		err := verifyMFACode(context.Background(), &testRegistry{rcr: mockRecoveryRepo, atr: mockAttemptRepo}, cfg, mfa, "ABCDE-FGHIJ", true)
+		require.NoError(t, err)
+	})
+
+	t.Run("recovery code is not accepted when not allowed", func(t *testing.T) {
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mfa, _ := newTestMFA(t)
+		mockAttemptRepo.On("Increment", mock.Anything, "mfa:user-1", 15*time.Minute).Return(1, nil)
+
+		err := verifyMFACode(context.Background(), &testRegistry{atr: mockAttemptRepo}, cfg, mfa, "abcde-fghij", false)
+		assert.Equal(t, response.ErrInvalidMFACode, err)
+	})
+
+	t.Run("last allowed failure locks verification", func(t *testing.T) {
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mfa, _ := newTestMFA(t)
+		mockAttemptRepo.On("Increment", mock.Anything, "mfa:user-1", 15*time.Minute).Return(3, nil)
+
+		err := verifyMFACode(context.Background(), &testRegistry{atr: mockAttemptRepo}, cfg, mfa, "000000", false)
+		assert.Equal(t, response.ErrMFALocked, err)
+	})
+
+	t.Run("locked verification does not check the code", func(t *testing.T) {
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mfa, secret := newTestMFA(t)
+		mockAttemptRepo.On("Increment", mock.Anything, "mfa:user-1", 15*time.Minute).Return(4, nil)
+
+		err := verifyMFACode(context.Background(), &testRegistry{atr: mockAttemptRepo}, cfg, mfa, currentCode(t, secret), false)
+		assert.Equal(t, response.ErrMFALocked, err)
+	})
+}
+
+func TestAuthService_LoginWithMFA(t *testing.T) {
+	cfg := newMFAConfig()
+	user := newTestUser(t, "secret123")
+
+	t.Run("login returns an mfa_pending token instead of a session", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockMFARepo := mocks.NewMFARepository(t)
+
+		mfa, _ := newTestMFA(t)
+		mockUserRepo.On("GetByPhoneNumber", mock.Anything, "081234567890").Return(user, nil)
+		mockMFARepo.On("GetByUserID", mock.Anything, "user-1").Return(mfa, nil)
+
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo, mfr: mockMFARepo, atr: withoutLoginFailures(t)}, cfg, testKeyring, nil)
+
+		res, err := svc.Login(context.Background(), dto.LoginRequest{PhoneNumber: "081234567890", Password: "secret123"})
+		require.NoError(t, err)
+		assert.True(t, res.MFARequired)
+		assert.Empty(t, res.AccessToken)
+		assert.Empty(t, res.RefreshToken)
+
+		claims := claimsOf(t, res.MFAToken)
+		assert.Equal(t, auth.TokenTypeMFAPending, claims["type"])
+		assert.Empty(t, claims["session_id"])
+	})
+
+	t.Run("verifying the code starts the session", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockMFARepo := mocks.NewMFARepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+		mockSessionRepo := mocks.NewSessionRepository(t)
+
+		mfa, secret := newTestMFA(t)
+		mockMFARepo.On("GetByUserID", mock.Anything, "user-1").Return(mfa, nil)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(user, nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "mfa:user-1", 15*time.Minute).Return(1, nil)
+		mockAttemptRepo.On("Reset", mock.Anything, "mfa:user-1").Return(nil)
+		mockMFARepo.On("UseStep", mock.Anything, "user-1", mock.Anything).Return(true, nil)
+		mockSessionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		svc := NewAuthService(&testRegistry{ur: mockUserRepo, mfr: mockMFARepo, atr: mockAttemptRepo, sr: mockSessionRepo, rlr: withPermissions(t, auth.RoleUser)}, cfg, testKeyring, nil)
+
+		pending, err := svc.(*AuthService).issueMFAToken(user)
+		require.NoError(t, err)
+
+		res, err := svc.VerifyMFA(context.Background(), dto.VerifyMFARequest{MFAToken: pending.MFAToken, Code: currentCode(t, secret)})
+		require.NoError(t, err)
+		assert.Equal(t, auth.TokenTypeAccess, claimsOf(t, res.AccessToken)["type"])
+	})
+
+	t.Run("an access token is not accepted as mfa token", func(t *testing.T) {
+		token, err := auth.NewToken(auth.UserAuth{ID: "user-1", SessionID: "session-1"}, auth.TokenTypeAccess, "jti", time.Now().Add(time.Minute), testKeyring)
+		require.NoError(t, err)
+
+		svc := NewAuthService(&testRegistry{}, cfg, testKeyring, nil)
+
+		_, err = svc.VerifyMFA(context.Background(), dto.VerifyMFARequest{MFAToken: token, Code: "123456"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid MFA token")
+	})
+}
+
+func TestWalletService_WithdrawAboveMFAThreshold(t *testing.T) {
+	threshold := 1000.0
+	mfa, _ := newTestMFA(t)
+	mfa.WithdrawalThreshold = &threshold
+
+	mockMFARepo := mocks.NewMFARepository(t)
+	mockMFARepo.On("GetByUserID", mock.Anything, "user-1").Return(mfa, nil)
+
+	svc := NewWalletService(withinKYCLimits(t, withPIN(t, &testRegistry{mfr: mockMFARepo}, "user-1")), newMFAConfig(), nil)
+
+	_, err := svc.Withdraw(context.Background(), dto.WithdrawRequest{UserID: "user-1", Amount: 5000, PIN: testPIN})
+	assert.Equal(t, response.ErrMFARequired, err)
+}
@@ -0,0 +1,377 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/internal/templates"
+	"digital-wallet/pkg/notifier"
+	response "digital-wallet/pkg/response"
+	"fmt"
+	"io/fs"
+	"math"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultNotificationLocale      = "en"
+	defaultNotificationMaxAttempts = 5
+	defaultNotificationBackoff     = time.Minute
+)
+
+type NotificationService struct {
+	repo      interfaces.RegistryRepository
+	cfg       *configs.Config
+	notifiers notifier.Registry
+	templates map[string]*template.Template
+}
+
+// Ensure NotificationService implements interfaces.NotificationService
+var _ interfaces.NotificationService = (*NotificationService)(nil)
+
+func NewNotificationService(repo interfaces.RegistryRepository, config *configs.Config, notifiers notifier.Registry) interfaces.NotificationService {
+	return &NotificationService{
+		repo:      repo,
+		cfg:       config,
+		notifiers: notifiers,
+		templates: mustParseNotificationTemplates(templates.Notifications),
+	}
+}
+
+// notificationData is what the event templates can refer to
+type notificationData struct {
+	Amount        string
+	Description   string
+	TransactionID string
+	Time          string
+	Device        string
+	IPAddress     string
+}
+
+// GetPreferences returns the user's preferences, or the defaults when none were saved
+func (s *NotificationService) GetPreferences(ctx context.Context, userID string) (*models.NotificationPreference, error) {
+	preference, err := s.repo.GetNotificationPreferenceRepository().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving notification preferences")
+	}
+
+	if preference == nil {
+		preference = &models.NotificationPreference{
+			UserID:       userID,
+			Locale:       s.defaultLocale(),
+			EmailEnabled: true,
+			PushEnabled:  true,
+		}
+	}
+
+	return preference, nil
+}
+
+// UpdatePreferences is
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID string, req dto.UpdateNotificationPreferencesRequest) (*models.NotificationPreference, error) {
+	preference, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *preference
+	if req.Locale != "" {
+		preference.Locale = req.Locale
+	}
+	preference.Email = req.Email
+	preference.PhoneNumber = req.PhoneNumber
+	preference.PushToken = req.PushToken
+	preference.EmailEnabled = req.EmailEnabled
+	preference.SMSEnabled = req.SMSEnabled
+	preference.PushEnabled = req.PushEnabled
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetNotificationPreferenceRepository().Save(ctx, preference); err != nil {
+			return nil, response.Wrap(err, "error saving notification preferences")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionPrefsUpdate, models.AuditResourcePrefs, userID, before, preference)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return preference, nil
+}
+
+// OnTransactionSettled queues notifications for completed and failed withdrawals and for
+// received deposits. The rows commit together with the transaction, so a rolled back
+// transaction never produces a notification.
+func (s *NotificationService) OnTransactionSettled(ctx context.Context, repo interfaces.RegistryRepository, transaction *models.WalletTransaction) error {
+	event := notificationEventFor(transaction)
+	if event == "" {
+		return nil
+	}
+
+	wallet, err := repo.GetWalletRepository().GetByID(ctx, transaction.WalletID)
+	if err != nil {
+		return response.Wrap(err, "error retrieving wallet")
+	}
+
+	occurredAt := transaction.UpdatedAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	return s.enqueue(ctx, repo, wallet.UserID, event, transaction.ID, notificationData{
+		Amount:        formatAmount(transaction.Amount, wallet.Currency),
+		Description:   transaction.Description,
+		TransactionID: transaction.ID,
+		Time:          occurredAt.UTC().Format("2 Jan 2006 15:04 UTC"),
+	})
+}
+
+// OnNewDevice queues a warning that the user was seen on a device for the first time, so
+// they can remove it if it was not them
+func (s *NotificationService) OnNewDevice(ctx context.Context, repo interfaces.RegistryRepository, device *models.Device) error {
+	name := device.DeviceName
+	if name == "" {
+		name = device.UserAgent
+	}
+
+	return s.enqueue(ctx, repo, device.UserID, models.NotificationEventNewDevice, device.ID, notificationData{
+		Device:    name,
+		IPAddress: device.IPAddress,
+		Time:      device.FirstSeenAt.UTC().Format("2 Jan 2006 15:04 UTC"),
+	})
+}
+
+// DeliverPending sends notifications whose next attempt is due and returns how many were
+// delivered. Failed sends are retried with exponential backoff until the attempts run out.
+func (s *NotificationService) DeliverPending(ctx context.Context, limit int) (int, error) {
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		notificationRepo := txRepo.GetNotificationRepository()
+
+		now := time.Now()
+		due, err := notificationRepo.GetDueForUpdate(ctx, now, limit)
+		if err != nil {
+			return nil, response.Wrap(err, "error retrieving notifications")
+		}
+
+		delivered := 0
+		for i := range due {
+			notification := &due[i]
+
+			sendErr := s.notifiers.Send(ctx, notification.Channel, notifier.Message{
+				To:      notification.Recipient,
+				Subject: notification.Subject,
+				Body:    notification.Body,
+			})
+
+			s.recordAttempt(notification, sendErr, time.Now())
+			if sendErr == nil {
+				delivered++
+			}
+
+			if err := notificationRepo.Update(ctx, notification); err != nil {
+				return nil, response.Wrap(err, "error updating notification")
+			}
+		}
+
+		return delivered, nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.(int), nil
+}
+
+// recordAttempt applies the outcome of one send to the notification
+func (s *NotificationService) recordAttempt(notification *models.Notification, sendErr error, now time.Time) {
+	notification.Attempts++
+
+	if sendErr == nil {
+		notification.Status = models.NotificationStatusSent
+		notification.SentAt = &now
+		notification.LastError = ""
+		return
+	}
+
+	notification.LastError = sendErr.Error()
+
+	if notification.Attempts >= s.maxAttempts() {
+		notification.Status = models.NotificationStatusFailed
+		return
+	}
+
+	backoff := s.retryBackoff() * time.Duration(math.Pow(2, float64(notification.Attempts-1)))
+	notification.NextAttemptAt = now.Add(backoff)
+}
+
+// enqueue renders the event for every channel the user enabled and queues one notification
+// per channel. Users without preferences have no address to notify.
+func (s *NotificationService) enqueue(ctx context.Context, repo interfaces.RegistryRepository, userID, event, referenceID string, data notificationData) error {
+	preference, err := repo.GetNotificationPreferenceRepository().GetByUserID(ctx, userID)
+	if err != nil {
+		return response.Wrap(err, "error retrieving notification preferences")
+	}
+
+	if preference == nil {
+		return nil
+	}
+
+	locale := preference.Locale
+	if locale == "" {
+		locale = s.defaultLocale()
+	}
+
+	rendered, err := s.render(locale, event, data)
+	if err != nil {
+		return response.Wrap(err, "error rendering notification")
+	}
+
+	notificationRepo := repo.GetNotificationRepository()
+	now := time.Now()
+
+	for channel, recipient := range preference.Recipients() {
+		body := rendered.short
+		if channel == notifier.ChannelEmail {
+			body = rendered.body
+		}
+
+		_, err := notificationRepo.CreateIfAbsent(ctx, &models.Notification{
+			ID:            uuid.New().String(),
+			UserID:        userID,
+			Event:         event,
+			Channel:       channel,
+			ReferenceID:   referenceID,
+			Recipient:     recipient,
+			Locale:        rendered.locale,
+			Subject:       rendered.subject,
+			Body:          body,
+			Status:        models.NotificationStatusPending,
+			NextAttemptAt: now,
+		})
+		if err != nil {
+			return response.Wrap(err, "error queueing notification")
+		}
+	}
+
+	return nil
+}
+
+type renderedNotification struct {
+	locale  string
+	subject string
+	body    string
+	short   string
+}
+
+// render executes the event template of the locale, falling back to the default locale and
+// then to English when the locale has no template for the event
+func (s *NotificationService) render(locale, event string, data notificationData) (*renderedNotification, error) {
+	for _, candidate := range []string{locale, s.defaultLocale(), defaultNotificationLocale} {
+		tmpl, ok := s.templates[candidate+"/"+event]
+		if !ok {
+			continue
+		}
+
+		rendered := &renderedNotification{locale: candidate}
+		for name, out := range map[string]*string{"subject": &rendered.subject, "body": &rendered.body, "short": &rendered.short} {
+			var buf bytes.Buffer
+			if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+				return nil, err
+			}
+			*out = strings.TrimSpace(buf.String())
+		}
+
+		return rendered, nil
+	}
+
+	return nil, fmt.Errorf("no notification template for event %s", event)
+}
+
+func (s *NotificationService) defaultLocale() string {
+	if s.cfg != nil && s.cfg.Notification.DefaultLocale != "" {
+		return s.cfg.Notification.DefaultLocale
+	}
+	return defaultNotificationLocale
+}
+
+func (s *NotificationService) maxAttempts() int {
+	if s.cfg != nil && s.cfg.Notification.MaxAttempts > 0 {
+		return s.cfg.Notification.MaxAttempts
+	}
+	return defaultNotificationMaxAttempts
+}
+
+func (s *NotificationService) retryBackoff() time.Duration {
+	if s.cfg != nil && s.cfg.Notification.RetryBackoff > 0 {
+		return time.Duration(s.cfg.Notification.RetryBackoff) * time.Second
+	}
+	return defaultNotificationBackoff
+}
+
+// notificationEventFor returns the event a settled transaction triggers, if any
+func notificationEventFor(transaction *models.WalletTransaction) string {
+	switch {
+	case transaction.Type == models.TransactionTypeWithdrawal && transaction.Status == models.TransactionStatusCompleted:
+		return models.NotificationEventWithdrawalCompleted
+	case transaction.Type == models.TransactionTypeWithdrawal && transaction.Status == models.TransactionStatusFailed:
+		return models.NotificationEventWithdrawalFailed
+	case transaction.Type == models.TransactionTypeDeposit && transaction.Status == models.TransactionStatusCompleted:
+		return models.NotificationEventDepositReceived
+	default:
+		return ""
+	}
+}
+
+// mustParseNotificationTemplates parses <locale>/<event>.tmpl files keyed by "<locale>/<event>".
+// The templates are embedded in the binary, so a parse error is a programming error.
+func mustParseNotificationTemplates(fsys fs.FS) map[string]*template.Template {
+	files, err := fs.Glob(fsys, "notifications/*/*.tmpl")
+	if err != nil {
+		panic(err)
+	}
+
+	parsed := make(map[string]*template.Template, len(files))
+	for _, file := range files {
+		locale := path.Base(path.Dir(file))
+		event := strings.TrimSuffix(path.Base(file), ".tmpl")
+
+		parsed[locale+"/"+event] = template.Must(template.ParseFS(fsys, file))
+	}
+
+	return parsed
+}
+
+// formatAmount writes an amount with thousands separators, such as "IDR 1,250,000" or
+// "IDR 10,000.50" when it has cents
+func formatAmount(amount float64, currency string) string {
+	cents := int64(math.Round(math.Abs(amount) * 100))
+	whole := fmt.Sprintf("%d", cents/100)
+
+	var b strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+
+	if fraction := cents % 100; fraction != 0 {
+		fmt.Fprintf(&b, ".%02d", fraction)
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+
+	return strings.TrimSpace(currency + " " + sign + b.String())
+}
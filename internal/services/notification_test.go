@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/notifier"
+	"digital-wallet/pkg/notifier/smtptest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// failingNotifier rejects every message
+type failingNotifier struct{}
+
+func (failingNotifier) Channel() string { return notifier.ChannelSMS }
+
+func (failingNotifier) Send(ctx context.Context, msg notifier.Message) error {
+	return errors.New("gateway unavailable")
+}
+
+func TestNotificationService_OnTransactionSettled(t *testing.T) {
+	withdrawal := &models.WalletTransaction{
+		ID:          "tx-1",
+		WalletID:    "wallet-1",
+		Amount:      1250000,
+		Type:        models.TransactionTypeWithdrawal,
+		Status:      models.TransactionStatusCompleted,
+		Description: "ATM",
+		UpdatedAt:   time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+	}
+
+	t.Run("queues one notification per enabled channel", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockPreferenceRepo := mocks.NewNotificationPreferenceRepository(t)
+		mockNotificationRepo := mocks.NewNotificationRepository(t)
+
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(&models.Wallet{ID: "wallet-1", UserID: "user-1", Currency: "IDR"}, nil)
+		mockPreferenceRepo.On("GetByUserID", mock.Anything, "user-1").Return(&models.NotificationPreference{
+			UserID: "user-1", Locale: "en", Email: "user@example.com", PhoneNumber: "+628123456789",
+			EmailEnabled: true, SMSEnabled: true, PushEnabled: true,
+		}, nil)
+		mockNotificationRepo.On("CreateIfAbsent", mock.Anything, mock.MatchedBy(func(n *models.Notification) bool {
+			return n.Channel == notifier.ChannelEmail && n.Recipient == "user@example.com" &&
+				n.Event == models.NotificationEventWithdrawalCompleted && n.ReferenceID == "tx-1" &&
+				n.Subject == "Withdrawal of IDR 1,250,000 completed" &&
+				strings.Contains(n.Body, "IDR 1,250,000 has been withdrawn from your wallet on 18 Oct 2026 09:30 UTC.") &&
+				strings.Contains(n.Body, "Description: ATM")
+		})).Return(true, nil).Once()
+		mockNotificationRepo.On("CreateIfAbsent", mock.Anything, mock.MatchedBy(func(n *models.Notification) bool {
+			return n.Channel == notifier.ChannelSMS && n.Recipient == "+628123456789" &&
+				strings.HasPrefix(n.Body, "IDR 1,250,000 was withdrawn from your wallet.")
+		})).Return(true, nil).Once()
+
+		reg := &testRegistry{wr: mockWalletRepo, npr: mockPreferenceRepo, nr: mockNotificationRepo}
+		svc := NewNotificationService(reg, &configs.Config{}, notifier.NewRegistry())
+
+		require.NoError(t, svc.OnTransactionSettled(context.Background(), reg, withdrawal))
+	})
+
+	t.Run("renders the user's locale", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockPreferenceRepo := mocks.NewNotificationPreferenceRepository(t)
+		mockNotificationRepo := mocks.NewNotificationRepository(t)
+
+		failed := *withdrawal
+		failed.Status = models.TransactionStatusFailed
+
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(&models.Wallet{ID: "wallet-1", UserID: "user-1", Currency: "IDR"}, nil)
+		mockPreferenceRepo.On("GetByUserID", mock.Anything, "user-1").Return(&models.NotificationPreference{
+			UserID: "user-1", Locale: "id", Email: "user@example.com", EmailEnabled: true,
+		}, nil)
+		mockNotificationRepo.On("CreateIfAbsent", mock.Anything, mock.MatchedBy(func(n *models.Notification) bool {
+			return n.Event == models.NotificationEventWithdrawalFailed && n.Locale == "id" && n.Subject == "Penarikan IDR 1,250,000 gagal"
+		})).Return(true, nil).Once()
+
+		reg := &testRegistry{wr: mockWalletRepo, npr: mockPreferenceRepo, nr: mockNotificationRepo}
+		svc := NewNotificationService(reg, &configs.Config{}, notifier.NewRegistry())
+
+		require.NoError(t, svc.OnTransactionSettled(context.Background(), reg, &failed))
+	})
+
+	t.Run("falls back to the default locale", func(t *testing.T) {
+		svc := NewNotificationService(&testRegistry{}, &configs.Config{}, notifier.NewRegistry()).(*NotificationService)
+
+		rendered, err := svc.render("fr", models.NotificationEventDepositReceived, notificationData{Amount: "IDR 10,000"})
+		require.NoError(t, err)
+		assert.Equal(t, "en", rendered.locale)
+		assert.Equal(t, "You received IDR 10,000", rendered.subject)
+	})
+
+	t.Run("users without preferences are not notified", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockPreferenceRepo := mocks.NewNotificationPreferenceRepository(t)
+
+		mockWalletRepo.On("GetByID", mock.Anything, "wallet-1").Return(&models.Wallet{ID: "wallet-1", UserID: "user-1"}, nil)
+		mockPreferenceRepo.On("GetByUserID", mock.Anything, "user-1").Return(nil, nil)
+
+		reg := &testRegistry{wr: mockWalletRepo, npr: mockPreferenceRepo}
+		svc := NewNotificationService(reg, &configs.Config{}, notifier.NewRegistry())
+
+		require.NoError(t, svc.OnTransactionSettled(context.Background(), reg, withdrawal))
+	})
+
+	t.Run("ignores transactions without an event", func(t *testing.T) {
+		reg := &testRegistry{}
+		svc := NewNotificationService(reg, &configs.Config{}, notifier.NewRegistry())
+
+		payment := &models.WalletTransaction{Type: models.TransactionTypePayment, Status: models.TransactionStatusCompleted}
+		require.NoError(t, svc.OnTransactionSettled(context.Background(), reg, payment))
+	})
+}
+
+func TestNotificationService_DeliverPending(t *testing.T) {
+	sink, err := smtptest.NewSink()
+	require.NoError(t, err)
+	defer sink.Close()
+
+	notifiers := notifier.NewRegistry(
+		notifier.NewSMTPNotifier(notifier.SMTPConfig{Host: sink.Host(), Port: sink.Port(), From: "wallet@example.com"}),
+		failingNotifier{},
+	)
+
+	mockNotificationRepo := mocks.NewNotificationRepository(t)
+	mockNotificationRepo.On("GetDueForUpdate", mock.Anything, mock.Anything, 10).Return([]models.Notification{
+		{ID: "n-1", Channel: notifier.ChannelEmail, Recipient: "user@example.com", Subject: "You received IDR 10,000", Body: "Hello", Status: models.NotificationStatusPending},
+		{ID: "n-2", Channel: notifier.ChannelSMS, Recipient: "+628123456789", Body: "Hi", Status: models.NotificationStatusPending, Attempts: 1},
+		{ID: "n-3", Channel: notifier.ChannelSMS, Recipient: "+628123456789", Body: "Hi", Status: models.NotificationStatusPending, Attempts: 2},
+	}, nil)
+	mockNotificationRepo.On("Update", mock.Anything, mock.MatchedBy(func(n *models.Notification) bool {
+		return n.ID == "n-1" && n.Status == models.NotificationStatusSent && n.Attempts == 1 && n.SentAt != nil
+	})).Return(nil).Once()
+	mockNotificationRepo.On("Update", mock.Anything, mock.MatchedBy(func(n *models.Notification) bool {
+		// second attempt failed: retried after twice the base backoff
+		return n.ID == "n-2" && n.Status == models.NotificationStatusPending && n.Attempts == 2 &&
+			n.LastError == "gateway unavailable" && time.Until(n.NextAttemptAt) > 90*time.Second
+	})).Return(nil).Once()
+	mockNotificationRepo.On("Update", mock.Anything, mock.MatchedBy(func(n *models.Notification) bool {
+		return n.ID == "n-3" && n.Status == models.NotificationStatusFailed && n.Attempts == 3
+	})).Return(nil).Once()
+
+	cfg := &configs.Config{}
+	cfg.Notification.MaxAttempts = 3
+	cfg.Notification.RetryBackoff = 60
+
+	svc := NewNotificationService(&testRegistry{nr: mockNotificationRepo}, cfg, notifiers)
+
+	delivered, err := svc.DeliverPending(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	messages := sink.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"user@example.com"}, messages[0].To)
+	assert.Contains(t, messages[0].Data, "Subject: You received IDR 10,000")
+}
+
+func TestNotificationService_UpdatePreferences(t *testing.T) {
+	mockPreferenceRepo := mocks.NewNotificationPreferenceRepository(t)
+	mockPreferenceRepo.On("GetByUserID", mock.Anything, "user-1").Return(nil, nil)
+	mockPreferenceRepo.On("Save", mock.Anything, mock.MatchedBy(func(p *models.NotificationPreference) bool {
+		return p.UserID == "user-1" && p.Locale == "en" && p.Email == "user@example.com" && p.EmailEnabled && !p.PushEnabled
+	})).Return(nil)
+	mockAuditRepo := mocks.NewAuditEventRepository(t)
+	mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.AuditEvent) bool {
+		return e.Action == models.AuditActionPrefsUpdate && e.ResourceID == "user-1" && e.Before != nil && e.After != nil
+	})).Return(nil)
+
+	svc := NewNotificationService(&testRegistry{npr: mockPreferenceRepo, aur: mockAuditRepo}, &configs.Config{}, notifier.NewRegistry())
+
+	preference, err := svc.UpdatePreferences(context.Background(), "user-1", dto.UpdateNotificationPreferencesRequest{
+		Email:        "user@example.com",
+		EmailEnabled: true,
+	})
+	require.NoError(t, err)
+	assert.False(t, preference.SMSEnabled)
+}
+
+func TestFormatAmount(t *testing.T) {
+	assert.Equal(t, "IDR 0", formatAmount(0, "IDR"))
+	assert.Equal(t, "IDR 999", formatAmount(999, "IDR"))
+	assert.Equal(t, "IDR 1,250,000", formatAmount(1250000, "IDR"))
+	assert.Equal(t, "USD 10,000.50", formatAmount(10000.5, "USD"))
+	assert.Equal(t, "IDR -1,000", formatAmount(-1000, "IDR"))
+}
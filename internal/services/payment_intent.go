@@ -0,0 +1,564 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	response "digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPaymentIntentExpiration = 15 * time.Minute
+	defaultPaymentCallbackTimeout  = 5 * time.Second
+)
+
+type PaymentIntentService struct {
+	repo       interfaces.RegistryRepository
+	cfg        *configs.Config
+	ledger     ledger
+	otp        interfaces.OTPSender
+	httpClient *http.Client
+}
+
+// Ensure PaymentIntentService implements interfaces.PaymentIntentService
+var _ interfaces.PaymentIntentService = (*PaymentIntentService)(nil)
+
+func NewPaymentIntentService(repo interfaces.RegistryRepository, config *configs.Config, otp interfaces.OTPSender, listeners ...interfaces.TransactionListener) interfaces.PaymentIntentService {
+	timeout := defaultPaymentCallbackTimeout
+	if config != nil && config.Payment.CallbackTimeout > 0 {
+		timeout = time.Duration(config.Payment.CallbackTimeout) * time.Second
+	}
+
+	return &PaymentIntentService{
+		repo:       repo,
+		cfg:        config,
+		ledger:     newLedger(config, listeners),
+		otp:        otp,
+		httpClient: newCallbackClient(timeout),
+	}
+}
+
+// paymentIntentOutcome carries the result of a locked status change out of DoInTransaction
+type paymentIntentOutcome struct {
+	intent   *models.PaymentIntent
+	merchant *models.Merchant
+	expired  bool
+}
+
+// CreateIntent opens a payment intent for an authenticated merchant
+func (s *PaymentIntentService) CreateIntent(ctx context.Context, merchantID string, req dto.CreatePaymentIntentRequest) (*dto.PaymentIntentResponse, error) {
+	merchant, err := s.repo.GetMerchantRepository().GetByID(ctx, merchantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("Merchant")
+		}
+		return nil, response.Wrap(err, "error retrieving merchant")
+	}
+
+	if !merchant.IsActive {
+		return nil, response.ErrAccountDeactivated
+	}
+
+	currency := strings.ToUpper(req.Currency)
+	if currency == "" {
+		currency = "IDR"
+	}
+
+	expiration := s.intentExpiration()
+	if req.ExpiresInSeconds > 0 {
+		expiration = time.Duration(req.ExpiresInSeconds) * time.Second
+	}
+
+	intent := &models.PaymentIntent{
+		ID:                uuid.New().String(),
+		MerchantID:        merchant.ID,
+		MerchantReference: req.MerchantReference,
+		Amount:            req.Amount,
+		Currency:          currency,
+		Description:       req.Description,
+		Status:            models.PaymentIntentStatusRequiresConfirmation,
+		ExpiresAt:         time.Now().Add(expiration),
+	}
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetPaymentIntentRepository().Create(ctx, intent); err != nil {
+			return nil, response.Wrap(err, "error creating payment intent")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionIntentCreate, models.AuditResourceIntent, intent.ID, nil, intent)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPaymentIntentResponse(intent), nil
+}
+
+// GetIntent returns an intent owned by the given merchant
+func (s *PaymentIntentService) GetIntent(ctx context.Context, merchantID, id string) (*dto.PaymentIntentResponse, error) {
+	intent, err := s.getIntent(ctx, s.repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if intent.MerchantID != merchantID {
+		return nil, response.NewNotFoundError("Payment intent")
+	}
+
+	return toPaymentIntentResponse(intent), nil
+}
+
+// GetCheckout returns what the hosted checkout page needs to show the paying user
+func (s *PaymentIntentService) GetCheckout(ctx context.Context, id string) (*dto.CheckoutResponse, error) {
+	intent, err := s.getIntent(ctx, s.repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	merchant, err := s.repo.GetMerchantRepository().GetByID(ctx, intent.MerchantID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving merchant")
+	}
+
+	status := intent.Status
+	if intent.IsExpired(time.Now()) {
+		status = models.PaymentIntentStatusExpired
+	}
+
+	return &dto.CheckoutResponse{
+		PaymentIntentID: intent.ID,
+		MerchantName:    merchant.Name,
+		Amount:          intent.Amount,
+		DiscountAmount:  intent.DiscountAmount,
+		AmountDue:       intent.AmountDue(),
+		Currency:        intent.Currency,
+		Description:     intent.Description,
+		Status:          status,
+		ExpiresAt:       intent.ExpiresAt.String(),
+	}, nil
+}
+
+// ConfirmIntent pays the intent from the user's wallet into the merchant settlement wallet
+func (s *PaymentIntentService) ConfirmIntent(ctx context.Context, userID, id string, req dto.ConfirmPaymentIntentRequest) (*dto.PaymentIntentResponse, error) {
+	if err := authorizeUser(ctx, s.repo, userID, auth.PermissionWalletWithdraw); err != nil {
+		return nil, err
+	}
+
+	if err := verifyPIN(ctx, s.repo, s.cfg, userID, req.PIN); err != nil {
+		return nil, err
+	}
+
+	unlocked, err := s.getIntent(ctx, s.repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkIntentPayer(unlocked, userID); err != nil {
+		return nil, err
+	}
+
+	// the second factor is checked against the amount the payer saw; the locked intent below
+	// may not ask for more
+	amountDue := unlocked.AmountDue()
+	if err := verifyOutgoingMFA(ctx, s.repo, s.cfg, userID, amountDue, req.MFACode); err != nil {
+		return nil, err
+	}
+
+	// like withdrawals, large payments and payments from a new device need a one-time code
+	payloadHash := paymentPayloadHash(userID, id, amountDue)
+	if req.ChallengeID != "" {
+		if err := verifyChallenge(ctx, s.repo, s.cfg, userID, payloadHash, req.ChallengeID, req.ChallengeCode); err != nil {
+			return nil, err
+		}
+	} else {
+		_, newDevice, err := deviceFirstSeen(ctx, s.repo, s.cfg, userID, req.SessionID)
+		if err != nil {
+			return nil, err
+		}
+
+		if reasons := outgoingRisks(s.cfg, amountDue, newDevice); len(reasons) > 0 {
+			challenge, err := issueChallenge(ctx, s.repo, s.cfg, s.otp, userID, payloadHash, reasons)
+			if err != nil {
+				return nil, err
+			}
+			return &dto.PaymentIntentResponse{Challenge: challenge}, nil
+		}
+	}
+
+	now := time.Now()
+
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		intent, merchant, err := s.lockIntent(ctx, txRepo, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if intent.IsExpired(now) {
+			return s.expireLocked(ctx, txRepo, intent, merchant)
+		}
+
+		if !intent.CanTransitionTo(models.PaymentIntentStatusSucceeded) {
+			return nil, response.NewValidationError(fmt.Sprintf("Payment intent cannot be confirmed in status %s", intent.Status))
+		}
+
+		if err := checkIntentPayer(intent, userID); err != nil {
+			return nil, err
+		}
+
+		if !merchant.IsActive {
+			return nil, response.NewValidationError("Merchant is not active")
+		}
+
+		if intent.AmountDue() > amountDue {
+			return nil, response.NewValidationError("Payment intent changed while it was being confirmed, try again")
+		}
+
+		payerWallet, err := txRepo.GetWalletRepository().GetByUserID(ctx, userID)
+		if err != nil {
+			return nil, response.Wrap(err, "error retrieving wallet")
+		}
+
+		if payerWallet == nil {
+			return nil, response.NewNotFoundError("Wallet")
+		}
+
+		if !payerWallet.IsActive {
+			return nil, response.NewValidationError("Wallet is not active")
+		}
+
+		if payerWallet.ID == merchant.SettlementWalletID {
+			return nil, response.NewValidationError("Merchant cannot pay its own payment intent")
+		}
+
+		if payerWallet.Currency != intent.Currency {
+			return nil, response.NewValidationError("Wallet currency does not match payment intent currency")
+		}
+
+		metadata := map[string]interface{}{
+			"payment_intent_id":  intent.ID,
+			"merchant_id":        merchant.ID,
+			"merchant_name":      merchant.Name,
+			"merchant_reference": intent.MerchantReference,
+		}
+
+		// a promo discount lowers what the payer is charged; the promo funding wallet makes up
+		// the difference so the merchant still settles in full
+		if intent.PromoCodeID != nil {
+			metadata["promo_code_id"] = *intent.PromoCodeID
+			metadata["discount_amount"] = intent.DiscountAmount
+		}
+
+		if intent.DiscountAmount > 0 {
+			promo, err := txRepo.GetPromoCodeRepository().GetByIDForUpdate(ctx, *intent.PromoCodeID)
+			if err != nil {
+				return nil, response.Wrap(err, "error retrieving promo code")
+			}
+
+			if err := fundPromo(ctx, txRepo, s.ledger, s.promoFundingWalletID(), promo.Code, intent.DiscountAmount, metadata); err != nil {
+				return nil, err
+			}
+		}
+
+		payment, _, err := s.ledger.debit(ctx, txRepo, ledgerEntry{
+			WalletID:      payerWallet.ID,
+			Amount:        intent.AmountDue(),
+			Type:          models.TransactionTypePayment,
+			Description:   fmt.Sprintf("Payment to %s", merchant.Name),
+			Metadata:      metadata,
+			LimitOutgoing: true,
+			SessionID:     req.SessionID,
+		})
+		if err != nil {
+			if errors.Is(err, response.ErrWithdrawalLimitExceeded) || errors.Is(err, response.ErrNewDeviceLimit) {
+				return nil, err
+			}
+			return nil, response.Wrap(err, "payment failed")
+		}
+
+		settlement, _, err := s.ledger.credit(ctx, txRepo, ledgerEntry{
+			WalletID:    merchant.SettlementWalletID,
+			Amount:      intent.Amount,
+			Type:        models.TransactionTypeDeposit,
+			Description: fmt.Sprintf("Settlement for payment intent %s", intent.ID),
+			Metadata:    metadata,
+		})
+		if err != nil {
+			return nil, response.Wrap(err, "settlement failed")
+		}
+
+		before := *intent
+		intent.Status = models.PaymentIntentStatusSucceeded
+		intent.PayerUserID = &userID
+		intent.WalletTransactionID = &payment.ID
+		intent.SettlementTransactionID = &settlement.ID
+		intent.ConfirmedAt = &now
+
+		if err := txRepo.GetPaymentIntentRepository().Update(ctx, intent); err != nil {
+			return nil, response.Wrap(err, "error updating payment intent")
+		}
+
+		if err := recordAudit(ctx, txRepo, models.AuditActionIntentConfirm, models.AuditResourceIntent, intent.ID, before, intent); err != nil {
+			return nil, err
+		}
+
+		return &paymentIntentOutcome{intent: intent, merchant: merchant}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.finish(result.(*paymentIntentOutcome))
+}
+
+// CancelIntent cancels an unconfirmed intent on behalf of its merchant
+func (s *PaymentIntentService) CancelIntent(ctx context.Context, merchantID, id string, req dto.CancelPaymentIntentRequest) (*dto.PaymentIntentResponse, error) {
+	now := time.Now()
+
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		intent, merchant, err := s.lockIntent(ctx, txRepo, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if intent.MerchantID != merchantID {
+			return nil, response.NewNotFoundError("Payment intent")
+		}
+
+		if intent.IsExpired(now) {
+			return s.expireLocked(ctx, txRepo, intent, merchant)
+		}
+
+		if !intent.CanTransitionTo(models.PaymentIntentStatusCanceled) {
+			return nil, response.NewValidationError(fmt.Sprintf("Payment intent cannot be canceled in status %s", intent.Status))
+		}
+
+		if err := releasePromoDiscount(ctx, txRepo, intent); err != nil {
+			return nil, err
+		}
+
+		before := *intent
+		intent.Status = models.PaymentIntentStatusCanceled
+		intent.CancellationReason = req.Reason
+		intent.CanceledAt = &now
+
+		if err := txRepo.GetPaymentIntentRepository().Update(ctx, intent); err != nil {
+			return nil, response.Wrap(err, "error updating payment intent")
+		}
+
+		if err := recordAudit(ctx, txRepo, models.AuditActionIntentCancel, models.AuditResourceIntent, intent.ID, before, intent); err != nil {
+			return nil, err
+		}
+
+		return &paymentIntentOutcome{intent: intent, merchant: merchant}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.finish(result.(*paymentIntentOutcome))
+}
+
+// ExpireIntents moves up to limit overdue intents to EXPIRED and returns how many were changed
+func (s *PaymentIntentService) ExpireIntents(ctx context.Context, limit int) (int, error) {
+	intents, err := s.repo.GetPaymentIntentRepository().GetExpired(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, response.Wrap(err, "error retrieving expired payment intents")
+	}
+
+	expired := 0
+	for _, candidate := range intents {
+		result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+			intent, merchant, err := s.lockIntent(ctx, txRepo, candidate.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			// the intent may have been confirmed or canceled since it was listed
+			if !intent.IsExpired(time.Now()) {
+				return nil, nil
+			}
+
+			return s.expireLocked(ctx, txRepo, intent, merchant)
+		})
+
+		if err != nil {
+			return expired, err
+		}
+
+		if outcome, ok := result.(*paymentIntentOutcome); ok {
+			s.sendCallback(outcome.merchant, outcome.intent)
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
+func (s *PaymentIntentService) getIntent(ctx context.Context, repo interfaces.RegistryRepository, id string) (*models.PaymentIntent, error) {
+	intent, err := repo.GetPaymentIntentRepository().GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("Payment intent")
+		}
+		return nil, response.Wrap(err, "error retrieving payment intent")
+	}
+
+	return intent, nil
+}
+
+// lockIntent loads the intent under a row lock together with its merchant
+func (s *PaymentIntentService) lockIntent(ctx context.Context, txRepo interfaces.RegistryRepository, id string) (*models.PaymentIntent, *models.Merchant, error) {
+	intent, err := txRepo.GetPaymentIntentRepository().GetByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, response.NewNotFoundError("Payment intent")
+		}
+		return nil, nil, response.Wrap(err, "error retrieving payment intent")
+	}
+
+	merchant, err := txRepo.GetMerchantRepository().GetByID(ctx, intent.MerchantID)
+	if err != nil {
+		return nil, nil, response.Wrap(err, "error retrieving merchant")
+	}
+
+	return intent, merchant, nil
+}
+
+// expireLocked marks a locked intent as EXPIRED. The change is committed and reported
+// back as an outcome rather than an error so that the rollback does not undo it.
+func (s *PaymentIntentService) expireLocked(ctx context.Context, txRepo interfaces.RegistryRepository, intent *models.PaymentIntent, merchant *models.Merchant) (interface{}, error) {
+	before := *intent
+	if err := releasePromoDiscount(ctx, txRepo, intent); err != nil {
+		return nil, err
+	}
+
+	intent.Status = models.PaymentIntentStatusExpired
+	if err := txRepo.GetPaymentIntentRepository().Update(ctx, intent); err != nil {
+		return nil, response.Wrap(err, "error expiring payment intent")
+	}
+
+	if err := recordAudit(ctx, txRepo, models.AuditActionIntentExpire, models.AuditResourceIntent, intent.ID, before, intent); err != nil {
+		return nil, err
+	}
+
+	return &paymentIntentOutcome{intent: intent, merchant: merchant, expired: true}, nil
+}
+
+// finish notifies the merchant about a committed status change and builds the response
+func (s *PaymentIntentService) finish(outcome *paymentIntentOutcome) (*dto.PaymentIntentResponse, error) {
+	s.sendCallback(outcome.merchant, outcome.intent)
+
+	if outcome.expired {
+		return nil, response.NewValidationError("Payment intent has expired")
+	}
+
+	return toPaymentIntentResponse(outcome.intent), nil
+}
+
+// sendCallback posts the new intent state to the merchant callback URL, signed with the merchant's
+// callback secret. Delivery is best effort and runs in the background; merchants should fetch the
+// intent to confirm its state.
+func (s *PaymentIntentService) sendCallback(merchant *models.Merchant, intent *models.PaymentIntent) {
+	if merchant == nil || merchant.CallbackURL == "" {
+		return
+	}
+
+	// URLs saved before https was required are skipped rather than sent in clear
+	if !strings.HasPrefix(merchant.CallbackURL, "https://") {
+		slog.Warn("Payment intent callback skipped, URL is not https", "payment_intent_id", intent.ID, "merchant_id", merchant.ID)
+		return
+	}
+
+	if merchant.CallbackSecret == "" {
+		slog.Warn("Payment intent callback skipped, merchant has no callback secret", "payment_intent_id", intent.ID, "merchant_id", merchant.ID)
+		return
+	}
+
+	secret, err := utils.Open(merchant.CallbackSecret, callbackEncryptionKey(s.cfg))
+	if err != nil {
+		slog.Error("Failed to open merchant callback secret", "merchant_id", merchant.ID, "error", err)
+		return
+	}
+
+	body, err := json.Marshal(dto.PaymentIntentCallback{
+		Event:         "payment_intent." + strings.ToLower(intent.Status),
+		PaymentIntent: *toPaymentIntentResponse(intent),
+	})
+	if err != nil {
+		slog.Error("Failed to encode payment intent callback", "payment_intent_id", intent.ID, "error", err)
+		return
+	}
+
+	timestamp := time.Now().Unix()
+	signature := signCallback(secret, timestamp, body)
+
+	go func(url string) {
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			slog.Error("Payment intent callback failed", "payment_intent_id", intent.ID, "error", err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(callbackTimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(callbackSignatureHeader, signature)
+
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			slog.Error("Payment intent callback failed", "payment_intent_id", intent.ID, "error", err)
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusMultipleChoices {
+			slog.Warn("Payment intent callback rejected", "payment_intent_id", intent.ID, "status", resp.StatusCode)
+		}
+	}(merchant.CallbackURL)
+}
+
+func (s *PaymentIntentService) promoFundingWalletID() string {
+	if s.cfg == nil {
+		return ""
+	}
+	return s.cfg.Promo.FundingWalletID
+}
+
+func (s *PaymentIntentService) intentExpiration() time.Duration {
+	if s.cfg != nil && s.cfg.Payment.IntentExpiration > 0 {
+		return time.Duration(s.cfg.Payment.IntentExpiration) * time.Second
+	}
+	return defaultPaymentIntentExpiration
+}
+
+func toPaymentIntentResponse(intent *models.PaymentIntent) *dto.PaymentIntentResponse {
+	return &dto.PaymentIntentResponse{
+		ID:                  intent.ID,
+		MerchantID:          intent.MerchantID,
+		MerchantReference:   intent.MerchantReference,
+		Amount:              intent.Amount,
+		DiscountAmount:      intent.DiscountAmount,
+		AmountDue:           intent.AmountDue(),
+		Currency:            intent.Currency,
+		Description:         intent.Description,
+		Status:              intent.Status,
+		WalletTransactionID: intent.WalletTransactionID,
+		ExpiresAt:           intent.ExpiresAt.String(),
+	}
+}
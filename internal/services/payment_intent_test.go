@@ -0,0 +1,422 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newPaymentIntentFixture() (*models.PaymentIntent, *models.Merchant) {
+	intent := &models.PaymentIntent{
+		ID:         "pi-1",
+		MerchantID: "merchant-1",
+		Amount:     250.00,
+		Currency:   "IDR",
+		Status:     models.PaymentIntentStatusRequiresConfirmation,
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+	merchant := &models.Merchant{
+		ID:                 "merchant-1",
+		Name:               "Toko Maju",
+		SettlementWalletID: "wallet-merchant",
+		IsActive:           true,
+	}
+	return intent, merchant
+}
+
+func TestPaymentIntentService_ConfirmIntent(t *testing.T) {
+	t.Run("successfully pays the merchant", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+
+		intent, merchant := newPaymentIntentFixture()
+		payerWallet := &models.Wallet{ID: "wallet-payer", UserID: "user-1", Balance: 1000, Currency: "IDR", IsActive: true}
+
+		mockIntentRepo.On("GetByID", mock.Anything, "pi-1").Return(intent, nil)
+		mockIntentRepo.On("GetByIDForUpdate", mock.Anything, "pi-1").Return(intent, nil)
+		mockMerchantRepo.On("GetByID", mock.Anything, "merchant-1").Return(merchant, nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(payerWallet, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Twice()
+		mockWalletRepo.On("Withdraw", mock.Anything, "wallet-payer", 250.00).Return(&models.Wallet{ID: "wallet-payer", Balance: 750}, nil)
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-merchant", 250.00).Return(&models.Wallet{ID: "wallet-merchant", Balance: 250}, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.MatchedBy(func(tx *models.WalletTransaction) bool {
+			return tx.Status == models.TransactionStatusCompleted
+		})).Return(nil).Twice()
+		mockIntentRepo.On("Update", mock.Anything, mock.MatchedBy(func(pi *models.PaymentIntent) bool {
+			return pi.Status == models.PaymentIntentStatusSucceeded && pi.WalletTransactionID != nil
+		})).Return(nil)
+
+		reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, mr: mockMerchantRepo, pir: mockIntentRepo}, "user-1"))
+		svc := NewPaymentIntentService(reg, &configs.Config{}, nil)
+
+		resp, err := svc.ConfirmIntent(context.Background(), "user-1", "pi-1", dto.ConfirmPaymentIntentRequest{PIN: testPIN})
+		require.NoError(t, err)
+		assert.Equal(t, models.PaymentIntentStatusSucceeded, resp.Status)
+		assert.NotNil(t, resp.WalletTransactionID)
+	})
+
+	t.Run("an intent discounted for another user cannot be paid", func(t *testing.T) {
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+
+		intent, _ := newPaymentIntentFixture()
+		redeemer := "user-2"
+		intent.PayerUserID = &redeemer
+		mockIntentRepo.On("GetByID", mock.Anything, "pi-1").Return(intent, nil)
+
+		reg := withPIN(t, &testRegistry{pir: mockIntentRepo}, "user-1")
+		svc := NewPaymentIntentService(reg, &configs.Config{}, nil)
+
+		_, err := svc.ConfirmIntent(context.Background(), "user-1", "pi-1", dto.ConfirmPaymentIntentRequest{PIN: testPIN})
+		assert.Equal(t, response.NewNotFoundError("Payment intent"), err)
+	})
+
+	t.Run("promo funding wallet pays the discount", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+		mockPromoRepo := mocks.NewPromoCodeRepository(t)
+
+		intent, merchant := newPaymentIntentFixture()
+		promoID := "promo-1"
+		intent.PromoCodeID = &promoID
+		intent.DiscountAmount = 50
+		payerWallet := &models.Wallet{ID: "wallet-payer", UserID: "user-1", Balance: 1000, Currency: "IDR", IsActive: true}
+
+		mockIntentRepo.On("GetByID", mock.Anything, "pi-1").Return(intent, nil)
+		mockIntentRepo.On("GetByIDForUpdate", mock.Anything, "pi-1").Return(intent, nil)
+		mockMerchantRepo.On("GetByID", mock.Anything, "merchant-1").Return(merchant, nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(payerWallet, nil)
+		mockPromoRepo.On("GetByIDForUpdate", mock.Anything, "promo-1").Return(&models.PromoCode{ID: "promo-1", Code: "SALE"}, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Times(3)
+		mockWalletRepo.On("Withdraw", mock.Anything, "wallet-promo", 50.00).Return(&models.Wallet{ID: "wallet-promo", Balance: 950}, nil)
+		mockWalletRepo.On("Withdraw", mock.Anything, "wallet-payer", 200.00).Return(&models.Wallet{ID: "wallet-payer", Balance: 800}, nil)
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-merchant", 250.00).Return(&models.Wallet{ID: "wallet-merchant", Balance: 250}, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Times(3)
+		mockIntentRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, mr: mockMerchantRepo, pir: mockIntentRepo, pcr: mockPromoRepo}, "user-1"))
+		svc := NewPaymentIntentService(reg, promoConfig(), nil)
+
+		resp, err := svc.ConfirmIntent(context.Background(), "user-1", "pi-1", dto.ConfirmPaymentIntentRequest{PIN: testPIN})
+		require.NoError(t, err)
+		assert.Equal(t, 200.0, resp.AmountDue)
+	})
+
+	t.Run("payments count towards the daily withdrawal limit", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+
+		intent, merchant := newPaymentIntentFixture()
+		payerWallet := &models.Wallet{ID: "wallet-payer", UserID: "user-1", Balance: 1000, Currency: "IDR", IsActive: true}
+
+		mockIntentRepo.On("GetByID", mock.Anything, "pi-1").Return(intent, nil)
+		mockIntentRepo.On("GetByIDForUpdate", mock.Anything, "pi-1").Return(intent, nil)
+		mockMerchantRepo.On("GetByID", mock.Anything, "merchant-1").Return(merchant, nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(payerWallet, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockWalletRepo.On("Withdraw", mock.Anything, "wallet-payer", 250.00).Return(&models.Wallet{ID: "wallet-payer", UserID: "user-1", Balance: 750}, nil)
+		mockTxRepo.On("SumOutgoing", mock.Anything, "wallet-payer", mock.Anything).Return(float64(999900), nil)
+
+		reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, mr: mockMerchantRepo, pir: mockIntentRepo}, "user-1"))
+		svc := NewPaymentIntentService(reg, newKYCConfig(), nil)
+
+		_, err := svc.ConfirmIntent(context.Background(), "user-1", "pi-1", dto.ConfirmPaymentIntentRequest{PIN: testPIN})
+		assert.Equal(t, response.ErrWithdrawalLimitExceeded, err)
+		mockWalletRepo.AssertNotCalled(t, "Deposit", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("expired intent is marked expired", func(t *testing.T) {
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+
+		intent, merchant := newPaymentIntentFixture()
+		intent.ExpiresAt = time.Now().Add(-time.Minute)
+
+		mockIntentRepo.On("GetByID", mock.Anything, "pi-1").Return(intent, nil)
+		mockIntentRepo.On("GetByIDForUpdate", mock.Anything, "pi-1").Return(intent, nil)
+		mockMerchantRepo.On("GetByID", mock.Anything, "merchant-1").Return(merchant, nil)
+		mockIntentRepo.On("Update", mock.Anything, mock.MatchedBy(func(pi *models.PaymentIntent) bool {
+			return pi.Status == models.PaymentIntentStatusExpired
+		})).Return(nil)
+
+		reg := withPIN(t, &testRegistry{mr: mockMerchantRepo, pir: mockIntentRepo}, "user-1")
+		svc := NewPaymentIntentService(reg, &configs.Config{}, nil)
+
+		resp, err := svc.ConfirmIntent(context.Background(), "user-1", "pi-1", dto.ConfirmPaymentIntentRequest{PIN: testPIN})
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "expired")
+	})
+
+	t.Run("cannot confirm an intent twice", func(t *testing.T) {
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+
+		intent, merchant := newPaymentIntentFixture()
+		intent.Status = models.PaymentIntentStatusSucceeded
+
+		mockIntentRepo.On("GetByID", mock.Anything, "pi-1").Return(intent, nil)
+		mockIntentRepo.On("GetByIDForUpdate", mock.Anything, "pi-1").Return(intent, nil)
+		mockMerchantRepo.On("GetByID", mock.Anything, "merchant-1").Return(merchant, nil)
+
+		reg := withPIN(t, &testRegistry{mr: mockMerchantRepo, pir: mockIntentRepo}, "user-1")
+		svc := NewPaymentIntentService(reg, &configs.Config{}, nil)
+
+		_, err := svc.ConfirmIntent(context.Background(), "user-1", "pi-1", dto.ConfirmPaymentIntentRequest{PIN: testPIN})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be confirmed")
+	})
+
+	t.Run("payment above the two-factor threshold needs the code", func(t *testing.T) {
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+		mockMFARepo := mocks.NewMFARepository(t)
+
+		intent, _ := newPaymentIntentFixture()
+		mfa, _ := newTestMFA(t)
+		threshold := 100.0
+		mfa.WithdrawalThreshold = &threshold
+
+		mockIntentRepo.On("GetByID", mock.Anything, "pi-1").Return(intent, nil)
+		mockMFARepo.On("GetByUserID", mock.Anything, "user-1").Return(mfa, nil)
+
+		reg := withPIN(t, &testRegistry{pir: mockIntentRepo, mfr: mockMFARepo}, "user-1")
+		svc := NewPaymentIntentService(reg, newMFAConfig(), nil)
+
+		_, err := svc.ConfirmIntent(context.Background(), "user-1", "pi-1", dto.ConfirmPaymentIntentRequest{PIN: testPIN})
+		assert.Equal(t, response.ErrMFARequired, err)
+		mockIntentRepo.AssertNotCalled(t, "GetByIDForUpdate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("wrong PIN moves no money", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newPINUser(t), nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "pin:user-1", mock.Anything).Return(1, nil)
+
+		svc := NewPaymentIntentService(&testRegistry{ur: mockUserRepo, atr: mockAttemptRepo}, &configs.Config{}, nil)
+
+		_, err := svc.ConfirmIntent(context.Background(), "user-1", "pi-1", dto.ConfirmPaymentIntentRequest{PIN: "654321"})
+		assert.Equal(t, response.ErrInvalidPIN, err)
+	})
+}
+
+func TestPaymentIntentService_CancelIntent(t *testing.T) {
+	t.Run("merchant cancels its own intent", func(t *testing.T) {
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+
+		intent, merchant := newPaymentIntentFixture()
+
+		mockIntentRepo.On("GetByIDForUpdate", mock.Anything, "pi-1").Return(intent, nil)
+		mockMerchantRepo.On("GetByID", mock.Anything, "merchant-1").Return(merchant, nil)
+		mockIntentRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		mockAuditRepo := mocks.NewAuditEventRepository(t)
+		mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.AuditEvent) bool {
+			return e.Action == models.AuditActionIntentCancel && e.ActorID == "merchant-1" && e.PrincipalType == auth.PrincipalTypeMerchant
+		})).Return(nil)
+
+		reg := &testRegistry{mr: mockMerchantRepo, pir: mockIntentRepo, aur: mockAuditRepo}
+		svc := NewPaymentIntentService(reg, &configs.Config{}, nil)
+
+		ctx := auth.WithPrincipal(context.Background(), auth.PrincipalFromMerchant("merchant-1", "Toko Maju"))
+		resp, err := svc.CancelIntent(ctx, "merchant-1", "pi-1", dto.CancelPaymentIntentRequest{Reason: "out of stock"})
+		require.NoError(t, err)
+		assert.Equal(t, models.PaymentIntentStatusCanceled, resp.Status)
+	})
+
+	t.Run("canceling gives back a reserved promo discount", func(t *testing.T) {
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+		mockPromoRepo := mocks.NewPromoCodeRepository(t)
+		mockRedemptionRepo := mocks.NewPromoRedemptionRepository(t)
+
+		intent, merchant := newPaymentIntentFixture()
+		promoID := "promo-1"
+		intent.PromoCodeID = &promoID
+		intent.DiscountAmount = 25
+
+		mockIntentRepo.On("GetByIDForUpdate", mock.Anything, "pi-1").Return(intent, nil)
+		mockMerchantRepo.On("GetByID", mock.Anything, "merchant-1").Return(merchant, nil)
+		mockPromoRepo.On("GetByIDForUpdate", mock.Anything, "promo-1").Return(&models.PromoCode{ID: "promo-1", RedemptionCount: 3}, nil)
+		mockRedemptionRepo.On("DeleteByPaymentIntentID", mock.Anything, "pi-1").Return(nil)
+		mockPromoRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *models.PromoCode) bool {
+			return p.RedemptionCount == 2
+		})).Return(nil)
+		mockIntentRepo.On("Update", mock.Anything, mock.MatchedBy(func(pi *models.PaymentIntent) bool {
+			return pi.PromoCodeID == nil && pi.DiscountAmount == 0
+		})).Return(nil)
+
+		reg := &testRegistry{mr: mockMerchantRepo, pir: mockIntentRepo, pcr: mockPromoRepo, prr: mockRedemptionRepo}
+		svc := NewPaymentIntentService(reg, &configs.Config{}, nil)
+
+		_, err := svc.CancelIntent(context.Background(), "merchant-1", "pi-1", dto.CancelPaymentIntentRequest{})
+		require.NoError(t, err)
+	})
+
+	t.Run("another merchant cannot cancel the intent", func(t *testing.T) {
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+
+		intent, merchant := newPaymentIntentFixture()
+
+		mockIntentRepo.On("GetByIDForUpdate", mock.Anything, "pi-1").Return(intent, nil)
+		mockMerchantRepo.On("GetByID", mock.Anything, "merchant-1").Return(merchant, nil)
+
+		reg := &testRegistry{mr: mockMerchantRepo, pir: mockIntentRepo}
+		svc := NewPaymentIntentService(reg, &configs.Config{}, nil)
+
+		_, err := svc.CancelIntent(context.Background(), "merchant-2", "pi-1", dto.CancelPaymentIntentRequest{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestMerchantService_Authenticate(t *testing.T) {
+	t.Run("valid key resolves merchant", func(t *testing.T) {
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		prefix, apiKey, err := generateMerchantAPIKey()
+		require.NoError(t, err)
+
+		merchant := &models.Merchant{ID: "merchant-1", APIKeyPrefix: prefix, IsActive: true}
+		merchant.APIKeyHash = utils.HashToken(apiKey)
+		mockMerchantRepo.On("GetByAPIKeyPrefix", mock.Anything, prefix).Return(merchant, nil)
+
+		svc := NewMerchantService(&testRegistry{mr: mockMerchantRepo}, &configs.Config{})
+		res, err := svc.Authenticate(context.Background(), apiKey)
+		require.NoError(t, err)
+		assert.Equal(t, "merchant-1", res.ID)
+	})
+
+	t.Run("wrong secret is rejected", func(t *testing.T) {
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		prefix, apiKey, err := generateMerchantAPIKey()
+		require.NoError(t, err)
+
+		merchant := &models.Merchant{ID: "merchant-1", APIKeyPrefix: prefix, IsActive: true}
+		merchant.APIKeyHash = utils.HashToken(apiKey)
+		mockMerchantRepo.On("GetByAPIKeyPrefix", mock.Anything, prefix).Return(merchant, nil)
+
+		svc := NewMerchantService(&testRegistry{mr: mockMerchantRepo}, &configs.Config{})
+		_, err = svc.Authenticate(context.Background(), prefix+".not-the-secret")
+		require.Error(t, err)
+	})
+
+	t.Run("malformed key is rejected without lookup", func(t *testing.T) {
+		svc := NewMerchantService(&testRegistry{}, &configs.Config{})
+		_, err := svc.Authenticate(context.Background(), "garbage")
+		require.Error(t, err)
+	})
+}
+
+func TestMerchantService_UpdateMerchant(t *testing.T) {
+	t.Run("fields left out keep their value", func(t *testing.T) {
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		merchant := &models.Merchant{ID: "merchant-1", UserID: "user-1", Name: "Toko Maju", CallbackURL: "https://merchant.example.com/cb"}
+		mockMerchantRepo.On("GetByID", mock.Anything, "merchant-1").Return(merchant, nil)
+		mockMerchantRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *models.Merchant) bool {
+			return m.Name == "Toko Baru" && m.CallbackURL == "https://merchant.example.com/cb"
+		})).Return(nil)
+
+		svc := NewMerchantService(&testRegistry{mr: mockMerchantRepo}, &configs.Config{})
+		res, err := svc.UpdateMerchant(context.Background(), "user-1", "merchant-1", dto.UpdateMerchantRequest{Name: "Toko Baru"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://merchant.example.com/cb", res.CallbackURL)
+	})
+
+	t.Run("empty callback URL removes it", func(t *testing.T) {
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		merchant := &models.Merchant{ID: "merchant-1", UserID: "user-1", Name: "Toko Maju", CallbackURL: "https://merchant.example.com/cb"}
+		mockMerchantRepo.On("GetByID", mock.Anything, "merchant-1").Return(merchant, nil)
+		mockMerchantRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		empty := ""
+		svc := NewMerchantService(&testRegistry{mr: mockMerchantRepo}, &configs.Config{})
+		res, err := svc.UpdateMerchant(context.Background(), "user-1", "merchant-1", dto.UpdateMerchantRequest{CallbackURL: &empty})
+		require.NoError(t, err)
+		assert.Empty(t, res.CallbackURL)
+	})
+}
+
+func TestMerchantService_RotateAPIKey(t *testing.T) {
+	t.Run("another user cannot rotate the key", func(t *testing.T) {
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		mockMerchantRepo.On("GetByID", mock.Anything, "merchant-1").Return(&models.Merchant{ID: "merchant-1", UserID: "user-1"}, nil)
+
+		svc := NewMerchantService(&testRegistry{mr: mockMerchantRepo}, &configs.Config{})
+		res, err := svc.RotateAPIKey(context.Background(), "user-2", "merchant-1")
+		require.Error(t, err)
+		assert.Nil(t, res)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("owner gets a new key and callback secret", func(t *testing.T) {
+		mockMerchantRepo := mocks.NewMerchantRepository(t)
+		mockMerchantRepo.On("GetByID", mock.Anything, "merchant-1").Return(&models.Merchant{ID: "merchant-1", UserID: "user-1", APIKeyPrefix: "mk_old"}, nil)
+		mockMerchantRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		cfg := &configs.Config{}
+		cfg.JWT.EncryptionKey = testEncryptionKey
+
+		svc := NewMerchantService(&testRegistry{mr: mockMerchantRepo}, cfg)
+		res, err := svc.RotateAPIKey(context.Background(), "user-1", "merchant-1")
+		require.NoError(t, err)
+		require.NotEmpty(t, res.CallbackSecret)
+
+		updated := mockMerchantRepo.Calls[1].Arguments.Get(1).(*models.Merchant)
+		assert.NotEqual(t, "mk_old", updated.APIKeyPrefix)
+		assert.NotEqual(t, res.CallbackSecret, updated.CallbackSecret)
+
+		opened, err := utils.Open(updated.CallbackSecret, testEncryptionKey)
+		require.NoError(t, err)
+		assert.Equal(t, res.CallbackSecret, opened)
+	})
+}
+
+func TestCallbackDialControl(t *testing.T) {
+	for _, address := range []string{"127.0.0.1:443", "[::1]:443", "10.0.0.5:443", "192.168.1.1:443", "169.254.169.254:80", "[fe80::1]:443", "0.0.0.0:443", "100.64.0.1:443"} {
+		err := callbackDialControl("tcp", address, nil)
+		assert.ErrorIs(t, err, errCallbackAddressBlocked, address)
+	}
+
+	assert.NoError(t, callbackDialControl("tcp", "93.184.216.34:443", nil))
+}
+
+func TestNewCallbackClient_RefusesLoopback(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := newCallbackClient(time.Second).Post(server.URL, "application/json", nil)
+	assert.ErrorIs(t, err, errCallbackAddressBlocked)
+}
+
+func TestSignCallback(t *testing.T) {
+	body := []byte(`{"event":"payment_intent.succeeded"}`)
+
+	signature := signCallback("secret", 1700000000, body)
+	assert.Len(t, signature, 64)
+	assert.Equal(t, signature, signCallback("secret", 1700000000, body))
+	assert.NotEqual(t, signature, signCallback("secret", 1700000001, body))
+	assert.NotEqual(t, signature, signCallback("other", 1700000000, body))
+}
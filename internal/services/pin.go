@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	response "digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPINMaxAttempts  = 5
+	defaultPINLockDuration = time.Hour
+)
+
+type PINService struct {
+	repo interfaces.RegistryRepository
+	cfg  *configs.Config
+}
+
+// Ensure PINService implements interfaces.PINService
+var _ interfaces.PINService = (*PINService)(nil)
+
+func NewPINService(repo interfaces.RegistryRepository, config *configs.Config) interfaces.PINService {
+	return &PINService{
+		repo: repo,
+		cfg:  config,
+	}
+}
+
+// SetupPIN sets the first PIN of the user
+func (s *PINService) SetupPIN(ctx context.Context, userID string, req dto.SetupPINRequest) error {
+	user, err := s.repo.GetUserRepository().GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NewNotFoundError("User")
+		}
+		return response.Wrap(err, "error retrieving user")
+	}
+
+	if user.PIN != nil {
+		return response.NewDuplicateEntryError("PIN has already been set")
+	}
+
+	return s.savePIN(ctx, userID, req.PIN, models.AuditActionPINSetup)
+}
+
+// ChangePIN replaces the PIN after checking the current one, which counts as an attempt
+func (s *PINService) ChangePIN(ctx context.Context, userID string, req dto.ChangePINRequest) error {
+	if err := verifyPIN(ctx, s.repo, s.cfg, userID, req.CurrentPIN); err != nil {
+		return err
+	}
+
+	return s.savePIN(ctx, userID, req.NewPIN, models.AuditActionPINChange)
+}
+
+// ResetPIN replaces a forgotten or locked PIN after checking the account password
+func (s *PINService) ResetPIN(ctx context.Context, userID string, req dto.ResetPINRequest) error {
+	user, err := s.repo.GetUserRepository().GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NewNotFoundError("User")
+		}
+		return response.Wrap(err, "error retrieving user")
+	}
+
+	attemptRepo := s.repo.GetAttemptRepository()
+	resetKey := pinResetAttemptKey(userID)
+
+	attempts, err := attemptRepo.Increment(ctx, resetKey, pinLockDuration(s.cfg))
+	if err != nil {
+		return response.Wrap(err, "error recording PIN reset attempt")
+	}
+
+	if attempts > pinMaxAttempts(s.cfg) {
+		return response.ErrPINResetLocked
+	}
+
+	if user.CheckPassword(req.Password) != nil {
+		return response.ErrInvalidCredentials
+	}
+
+	if err := s.savePIN(ctx, userID, req.NewPIN, models.AuditActionPINReset); err != nil {
+		return err
+	}
+
+	for _, key := range []string{pinAttemptKey(userID), resetKey} {
+		if err := attemptRepo.Reset(ctx, key); err != nil {
+			return response.Wrap(err, "error resetting PIN attempts")
+		}
+	}
+
+	return nil
+}
+
+// savePIN stores the hash of the new PIN and records the action in the audit log, without
+// the PIN itself
+func (s *PINService) savePIN(ctx context.Context, userID, pin, action string) error {
+	hashed, err := utils.HashAndSalt([]byte(pin))
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetUserRepository().UpdatePIN(ctx, userID, hashed); err != nil {
+			return nil, response.Wrap(err, "error saving PIN")
+		}
+
+		return nil, recordAudit(ctx, txRepo, action, models.AuditResourceUser, userID, nil, nil)
+	})
+	return err
+}
+
+// verifyPIN checks the transaction PIN of the user before money leaves their balance. Every
+// attempt is counted in Redis before the PIN is compared, so concurrent guesses each use one up;
+// once the count passes the maximum the PIN stays locked until the lock duration has passed
+// since the last attempt, or the PIN is reset. A correct PIN clears the count.
+func verifyPIN(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, userID, pin string) error {
+	user, err := repo.GetUserRepository().GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NewNotFoundError("User")
+		}
+		return response.Wrap(err, "error retrieving user")
+	}
+
+	if err := checkAccountActive(user); err != nil {
+		return err
+	}
+
+	if user.PIN == nil {
+		return response.ErrPINNotSet
+	}
+
+	attemptRepo := repo.GetAttemptRepository()
+	key := pinAttemptKey(userID)
+	maxAttempts := pinMaxAttempts(cfg)
+
+	attempts, err := attemptRepo.Increment(ctx, key, pinLockDuration(cfg))
+	if err != nil {
+		return response.Wrap(err, "error recording PIN attempt")
+	}
+
+	if attempts > maxAttempts {
+		return response.ErrPINLocked
+	}
+
+	if !utils.ComparePasswords(*user.PIN, []byte(pin)) {
+		if attempts == maxAttempts {
+			if err := recordSecurityEvent(ctx, repo, auth.SecurityEventPINLocked, models.AuditResourceUser, userID, map[string]interface{}{"attempts": attempts}); err != nil {
+				return err
+			}
+			return response.ErrPINLocked
+		}
+		return response.ErrInvalidPIN
+	}
+
+	if err := attemptRepo.Reset(ctx, key); err != nil {
+		return response.Wrap(err, "error resetting PIN attempts")
+	}
+
+	return nil
+}
+
+func pinAttemptKey(userID string) string {
+	return "pin:" + userID
+}
+
+func pinResetAttemptKey(userID string) string {
+	return "pin-reset:" + userID
+}
+
+func pinMaxAttempts(cfg *configs.Config) int {
+	if cfg != nil && cfg.PIN.MaxAttempts > 0 {
+		return cfg.PIN.MaxAttempts
+	}
+	return defaultPINMaxAttempts
+}
+
+func pinLockDuration(cfg *configs.Config) time.Duration {
+	if cfg != nil && cfg.PIN.LockDuration > 0 {
+		return time.Duration(cfg.PIN.LockDuration) * time.Second
+	}
+	return defaultPINLockDuration
+}
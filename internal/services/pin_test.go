@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testPIN = "123456"
+
+// withPIN sets up reg so that testPIN is accepted as the transaction PIN of userID
+func withPIN(t *testing.T, reg *testRegistry, userID string) *testRegistry {
+	mockUserRepo := mocks.NewUserRepository(t)
+	mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+	hashed, err := utils.HashAndSalt([]byte(testPIN))
+	require.NoError(t, err)
+
+	mockUserRepo.On("GetByID", mock.Anything, userID).Return(&models.User{ID: userID, PIN: &hashed, IsActive: true}, nil)
+	mockAttemptRepo.On("Increment", mock.Anything, "pin:"+userID, mock.Anything).Return(1, nil)
+	mockAttemptRepo.On("Reset", mock.Anything, "pin:"+userID).Return(nil)
+
+	reg.ur = mockUserRepo
+	reg.atr = mockAttemptRepo
+	if reg.mfr == nil {
+		reg.mfr = withoutMFA(t, userID)
+	}
+	return reg
+}
+
+func newPINUser(t *testing.T) *models.User {
+	user := newTestUser(t, "secret123")
+	hashed, err := utils.HashAndSalt([]byte(testPIN))
+	require.NoError(t, err)
+	user.PIN = &hashed
+	return user
+}
+
+func TestVerifyPIN(t *testing.T) {
+	cfg := &configs.Config{}
+	cfg.PIN.MaxAttempts = 3
+	cfg.PIN.LockDuration = 600
+
+	t.Run("correct PIN clears earlier failures", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newPINUser(t), nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "pin:user-1", 10*time.Minute).Return(3, nil)
+		mockAttemptRepo.On("Reset", mock.Anything, "pin:user-1").Return(nil)
+
+		err := verifyPIN(context.Background(), &testRegistry{ur: mockUserRepo, atr: mockAttemptRepo}, cfg, "user-1", testPIN)
+		require.NoError(t, err)
+	})
+
+	t.Run("wrong PIN is counted", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newPINUser(t), nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "pin:user-1", 10*time.Minute).Return(1, nil)
+
+		err := verifyPIN(context.Background(), &testRegistry{ur: mockUserRepo, atr: mockAttemptRepo}, cfg, "user-1", "654321")
+		assert.Equal(t, response.ErrInvalidPIN, err)
+	})
+
+	t.Run("last allowed failure locks the PIN", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newPINUser(t), nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "pin:user-1", 10*time.Minute).Return(3, nil)
+
+		err := verifyPIN(context.Background(), &testRegistry{ur: mockUserRepo, atr: mockAttemptRepo}, cfg, "user-1", "654321")
+		assert.Equal(t, response.ErrPINLocked, err)
+	})
+
+	t.Run("attempts past the maximum are refused without checking the PIN", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newPINUser(t), nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "pin:user-1", 10*time.Minute).Return(4, nil)
+
+		err := verifyPIN(context.Background(), &testRegistry{ur: mockUserRepo, atr: mockAttemptRepo}, cfg, "user-1", testPIN)
+		assert.Equal(t, response.ErrPINLocked, err)
+		mockAttemptRepo.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+	})
+
+	t.Run("PIN not set", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newTestUser(t, "secret123"), nil)
+
+		err := verifyPIN(context.Background(), &testRegistry{ur: mockUserRepo}, cfg, "user-1", testPIN)
+		assert.Equal(t, response.ErrPINNotSet, err)
+	})
+}
+
+func TestPINService(t *testing.T) {
+	t.Run("setup stores a hash of the PIN", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newTestUser(t, "secret123"), nil)
+		mockUserRepo.On("UpdatePIN", mock.Anything, "user-1", mock.MatchedBy(func(hash string) bool {
+			return hash != testPIN && utils.ComparePasswords(hash, []byte(testPIN))
+		})).Return(nil)
+
+		svc := NewPINService(&testRegistry{ur: mockUserRepo}, &configs.Config{})
+
+		require.NoError(t, svc.SetupPIN(context.Background(), "user-1", dto.SetupPINRequest{PIN: testPIN}))
+	})
+
+	t.Run("setup refuses to overwrite a PIN", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newPINUser(t), nil)
+
+		svc := NewPINService(&testRegistry{ur: mockUserRepo}, &configs.Config{})
+
+		err := svc.SetupPIN(context.Background(), "user-1", dto.SetupPINRequest{PIN: "111111"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already been set")
+	})
+
+	t.Run("change requires the current PIN", func(t *testing.T) {
+		reg := withPIN(t, &testRegistry{}, "user-1")
+		reg.ur.(*mocks.UserRepository).On("UpdatePIN", mock.Anything, "user-1", mock.Anything).Return(nil)
+
+		svc := NewPINService(reg, &configs.Config{})
+
+		require.NoError(t, svc.ChangePIN(context.Background(), "user-1", dto.ChangePINRequest{CurrentPIN: testPIN, NewPIN: "246810"}))
+	})
+
+	t.Run("reset with the account password unlocks the PIN", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newPINUser(t), nil)
+		mockUserRepo.On("UpdatePIN", mock.Anything, "user-1", mock.Anything).Return(nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "pin-reset:user-1", time.Hour).Return(1, nil)
+		mockAttemptRepo.On("Reset", mock.Anything, "pin:user-1").Return(nil)
+		mockAttemptRepo.On("Reset", mock.Anything, "pin-reset:user-1").Return(nil)
+
+		svc := NewPINService(&testRegistry{ur: mockUserRepo, atr: mockAttemptRepo}, &configs.Config{})
+
+		require.NoError(t, svc.ResetPIN(context.Background(), "user-1", dto.ResetPINRequest{Password: "secret123", NewPIN: "246810"}))
+	})
+
+	t.Run("reset with a wrong password", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newPINUser(t), nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "pin-reset:user-1", time.Hour).Return(1, nil)
+
+		svc := NewPINService(&testRegistry{ur: mockUserRepo, atr: mockAttemptRepo}, &configs.Config{})
+
+		err := svc.ResetPIN(context.Background(), "user-1", dto.ResetPINRequest{Password: "wrong", NewPIN: "246810"})
+		assert.Equal(t, response.ErrInvalidCredentials, err)
+	})
+
+	t.Run("reset attempts past the maximum are refused without checking the password", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockAttemptRepo := mocks.NewAttemptRepository(t)
+
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(newPINUser(t), nil)
+		mockAttemptRepo.On("Increment", mock.Anything, "pin-reset:user-1", time.Hour).Return(6, nil)
+
+		svc := NewPINService(&testRegistry{ur: mockUserRepo, atr: mockAttemptRepo}, &configs.Config{})
+
+		err := svc.ResetPIN(context.Background(), "user-1", dto.ResetPINRequest{Password: "secret123", NewPIN: "246810"})
+		assert.Equal(t, response.ErrPINResetLocked, err)
+	})
+}
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	response "digital-wallet/pkg/response"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PocketService struct {
+	repo   interfaces.RegistryRepository
+	cfg    *configs.Config
+	ledger ledger
+}
+
+// Ensure PocketService implements interfaces.PocketService
+var _ interfaces.PocketService = (*PocketService)(nil)
+
+func NewPocketService(repo interfaces.RegistryRepository, config *configs.Config, listeners ...interfaces.TransactionListener) interfaces.PocketService {
+	return &PocketService{
+		repo:   repo,
+		cfg:    config,
+		ledger: newLedger(config, listeners),
+	}
+}
+
+// CreatePocket adds an empty pocket to the user's wallet
+func (s *PocketService) CreatePocket(ctx context.Context, userID string, req dto.CreatePocketRequest) (*dto.PocketBalance, error) {
+	wallet, err := getOrCreateWallet(ctx, s.repo.GetWalletRepository(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pocketRepo := s.repo.GetPocketRepository()
+
+	pockets, err := pocketRepo.GetByWalletID(ctx, wallet.ID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving pockets")
+	}
+
+	for _, p := range pockets {
+		if strings.EqualFold(p.Name, req.Name) {
+			return nil, response.NewDuplicateEntryError("Pocket with this name already exists")
+		}
+	}
+
+	pocket := &models.Pocket{
+		ID:           uuid.New().String(),
+		WalletID:     wallet.ID,
+		Name:         req.Name,
+		Balance:      0,
+		TargetAmount: req.TargetAmount,
+		Deadline:     req.Deadline,
+	}
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetPocketRepository().Create(ctx, pocket); err != nil {
+			return nil, response.Wrap(err, "error creating pocket")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionPocketCreate, models.AuditResourcePocket, pocket.ID, nil, pocket)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := toPocketBalance(*pocket)
+	return &res, nil
+}
+
+// ListPockets is
+func (s *PocketService) ListPockets(ctx context.Context, userID string) ([]dto.PocketBalance, error) {
+	wallet, err := s.repo.GetWalletRepository().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving wallet")
+	}
+
+	if wallet == nil {
+		return []dto.PocketBalance{}, nil
+	}
+
+	pockets, err := s.repo.GetPocketRepository().GetByWalletID(ctx, wallet.ID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving pockets")
+	}
+
+	return toPocketBalances(pockets), nil
+}
+
+// UpdatePocket changes the pocket name, target and deadline
+func (s *PocketService) UpdatePocket(ctx context.Context, userID, pocketID string, req dto.UpdatePocketRequest) (*dto.PocketBalance, error) {
+	wallet, err := s.getWallet(ctx, s.repo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pocket, err := s.getOwnedPocket(ctx, s.repo, wallet.ID, pocketID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *pocket
+	if req.Name != "" {
+		pocket.Name = req.Name
+	}
+	pocket.TargetAmount = req.TargetAmount
+	pocket.Deadline = req.Deadline
+
+	_, err = s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		if err := txRepo.GetPocketRepository().Update(ctx, pocket); err != nil {
+			return nil, response.Wrap(err, "error updating pocket")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionPocketUpdate, models.AuditResourcePocket, pocket.ID, before, pocket)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := toPocketBalance(*pocket)
+	return &res, nil
+}
+
+// DeletePocket removes a pocket, moving whatever is left in it back to the main balance
+func (s *PocketService) DeletePocket(ctx context.Context, userID, pocketID string) error {
+	_, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		wallet, err := s.getWallet(ctx, txRepo, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		// the row stays locked until the pocket is deleted, so a transfer cannot add to the
+		// balance after it was moved out
+		pocket, err := s.lockOwnedPocket(ctx, txRepo, wallet.ID, pocketID)
+		if err != nil {
+			return nil, err
+		}
+
+		if pocket.Balance > 0 {
+			if _, _, err := s.moveOut(ctx, txRepo, wallet, pocket, pocket.Balance, fmt.Sprintf("Closed pocket %s", pocket.Name)); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := txRepo.GetPocketRepository().Delete(ctx, pocket.ID); err != nil {
+			return nil, response.Wrap(err, "error deleting pocket")
+		}
+
+		return nil, recordAudit(ctx, txRepo, models.AuditActionPocketDelete, models.AuditResourcePocket, pocket.ID, pocket, nil)
+	})
+
+	return err
+}
+
+// Transfer moves money between the main balance and a pocket, recording it in wallet_transactions
+func (s *PocketService) Transfer(ctx context.Context, userID, pocketID string, req dto.PocketTransferRequest) (*dto.PocketTransferResponse, error) {
+	if err := verifyPIN(ctx, s.repo, s.cfg, userID, req.PIN); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		wallet, err := s.getWallet(ctx, txRepo, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !wallet.IsActive {
+			return nil, response.NewValidationError("Wallet is not active")
+		}
+
+		pocket, err := s.getOwnedPocket(ctx, txRepo, wallet.ID, pocketID)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			transaction   *models.WalletTransaction
+			updatedWallet *models.Wallet
+			updatedPocket *models.Pocket
+		)
+
+		switch req.Direction {
+		case dto.PocketTransferIn:
+			transaction, updatedWallet, err = s.ledger.debit(ctx, txRepo, ledgerEntry{
+				WalletID:    wallet.ID,
+				Amount:      req.Amount,
+				Type:        models.TransactionTypeTransfer,
+				Description: pocketTransferDescription(req.Description, "Move to pocket "+pocket.Name),
+				Metadata:    pocketTransferMetadata(pocket, req.Direction),
+			})
+			if err != nil {
+				return nil, response.Wrap(err, "pocket transfer failed")
+			}
+
+			updatedPocket, err = txRepo.GetPocketRepository().Deposit(ctx, pocket.ID, req.Amount)
+			if err != nil {
+				return nil, response.Wrap(err, "pocket transfer failed")
+			}
+		case dto.PocketTransferOut:
+			transaction, updatedWallet, err = s.moveOut(ctx, txRepo, wallet, pocket, req.Amount,
+				pocketTransferDescription(req.Description, "Move from pocket "+pocket.Name))
+			if err != nil {
+				return nil, err
+			}
+			updatedPocket, err = txRepo.GetPocketRepository().GetByID(ctx, pocket.ID)
+			if err != nil {
+				return nil, response.Wrap(err, "error retrieving pocket")
+			}
+		default:
+			return nil, response.NewValidationError("Unknown transfer direction")
+		}
+
+		return &dto.PocketTransferResponse{
+			TransactionID: transaction.ID,
+			WalletBalance: updatedWallet.Balance,
+			Pocket:        toPocketBalance(*updatedPocket),
+		}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*dto.PocketTransferResponse), nil
+}
+
+// moveOut takes amount from the pocket and credits it back to the main balance
+func (s *PocketService) moveOut(ctx context.Context, txRepo interfaces.RegistryRepository, wallet *models.Wallet, pocket *models.Pocket, amount float64, description string) (*models.WalletTransaction, *models.Wallet, error) {
+	if _, err := txRepo.GetPocketRepository().Withdraw(ctx, pocket.ID, amount); err != nil {
+		return nil, nil, response.Wrap(err, "pocket transfer failed")
+	}
+
+	transaction, updatedWallet, err := s.ledger.credit(ctx, txRepo, ledgerEntry{
+		WalletID:    wallet.ID,
+		Amount:      amount,
+		Type:        models.TransactionTypeTransfer,
+		Description: description,
+		Metadata:    pocketTransferMetadata(pocket, dto.PocketTransferOut),
+	})
+	if err != nil {
+		return nil, nil, response.Wrap(err, "pocket transfer failed")
+	}
+
+	return transaction, updatedWallet, nil
+}
+
+func (s *PocketService) getWallet(ctx context.Context, repo interfaces.RegistryRepository, userID string) (*models.Wallet, error) {
+	wallet, err := repo.GetWalletRepository().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving wallet")
+	}
+
+	if wallet == nil {
+		return nil, response.NewNotFoundError("Wallet")
+	}
+
+	return wallet, nil
+}
+
+func (s *PocketService) getOwnedPocket(ctx context.Context, repo interfaces.RegistryRepository, walletID, pocketID string) (*models.Pocket, error) {
+	pocket, err := repo.GetPocketRepository().GetByID(ctx, pocketID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("Pocket")
+		}
+		return nil, response.Wrap(err, "error retrieving pocket")
+	}
+
+	if pocket.WalletID != walletID {
+		return nil, response.NewNotFoundError("Pocket")
+	}
+
+	return pocket, nil
+}
+
+// lockOwnedPocket is getOwnedPocket with the pocket row locked until the transaction ends
+func (s *PocketService) lockOwnedPocket(ctx context.Context, repo interfaces.RegistryRepository, walletID, pocketID string) (*models.Pocket, error) {
+	pocket, err := repo.GetPocketRepository().GetByIDForUpdate(ctx, pocketID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.NewNotFoundError("Pocket")
+		}
+		return nil, response.Wrap(err, "error retrieving pocket")
+	}
+
+	if pocket.WalletID != walletID {
+		return nil, response.NewNotFoundError("Pocket")
+	}
+
+	return pocket, nil
+}
+
+func pocketTransferMetadata(pocket *models.Pocket, direction string) map[string]interface{} {
+	return map[string]interface{}{
+		"pocket_id":   pocket.ID,
+		"pocket_name": pocket.Name,
+		"direction":   direction,
+	}
+}
+
+func pocketTransferDescription(description, fallback string) string {
+	if description != "" {
+		return description
+	}
+	return fallback
+}
+
+func toPocketBalance(pocket models.Pocket) dto.PocketBalance {
+	res := dto.PocketBalance{
+		ID:           pocket.ID,
+		Name:         pocket.Name,
+		Balance:      pocket.Balance,
+		TargetAmount: pocket.TargetAmount,
+	}
+
+	if pocket.Deadline != nil {
+		deadline := pocket.Deadline.String()
+		res.Deadline = &deadline
+	}
+
+	if pocket.TargetAmount != nil && *pocket.TargetAmount > 0 {
+		progress := pocket.Balance / *pocket.TargetAmount
+		res.Progress = &progress
+	}
+
+	return res
+}
+
+func toPocketBalances(pockets []models.Pocket) []dto.PocketBalance {
+	res := make([]dto.PocketBalance, len(pockets))
+	for i, p := range pockets {
+		res[i] = toPocketBalance(p)
+	}
+	return res
+}
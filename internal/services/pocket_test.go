@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPocketService_Transfer(t *testing.T) {
+	wallet := &models.Wallet{ID: "wallet-1", UserID: "user-1", Balance: 1000, Currency: "IDR", IsActive: true}
+	pocket := &models.Pocket{ID: "pocket-1", WalletID: "wallet-1", Name: "Holiday", Balance: 300}
+
+	t.Run("move money into a pocket", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockPocketRepo := mocks.NewPocketRepository(t)
+
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
+		mockPocketRepo.On("GetByID", mock.Anything, "pocket-1").Return(pocket, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.MatchedBy(func(tx *models.WalletTransaction) bool {
+			return tx.Type == models.TransactionTypeTransfer && tx.Amount == 200
+		})).Return(nil)
+		mockWalletRepo.On("Withdraw", mock.Anything, "wallet-1", 200.0).Return(&models.Wallet{ID: "wallet-1", Balance: 800}, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		mockPocketRepo.On("Deposit", mock.Anything, "pocket-1", 200.0).Return(&models.Pocket{ID: "pocket-1", Name: "Holiday", Balance: 500}, nil)
+
+		reg := withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, pkr: mockPocketRepo}, "user-1")
+		svc := NewPocketService(reg, &configs.Config{})
+
+		resp, err := svc.Transfer(context.Background(), "user-1", "pocket-1", dto.PocketTransferRequest{Direction: dto.PocketTransferIn, Amount: 200, PIN: testPIN})
+		require.NoError(t, err)
+		assert.Equal(t, 800.0, resp.WalletBalance)
+		assert.Equal(t, 500.0, resp.Pocket.Balance)
+	})
+
+	t.Run("move money out of a pocket", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockPocketRepo := mocks.NewPocketRepository(t)
+
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
+		mockPocketRepo.On("GetByID", mock.Anything, "pocket-1").Return(pocket, nil).Once()
+		mockPocketRepo.On("Withdraw", mock.Anything, "pocket-1", 100.0).Return(&models.Pocket{ID: "pocket-1", Balance: 200}, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-1", 100.0).Return(&models.Wallet{ID: "wallet-1", Balance: 1100}, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		mockPocketRepo.On("GetByID", mock.Anything, "pocket-1").Return(&models.Pocket{ID: "pocket-1", Name: "Holiday", Balance: 200}, nil).Once()
+
+		reg := withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, pkr: mockPocketRepo}, "user-1")
+		svc := NewPocketService(reg, &configs.Config{})
+
+		resp, err := svc.Transfer(context.Background(), "user-1", "pocket-1", dto.PocketTransferRequest{Direction: dto.PocketTransferOut, Amount: 100, PIN: testPIN})
+		require.NoError(t, err)
+		assert.Equal(t, 1100.0, resp.WalletBalance)
+		assert.Equal(t, 200.0, resp.Pocket.Balance)
+	})
+
+	t.Run("pocket of another wallet is not found", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockPocketRepo := mocks.NewPocketRepository(t)
+
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
+		mockPocketRepo.On("GetByID", mock.Anything, "pocket-9").Return(&models.Pocket{ID: "pocket-9", WalletID: "wallet-9"}, nil)
+
+		reg := withPIN(t, &testRegistry{wr: mockWalletRepo, pkr: mockPocketRepo}, "user-1")
+		svc := NewPocketService(reg, &configs.Config{})
+
+		_, err := svc.Transfer(context.Background(), "user-1", "pocket-9", dto.PocketTransferRequest{Direction: dto.PocketTransferIn, Amount: 100, PIN: testPIN})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Pocket not found")
+	})
+
+	t.Run("insufficient available balance", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockPocketRepo := mocks.NewPocketRepository(t)
+
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
+		mockPocketRepo.On("GetByID", mock.Anything, "pocket-1").Return(pocket, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockWalletRepo.On("Withdraw", mock.Anything, "wallet-1", 5000.0).Return(nil, errors.New("insufficient balance"))
+
+		reg := withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, pkr: mockPocketRepo}, "user-1")
+		svc := NewPocketService(reg, &configs.Config{})
+
+		_, err := svc.Transfer(context.Background(), "user-1", "pocket-1", dto.PocketTransferRequest{Direction: dto.PocketTransferIn, Amount: 5000, PIN: testPIN})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insufficient balance")
+	})
+}
+
+func TestPocketService_CreatePocket(t *testing.T) {
+	t.Run("duplicate pocket name", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockPocketRepo := mocks.NewPocketRepository(t)
+
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(&models.Wallet{ID: "wallet-1"}, nil)
+		mockPocketRepo.On("GetByWalletID", mock.Anything, "wallet-1").Return([]models.Pocket{{ID: "pocket-1", Name: "Holiday"}}, nil)
+
+		reg := &testRegistry{wr: mockWalletRepo, pkr: mockPocketRepo}
+		svc := NewPocketService(reg, &configs.Config{})
+
+		_, err := svc.CreatePocket(context.Background(), "user-1", dto.CreatePocketRequest{Name: "holiday"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+	})
+}
+
+func TestPocketService_DeletePocket(t *testing.T) {
+	mockWalletRepo := mocks.NewWalletRepository(t)
+	mockTxRepo := mocks.NewWalletTransactionRepository(t)
+	mockPocketRepo := mocks.NewPocketRepository(t)
+
+	mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(&models.Wallet{ID: "wallet-1", UserID: "user-1", IsActive: true}, nil)
+	mockPocketRepo.On("GetByIDForUpdate", mock.Anything, "pocket-1").Return(&models.Pocket{ID: "pocket-1", WalletID: "wallet-1", Name: "Holiday", Balance: 300}, nil)
+	mockPocketRepo.On("Withdraw", mock.Anything, "pocket-1", 300.0).Return(&models.Pocket{ID: "pocket-1"}, nil)
+	mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockWalletRepo.On("Deposit", mock.Anything, "wallet-1", 300.0).Return(&models.Wallet{ID: "wallet-1", Balance: 300}, nil)
+	mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockPocketRepo.On("Delete", mock.Anything, "pocket-1").Return(nil)
+
+	svc := NewPocketService(&testRegistry{wr: mockWalletRepo, tr: mockTxRepo, pkr: mockPocketRepo}, &configs.Config{})
+
+	require.NoError(t, svc.DeletePocket(context.Background(), "user-1", "pocket-1"))
+	mockPocketRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
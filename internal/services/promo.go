@@ -0,0 +1,442 @@
+package services
+
+import (
+	"context"
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/interfaces"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	response "digital-wallet/pkg/response"
+	"digital-wallet/pkg/shared/utils"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// errPromoFundingNotConfigured is returned while PROMO_FUNDING_WALLET_ID is unset, since promos
+// would otherwise have nothing to be paid from
+var errPromoFundingNotConfigured = errors.New("promo funding wallet is not configured")
+
+type PromoService struct {
+	repo   interfaces.RegistryRepository
+	cfg    *configs.Config
+	ledger ledger
+}
+
+// Ensure PromoService implements interfaces.PromoService
+var _ interfaces.PromoService = (*PromoService)(nil)
+
+func NewPromoService(repo interfaces.RegistryRepository, config *configs.Config, listeners ...interfaces.TransactionListener) interfaces.PromoService {
+	return &PromoService{
+		repo:   repo,
+		cfg:    config,
+		ledger: newLedger(config, listeners),
+	}
+}
+
+// Redeem applies a promo code for a wallet user. The code row is locked for the whole
+// redemption, so global and per-user limits hold under concurrent requests.
+func (s *PromoService) Redeem(ctx context.Context, userID string, req dto.RedeemPromoRequest) (*dto.PromoRedemptionResponse, error) {
+	if err := authorizeUser(ctx, s.repo, userID, auth.PermissionWalletManage); err != nil {
+		return nil, err
+	}
+
+	if s.promoFundingWalletID() == "" {
+		return nil, errPromoFundingNotConfigured
+	}
+
+	now := time.Now()
+	code := normalizePromoCode(req.Code)
+
+	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
+		promo, err := txRepo.GetPromoCodeRepository().GetByCodeForUpdate(ctx, code)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, response.NewNotFoundError("Promo code")
+			}
+			return nil, response.Wrap(err, "error retrieving promo code")
+		}
+
+		if !promo.IsActive {
+			return nil, response.NewValidationError("Promo code is not active")
+		}
+
+		if promo.IsExpired(now) {
+			return nil, response.NewValidationError("Promo code has expired")
+		}
+
+		if promo.IsExhausted() {
+			return nil, response.NewValidationError("Promo code has been fully redeemed")
+		}
+
+		wallet, err := txRepo.GetWalletRepository().GetByUserID(ctx, userID)
+		if err != nil {
+			return nil, response.Wrap(err, "error retrieving wallet")
+		}
+
+		if wallet == nil {
+			return nil, response.NewNotFoundError("Wallet")
+		}
+
+		if !wallet.IsActive {
+			return nil, response.NewValidationError("Wallet is not active")
+		}
+
+		redemptionRepo := txRepo.GetPromoRedemptionRepository()
+
+		if promo.MaxPerUser > 0 {
+			used, err := redemptionRepo.CountByPromoAndUser(ctx, promo.ID, userID)
+			if err != nil {
+				return nil, response.Wrap(err, "error counting promo redemptions")
+			}
+
+			if used >= int64(promo.MaxPerUser) {
+				return nil, response.NewValidationError("Promo code redemption limit reached for this user")
+			}
+		}
+
+		redemption := &models.PromoRedemption{
+			ID:          uuid.New().String(),
+			PromoCodeID: promo.ID,
+			UserID:      userID,
+			WalletID:    wallet.ID,
+		}
+
+		res := &dto.PromoRedemptionResponse{
+			RedemptionID: redemption.ID,
+			Code:         promo.Code,
+			RewardType:   promo.RewardType,
+		}
+
+		switch promo.RewardType {
+		case models.PromoRewardCredit:
+			err = s.redeemCredit(ctx, txRepo, promo, wallet, req.TransactionID, redemption, res)
+		case models.PromoRewardDiscount:
+			err = s.redeemDiscount(ctx, txRepo, promo, userID, req.PaymentIntentID, redemption, res, now)
+		default:
+			err = response.NewValidationError(fmt.Sprintf("Unsupported promo reward type %s", promo.RewardType))
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := redemptionRepo.Create(ctx, redemption); err != nil {
+			return nil, response.Wrap(err, "error recording promo redemption")
+		}
+
+		promo.RedemptionCount++
+		if err := txRepo.GetPromoCodeRepository().Update(ctx, promo); err != nil {
+			return nil, response.Wrap(err, "error updating promo code")
+		}
+
+		return res, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*dto.PromoRedemptionResponse), nil
+}
+
+// GenerateCodes creates a batch of random codes that share the same rules
+func (s *PromoService) GenerateCodes(ctx context.Context, req dto.GeneratePromoCodesRequest) ([]models.PromoCode, error) {
+	if err := validatePromoRules(req); err != nil {
+		return nil, err
+	}
+
+	batchID := uuid.New().String()
+	prefix := normalizePromoCode(req.Prefix)
+	seen := make(map[string]bool, req.Count)
+	promos := make([]models.PromoCode, 0, req.Count)
+
+	for len(promos) < req.Count {
+		random, err := utils.RandomCode(req.Length)
+		if err != nil {
+			return nil, err
+		}
+
+		code := random
+		if prefix != "" {
+			code = prefix + "-" + random
+		}
+
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		promos = append(promos, models.PromoCode{
+			ID:              uuid.New().String(),
+			Code:            code,
+			BatchID:         batchID,
+			Description:     req.Description,
+			RewardType:      req.RewardType,
+			ValueType:       req.ValueType,
+			Value:           req.Value,
+			MaxValue:        req.MaxValue,
+			MinAmount:       req.MinAmount,
+			TransactionType: req.TransactionType,
+			MaxRedemptions:  req.MaxRedemptions,
+			MaxPerUser:      req.MaxPerUser,
+			ExpiresAt:       req.ExpiresAt,
+			IsActive:        true,
+		})
+	}
+
+	if err := s.repo.GetPromoCodeRepository().CreateBatch(ctx, promos); err != nil {
+		return nil, response.Wrap(err, "error creating promo codes")
+	}
+
+	return promos, nil
+}
+
+// redeemCredit pays the promo value into the wallet. Codes with eligibility rules need a
+// qualifying transaction of the user's, which can only be used once per code.
+func (s *PromoService) redeemCredit(ctx context.Context, repo interfaces.RegistryRepository, promo *models.PromoCode, wallet *models.Wallet, transactionID string, redemption *models.PromoRedemption, res *dto.PromoRedemptionResponse) error {
+	base := 0.0
+
+	if transactionID != "" {
+		source, err := repo.GetWalletTransactionRepository().GetByID(ctx, transactionID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return response.NewNotFoundError("Transaction")
+			}
+			return response.Wrap(err, "error retrieving transaction")
+		}
+
+		if source.WalletID != wallet.ID {
+			return response.NewNotFoundError("Transaction")
+		}
+
+		if source.Status != models.TransactionStatusCompleted {
+			return response.NewValidationError("Transaction is not completed")
+		}
+
+		if err := checkPromoEligibility(promo, source.Type, source.Amount); err != nil {
+			return err
+		}
+
+		used, err := repo.GetPromoRedemptionRepository().ExistsBySourceTransaction(ctx, promo.ID, source.ID)
+		if err != nil {
+			return response.Wrap(err, "error retrieving promo redemptions")
+		}
+
+		if used {
+			return response.NewDuplicateEntryError("Promo code was already redeemed for this transaction")
+		}
+
+		base = source.Amount
+		redemption.SourceTransactionID = &source.ID
+	} else if promo.TransactionType != "" || promo.MinAmount > 0 {
+		return response.NewValidationError("Promo code requires a qualifying transaction")
+	}
+
+	amount := promo.RewardFor(base)
+	if amount <= 0 {
+		return response.NewValidationError("Promo code has no value for this transaction")
+	}
+
+	metadata := map[string]interface{}{
+		"promo_code_id":       promo.ID,
+		"promo_redemption_id": redemption.ID,
+	}
+
+	if err := fundPromo(ctx, repo, s.ledger, s.promoFundingWalletID(), promo.Code, amount, metadata); err != nil {
+		return err
+	}
+
+	transaction, updatedWallet, err := s.ledger.credit(ctx, repo, ledgerEntry{
+		WalletID:    wallet.ID,
+		Amount:      amount,
+		Type:        models.TransactionTypeDeposit,
+		Description: fmt.Sprintf("Promo code %s", promo.Code),
+		Metadata:    metadata,
+		CapBalance:  true,
+	})
+	if err != nil {
+		if errors.Is(err, response.ErrBalanceLimitExceeded) {
+			return err
+		}
+		return response.Wrap(err, "error crediting promo")
+	}
+
+	redemption.Amount = amount
+	redemption.TransactionID = &transaction.ID
+
+	res.Amount = amount
+	res.TransactionID = &transaction.ID
+	res.WalletBalance = &updatedWallet.Balance
+
+	return nil
+}
+
+// redeemDiscount reserves the discount on an unconfirmed payment intent for the redeeming user,
+// who becomes the only user that can pay it. The payer is charged the reduced amount at
+// confirmation, when the promo funding wallet pays the discount to the merchant; canceling or
+// expiring the intent gives the redemption back.
+func (s *PromoService) redeemDiscount(ctx context.Context, repo interfaces.RegistryRepository, promo *models.PromoCode, userID, paymentIntentID string, redemption *models.PromoRedemption, res *dto.PromoRedemptionResponse, now time.Time) error {
+	if paymentIntentID == "" {
+		return response.NewValidationError("Discount promo codes must be applied to a payment intent")
+	}
+
+	intentRepo := repo.GetPaymentIntentRepository()
+
+	intent, err := intentRepo.GetByIDForUpdate(ctx, paymentIntentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return response.NewNotFoundError("Payment intent")
+		}
+		return response.Wrap(err, "error retrieving payment intent")
+	}
+
+	if intent.Status != models.PaymentIntentStatusRequiresConfirmation || intent.IsExpired(now) {
+		return response.NewValidationError("Payment intent can no longer be discounted")
+	}
+
+	if intent.PromoCodeID != nil {
+		return response.NewDuplicateEntryError("Payment intent already has a promo code applied")
+	}
+
+	if err := checkIntentPayer(intent, userID); err != nil {
+		return err
+	}
+
+	if err := checkPromoEligibility(promo, models.TransactionTypePayment, intent.Amount); err != nil {
+		return err
+	}
+
+	amount := math.Min(promo.RewardFor(intent.Amount), intent.Amount)
+	if amount <= 0 {
+		return response.NewValidationError("Promo code has no value for this payment")
+	}
+
+	intent.DiscountAmount = amount
+	intent.PromoCodeID = &promo.ID
+	intent.PayerUserID = &userID
+
+	if err := intentRepo.Update(ctx, intent); err != nil {
+		return response.Wrap(err, "error updating payment intent")
+	}
+
+	redemption.Amount = amount
+	redemption.PaymentIntentID = &intent.ID
+
+	amountDue := intent.AmountDue()
+	res.Amount = amount
+	res.PaymentIntentID = &intent.ID
+	res.AmountDue = &amountDue
+
+	return nil
+}
+
+func (s *PromoService) promoFundingWalletID() string {
+	if s.cfg == nil {
+		return ""
+	}
+	return s.cfg.Promo.FundingWalletID
+}
+
+// fundPromo takes the value of a promo from the promo funding wallet, so credits and discounts
+// pay out money set aside for promotions rather than creating it. Like the ledger it must run
+// inside the caller's DoInTransaction.
+func fundPromo(ctx context.Context, repo interfaces.RegistryRepository, l ledger, fundingWalletID, code string, amount float64, metadata map[string]interface{}) error {
+	if fundingWalletID == "" {
+		return errPromoFundingNotConfigured
+	}
+
+	_, _, err := l.debit(ctx, repo, ledgerEntry{
+		WalletID:    fundingWalletID,
+		Amount:      amount,
+		Type:        models.TransactionTypeTransfer,
+		Description: fmt.Sprintf("Funding for promo code %s", code),
+		Metadata:    metadata,
+	})
+	if err != nil {
+		return response.Wrap(err, "promo funding failed")
+	}
+
+	return nil
+}
+
+// releasePromoDiscount gives back the redemption reserved on an intent that will never be
+// paid. It runs inside the caller's transaction, which already holds the intent lock.
+func releasePromoDiscount(ctx context.Context, repo interfaces.RegistryRepository, intent *models.PaymentIntent) error {
+	if intent.PromoCodeID == nil {
+		return nil
+	}
+
+	promo, err := repo.GetPromoCodeRepository().GetByIDForUpdate(ctx, *intent.PromoCodeID)
+	if err != nil {
+		return response.Wrap(err, "error retrieving promo code")
+	}
+
+	if err := repo.GetPromoRedemptionRepository().DeleteByPaymentIntentID(ctx, intent.ID); err != nil {
+		return response.Wrap(err, "error releasing promo redemption")
+	}
+
+	if promo.RedemptionCount > 0 {
+		promo.RedemptionCount--
+	}
+
+	if err := repo.GetPromoCodeRepository().Update(ctx, promo); err != nil {
+		return response.Wrap(err, "error updating promo code")
+	}
+
+	intent.DiscountAmount = 0
+	intent.PromoCodeID = nil
+	intent.PayerUserID = nil
+
+	return nil
+}
+
+// checkIntentPayer refuses a user other than the one a discounted payment intent is reserved for
+func checkIntentPayer(intent *models.PaymentIntent, userID string) error {
+	if intent.PayerUserID != nil && *intent.PayerUserID != userID {
+		return response.NewNotFoundError("Payment intent")
+	}
+	return nil
+}
+
+func checkPromoEligibility(promo *models.PromoCode, transactionType string, amount float64) error {
+	if promo.TransactionType != "" && promo.TransactionType != transactionType {
+		return response.NewValidationError(fmt.Sprintf("Promo code only applies to %s transactions", promo.TransactionType))
+	}
+
+	if amount < promo.MinAmount {
+		return response.NewValidationError(fmt.Sprintf("Promo code requires a minimum amount of %.2f", promo.MinAmount))
+	}
+
+	return nil
+}
+
+func validatePromoRules(req dto.GeneratePromoCodesRequest) error {
+	if req.ValueType == models.PromoValuePercentage && req.Value > 100 {
+		return response.NewValidationError("Percentage promo value cannot exceed 100")
+	}
+
+	if req.RewardType == models.PromoRewardDiscount && req.TransactionType != "" && req.TransactionType != models.TransactionTypePayment {
+		return response.NewValidationError("Discount promo codes only apply to payments")
+	}
+
+	if req.RewardType == models.PromoRewardCredit && req.ValueType == models.PromoValuePercentage && req.TransactionType == "" {
+		return response.NewValidationError("Percentage credit promo codes need a transaction type to take the amount from")
+	}
+
+	if req.MaxPerUser > 0 && req.MaxRedemptions > 0 && req.MaxPerUser > req.MaxRedemptions {
+		return response.NewValidationError("Per-user limit cannot exceed the global redemption limit")
+	}
+
+	return nil
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
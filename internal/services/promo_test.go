@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"digital-wallet/configs"
+	"digital-wallet/internal/dto"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/response"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// promoConfig pays promos from wallet-promo
+func promoConfig() *configs.Config {
+	cfg := &configs.Config{}
+	cfg.Promo.FundingWalletID = "wallet-promo"
+	return cfg
+}
+
+func TestPromoService_Redeem(t *testing.T) {
+	wallet := &models.Wallet{ID: "wallet-1", UserID: "user-1", Balance: 1000, IsActive: true}
+
+	t.Run("credits a fixed promo to the wallet", func(t *testing.T) {
+		promo := &models.PromoCode{ID: "promo-1", Code: "WELCOME", RewardType: models.PromoRewardCredit, ValueType: models.PromoValueFixed, Value: 50, MaxRedemptions: 100, MaxPerUser: 1, IsActive: true}
+
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockPromoRepo := mocks.NewPromoCodeRepository(t)
+		mockRedemptionRepo := mocks.NewPromoRedemptionRepository(t)
+
+		mockPromoRepo.On("GetByCodeForUpdate", mock.Anything, "WELCOME").Return(promo, nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
+		mockRedemptionRepo.On("CountByPromoAndUser", mock.Anything, "promo-1", "user-1").Return(int64(0), nil)
+		mockTxRepo.On("Create", mock.Anything, mock.MatchedBy(func(tx *models.WalletTransaction) bool {
+			return tx.WalletID == "wallet-promo" && tx.Direction == models.TransactionDirectionDebit && tx.Amount == 50
+		})).Return(nil).Once()
+		mockWalletRepo.On("Withdraw", mock.Anything, "wallet-promo", 50.0).Return(&models.Wallet{ID: "wallet-promo", Balance: 9950}, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.MatchedBy(func(tx *models.WalletTransaction) bool {
+			return tx.WalletID == "wallet-1" && tx.Type == models.TransactionTypeDeposit && tx.Amount == 50
+		})).Return(nil).Once()
+		mockWalletRepo.On("Deposit", mock.Anything, "wallet-1", 50.0).Return(&models.Wallet{ID: "wallet-1", Balance: 1050}, nil)
+		mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		mockRedemptionRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *models.PromoRedemption) bool {
+			return r.Amount == 50 && r.TransactionID != nil
+		})).Return(nil)
+		mockPromoRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *models.PromoCode) bool {
+			return p.RedemptionCount == 1
+		})).Return(nil)
+
+		reg := withinKYCLimits(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, pcr: mockPromoRepo, prr: mockRedemptionRepo})
+		svc := NewPromoService(reg, promoConfig())
+
+		res, err := svc.Redeem(context.Background(), "user-1", dto.RedeemPromoRequest{Code: " welcome "})
+		require.NoError(t, err)
+		assert.Equal(t, 50.0, res.Amount)
+		assert.Equal(t, 1050.0, *res.WalletBalance)
+	})
+
+	t.Run("credit fails when the funding wallet cannot pay", func(t *testing.T) {
+		promo := &models.PromoCode{ID: "promo-1", Code: "WELCOME", RewardType: models.PromoRewardCredit, ValueType: models.PromoValueFixed, Value: 50, IsActive: true}
+
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockTxRepo := mocks.NewWalletTransactionRepository(t)
+		mockPromoRepo := mocks.NewPromoCodeRepository(t)
+
+		mockPromoRepo.On("GetByCodeForUpdate", mock.Anything, "WELCOME").Return(promo, nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
+		mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		mockWalletRepo.On("Withdraw", mock.Anything, "wallet-promo", 50.0).Return(nil, errors.New("insufficient balance"))
+
+		reg := &testRegistry{wr: mockWalletRepo, tr: mockTxRepo, pcr: mockPromoRepo, prr: mocks.NewPromoRedemptionRepository(t)}
+		svc := NewPromoService(reg, promoConfig())
+
+		_, err := svc.Redeem(context.Background(), "user-1", dto.RedeemPromoRequest{Code: "WELCOME"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "promo funding failed")
+		mockWalletRepo.AssertNotCalled(t, "Deposit", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("refused without a funding wallet", func(t *testing.T) {
+		svc := NewPromoService(&testRegistry{}, &configs.Config{})
+
+		_, err := svc.Redeem(context.Background(), "user-1", dto.RedeemPromoRequest{Code: "WELCOME"})
+		require.ErrorIs(t, err, errPromoFundingNotConfigured)
+	})
+
+	t.Run("single-use code that was already redeemed", func(t *testing.T) {
+		promo := &models.PromoCode{ID: "promo-1", Code: "ONCE", RewardType: models.PromoRewardCredit, MaxRedemptions: 1, RedemptionCount: 1, IsActive: true}
+
+		mockPromoRepo := mocks.NewPromoCodeRepository(t)
+		mockPromoRepo.On("GetByCodeForUpdate", mock.Anything, "ONCE").Return(promo, nil)
+
+		reg := &testRegistry{pcr: mockPromoRepo}
+		svc := NewPromoService(reg, promoConfig())
+
+		_, err := svc.Redeem(context.Background(), "user-1", dto.RedeemPromoRequest{Code: "ONCE"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fully redeemed")
+	})
+
+	t.Run("expired code", func(t *testing.T) {
+		expiredAt := time.Now().Add(-time.Minute)
+		promo := &models.PromoCode{ID: "promo-1", Code: "OLD", IsActive: true, ExpiresAt: &expiredAt}
+
+		mockPromoRepo := mocks.NewPromoCodeRepository(t)
+		mockPromoRepo.On("GetByCodeForUpdate", mock.Anything, "OLD").Return(promo, nil)
+
+		reg := &testRegistry{pcr: mockPromoRepo}
+		svc := NewPromoService(reg, promoConfig())
+
+		_, err := svc.Redeem(context.Background(), "user-1", dto.RedeemPromoRequest{Code: "OLD"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expired")
+	})
+
+	t.Run("per-user limit reached", func(t *testing.T) {
+		promo := &models.PromoCode{ID: "promo-1", Code: "TWICE", RewardType: models.PromoRewardCredit, MaxPerUser: 2, IsActive: true}
+
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockPromoRepo := mocks.NewPromoCodeRepository(t)
+		mockRedemptionRepo := mocks.NewPromoRedemptionRepository(t)
+
+		mockPromoRepo.On("GetByCodeForUpdate", mock.Anything, "TWICE").Return(promo, nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
+		mockRedemptionRepo.On("CountByPromoAndUser", mock.Anything, "promo-1", "user-1").Return(int64(2), nil)
+
+		reg := &testRegistry{wr: mockWalletRepo, pcr: mockPromoRepo, prr: mockRedemptionRepo}
+		svc := NewPromoService(reg, promoConfig())
+
+		_, err := svc.Redeem(context.Background(), "user-1", dto.RedeemPromoRequest{Code: "TWICE"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "limit reached")
+	})
+
+	t.Run("deposit promo requires a qualifying transaction", func(t *testing.T) {
+		promo := &models.PromoCode{ID: "promo-1", Code: "TOPUP", RewardType: models.PromoRewardCredit, ValueType: models.PromoValuePercentage, Value: 10, TransactionType: models.TransactionTypeDeposit, IsActive: true}
+
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockPromoRepo := mocks.NewPromoCodeRepository(t)
+
+		mockPromoRepo.On("GetByCodeForUpdate", mock.Anything, "TOPUP").Return(promo, nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
+
+		reg := &testRegistry{wr: mockWalletRepo, pcr: mockPromoRepo, prr: mocks.NewPromoRedemptionRepository(t)}
+		svc := NewPromoService(reg, promoConfig())
+
+		_, err := svc.Redeem(context.Background(), "user-1", dto.RedeemPromoRequest{Code: "TOPUP"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "qualifying transaction")
+	})
+
+	t.Run("discount is reserved on the payment intent", func(t *testing.T) {
+		promo := &models.PromoCode{ID: "promo-1", Code: "SALE", RewardType: models.PromoRewardDiscount, ValueType: models.PromoValuePercentage, Value: 20, MaxValue: 30, MinAmount: 100, IsActive: true}
+		intent := &models.PaymentIntent{ID: "pi-1", Amount: 200, Status: models.PaymentIntentStatusRequiresConfirmation, ExpiresAt: time.Now().Add(time.Minute)}
+
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockPromoRepo := mocks.NewPromoCodeRepository(t)
+		mockRedemptionRepo := mocks.NewPromoRedemptionRepository(t)
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+
+		mockPromoRepo.On("GetByCodeForUpdate", mock.Anything, "SALE").Return(promo, nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
+		mockIntentRepo.On("GetByIDForUpdate", mock.Anything, "pi-1").Return(intent, nil)
+		mockIntentRepo.On("Update", mock.Anything, mock.MatchedBy(func(pi *models.PaymentIntent) bool {
+			return pi.DiscountAmount == 30 && pi.PromoCodeID != nil && pi.PayerUserID != nil && *pi.PayerUserID == "user-1"
+		})).Return(nil)
+		mockRedemptionRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mockPromoRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		reg := &testRegistry{wr: mockWalletRepo, pir: mockIntentRepo, pcr: mockPromoRepo, prr: mockRedemptionRepo}
+		svc := NewPromoService(reg, promoConfig())
+
+		res, err := svc.Redeem(context.Background(), "user-1", dto.RedeemPromoRequest{Code: "SALE", PaymentIntentID: "pi-1"})
+		require.NoError(t, err)
+		assert.Equal(t, 30.0, res.Amount)
+		assert.Equal(t, 170.0, *res.AmountDue)
+	})
+
+	t.Run("intents another user is paying cannot be discounted", func(t *testing.T) {
+		promo := &models.PromoCode{ID: "promo-1", Code: "SALE", RewardType: models.PromoRewardDiscount, ValueType: models.PromoValuePercentage, Value: 20, IsActive: true}
+		payer := "user-2"
+		intent := &models.PaymentIntent{ID: "pi-1", Amount: 200, Status: models.PaymentIntentStatusRequiresConfirmation, ExpiresAt: time.Now().Add(time.Minute), PayerUserID: &payer}
+
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockPromoRepo := mocks.NewPromoCodeRepository(t)
+		mockIntentRepo := mocks.NewPaymentIntentRepository(t)
+
+		mockPromoRepo.On("GetByCodeForUpdate", mock.Anything, "SALE").Return(promo, nil)
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
+		mockIntentRepo.On("GetByIDForUpdate", mock.Anything, "pi-1").Return(intent, nil)
+
+		reg := &testRegistry{wr: mockWalletRepo, pir: mockIntentRepo, pcr: mockPromoRepo, prr: mocks.NewPromoRedemptionRepository(t)}
+		svc := NewPromoService(reg, promoConfig())
+
+		_, err := svc.Redeem(context.Background(), "user-1", dto.RedeemPromoRequest{Code: "SALE", PaymentIntentID: "pi-1"})
+		assert.Equal(t, response.NewNotFoundError("Payment intent"), err)
+	})
+}
+
+func TestPromoService_GenerateCodes(t *testing.T) {
+	t.Run("generates unique prefixed codes in one batch", func(t *testing.T) {
+		mockPromoRepo := mocks.NewPromoCodeRepository(t)
+		mockPromoRepo.On("CreateBatch", mock.Anything, mock.MatchedBy(func(promos []models.PromoCode) bool {
+			return len(promos) == 50
+		})).Return(nil)
+
+		svc := NewPromoService(&testRegistry{pcr: mockPromoRepo}, &configs.Config{})
+
+		promos, err := svc.GenerateCodes(context.Background(), dto.GeneratePromoCodesRequest{
+			Count: 50, Prefix: "xmas", Length: 8, RewardType: models.PromoRewardCredit, ValueType: models.PromoValueFixed, Value: 10, MaxRedemptions: 1,
+		})
+		require.NoError(t, err)
+
+		seen := map[string]bool{}
+		for _, p := range promos {
+			assert.Regexp(t, `^XMAS-[A-Z2-9]{8}$`, p.Code)
+			assert.Equal(t, promos[0].BatchID, p.BatchID)
+			seen[p.Code] = true
+		}
+		assert.Len(t, seen, 50)
+	})
+
+	t.Run("rejects discounts on non-payment transactions", func(t *testing.T) {
+		svc := NewPromoService(&testRegistry{}, &configs.Config{})
+
+		_, err := svc.GenerateCodes(context.Background(), dto.GeneratePromoCodesRequest{
+			Count: 1, Length: 8, RewardType: models.PromoRewardDiscount, ValueType: models.PromoValueFixed, Value: 10, TransactionType: models.TransactionTypeDeposit,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only apply to payments")
+	})
+}
@@ -6,32 +6,41 @@ import (
 	"digital-wallet/internal/dto"
 	"digital-wallet/internal/interfaces"
 	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
 	response "digital-wallet/pkg/response"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type WalletService struct {
-	repo interfaces.RegistryRepository
-	cfg  *configs.Config
+	repo   interfaces.RegistryRepository
+	cfg    *configs.Config
+	otp    interfaces.OTPSender
+	ledger ledger
 }
 
 // Ensure WalletService implements interfaces.WalletService
 var _ interfaces.WalletService = (*WalletService)(nil)
 
-func NewWalletService(repo interfaces.RegistryRepository, config *configs.Config) interfaces.WalletService {
+func NewWalletService(repo interfaces.RegistryRepository, config *configs.Config, otp interfaces.OTPSender, listeners ...interfaces.TransactionListener) interfaces.WalletService {
 	return &WalletService{
-		repo: repo,
-		cfg:  config,
+		repo:   repo,
+		cfg:    config,
+		otp:    otp,
+		ledger: newLedger(config, listeners),
 	}
 }
 
 // GetOrCreateWallet is
 func (s *WalletService) GetOrCreateWallet(ctx context.Context, userID string) (*models.Wallet, error) {
-	walletRepo := s.repo.GetWalletRepository()
+	return getOrCreateWallet(ctx, s.repo.GetWalletRepository(), userID)
+}
 
+// getOrCreateWallet returns the user's wallet, creating an empty IDR wallet on first use
+func getOrCreateWallet(ctx context.Context, walletRepo interfaces.WalletRepository, userID string) (*models.Wallet, error) {
 	// check if exists
 	wallet, err := walletRepo.GetByUserID(ctx, userID)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -74,16 +83,43 @@ func (s *WalletService) GetBalance(ctx context.Context, userID string) (*dto.Bal
 		return nil, response.Wrap(err, "error retrieving balance")
 	}
 
+	// pockets are kept apart from the available balance
+	pockets, err := s.repo.GetPocketRepository().GetByWalletID(ctx, wallet.ID)
+	if err != nil {
+		return nil, response.Wrap(err, "error retrieving pockets")
+	}
+
+	var pocketTotal float64
+	for _, p := range pockets {
+		pocketTotal += p.Balance
+	}
+
 	return &dto.BalanceResponse{
-		WalletID: wallet.ID,
-		Balance:  balance,
-		Currency: wallet.Currency,
-		IsActive: wallet.IsActive,
+		WalletID:     wallet.ID,
+		Balance:      balance,
+		PocketTotal:  pocketTotal,
+		TotalBalance: balance + pocketTotal,
+		Currency:     wallet.Currency,
+		IsActive:     wallet.IsActive,
+		Pockets:      toPocketBalances(pockets),
 	}, nil
 }
 
 // Withdraw is
 func (s *WalletService) Withdraw(ctx context.Context, req dto.WithdrawRequest) (*dto.WithdrawResponse, error) {
+	// Withdrawing from someone else's wallet needs the permission, whichever route was used
+	if err := authorizeUser(ctx, s.repo, req.UserID, auth.PermissionWalletWithdraw); err != nil {
+		return nil, err
+	}
+
+	if err := verifyPIN(ctx, s.repo, s.cfg, req.UserID, req.PIN); err != nil {
+		return nil, err
+	}
+
+	if err := verifyOutgoingMFA(ctx, s.repo, s.cfg, req.UserID, req.Amount, req.MFACode); err != nil {
+		return nil, err
+	}
+
 	// GetOrCreateWallet is
 	wallet, err := s.GetOrCreateWallet(ctx, req.UserID)
 	if err != nil {
@@ -95,6 +131,36 @@ func (s *WalletService) Withdraw(ctx context.Context, req dto.WithdrawRequest) (
 		return nil, response.NewValidationError("Wallet is not active")
 	}
 
+	_, newDevice, err := deviceFirstSeen(ctx, s.repo, s.cfg, req.UserID, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// risky withdrawals need a one-time code on top of the PIN; the first request gets a
+	// challenge, and the same request repeated with its code goes through
+	if req.ChallengeID != "" {
+		if err := verifyChallenge(ctx, s.repo, s.cfg, req.UserID, withdrawalPayloadHash(req), req.ChallengeID, req.ChallengeCode); err != nil {
+			return nil, err
+		}
+	} else {
+		reasons, err := s.withdrawalRisks(ctx, req, wallet, newDevice)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(reasons) > 0 {
+			challenge, err := issueChallenge(ctx, s.repo, s.cfg, s.otp, req.UserID, withdrawalPayloadHash(req), reasons)
+			if err != nil {
+				return nil, err
+			}
+			return &dto.WithdrawResponse{Status: dto.WithdrawStatusChallengeRequired, Challenge: challenge}, nil
+		}
+	}
+
+	// a refused withdrawal still commits its FAILED transaction so listeners can report it;
+	// the refusal is returned after the commit
+	var withdrawErr error
+
 	result, err := s.repo.DoInTransaction(ctx, func(ctx context.Context, txRepo interfaces.RegistryRepository) (interface{}, error) {
 		walletRepo := txRepo.GetWalletRepository()
 		transactionRepo := txRepo.GetWalletTransactionRepository()
@@ -106,9 +172,13 @@ func (s *WalletService) Withdraw(ctx context.Context, req dto.WithdrawRequest) (
 			WalletID:    wallet.ID,
 			Amount:      req.Amount,
 			Type:        "WITHDRAWAL",
+			Direction:   models.TransactionDirectionDebit,
 			Status:      "PENDING",
 			Description: req.Description,
 		}
+		if req.Beneficiary != "" {
+			transaction.Beneficiary = &req.Beneficiary
+		}
 
 		// Create transaction
 		if err := transactionRepo.Create(ctx, transaction); err != nil {
@@ -120,16 +190,44 @@ func (s *WalletService) Withdraw(ctx context.Context, req dto.WithdrawRequest) (
 		if err != nil {
 			// if withdrawal fails
 			transaction.Status = "FAILED"
-			_ = transactionRepo.Update(ctx, transaction)
-			return nil, response.Wrap(err, "withdrawal failed")
+			if err := chainTransaction(ctx, txRepo, transaction); err != nil {
+				return nil, err
+			}
+			if err := transactionRepo.Update(ctx, transaction); err != nil {
+				return nil, response.Wrap(err, "error updating transaction status")
+			}
+
+			if err := s.ledger.publish(ctx, txRepo, transaction); err != nil {
+				return nil, err
+			}
+
+			withdrawErr = response.Wrap(err, "withdrawal failed")
+			return nil, nil
+		}
+
+		// the limits are checked under the row lock so concurrent withdrawals cannot each pass
+		// them; a refusal rolls the debit back
+		if err := checkOutgoingLimits(ctx, txRepo, s.cfg, wallet, req.Amount, req.SessionID); err != nil {
+			return nil, err
 		}
 
 		// transaction is completed
 		transaction.Status = "COMPLETED"
+		if err := chainTransaction(ctx, txRepo, transaction); err != nil {
+			return nil, err
+		}
 		if err := transactionRepo.Update(ctx, transaction); err != nil {
 			return nil, response.Wrap(err, "error updating transaction status")
 		}
 
+		if err := auditBalanceChange(ctx, txRepo, updatedWallet, transaction); err != nil {
+			return nil, err
+		}
+
+		if err := s.ledger.publish(ctx, txRepo, transaction); err != nil {
+			return nil, err
+		}
+
 		return &dto.WithdrawResponse{
 			ID:            wallet.ID,
 			WalletID:      wallet.ID,
@@ -145,9 +243,56 @@ func (s *WalletService) Withdraw(ctx context.Context, req dto.WithdrawRequest) (
 		return nil, err
 	}
 
+	if withdrawErr != nil {
+		return nil, withdrawErr
+	}
+
 	return result.(*dto.WithdrawResponse), nil
 }
 
+// checkOutgoingLimits applies the limits on money leaving a wallet to a debit of amount: the
+// daily withdrawal limit, and the new device limit when the owner's session is on a new device.
+// Withdrawals and every ledger debit marked LimitOutgoing go through it.
+func checkOutgoingLimits(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, wallet *models.Wallet, amount float64, sessionID string) error {
+	firstSeen, newDevice, err := deviceFirstSeen(ctx, repo, cfg, wallet.UserID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if newDevice {
+		if err := checkNewDeviceLimit(ctx, repo, cfg, wallet, amount, firstSeen); err != nil {
+			return err
+		}
+	}
+
+	return checkWithdrawalLimit(ctx, repo, cfg, wallet, amount)
+}
+
+// checkWithdrawalLimit refuses a debit that would take what the wallet sent out since the start
+// of the day past the daily withdrawal limit of its owner's KYC level. Withdrawals, checkout
+// payments and escrow funding all count. It runs after the balance changed, while the wallet
+// row is locked and before the debit is completed, so the refusal rolls the debit back.
+func checkWithdrawalLimit(ctx context.Context, repo interfaces.RegistryRepository, cfg *configs.Config, wallet *models.Wallet, amount float64) error {
+	user, err := getUser(ctx, repo, wallet.UserID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	sent, err := repo.GetWalletTransactionRepository().SumOutgoing(ctx, wallet.ID, startOfDay)
+	if err != nil {
+		return response.Wrap(err, "error retrieving outgoing transactions")
+	}
+
+	if sent+amount > kycDailyWithdrawalLimit(cfg, kycLevel(user)) {
+		return response.ErrWithdrawalLimitExceeded
+	}
+
+	return nil
+}
+
 // GetTransactionHistory is
 func (s *WalletService) GetTransactionHistory(ctx context.Context, userID string, limit, offset int) ([]models.WalletTransaction, int64, error) {
 	walletRepo := s.repo.GetWalletRepository()
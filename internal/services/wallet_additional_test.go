@@ -9,7 +9,10 @@ import (
 	"digital-wallet/internal/dto"
 	"digital-wallet/internal/mocks"
 	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -30,10 +33,10 @@ func TestWalletService_Withdraw_InactiveWallet_Real(t *testing.T) {
 
 	mockWalletRepo.On("GetByUserID", mock.Anything, "user-inactive").Return(inactiveWallet, nil)
 
-	reg := &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}
-	svc := NewWalletService(reg, (*configs.Config)(nil))
+	reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}, "user-inactive"))
+	svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
-	req := dto.WithdrawRequest{UserID: "user-inactive", Amount: 100.00, Description: "test withdrawal"}
+	req := dto.WithdrawRequest{UserID: "user-inactive", Amount: 100.00, Description: "test withdrawal", PIN: testPIN}
 	resp, err := svc.Withdraw(context.Background(), req)
 
 	require.Error(t, err)
@@ -57,10 +60,10 @@ func TestWalletService_Withdraw_TransactionCreateError(t *testing.T) {
 	mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
 	mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("transaction create error"))
 
-	reg := &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}
-	svc := NewWalletService(reg, (*configs.Config)(nil))
+	reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}, "user-1"))
+	svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
-	req := dto.WithdrawRequest{UserID: "user-1", Amount: 100.00, Description: "test withdrawal"}
+	req := dto.WithdrawRequest{UserID: "user-1", Amount: 100.00, Description: "test withdrawal", PIN: testPIN}
 	resp, err := svc.Withdraw(context.Background(), req)
 
 	require.Error(t, err)
@@ -87,10 +90,10 @@ func TestWalletService_Withdraw_WithdrawalFails(t *testing.T) {
 		return tx.Status == "FAILED"
 	})).Return(nil)
 
-	reg := &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}
-	svc := NewWalletService(reg, (*configs.Config)(nil))
+	reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}, "user-1"))
+	svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
-	req := dto.WithdrawRequest{UserID: "user-1", Amount: 500.00, Description: "test withdrawal"}
+	req := dto.WithdrawRequest{UserID: "user-1", Amount: 500.00, Description: "test withdrawal", PIN: testPIN}
 	resp, err := svc.Withdraw(context.Background(), req)
 
 	require.Error(t, err)
@@ -98,6 +101,31 @@ func TestWalletService_Withdraw_WithdrawalFails(t *testing.T) {
 	assert.Contains(t, err.Error(), "withdrawal failed")
 }
 
+// TestWalletService_Withdraw_WithdrawalFailsNotifiesListeners tests that a refused withdrawal
+// keeps its FAILED transaction and reports it to listeners
+func TestWalletService_Withdraw_WithdrawalFailsNotifiesListeners(t *testing.T) {
+	mockWalletRepo := mocks.NewWalletRepository(t)
+	mockTxRepo := mocks.NewWalletTransactionRepository(t)
+	mockListener := mocks.NewTransactionListener(t)
+
+	wallet := &models.Wallet{ID: "wallet-1", UserID: "user-1", Balance: 100.00, Currency: "IDR", IsActive: true}
+
+	mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
+	mockTxRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockWalletRepo.On("Withdraw", mock.Anything, "wallet-1", 500.00).Return(nil, errors.New("insufficient balance"))
+	mockTxRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockListener.On("OnTransactionSettled", mock.Anything, mock.Anything, mock.MatchedBy(func(tx *models.WalletTransaction) bool {
+		return tx.Type == models.TransactionTypeWithdrawal && tx.Status == models.TransactionStatusFailed
+	})).Return(nil)
+
+	reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}, "user-1"))
+	svc := NewWalletService(reg, (*configs.Config)(nil), nil, mockListener)
+
+	_, err := svc.Withdraw(context.Background(), dto.WithdrawRequest{UserID: "user-1", Amount: 500.00, PIN: testPIN})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient balance")
+}
+
 // TestWalletService_Withdraw_TransactionUpdateError tests error when transaction update fails
 func TestWalletService_Withdraw_TransactionUpdateError(t *testing.T) {
 	mockWalletRepo := mocks.NewWalletRepository(t)
@@ -126,10 +154,10 @@ func TestWalletService_Withdraw_TransactionUpdateError(t *testing.T) {
 		return tx.Status == "COMPLETED"
 	})).Return(errors.New("update error"))
 
-	reg := &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}
-	svc := NewWalletService(reg, (*configs.Config)(nil))
+	reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}, "user-1"))
+	svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
-	req := dto.WithdrawRequest{UserID: "user-1", Amount: 500.00, Description: "test withdrawal"}
+	req := dto.WithdrawRequest{UserID: "user-1", Amount: 500.00, Description: "test withdrawal", PIN: testPIN}
 	resp, err := svc.Withdraw(context.Background(), req)
 
 	require.Error(t, err)
@@ -143,12 +171,27 @@ func TestWalletService_Withdraw_GetOrCreateWalletError(t *testing.T) {
 
 	mockWalletRepo.On("GetByUserID", mock.Anything, "user-error").Return(nil, errors.New("database connection error"))
 
-	reg := &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}
-	svc := NewWalletService(reg, (*configs.Config)(nil))
+	reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}, "user-error"))
+	svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
-	req := dto.WithdrawRequest{UserID: "user-error", Amount: 100.00, Description: "test withdrawal"}
+	req := dto.WithdrawRequest{UserID: "user-error", Amount: 100.00, Description: "test withdrawal", PIN: testPIN}
 	resp, err := svc.Withdraw(context.Background(), req)
 
 	require.Error(t, err)
 	require.Nil(t, resp)
 }
+
+// TestWalletService_Withdraw_OtherUserNeedsPermission tests that a principal may only withdraw
+// from another user's wallet with the withdraw permission
+func TestWalletService_Withdraw_OtherUserNeedsPermission(t *testing.T) {
+	token := &jwt.Token{Claims: jwt.MapClaims{"user_id": "support-1", "role": auth.RoleSupport, "permissions": []interface{}{auth.PermissionWalletRead}}}
+	ctx := context.WithValue(context.Background(), auth.ContextKeyUser, token)
+
+	svc := NewWalletService(&testRegistry{}, (*configs.Config)(nil), nil)
+
+	req := dto.WithdrawRequest{UserID: "user-1", Amount: 100.00, Description: "test withdrawal", PIN: testPIN}
+	resp, err := svc.Withdraw(ctx, req)
+
+	assert.Nil(t, resp)
+	assert.Equal(t, response.ErrInsufficientPermissions, err)
+}
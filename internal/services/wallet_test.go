@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"digital-wallet/configs"
 	"digital-wallet/internal/dto"
@@ -20,8 +21,37 @@ import (
 
 // testRegistry is a minimal in-test implementation of interfaces.RegistryRepository
 type testRegistry struct {
-	wr interfaces.WalletRepository
-	tr interfaces.WalletTransactionRepository
+	wr   interfaces.WalletRepository
+	tr   interfaces.WalletTransactionRepository
+	mr   interfaces.MerchantRepository
+	pir  interfaces.PaymentIntentRepository
+	pkr  interfaces.PocketRepository
+	ccr  interfaces.CashbackCampaignRepository
+	crr  interfaces.CashbackRewardRepository
+	pcr  interfaces.PromoCodeRepository
+	prr  interfaces.PromoRedemptionRepository
+	er   interfaces.EscrowRepository
+	eer  interfaces.EscrowEventRepository
+	tsr  interfaces.TransactionSummaryRepository
+	br   interfaces.BudgetRepository
+	bar  interfaces.BudgetAlertRepository
+	nr   interfaces.NotificationRepository
+	npr  interfaces.NotificationPreferenceRepository
+	ur   interfaces.UserRepository
+	sr   interfaces.SessionRepository
+	atr  interfaces.AttemptRepository
+	mfr  interfaces.MFARepository
+	rcr  interfaces.MFARecoveryCodeRepository
+	chr  interfaces.ChallengeRepository
+	akr  interfaces.AccountTokenRepository
+	rlr  interfaces.RoleRepository
+	aar  interfaces.AdminActionRepository
+	apr  interfaces.ApprovalRequestRepository
+	akey interfaces.APIKeyRepository
+	dvr  interfaces.DeviceRepository
+	ksr  interfaces.KYCSubmissionRepository
+	aur  interfaces.AuditEventRepository
+	lcr  interfaces.LedgerChainRepository
 }
 
 func (r *testRegistry) DoInTransaction(ctx context.Context, txFunc interfaces.InTransaction) (interface{}, error) {
@@ -34,6 +64,170 @@ func (r *testRegistry) GetWalletTransactionRepository() interfaces.WalletTransac
 	return r.tr
 }
 
+func (r *testRegistry) GetMerchantRepository() interfaces.MerchantRepository { return r.mr }
+
+func (r *testRegistry) GetPaymentIntentRepository() interfaces.PaymentIntentRepository {
+	return r.pir
+}
+
+func (r *testRegistry) GetPocketRepository() interfaces.PocketRepository { return r.pkr }
+
+func (r *testRegistry) GetCashbackCampaignRepository() interfaces.CashbackCampaignRepository {
+	return r.ccr
+}
+
+func (r *testRegistry) GetCashbackRewardRepository() interfaces.CashbackRewardRepository {
+	return r.crr
+}
+
+func (r *testRegistry) GetPromoCodeRepository() interfaces.PromoCodeRepository {
+	return r.pcr
+}
+
+func (r *testRegistry) GetPromoRedemptionRepository() interfaces.PromoRedemptionRepository {
+	return r.prr
+}
+
+func (r *testRegistry) GetEscrowRepository() interfaces.EscrowRepository {
+	return r.er
+}
+
+func (r *testRegistry) GetEscrowEventRepository() interfaces.EscrowEventRepository {
+	return r.eer
+}
+
+func (r *testRegistry) GetTransactionSummaryRepository() interfaces.TransactionSummaryRepository {
+	return r.tsr
+}
+
+func (r *testRegistry) GetBudgetRepository() interfaces.BudgetRepository {
+	return r.br
+}
+
+func (r *testRegistry) GetBudgetAlertRepository() interfaces.BudgetAlertRepository {
+	return r.bar
+}
+
+func (r *testRegistry) GetNotificationRepository() interfaces.NotificationRepository {
+	return r.nr
+}
+
+func (r *testRegistry) GetNotificationPreferenceRepository() interfaces.NotificationPreferenceRepository {
+	return r.npr
+}
+
+func (r *testRegistry) GetUserRepository() interfaces.UserRepository {
+	return r.ur
+}
+
+func (r *testRegistry) GetSessionRepository() interfaces.SessionRepository {
+	return r.sr
+}
+
+func (r *testRegistry) GetAttemptRepository() interfaces.AttemptRepository {
+	return r.atr
+}
+
+func (r *testRegistry) GetMFARepository() interfaces.MFARepository {
+	return r.mfr
+}
+
+func (r *testRegistry) GetMFARecoveryCodeRepository() interfaces.MFARecoveryCodeRepository {
+	return r.rcr
+}
+
+func (r *testRegistry) GetChallengeRepository() interfaces.ChallengeRepository {
+	return r.chr
+}
+
+func (r *testRegistry) GetAccountTokenRepository() interfaces.AccountTokenRepository {
+	return r.akr
+}
+
+func (r *testRegistry) GetRoleRepository() interfaces.RoleRepository {
+	return r.rlr
+}
+
+func (r *testRegistry) GetAdminActionRepository() interfaces.AdminActionRepository {
+	return r.aar
+}
+
+func (r *testRegistry) GetApprovalRequestRepository() interfaces.ApprovalRequestRepository {
+	return r.apr
+}
+
+func (r *testRegistry) GetAPIKeyRepository() interfaces.APIKeyRepository {
+	return r.akey
+}
+
+func (r *testRegistry) GetDeviceRepository() interfaces.DeviceRepository {
+	return r.dvr
+}
+
+func (r *testRegistry) GetKYCSubmissionRepository() interfaces.KYCSubmissionRepository {
+	return r.ksr
+}
+
+// GetAuditEventRepository discards the events of tests that do not set aur, since nearly every
+// change writes one
+func (r *testRegistry) GetAuditEventRepository() interfaces.AuditEventRepository {
+	if r.aur == nil {
+		return discardAuditEvents{}
+	}
+	return r.aur
+}
+
+type discardAuditEvents struct{}
+
+func (discardAuditEvents) Create(ctx context.Context, event *models.AuditEvent) error { return nil }
+
+func (discardAuditEvents) List(ctx context.Context, filter models.AuditEventFilter, limit, offset int) ([]models.AuditEvent, int64, error) {
+	return nil, 0, nil
+}
+
+func (r *testRegistry) GetLedgerChainRepository() interfaces.LedgerChainRepository {
+	if r.lcr == nil {
+		return emptyLedgerChain{}
+	}
+	return r.lcr
+}
+
+// emptyLedgerChain is the chain of wallets without transactions, so tests of the ledger need
+// not expect the chain lookups
+type emptyLedgerChain struct{}
+
+func (emptyLedgerChain) GetHead(ctx context.Context, walletID string) (*models.WalletTransaction, error) {
+	return nil, nil
+}
+
+func (emptyLedgerChain) GetChain(ctx context.Context, walletID string, afterSeq int64, limit int) ([]models.WalletTransaction, error) {
+	return nil, nil
+}
+
+func (emptyLedgerChain) CountUnchainedSince(ctx context.Context, walletID string, since time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (emptyLedgerChain) GetChainedWalletIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	return nil, nil
+}
+
+func (emptyLedgerChain) GetUncheckpointedWalletIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	return nil, nil
+}
+
+func (emptyLedgerChain) CreateCheckpoint(ctx context.Context, checkpoint *models.LedgerCheckpoint) error {
+	return nil
+}
+
+func (emptyLedgerChain) GetCheckpoints(ctx context.Context, walletID string) ([]models.LedgerCheckpoint, error) {
+	return nil, nil
+}
+
+func (emptyLedgerChain) GetLatestCheckpoint(ctx context.Context, walletID string) (*models.LedgerCheckpoint, error) {
+	return nil, nil
+}
+
 // TestWalletService_GetOrCreateWallet tests wallet creation or retrieval
 func TestWalletService_GetOrCreateWallet(t *testing.T) {
 	tests := []struct {
@@ -92,9 +286,11 @@ func TestWalletService_GetBalance(t *testing.T) {
 
 		mockWalletRepo.On("GetByUserID", mock.Anything, "user-1").Return(wallet, nil)
 		mockWalletRepo.On("GetBalance", mock.Anything, "wallet-1").Return(1500.50, nil)
+		mockPocketRepo := mocks.NewPocketRepository(t)
+		mockPocketRepo.On("GetByWalletID", mock.Anything, "wallet-1").Return([]models.Pocket{}, nil)
 
-		reg := &testRegistry{wr: mockWalletRepo, tr: nil}
-		svc := NewWalletService(reg, (*configs.Config)(nil))
+		reg := &testRegistry{wr: mockWalletRepo, tr: nil, pkr: mockPocketRepo}
+		svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
 		resp, err := svc.GetBalance(context.Background(), "user-1")
 		require.NoError(t, err)
@@ -104,6 +300,33 @@ func TestWalletService_GetBalance(t *testing.T) {
 		assert.Equal(t, "IDR", resp.Currency)
 	})
 
+	t.Run("balance excludes money set aside in pockets", func(t *testing.T) {
+		mockWalletRepo := mocks.NewWalletRepository(t)
+		mockPocketRepo := mocks.NewPocketRepository(t)
+
+		wallet := &models.Wallet{ID: "wallet-5", UserID: "user-5", Balance: 1000, Currency: "IDR", IsActive: true}
+		target := 2000.0
+
+		mockWalletRepo.On("GetByUserID", mock.Anything, "user-5").Return(wallet, nil)
+		mockWalletRepo.On("GetBalance", mock.Anything, "wallet-5").Return(1000.0, nil)
+		mockPocketRepo.On("GetByWalletID", mock.Anything, "wallet-5").Return([]models.Pocket{
+			{ID: "pocket-1", Name: "Holiday", Balance: 500, TargetAmount: &target},
+			{ID: "pocket-2", Name: "Emergency", Balance: 250},
+		}, nil)
+
+		reg := &testRegistry{wr: mockWalletRepo, pkr: mockPocketRepo}
+		svc := NewWalletService(reg, (*configs.Config)(nil), nil)
+
+		resp, err := svc.GetBalance(context.Background(), "user-5")
+		require.NoError(t, err)
+		assert.Equal(t, 1000.0, resp.Balance)
+		assert.Equal(t, 750.0, resp.PocketTotal)
+		assert.Equal(t, 1750.0, resp.TotalBalance)
+		require.Len(t, resp.Pockets, 2)
+		assert.Equal(t, 0.25, *resp.Pockets[0].Progress)
+		assert.Nil(t, resp.Pockets[1].Progress)
+	})
+
 	t.Run("get balance creates new wallet if not exists", func(t *testing.T) {
 		mockWalletRepo := mocks.NewWalletRepository(t)
 
@@ -112,9 +335,11 @@ func TestWalletService_GetBalance(t *testing.T) {
 			return w.UserID == "user-2" && w.Balance == 0
 		})).Return(nil)
 		mockWalletRepo.On("GetBalance", mock.Anything, mock.Anything).Return(0.0, nil)
+		mockPocketRepo := mocks.NewPocketRepository(t)
+		mockPocketRepo.On("GetByWalletID", mock.Anything, mock.Anything).Return([]models.Pocket{}, nil)
 
-		reg := &testRegistry{wr: mockWalletRepo, tr: nil}
-		svc := NewWalletService(reg, (*configs.Config)(nil))
+		reg := &testRegistry{wr: mockWalletRepo, tr: nil, pkr: mockPocketRepo}
+		svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
 		resp, err := svc.GetBalance(context.Background(), "user-2")
 		require.NoError(t, err)
@@ -129,7 +354,7 @@ func TestWalletService_GetBalance(t *testing.T) {
 		mockWalletRepo.On("GetByUserID", mock.Anything, "user-3").Return(nil, errors.New("database error"))
 
 		reg := &testRegistry{wr: mockWalletRepo, tr: nil}
-		svc := NewWalletService(reg, (*configs.Config)(nil))
+		svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
 		resp, err := svc.GetBalance(context.Background(), "user-3")
 		require.Error(t, err)
@@ -151,7 +376,7 @@ func TestWalletService_GetBalance(t *testing.T) {
 		mockWalletRepo.On("GetBalance", mock.Anything, "wallet-4").Return(0.0, errors.New("balance fetch error"))
 
 		reg := &testRegistry{wr: mockWalletRepo, tr: nil}
-		svc := NewWalletService(reg, (*configs.Config)(nil))
+		svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
 		resp, err := svc.GetBalance(context.Background(), "user-4")
 		require.Error(t, err)
@@ -326,7 +551,7 @@ func TestWalletService_GetTransactionHistory(t *testing.T) {
 		mockTxRepo.On("GetByWalletID", mock.Anything, "wallet-1", 10, 0).Return(transactions, nil)
 
 		reg := &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}
-		svc := NewWalletService(reg, (*configs.Config)(nil))
+		svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
 		txs, total, err := svc.GetTransactionHistory(context.Background(), "user-1", 10, 0)
 		require.NoError(t, err)
@@ -354,7 +579,7 @@ func TestWalletService_GetTransactionHistory(t *testing.T) {
 		mockTxRepo.On("GetByWalletID", mock.Anything, "wallet-2", 10, 0).Return([]models.WalletTransaction{}, nil)
 
 		reg := &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}
-		svc := NewWalletService(reg, (*configs.Config)(nil))
+		svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
 		txs, total, err := svc.GetTransactionHistory(context.Background(), "user-2", 10, 0)
 		require.NoError(t, err)
@@ -370,7 +595,7 @@ func TestWalletService_GetTransactionHistory(t *testing.T) {
 		mockWalletRepo.On("GetByUserID", mock.Anything, "user-3").Return(nil, gorm.ErrRecordNotFound)
 
 		reg := &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}
-		svc := NewWalletService(reg, (*configs.Config)(nil))
+		svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
 		txs, total, err := svc.GetTransactionHistory(context.Background(), "user-3", 10, 0)
 		require.NoError(t, err)
@@ -386,7 +611,7 @@ func TestWalletService_GetTransactionHistory(t *testing.T) {
 		mockWalletRepo.On("GetByUserID", mock.Anything, "user-4").Return(nil, errors.New("database error"))
 
 		reg := &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}
-		svc := NewWalletService(reg, (*configs.Config)(nil))
+		svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
 		txs, total, err := svc.GetTransactionHistory(context.Background(), "user-4", 10, 0)
 		require.Error(t, err)
@@ -410,7 +635,7 @@ func TestWalletService_GetTransactionHistory(t *testing.T) {
 		mockTxRepo.On("CountByWalletID", mock.Anything, "wallet-5").Return(int64(0), errors.New("count error"))
 
 		reg := &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}
-		svc := NewWalletService(reg, (*configs.Config)(nil))
+		svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
 		txs, total, err := svc.GetTransactionHistory(context.Background(), "user-5", 10, 0)
 		require.Error(t, err)
@@ -440,7 +665,7 @@ func TestWalletService_GetTransactionHistory(t *testing.T) {
 		mockTxRepo.On("GetByWalletID", mock.Anything, "wallet-6", 5, 10).Return(transactions, nil)
 
 		reg := &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}
-		svc := NewWalletService(reg, (*configs.Config)(nil))
+		svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
 		txs, total, err := svc.GetTransactionHistory(context.Background(), "user-6", 5, 10)
 		require.NoError(t, err)
@@ -490,7 +715,7 @@ func TestWalletService_WithMockedRepository(t *testing.T) {
 			tt.setupMock(mockWalletRepo)
 
 			reg := &testRegistry{wr: mockWalletRepo, tr: nil}
-			svc := NewWalletService(reg, (*configs.Config)(nil))
+			svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
 			_, err := svc.GetOrCreateWallet(context.Background(), tt.userID)
 			if tt.expectedErr {
@@ -536,11 +761,11 @@ func TestWalletService_Withdraw_WithMockedRepo(t *testing.T) {
 			tt.setupMocks(mockWalletRepo, mockTxRepo)
 
 			// use package-level testRegistry
-			reg := &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}
-			svc := NewWalletService(reg, (*configs.Config)(nil))
+			reg := withinKYCLimits(t, withPIN(t, &testRegistry{wr: mockWalletRepo, tr: mockTxRepo}, "user-1"))
+			svc := NewWalletService(reg, (*configs.Config)(nil), nil)
 
 			// call withdraw on service to exercise mocks
-			req := dto.WithdrawRequest{UserID: "user-1", Amount: 500.00, Description: "test"}
+			req := dto.WithdrawRequest{UserID: "user-1", Amount: 500.00, Description: "test", PIN: testPIN}
 			resp, err := svc.Withdraw(context.Background(), req)
 			require.NoError(t, err)
 			require.NotNil(t, resp)
@@ -0,0 +1,11 @@
+// Package templates embeds the message templates shipped with the binary.
+//
+// Notification templates live in notifications/<locale>/<event>.tmpl. Each file defines
+// three templates: "subject" for the email subject and push title, "body" for the email
+// body, and "short" for SMS and push text.
+package templates
+
+import "embed"
+
+//go:embed notifications/*/*.tmpl
+var Notifications embed.FS
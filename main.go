@@ -4,6 +4,7 @@ import (
 	"digital-wallet/cmd/api"
 	"digital-wallet/cmd/cron"
 	"digital-wallet/cmd/migrate"
+	"digital-wallet/cmd/promo"
 
 	"github.com/spf13/cobra"
 )
@@ -19,6 +20,7 @@ func main() {
 	rootCmd.AddCommand(api.ServerCmd)
 	rootCmd.AddCommand(cron.CronCmd)
 	rootCmd.AddCommand(migrate.MigrateCmd)
+	rootCmd.AddCommand(promo.PromoCmd)
 
 	// Execute the root command
 	if err := rootCmd.Execute(); err != nil {
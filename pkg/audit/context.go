@@ -0,0 +1,26 @@
+// Package audit carries what the audit log records about the HTTP request behind a change
+package audit
+
+import "context"
+
+type contextKey string
+
+const contextKeyRequest contextKey = "audit_request"
+
+// Request identifies the HTTP request a change was made in
+type Request struct {
+	ID        string
+	IPAddress string
+}
+
+// WithRequest returns a copy of ctx carrying the request
+func WithRequest(ctx context.Context, request Request) context.Context {
+	return context.WithValue(ctx, contextKeyRequest, request)
+}
+
+// RequestFrom returns the request carried by ctx, and false for contexts outside an HTTP
+// request such as cron jobs
+func RequestFrom(ctx context.Context) (Request, bool) {
+	request, ok := ctx.Value(contextKeyRequest).(Request)
+	return request, ok
+}
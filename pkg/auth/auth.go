@@ -12,12 +12,45 @@ type UserAuth struct {
 	Role      string `json:"role"`
 	SessionID string `json:"session_id"`
 	Type      string `json:"type"`
+	// Permissions granted by Role when the token was issued
+	Permissions []string `json:"permissions"`
+}
+
+// Roles of an authenticated principal
+const (
+	RoleUser       = "USER"
+	RoleSupport    = "SUPPORT"
+	RoleOps        = "OPS"
+	RoleCompliance = "COMPLIANCE"
+	RoleAdmin      = "ADMIN"
+	RoleService    = "SERVICE"
+)
+
+// HasRole reports whether the principal has one of the roles
+func (u UserAuth) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if u.Role == role {
+			return true
+		}
+	}
+	return false
 }
 
 type ContextUser string
 
 const ContextKeyUser ContextUser = "user"
 
+// ContextKeyMerchant holds the ID of the merchant authenticated by API key
+const ContextKeyMerchant ContextUser = "merchant"
+
+// GetMerchantID returns the authenticated merchant ID, or an empty string when there is none
+func GetMerchantID(ctx context.Context) string {
+	if merchantID, ok := ctx.Value(ContextKeyMerchant).(string); ok {
+		return merchantID
+	}
+	return ""
+}
+
 func GetLoggedInUser(ctx context.Context) UserAuth {
 
 	v := ctx.Value(ContextKeyUser)
@@ -49,11 +82,27 @@ func GetLoggedInUser(ctx context.Context) UserAuth {
 		sessionID = val
 	}
 
+	var role string
+	if val, ok := claims["role"].(string); ok {
+		role = val
+	}
+
+	var permissions []string
+	if val, ok := claims["permissions"].([]interface{}); ok {
+		for _, p := range val {
+			if permission, ok := p.(string); ok {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+
 	return UserAuth{
-		ID:        id,
-		Email:     email,
-		Type:      userType,
-		SessionID: sessionID,
+		ID:          id,
+		Email:       email,
+		Type:        userType,
+		SessionID:   sessionID,
+		Role:        role,
+		Permissions: permissions,
 	}
 
 }
@@ -15,6 +15,7 @@ func TestGetLoggedInUser(t *testing.T) {
 			"email":      "user1@example.com",
 			"type":       "admin",
 			"session_id": "session-123",
+			"role":       RoleAdmin,
 		}
 		token := &jwt.Token{
 			Claims: claims,
@@ -27,6 +28,9 @@ func TestGetLoggedInUser(t *testing.T) {
 		assert.Equal(t, "user1@example.com", user.Email)
 		assert.Equal(t, "admin", user.Type)
 		assert.Equal(t, "session-123", user.SessionID)
+		assert.Equal(t, RoleAdmin, user.Role)
+		assert.True(t, user.HasRole(RoleAdmin, RoleService))
+		assert.False(t, user.HasRole(RoleUser))
 	})
 
 	t.Run("no user in context", func(t *testing.T) {
@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWK is a public key in the JSON Web Key format (RFC 7517). Only RSA and Ed25519 signing
+// keys are supported.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg,omitempty"`
+	Use       string `json:"use,omitempty"`
+	// N and E are the modulus and exponent of an RSA key
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Curve and X are the curve and public key of an OKP key
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKSet is the document served at /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+func newJWK(kid string, key verificationKey) (JWK, bool) {
+	if rsaKey, ok := key.rsaKey(); ok {
+		return JWK{
+			KeyType:   "RSA",
+			KeyID:     kid,
+			Algorithm: jwt.SigningMethodRS256.Alg(),
+			Use:       "sig",
+			N:         base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+		}, true
+	}
+
+	if edKey, ok := key.edKey(); ok {
+		return JWK{
+			KeyType:   "OKP",
+			KeyID:     kid,
+			Algorithm: jwt.SigningMethodEdDSA.Alg(),
+			Use:       "sig",
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(edKey),
+		}, true
+	}
+
+	return JWK{}, false
+}
+
+// parseJWKS reads the signing keys of a JWKS document by key ID. Keys of other types or uses
+// are skipped.
+func parseJWKS(data []byte) (map[string]verificationKey, error) {
+	var set JWKSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("decoding JWKS: %w", err)
+	}
+
+	keys := make(map[string]verificationKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.KeyID == "" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+
+		key, err := jwk.verificationKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", jwk.KeyID, err)
+		}
+		if key.key != nil {
+			keys[jwk.KeyID] = key
+		}
+	}
+
+	return keys, nil
+}
+
+func (j JWK) verificationKey() (verificationKey, error) {
+	switch {
+	case j.KeyType == "RSA" && (j.Algorithm == "" || j.Algorithm == jwt.SigningMethodRS256.Alg()):
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return verificationKey{}, errors.New("invalid modulus")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return verificationKey{}, errors.New("invalid exponent")
+		}
+
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		return verificationKey{method: jwt.SigningMethodRS256, key: key}, nil
+
+	case j.KeyType == "OKP" && j.Curve == "Ed25519":
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return verificationKey{}, errors.New("invalid Ed25519 key")
+		}
+		return verificationKey{method: jwt.SigningMethodEdDSA, key: ed25519.PublicKey(x)}, nil
+	}
+
+	// unsupported keys are skipped rather than failing the whole set
+	return verificationKey{}, nil
+}
+
+// jwksSource holds the keys of a trusted issuer's JWKS, read from a file or URL. The keys are
+// read again once refresh has passed, or when a token names a key ID that is not known yet, so
+// the issuer can rotate its keys. Refetches for unknown key IDs are limited to one per
+// minRefetch to keep forged key IDs from hammering the issuer.
+type jwksSource struct {
+	location   string
+	refresh    time.Duration
+	minRefetch time.Duration
+	client     *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]verificationKey
+	fetchedAt time.Time
+}
+
+const jwksMinRefetch = 30 * time.Second
+
+func newJWKSSource(location string, refresh time.Duration) *jwksSource {
+	return &jwksSource{
+		location:   location,
+		refresh:    refresh,
+		minRefetch: jwksMinRefetch,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *jwksSource) key(kid string) (verificationKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	stale := s.keys == nil || now.Sub(s.fetchedAt) >= s.refresh
+	if _, known := s.keys[kid]; !known && now.Sub(s.fetchedAt) >= s.minRefetch {
+		stale = true
+	}
+
+	if stale {
+		keys, err := s.fetch()
+		if err != nil && s.keys == nil {
+			return verificationKey{}, err
+		}
+		// on a failed refresh the previous keys stay in use
+		if err == nil {
+			s.keys = keys
+		}
+		s.fetchedAt = now
+	}
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return verificationKey{}, fmt.Errorf("unknown key ID %q", kid)
+	}
+	return key, nil
+}
+
+func (s *jwksSource) fetch() (map[string]verificationKey, error) {
+	if !strings.HasPrefix(s.location, "http://") && !strings.HasPrefix(s.location, "https://") {
+		data, err := os.ReadFile(s.location)
+		if err != nil {
+			return nil, fmt.Errorf("reading JWKS: %w", err)
+		}
+		return parseJWKS(data)
+	}
+
+	resp, err := s.client.Get(s.location)
+	if err != nil {
+		return nil, fmt.Errorf("fetching JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching JWKS: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("reading JWKS: %w", err)
+	}
+	return parseJWKS(data)
+}
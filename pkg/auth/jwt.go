@@ -1,28 +1,22 @@
 package auth
 
 import (
-	"fmt"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 )
 
 // VerifyTokenFromRequest verifies token from the request
-func VerifyTokenFromRequest(c echo.Context, signingKey string) (*jwt.Token, error) {
+func VerifyTokenFromRequest(c echo.Context, keyring *Keyring) (*jwt.Token, error) {
 	tokenString := extractToken(c)
-	return VerifyToken(tokenString, signingKey)
+	return VerifyToken(tokenString, keyring)
 }
 
-// VerifyToken verifies the given token
-func VerifyToken(tokenString, signingKey string) (*jwt.Token, error) {
-	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		//Make sure that the token method conform to "SigningMethodHMAC"
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(signingKey), nil
-	})
+// VerifyToken verifies the given token against the keys of the keyring
+func VerifyToken(tokenString string, keyring *Keyring) (*jwt.Token, error) {
+	return keyring.Verify(tokenString)
 }
 
 func extractToken(c echo.Context) string {
@@ -35,3 +29,32 @@ func extractToken(c echo.Context) string {
 	}
 	return ""
 }
+
+// Token types carried in the "type" claim
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+	// TokenTypeMFAPending is issued by login when a second factor still has to be verified
+	TokenTypeMFAPending = "mfa_pending"
+)
+
+// NewToken signs a token carrying the claims GetLoggedInUser reads with the keyring's signing
+// key. tokenID becomes the "jti" claim, which lets a session tell its current refresh token
+// from older ones.
+func NewToken(user UserAuth, tokenType, tokenID string, expiresAt time.Time, keyring *Keyring) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"user_id":     user.ID,
+		"email":       user.Email,
+		"session_id":  user.SessionID,
+		"role":        user.Role,
+		"permissions": user.Permissions,
+		"type":        tokenType,
+		"jti":         tokenID,
+		"iat":         now.Unix(),
+		"nbf":         now.Unix(),
+		"exp":         expiresAt.Unix(),
+	}
+
+	return keyring.Sign(claims)
+}
@@ -0,0 +1,266 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeyringConfig describes the keys of a Keyring. With no SigningKeyFile tokens are signed with
+// HS256 and HMACSecret, as before asymmetric keys were supported.
+type KeyringConfig struct {
+	Issuer   string
+	Audience string
+	// HMACSecret signs tokens when there is no asymmetric signing key, and verifies HS256
+	// tokens then or when AcceptHMAC is set
+	HMACSecret string
+	AcceptHMAC bool
+	// SigningKeyFile is a PEM private key; RSA keys sign with RS256 and Ed25519 keys with EdDSA
+	SigningKeyID   string
+	SigningKeyFile string
+	// VerificationKeys are "kid:path" pairs of PEM public keys that are accepted and published
+	// besides the signing key, such as the previous key during a rotation
+	VerificationKeys []string
+	// TrustedIssuer and TrustedJWKS accept tokens of another issuer, verified against the keys
+	// of a JWKS file or URL
+	TrustedIssuer      string
+	TrustedJWKS        string
+	TrustedJWKSRefresh time.Duration
+	// Leeway tolerates clock skew when checking exp, nbf and iat
+	Leeway time.Duration
+}
+
+// verificationKey is a public key and the algorithm it verifies
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+}
+
+// Keyring signs the tokens this service issues and verifies tokens signed by it or by a
+// trusted issuer. Tokens must carry the issuer of the key that signed them and the audience.
+type Keyring struct {
+	issuer     string
+	audience   string
+	hmacSecret []byte
+	acceptHMAC bool
+	leeway     time.Duration
+
+	signingKeyID  string
+	signingMethod jwt.SigningMethod
+	signingKey    crypto.PrivateKey
+
+	keys map[string]verificationKey
+
+	trustedIssuer string
+	trusted       *jwksSource
+}
+
+// NewHMACKeyring returns a keyring signing and verifying HS256 tokens with the shared secret
+func NewHMACKeyring(secret, issuer, audience string) *Keyring {
+	return &Keyring{
+		issuer:     issuer,
+		audience:   audience,
+		hmacSecret: []byte(secret),
+		acceptHMAC: true,
+		keys:       map[string]verificationKey{},
+	}
+}
+
+// NewKeyring loads the keys described by cfg
+func NewKeyring(cfg KeyringConfig) (*Keyring, error) {
+	k := NewHMACKeyring(cfg.HMACSecret, cfg.Issuer, cfg.Audience)
+	k.leeway = cfg.Leeway
+
+	if cfg.SigningKeyFile != "" {
+		if cfg.SigningKeyID == "" {
+			return nil, errors.New("a key ID is required with a signing key")
+		}
+
+		pem, err := os.ReadFile(cfg.SigningKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading signing key: %w", err)
+		}
+
+		method, private, public, err := parsePrivateKey(pem)
+		if err != nil {
+			return nil, fmt.Errorf("parsing signing key: %w", err)
+		}
+
+		k.signingKeyID = cfg.SigningKeyID
+		k.signingMethod = method
+		k.signingKey = private
+		k.keys[cfg.SigningKeyID] = verificationKey{method: method, key: public}
+		k.acceptHMAC = cfg.AcceptHMAC
+	}
+
+	for _, entry := range cfg.VerificationKeys {
+		kid, path, ok := strings.Cut(entry, ":")
+		if !ok || kid == "" || path == "" {
+			return nil, fmt.Errorf("verification key %q is not kid:path", entry)
+		}
+
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading verification key %s: %w", kid, err)
+		}
+
+		key, err := parsePublicKey(pem)
+		if err != nil {
+			return nil, fmt.Errorf("parsing verification key %s: %w", kid, err)
+		}
+		k.keys[kid] = key
+	}
+
+	if cfg.TrustedJWKS != "" {
+		if cfg.TrustedIssuer == "" {
+			return nil, errors.New("a trusted issuer is required with a trusted JWKS")
+		}
+		k.trustedIssuer = cfg.TrustedIssuer
+		k.trusted = newJWKSSource(cfg.TrustedJWKS, cfg.TrustedJWKSRefresh)
+	}
+
+	return k, nil
+}
+
+// Issuer is the issuer of the tokens this keyring signs
+func (k *Keyring) Issuer() string {
+	return k.issuer
+}
+
+// Sign signs the claims with the signing key, adding the issuer, the audience and the key ID
+func (k *Keyring) Sign(claims jwt.MapClaims) (string, error) {
+	claims["iss"] = k.issuer
+	claims["aud"] = k.audience
+
+	if k.signingKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(k.hmacSecret)
+	}
+
+	token := jwt.NewWithClaims(k.signingMethod, claims)
+	token.Header["kid"] = k.signingKeyID
+	return token.SignedString(k.signingKey)
+}
+
+// Verify checks the signature of the token against the key its kid names, then its issuer,
+// audience and time claims. Tokens without an expiry are refused.
+func (k *Keyring) Verify(tokenString string) (*jwt.Token, error) {
+	var issuer string
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		key, keyIssuer, err := k.lookup(token)
+		issuer = keyIssuer
+		return key, err
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithAudience(k.audience),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(k.leeway),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// the expected issuer depends on which key signed the token
+	if iss, _ := token.Claims.GetIssuer(); iss != issuer {
+		return nil, fmt.Errorf("unexpected issuer %q", iss)
+	}
+
+	return token, nil
+}
+
+// lookup returns the key verifying the token and the issuer that key belongs to
+func (k *Keyring) lookup(token *jwt.Token) (interface{}, string, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if !k.acceptHMAC {
+			return nil, "", errors.New("HMAC signed tokens are not accepted")
+		}
+		return k.hmacSecret, k.issuer, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, "", errors.New("token has no key ID")
+	}
+
+	if key, ok := k.keys[kid]; ok {
+		return key.publicFor(token, k.issuer)
+	}
+
+	if k.trusted != nil {
+		key, err := k.trusted.key(kid)
+		if err != nil {
+			return nil, "", err
+		}
+		return key.publicFor(token, k.trustedIssuer)
+	}
+
+	return nil, "", fmt.Errorf("unknown key ID %q", kid)
+}
+
+// publicFor returns the key unless the token claims another algorithm than the key's
+func (v verificationKey) publicFor(token *jwt.Token, issuer string) (interface{}, string, error) {
+	if token.Method.Alg() != v.method.Alg() {
+		return nil, "", fmt.Errorf("key does not verify %s", token.Method.Alg())
+	}
+	return v.key, issuer, nil
+}
+
+// JWKS returns the public keys tokens of this service are verified with. HMAC secrets are
+// never published.
+func (k *Keyring) JWKS() JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0, len(k.keys))}
+	for kid, key := range k.keys {
+		if jwk, ok := newJWK(kid, key); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+func parsePrivateKey(pem []byte) (jwt.SigningMethod, crypto.PrivateKey, crypto.PublicKey, error) {
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(pem); err == nil {
+		return jwt.SigningMethodRS256, key, &key.PublicKey, nil
+	}
+
+	key, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+	if err != nil {
+		return nil, nil, nil, errors.New("not an RSA or Ed25519 private key")
+	}
+
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, nil, nil, errors.New("not an Ed25519 private key")
+	}
+	return jwt.SigningMethodEdDSA, edKey, edKey.Public(), nil
+}
+
+func parsePublicKey(pem []byte) (verificationKey, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(pem); err == nil {
+		return verificationKey{method: jwt.SigningMethodRS256, key: key}, nil
+	}
+
+	key, err := jwt.ParseEdPublicKeyFromPEM(pem)
+	if err != nil {
+		return verificationKey{}, errors.New("not an RSA or Ed25519 public key")
+	}
+	return verificationKey{method: jwt.SigningMethodEdDSA, key: key}, nil
+}
+
+// rsaKey and edKey narrow a verification key to its type
+func (v verificationKey) rsaKey() (*rsa.PublicKey, bool) {
+	key, ok := v.key.(*rsa.PublicKey)
+	return key, ok
+}
+
+func (v verificationKey) edKey() (ed25519.PublicKey, bool) {
+	key, ok := v.key.(ed25519.PublicKey)
+	return key, ok
+}
@@ -0,0 +1,227 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeyPair writes the PEM private and public key files of key and returns their paths
+func writeKeyPair(t *testing.T, name string, key crypto.Signer) (string, string) {
+	dir := t.TempDir()
+
+	private, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	public, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	privatePath := filepath.Join(dir, name+".pem")
+	publicPath := filepath.Join(dir, name+".pub.pem")
+	require.NoError(t, os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: private}), 0o600))
+	require.NoError(t, os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}), 0o600))
+
+	return privatePath, publicPath
+}
+
+func rsaKeyPair(t *testing.T, name string) (string, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return writeKeyPair(t, name, key)
+}
+
+func edKeyPair(t *testing.T, name string) (string, string) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return writeKeyPair(t, name, key)
+}
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{"user_id": "user-1", "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+func TestKeyring_SignAndVerify(t *testing.T) {
+	rsaPrivate, _ := rsaKeyPair(t, "rsa")
+	edPrivate, _ := edKeyPair(t, "ed")
+
+	for _, tc := range []struct {
+		name string
+		file string
+		alg  string
+	}{
+		{"RSA keys sign with RS256", rsaPrivate, "RS256"},
+		{"Ed25519 keys sign with EdDSA", edPrivate, "EdDSA"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			keyring, err := NewKeyring(KeyringConfig{Issuer: "digital-wallet", Audience: "digital-wallet", SigningKeyID: "key-1", SigningKeyFile: tc.file})
+			require.NoError(t, err)
+
+			tokenString, err := keyring.Sign(testClaims())
+			require.NoError(t, err)
+
+			token, err := keyring.Verify(tokenString)
+			require.NoError(t, err)
+			assert.Equal(t, tc.alg, token.Method.Alg())
+			assert.Equal(t, "key-1", token.Header["kid"])
+			assert.Equal(t, "digital-wallet", token.Claims.(jwt.MapClaims)["iss"])
+
+			jwks := keyring.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, tc.alg, jwks.Keys[0].Algorithm)
+		})
+	}
+
+	t.Run("signing key needs a key ID", func(t *testing.T) {
+		_, err := NewKeyring(KeyringConfig{SigningKeyFile: rsaPrivate})
+		assert.Error(t, err)
+	})
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	oldPrivate, oldPublic := rsaKeyPair(t, "old")
+	newPrivate, _ := edKeyPair(t, "new")
+
+	before, err := NewKeyring(KeyringConfig{Issuer: "digital-wallet", Audience: "digital-wallet", SigningKeyID: "2026-01", SigningKeyFile: oldPrivate})
+	require.NoError(t, err)
+
+	oldToken, err := before.Sign(testClaims())
+	require.NoError(t, err)
+
+	after, err := NewKeyring(KeyringConfig{
+		Issuer: "digital-wallet", Audience: "digital-wallet",
+		SigningKeyID: "2026-10", SigningKeyFile: newPrivate,
+		VerificationKeys: []string{"2026-01:" + oldPublic},
+	})
+	require.NoError(t, err)
+
+	_, err = after.Verify(oldToken)
+	assert.NoError(t, err, "tokens of the previous key stay valid")
+
+	newToken, err := after.Sign(testClaims())
+	require.NoError(t, err)
+	_, err = before.Verify(newToken)
+	assert.Error(t, err, "the new key is unknown to the old keyring")
+
+	assert.Len(t, after.JWKS().Keys, 2)
+}
+
+func TestKeyring_Claims(t *testing.T) {
+	keyring := NewHMACKeyring("secret", "digital-wallet", "digital-wallet")
+
+	sign := func(t *testing.T, claims jwt.MapClaims) string {
+		tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+		require.NoError(t, err)
+		return tokenString
+	}
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		_, err := keyring.Verify(sign(t, jwt.MapClaims{"iss": "someone-else", "aud": "digital-wallet"}))
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		_, err := keyring.Verify(sign(t, jwt.MapClaims{"iss": "digital-wallet", "aud": "another-api"}))
+		assert.Error(t, err)
+	})
+
+	t.Run("not valid yet", func(t *testing.T) {
+		_, err := keyring.Verify(sign(t, jwt.MapClaims{"iss": "digital-wallet", "aud": "digital-wallet", "nbf": time.Now().Add(time.Hour).Unix()}))
+		assert.ErrorIs(t, err, jwt.ErrTokenNotValidYet)
+	})
+
+	t.Run("clock skew within the leeway", func(t *testing.T) {
+		skewed := NewHMACKeyring("secret", "digital-wallet", "digital-wallet")
+		skewed.leeway = time.Minute
+
+		_, err := skewed.Verify(sign(t, jwt.MapClaims{"iss": "digital-wallet", "aud": "digital-wallet", "nbf": time.Now().Add(10 * time.Second).Unix(), "exp": time.Now().Add(time.Hour).Unix()}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("no expiry", func(t *testing.T) {
+		_, err := keyring.Verify(sign(t, jwt.MapClaims{"iss": "digital-wallet", "aud": "digital-wallet"}))
+		assert.ErrorIs(t, err, jwt.ErrTokenRequiredClaimMissing)
+	})
+}
+
+func TestKeyring_HMAC(t *testing.T) {
+	rsaPrivate, _ := rsaKeyPair(t, "rsa")
+	hmacToken, err := NewHMACKeyring("secret", "digital-wallet", "digital-wallet").Sign(testClaims())
+	require.NoError(t, err)
+
+	t.Run("refused once an asymmetric key signs", func(t *testing.T) {
+		keyring, err := NewKeyring(KeyringConfig{Issuer: "digital-wallet", Audience: "digital-wallet", HMACSecret: "secret", SigningKeyID: "key-1", SigningKeyFile: rsaPrivate})
+		require.NoError(t, err)
+
+		_, err = keyring.Verify(hmacToken)
+		assert.Error(t, err)
+	})
+
+	t.Run("accepted while switching over", func(t *testing.T) {
+		keyring, err := NewKeyring(KeyringConfig{Issuer: "digital-wallet", Audience: "digital-wallet", HMACSecret: "secret", AcceptHMAC: true, SigningKeyID: "key-1", SigningKeyFile: rsaPrivate})
+		require.NoError(t, err)
+
+		_, err = keyring.Verify(hmacToken)
+		assert.NoError(t, err)
+	})
+}
+
+func TestKeyring_TrustedIssuer(t *testing.T) {
+	idpPrivate, _ := edKeyPair(t, "idp")
+	ownPrivate, _ := rsaKeyPair(t, "own")
+
+	idp, err := NewKeyring(KeyringConfig{Issuer: "https://idp.example.com", Audience: "digital-wallet", SigningKeyID: "idp-1", SigningKeyFile: idpPrivate})
+	require.NoError(t, err)
+
+	jwks, err := json.Marshal(idp.JWKS())
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(jwks)
+	}))
+	defer server.Close()
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, jwks, 0o600))
+
+	for _, location := range []string{jwksFile, server.URL} {
+		keyring, err := NewKeyring(KeyringConfig{
+			Issuer: "digital-wallet", Audience: "digital-wallet",
+			SigningKeyID: "own-1", SigningKeyFile: ownPrivate,
+			TrustedIssuer: "https://idp.example.com", TrustedJWKS: location, TrustedJWKSRefresh: time.Minute,
+		})
+		require.NoError(t, err)
+
+		idpToken, err := idp.Sign(testClaims())
+		require.NoError(t, err)
+
+		token, err := keyring.Verify(idpToken)
+		require.NoError(t, err, location)
+		issuer, _ := token.Claims.GetIssuer()
+		assert.Equal(t, "https://idp.example.com", issuer)
+
+		// the trusted issuer's keys cannot vouch for tokens claiming to be ours
+		forged := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{"iss": "digital-wallet", "aud": "digital-wallet"})
+		forged.Header["kid"] = "idp-1"
+		forgedString, err := forged.SignedString(idp.signingKey)
+		require.NoError(t, err)
+
+		_, err = keyring.Verify(forgedString)
+		assert.Error(t, err)
+
+		// only our own keys are published
+		assert.Len(t, keyring.JWKS().Keys, 1)
+	}
+}
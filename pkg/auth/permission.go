@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"digital-wallet/pkg/response"
+)
+
+// Permissions granted to roles. The matrix itself lives in the role_permissions table and the
+// permissions of the principal's role are carried in the "permissions" claim.
+const (
+	// PermissionUserRead allows looking up any user
+	PermissionUserRead = "user.read"
+	// PermissionUserUnlock allows lifting the login lockout of any user
+	PermissionUserUnlock = "user.unlock"
+	// PermissionWalletRead allows viewing the wallet of any user
+	PermissionWalletRead = "wallet.read"
+	// PermissionWalletManage allows changing the pockets, budgets and preferences of any user
+	PermissionWalletManage = "wallet.manage"
+	// PermissionWalletWithdraw allows withdrawing from the wallet of any user
+	PermissionWalletWithdraw = "wallet.withdraw"
+	// PermissionWalletFreeze allows freezing and unfreezing wallets
+	PermissionWalletFreeze = "wallet.freeze"
+	// PermissionWalletAdjust allows manual credit and debit adjustments
+	PermissionWalletAdjust = "wallet.adjust"
+	// PermissionWalletReverse allows reversing settled transactions
+	PermissionWalletReverse = "wallet.reverse"
+	// PermissionWalletExport allows exporting the transactions of any user
+	PermissionWalletExport = "wallet.export"
+	// PermissionCashbackRead allows viewing cashback campaigns and their reports
+	PermissionCashbackRead = "cashback.read"
+	// PermissionCashbackManage allows creating and deactivating cashback campaigns
+	PermissionCashbackManage = "cashback.manage"
+	// PermissionApprovalReview allows approving and rejecting other people's approval requests
+	PermissionApprovalReview = "approval.review"
+	// PermissionKYCReview allows reviewing the identity documents users submit
+	PermissionKYCReview = "kyc.review"
+	// PermissionAuditRead allows querying the audit log
+	PermissionAuditRead = "audit.read"
+	// PermissionAPIKeyManage allows issuing, rotating and revoking API keys
+	PermissionAPIKeyManage = "apikey.manage"
+)
+
+// Scopes an API key can be issued with. Each grants the permission of the same name.
+const (
+	ScopeWalletRead     = "wallet:read"
+	ScopeWalletManage   = "wallet:manage"
+	ScopeWalletWithdraw = "wallet:withdraw"
+)
+
+var scopePermissions = map[string]string{
+	ScopeWalletRead:     PermissionWalletRead,
+	ScopeWalletManage:   PermissionWalletManage,
+	ScopeWalletWithdraw: PermissionWalletWithdraw,
+}
+
+// ScopePermission returns the permission a scope grants, and false for unknown scopes
+func ScopePermission(scope string) (string, bool) {
+	permission, ok := scopePermissions[scope]
+	return permission, ok
+}
+
+// HasPermission reports whether the principal's role grants the permission
+func (u UserAuth) HasPermission(permission string) bool {
+	for _, p := range u.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RequirePermission returns ErrInsufficientPermissions unless the principal of the request has the
+// permission. Services use it to guard operations that act on behalf of other users.
+func RequirePermission(ctx context.Context, permission string) error {
+	if GetPrincipal(ctx).HasPermission(permission) {
+		return nil
+	}
+	return response.ErrInsufficientPermissions
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"digital-wallet/pkg/response"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequirePermission(t *testing.T) {
+	withClaims := func(claims jwt.MapClaims) context.Context {
+		return context.WithValue(context.Background(), ContextKeyUser, &jwt.Token{Claims: claims})
+	}
+
+	t.Run("granted permission passes", func(t *testing.T) {
+		ctx := withClaims(jwt.MapClaims{"user_id": "user-1", "permissions": []interface{}{PermissionWalletRead, PermissionWalletFreeze}})
+		assert.NoError(t, RequirePermission(ctx, PermissionWalletFreeze))
+	})
+
+	t.Run("missing permission is refused", func(t *testing.T) {
+		ctx := withClaims(jwt.MapClaims{"user_id": "user-1", "permissions": []interface{}{PermissionWalletRead}})
+		assert.Equal(t, response.ErrInsufficientPermissions, RequirePermission(ctx, PermissionWalletFreeze))
+	})
+
+	t.Run("role alone grants nothing", func(t *testing.T) {
+		ctx := withClaims(jwt.MapClaims{"user_id": "user-1", "role": RoleAdmin})
+		assert.Equal(t, response.ErrInsufficientPermissions, RequirePermission(ctx, PermissionWalletRead))
+	})
+
+	t.Run("API key scopes grant their permissions", func(t *testing.T) {
+		ctx := WithPrincipal(context.Background(), &Principal{Type: PrincipalTypeAPIKey, ID: "key-1", Permissions: []string{PermissionWalletRead}})
+		assert.NoError(t, RequirePermission(ctx, PermissionWalletRead))
+		assert.Equal(t, response.ErrInsufficientPermissions, RequirePermission(ctx, PermissionWalletWithdraw))
+	})
+
+	t.Run("no principal is refused", func(t *testing.T) {
+		assert.Equal(t, response.ErrInsufficientPermissions, RequirePermission(context.Background(), PermissionWalletRead))
+	})
+}
+
+func TestScopePermission(t *testing.T) {
+	permission, ok := ScopePermission(ScopeWalletWithdraw)
+	assert.True(t, ok)
+	assert.Equal(t, PermissionWalletWithdraw, permission)
+
+	_, ok = ScopePermission("wallet:adjust")
+	assert.False(t, ok)
+}
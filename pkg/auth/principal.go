@@ -0,0 +1,81 @@
+package auth
+
+import "context"
+
+// Kinds of authenticated principal
+const (
+	// PrincipalTypeUser is a user authenticated by an access token
+	PrincipalTypeUser = "USER"
+	// PrincipalTypeAPIKey is an internal service authenticated by an API key
+	PrincipalTypeAPIKey = "API_KEY"
+	// PrincipalTypeMerchant is a merchant authenticated by its merchant API key. It holds no
+	// permissions and only reaches the merchant routes.
+	PrincipalTypeMerchant = "MERCHANT"
+)
+
+// ContextKeyPrincipal holds the *Principal of the request, whichever way it authenticated
+const ContextKeyPrincipal ContextUser = "principal"
+
+// Principal is whoever made the request. For a user ID is the user ID and Permissions come from
+// their role; for an API key ID is the key ID and Permissions come from its scopes.
+type Principal struct {
+	Type        string   `json:"type"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+// IsAPIKey reports whether the principal is a service authenticated by API key
+func (p Principal) IsAPIKey() bool {
+	return p.Type == PrincipalTypeAPIKey
+}
+
+// HasPermission reports whether the principal has been granted the permission
+func (p Principal) HasPermission(permission string) bool {
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, ContextKeyPrincipal, principal)
+}
+
+// PrincipalFromMerchant builds the principal of a merchant authenticated by merchant API key
+func PrincipalFromMerchant(merchantID, name string) *Principal {
+	return &Principal{
+		Type: PrincipalTypeMerchant,
+		ID:   merchantID,
+		Name: name,
+	}
+}
+
+// PrincipalFromUser builds the principal of a user authenticated by access token
+func PrincipalFromUser(user UserAuth) *Principal {
+	return &Principal{
+		Type:        PrincipalTypeUser,
+		ID:          user.ID,
+		Name:        user.Email,
+		Role:        user.Role,
+		Permissions: user.Permissions,
+	}
+}
+
+// GetPrincipal returns the principal of the request. Contexts that only carry an access token
+// under ContextKeyUser get the principal of that user, and unauthenticated ones a zero Principal.
+func GetPrincipal(ctx context.Context) Principal {
+	if principal, ok := ctx.Value(ContextKeyPrincipal).(*Principal); ok && principal != nil {
+		return *principal
+	}
+
+	if user := GetLoggedInUser(ctx); user.ID != "" {
+		return *PrincipalFromUser(user)
+	}
+
+	return Principal{}
+}
@@ -0,0 +1,20 @@
+package auth
+
+import "log/slog"
+
+// Security events written by LogSecurityEvent
+const (
+	SecurityEventCrossUserAccess = "cross_user_access"
+	SecurityEventPermission      = "permission_denied"
+	SecurityEventPINLocked       = "pin_locked"
+	SecurityEventMFALocked       = "mfa_locked"
+	SecurityEventLoginLocked     = "login_locked"
+	SecurityEventLoginUnlocked   = "login_unlocked"
+	SecurityEventLedgerBroken    = "ledger_chain_broken"
+)
+
+// LogSecurityEvent writes a security event to the application log. attrs are slog key-value
+// pairs describing who did what.
+func LogSecurityEvent(event string, attrs ...any) {
+	slog.Warn("Security event", append([]any{"event", event}, attrs...)...)
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequireActiveAccount refuses principals whose account is not active and must run after
+// AuthMiddleware. Login already refuses them, but their access tokens outlive a deactivation.
+// API keys have no account; revoking the key is what stops them.
+func RequireActiveAccount(di *di.Container) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+
+			if auth.GetPrincipal(ctx).IsAPIKey() {
+				return next(c)
+			}
+
+			if err := di.AccountService.CheckActive(ctx, auth.GetLoggedInUser(ctx).ID); err != nil {
+				return response.GenerateResponseFromIError(err)
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"digital-wallet/di"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRequireActiveAccount(t *testing.T) {
+	e := echo.New()
+
+	request := func(container *di.Container) (*httptest.ResponseRecorder, error) {
+		token := &jwt.Token{Claims: jwt.MapClaims{"user_id": "user-1"}}
+		req := httptest.NewRequest(http.MethodGet, "/v1/me/wallet/balance", nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKeyUser, token))
+		rec := httptest.NewRecorder()
+
+		handler := RequireActiveAccount(container)(func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+		err := handler(e.NewContext(req, rec))
+		return rec, err
+	}
+
+	t.Run("active account passes", func(t *testing.T) {
+		mockSvc := mocks.NewAccountService(t)
+		mockSvc.On("CheckActive", mock.Anything, "user-1").Return(nil)
+
+		rec, err := request(&di.Container{AccountService: mockSvc})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("deactivated account is refused", func(t *testing.T) {
+		mockSvc := mocks.NewAccountService(t)
+		mockSvc.On("CheckActive", mock.Anything, "user-1").Return(response.ErrAccountDeactivated)
+
+		_, err := request(&di.Container{AccountService: mockSvc})
+		errResponse, ok := err.(response.ErrorResponse)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusForbidden, errResponse.HTTPCode)
+		assert.Equal(t, response.ErrAccountDeactivated.Code, errResponse.Code)
+	})
+
+	t.Run("API keys have no account to check", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/wallet/balance/user-1", nil)
+		req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Type: auth.PrincipalTypeAPIKey, ID: "key-1"}))
+		rec := httptest.NewRecorder()
+
+		handler := RequireActiveAccount(&di.Container{AccountService: mocks.NewAccountService(t)})(func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+		assert.NoError(t, handler(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
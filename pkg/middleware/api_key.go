@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderAPIKey carries the API key of an internal service
+const HeaderAPIKey = "X-API-Key"
+
+// AuthOrAPIKeyMiddleware authenticates either an internal service by the API key in X-API-Key or
+// a user by access token, like AuthMiddleware. Either way the principal is stored in the request
+// context for auth.GetPrincipal.
+func AuthOrAPIKeyMiddleware(di *di.Container) echo.MiddlewareFunc {
+	authenticateUser := AuthMiddleware(di)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		userNext := authenticateUser(next)
+
+		return func(c echo.Context) error {
+			apiKey := c.Request().Header.Get(HeaderAPIKey)
+			if apiKey == "" {
+				return userNext(c)
+			}
+
+			principal, err := di.APIKeyService.Authenticate(c.Request().Context(), apiKey)
+			if err != nil {
+				return response.NewUnauthorizedError("Invalid API key")
+			}
+
+			c.SetRequest(c.Request().WithContext(auth.WithPrincipal(c.Request().Context(), principal)))
+
+			return next(c)
+		}
+	}
+}
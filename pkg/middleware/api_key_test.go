@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"digital-wallet/configs"
+	"digital-wallet/di"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthOrAPIKeyMiddleware(t *testing.T) {
+	e := echo.New()
+	cfg := &configs.Config{}
+	keyring := auth.NewHMACKeyring("test-signing-key", "digital-wallet", "digital-wallet")
+
+	var principal auth.Principal
+	request := func(container *di.Container, apiKey string) (*httptest.ResponseRecorder, error) {
+		principal = auth.Principal{}
+		req := httptest.NewRequest(http.MethodGet, "/v1/wallet/balance/user-1", nil)
+		if apiKey != "" {
+			req.Header.Set(HeaderAPIKey, apiKey)
+		}
+		rec := httptest.NewRecorder()
+
+		handler := AuthOrAPIKeyMiddleware(container)(func(c echo.Context) error {
+			principal = auth.GetPrincipal(c.Request().Context())
+			return c.NoContent(http.StatusOK)
+		})
+		return rec, handler(e.NewContext(req, rec))
+	}
+
+	t.Run("valid API key becomes the principal", func(t *testing.T) {
+		mockSvc := mocks.NewAPIKeyService(t)
+		mockSvc.On("Authenticate", mock.Anything, "sk_abc.secret").Return(&auth.Principal{
+			Type: auth.PrincipalTypeAPIKey, ID: "key-1", Permissions: []string{auth.PermissionWalletRead},
+		}, nil)
+
+		rec, err := request(&di.Container{Config: cfg, Keyring: keyring, APIKeyService: mockSvc}, "sk_abc.secret")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, principal.IsAPIKey())
+		assert.True(t, principal.HasPermission(auth.PermissionWalletRead))
+	})
+
+	t.Run("invalid API key is refused", func(t *testing.T) {
+		mockSvc := mocks.NewAPIKeyService(t)
+		mockSvc.On("Authenticate", mock.Anything, "sk_abc.wrong").Return(nil, response.ErrInvalidCredentials)
+
+		_, err := request(&di.Container{Config: cfg, Keyring: keyring, APIKeyService: mockSvc}, "sk_abc.wrong")
+		assert.Equal(t, http.StatusUnauthorized, response.GenerateResponseFromIError(err).HTTPCode)
+		assert.Empty(t, principal.ID)
+	})
+
+	t.Run("without an API key an access token is required", func(t *testing.T) {
+		_, err := request(&di.Container{Config: cfg, Keyring: keyring, APIKeyService: mocks.NewAPIKeyService(t)}, "")
+		assert.Equal(t, http.StatusUnauthorized, response.GenerateResponseFromIError(err).HTTPCode)
+	})
+}
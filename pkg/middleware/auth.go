@@ -1,41 +1,56 @@
 package middleware
 
-// func AuthMiddleware(di *di.Container) echo.MiddlewareFunc {
-// 	return func(next echo.HandlerFunc) echo.HandlerFunc {
-// 		return func(c echo.Context) error {
-// 			token, err := auth.VerifyTokenFromRequest(c, di.Config.JWT.SigningKey)
-// 			if err != nil {
-// 				return response.NewUnauthorizedError(response.ErrUnauthorizedType.Message)
-// 			}
-
-// 			claims := token.Claims.(jwt.MapClaims)
-
-// 			var tokenType string
-// 			if val, ok := claims["type"].(string); ok {
-// 				tokenType = val
-// 			}
-
-// 			if tokenType != "access" {
-// 				return response.NewUnauthorizedError(response.ErrUnauthorizedType.Message)
-// 			}
-
-// 			validToken, err := di.RepoRegistry.GetSessionRepository().IsValidSession(context.Background(), claims["user_id"].(string), claims["session_id"].(string))
-// 			if err != nil {
-// 				return response.NewUnauthorizedError("Invalid token")
-// 			}
-
-// 			if !validToken {
-// 				return response.NewUnauthorizedError("the client's session has expired")
-// 			}
-// 			ctx := context.WithValue(c.Request().Context(), auth.ContextKeyUser, token)
-// 			r := c.Request().WithContext(ctx)
-// 			c.SetRequest(r)
-
-// 			if ctx.Value(auth.ContextKeyUser) != nil {
-// 				return next(c)
-// 			}
-
-// 			return response.NewUnauthorizedError(response.ErrUnauthorizedType.Message)
-// 		}
-// 	}
-// }
+import (
+	"context"
+	"digital-wallet/di"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AuthMiddleware authenticates the access token in the Authorization header, checks that the
+// session of a token this service issued is still active and stores the token in the request
+// context for auth.GetLoggedInUser, and the user's principal for auth.GetPrincipal. Tokens of a
+// trusted issuer get the role and permissions of the local user, whatever their claims say.
+func AuthMiddleware(di *di.Container) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token, err := auth.VerifyTokenFromRequest(c, di.Keyring)
+			if err != nil || !token.Valid {
+				return response.NewUnauthorizedError(response.ErrUnauthorizedType.Message)
+			}
+
+			ctx := context.WithValue(c.Request().Context(), auth.ContextKeyUser, token)
+
+			user := auth.GetLoggedInUser(ctx)
+			if user.Type != auth.TokenTypeAccess || user.ID == "" {
+				return response.NewUnauthorizedError(response.ErrUnauthorizedType.Message)
+			}
+
+			principal := auth.PrincipalFromUser(user)
+
+			// sessions only exist for our own tokens; a trusted issuer answers for its tokens itself,
+			// but not for the role and permissions here, which come from the local user record
+			if issuer, _ := token.Claims.GetIssuer(); issuer == di.Keyring.Issuer() {
+				if user.SessionID == "" {
+					return response.NewUnauthorizedError(response.ErrUnauthorizedType.Message)
+				}
+
+				if _, err := di.AuthService.TouchSession(ctx, user.ID, user.SessionID); err != nil {
+					return response.GenerateResponseFromIError(err)
+				}
+			} else {
+				principal, err = di.AuthService.LocalPrincipal(ctx, user.ID)
+				if err != nil {
+					return response.GenerateResponseFromIError(err)
+				}
+			}
+
+			ctx = auth.WithPrincipal(ctx, principal)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
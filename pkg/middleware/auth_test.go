@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"digital-wallet/di"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/pkg/auth"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newIdPKeyring returns the keyring of an identity provider and a keyring of ours that trusts it
+func newIdPKeyring(t *testing.T) (*auth.Keyring, *auth.Keyring) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	private, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "idp.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: private}), 0o600))
+
+	idp, err := auth.NewKeyring(auth.KeyringConfig{Issuer: "https://idp.example.com", Audience: "digital-wallet", SigningKeyID: "idp-1", SigningKeyFile: keyFile})
+	require.NoError(t, err)
+
+	jwks, err := json.Marshal(idp.JWKS())
+	require.NoError(t, err)
+	jwksFile := filepath.Join(dir, "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, jwks, 0o600))
+
+	own, err := auth.NewKeyring(auth.KeyringConfig{
+		Issuer: "digital-wallet", Audience: "digital-wallet", HMACSecret: "secret",
+		TrustedIssuer: "https://idp.example.com", TrustedJWKS: jwksFile, TrustedJWKSRefresh: time.Minute,
+	})
+	require.NoError(t, err)
+
+	return idp, own
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	e := echo.New()
+	idp, own := newIdPKeyring(t)
+
+	request := func(container *di.Container, token string) (*auth.Principal, error) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/me/wallet/balance", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+
+		var principal *auth.Principal
+		handler := AuthMiddleware(container)(func(c echo.Context) error {
+			p := auth.GetPrincipal(c.Request().Context())
+			principal = &p
+			return c.NoContent(http.StatusOK)
+		})
+		return principal, handler(e.NewContext(req, rec))
+	}
+
+	t.Run("role and permissions of our own tokens come from the claims", func(t *testing.T) {
+		mockAuthSvc := mocks.NewAuthService(t)
+		mockAuthSvc.On("TouchSession", mock.Anything, "user-1", "session-1").Return(nil, nil)
+
+		token, err := auth.NewToken(auth.UserAuth{ID: "user-1", Role: auth.RoleUser, SessionID: "session-1", Permissions: []string{auth.PermissionWalletWithdraw}}, auth.TokenTypeAccess, "jti-1", time.Now().Add(time.Hour), own)
+		require.NoError(t, err)
+
+		principal, err := request(&di.Container{Keyring: own, AuthService: mockAuthSvc}, token)
+		require.NoError(t, err)
+		assert.Equal(t, auth.RoleUser, principal.Role)
+		assert.Equal(t, []string{auth.PermissionWalletWithdraw}, principal.Permissions)
+	})
+
+	t.Run("trusted issuer tokens get the role and permissions of the local user", func(t *testing.T) {
+		mockAuthSvc := mocks.NewAuthService(t)
+		mockAuthSvc.On("LocalPrincipal", mock.Anything, "user-1").Return(&auth.Principal{Type: auth.PrincipalTypeUser, ID: "user-1", Role: auth.RoleUser, Permissions: []string{auth.PermissionWalletWithdraw}}, nil)
+
+		token, err := auth.NewToken(auth.UserAuth{ID: "user-1", Role: auth.RoleAdmin, Permissions: []string{"admin.manage"}}, auth.TokenTypeAccess, "jti-1", time.Now().Add(time.Hour), idp)
+		require.NoError(t, err)
+
+		principal, err := request(&di.Container{Keyring: own, AuthService: mockAuthSvc}, token)
+		require.NoError(t, err)
+		assert.Equal(t, auth.RoleUser, principal.Role)
+		assert.Equal(t, []string{auth.PermissionWalletWithdraw}, principal.Permissions)
+		mockAuthSvc.AssertNotCalled(t, "TouchSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
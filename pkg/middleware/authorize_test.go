@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"digital-wallet/di"
+	"digital-wallet/internal/mocks"
+	"digital-wallet/internal/models"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRequirePermission(t *testing.T) {
+	e := echo.New()
+
+	request := func(role, targetUserID string, permissions ...interface{}) (echo.Context, *httptest.ResponseRecorder) {
+		token := &jwt.Token{Claims: jwt.MapClaims{"user_id": "user-1", "role": role, "permissions": permissions}}
+		req := httptest.NewRequest(http.MethodGet, "/v1/wallet/balance/"+targetUserID, nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKeyUser, token))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/v1/wallet/balance/:user_id")
+		c.SetParamNames("user_id")
+		c.SetParamValues(targetUserID)
+		return c, rec
+	}
+
+	handlerFor := func(auditService *mocks.AuditService) echo.HandlerFunc {
+		return RequirePermission(&di.Container{AuditService: auditService}, auth.PermissionWalletRead)(func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+	}
+
+	t.Run("granted permission may act on any user", func(t *testing.T) {
+		c, rec := request(auth.RoleSupport, "user-2", auth.PermissionUserRead, auth.PermissionWalletRead)
+
+		assert.NoError(t, handlerFor(mocks.NewAuditService(t))(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("users are refused and cross-user attempts recorded", func(t *testing.T) {
+		auditService := mocks.NewAuditService(t)
+		auditService.On("RecordSecurityEvent", mock.Anything, auth.SecurityEventCrossUserAccess, models.AuditResourceUser, "user-2",
+			mock.MatchedBy(func(details map[string]interface{}) bool {
+				return details["principal_id"] == "user-1" && details["target_user_id"] == "user-2" && details["permission"] == auth.PermissionWalletRead
+			})).Return(nil)
+		c, _ := request(auth.RoleUser, "user-2")
+
+		err := handlerFor(auditService)(c)
+		assert.Equal(t, response.ErrInsufficientPermissions.Code, err.(response.ErrorResponse).Code)
+		assert.Equal(t, http.StatusForbidden, err.(response.ErrorResponse).HTTPCode)
+	})
+
+	t.Run("other permissions do not count", func(t *testing.T) {
+		auditService := mocks.NewAuditService(t)
+		auditService.On("RecordSecurityEvent", mock.Anything, auth.SecurityEventCrossUserAccess, models.AuditResourceUser, "user-2", mock.Anything).Return(nil)
+		c, _ := request(auth.RoleOps, "user-2", auth.PermissionCashbackRead)
+
+		assert.Error(t, handlerFor(auditService)(c))
+	})
+
+	t.Run("users naming themselves are recorded as permission denied", func(t *testing.T) {
+		auditService := mocks.NewAuditService(t)
+		auditService.On("RecordSecurityEvent", mock.Anything, auth.SecurityEventPermission, models.AuditResourceUser, "user-1",
+			mock.MatchedBy(func(details map[string]interface{}) bool {
+				_, named := details["target_user_id"]
+				return details["principal_id"] == "user-1" && !named
+			})).Return(nil)
+		c, _ := request(auth.RoleUser, "user-1")
+
+		assert.Error(t, handlerFor(auditService)(c))
+	})
+
+	t.Run("routes without a user are recorded as permission denied", func(t *testing.T) {
+		auditService := mocks.NewAuditService(t)
+		auditService.On("RecordSecurityEvent", mock.Anything, auth.SecurityEventPermission, models.AuditResourceUser, "user-1", mock.Anything).Return(nil)
+
+		token := &jwt.Token{Claims: jwt.MapClaims{"user_id": "user-1", "role": auth.RoleUser}}
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/audit-events", nil)
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKeyUser, token))
+		c := e.NewContext(req, httptest.NewRecorder())
+		c.SetPath("/v1/admin/audit-events")
+
+		assert.Error(t, handlerFor(auditService)(c))
+	})
+
+	t.Run("a user named in the body is a cross-user attempt", func(t *testing.T) {
+		auditService := mocks.NewAuditService(t)
+		auditService.On("RecordSecurityEvent", mock.Anything, auth.SecurityEventCrossUserAccess, models.AuditResourceUser, "user-2",
+			mock.MatchedBy(func(details map[string]interface{}) bool {
+				return details["target_user_id"] == "user-2"
+			})).Return(nil)
+
+		token := &jwt.Token{Claims: jwt.MapClaims{"user_id": "user-1", "role": auth.RoleUser}}
+		req := httptest.NewRequest(http.MethodPost, "/v1/wallet/withdraw", strings.NewReader(`{"user_id":"user-2","amount":100}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKeyUser, token))
+		c := e.NewContext(req, httptest.NewRecorder())
+		c.SetPath("/v1/wallet/withdraw")
+
+		assert.Error(t, handlerFor(auditService)(c))
+	})
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"digital-wallet/di"
+	"digital-wallet/internal/dto"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RecordDevice records the device named by the X-Device-ID header of a user's request, so the
+// device registry follows devices between logins, and must run after AuthMiddleware. Requests
+// of API keys or without the header are passed through.
+func RecordDevice(di *di.Container) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+
+			deviceID := c.Request().Header.Get(dto.HeaderDeviceID)
+			if deviceID == "" || auth.GetPrincipal(ctx).IsAPIKey() {
+				return next(c)
+			}
+
+			if len(deviceID) > dto.MaxDeviceIDLength {
+				return response.NewValidationError(dto.HeaderDeviceID + " is too long")
+			}
+
+			device := dto.DeviceInfo{
+				DeviceID:  deviceID,
+				UserAgent: c.Request().UserAgent(),
+				IPAddress: c.RealIP(),
+			}
+			if err := di.DeviceService.Record(ctx, auth.GetLoggedInUser(ctx).ID, device); err != nil {
+				return response.GenerateResponseFromIError(err)
+			}
+
+			return next(c)
+		}
+	}
+}
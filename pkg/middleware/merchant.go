@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"context"
+	"digital-wallet/di"
+	"digital-wallet/pkg/auth"
+	"digital-wallet/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderMerchantKey carries the merchant API key on merchant-facing endpoints
+const HeaderMerchantKey = "X-Merchant-Key"
+
+// MerchantAuthMiddleware authenticates merchant API calls and stores the merchant ID in the request context
+func MerchantAuthMiddleware(di *di.Container) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			apiKey := c.Request().Header.Get(HeaderMerchantKey)
+			if apiKey == "" {
+				return response.NewUnauthorizedError(response.ErrUnauthorizedType.Message)
+			}
+
+			merchant, err := di.MerchantService.Authenticate(c.Request().Context(), apiKey)
+			if err != nil {
+				return response.NewUnauthorizedError("Invalid merchant API key")
+			}
+
+			ctx := context.WithValue(c.Request().Context(), auth.ContextKeyMerchant, merchant.ID)
+			ctx = auth.WithPrincipal(ctx, auth.PrincipalFromMerchant(merchant.ID, merchant.Name))
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
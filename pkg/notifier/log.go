@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// LogNotifier writes messages as JSON lines instead of delivering them. It stands in for
+// channels that have no gateway configured during development.
+type LogNotifier struct {
+	channel string
+	mu      sync.Mutex
+	w       io.Writer
+}
+
+// Ensure LogNotifier implements Notifier
+var _ Notifier = (*LogNotifier)(nil)
+
+func NewLogNotifier(channel string, w io.Writer) *LogNotifier {
+	return &LogNotifier{channel: channel, w: w}
+}
+
+func (n *LogNotifier) Channel() string {
+	return n.channel
+}
+
+func (n *LogNotifier) Send(ctx context.Context, msg Message) error {
+	line, err := json.Marshal(map[string]string{
+		"time":    time.Now().Format(time.RFC3339),
+		"channel": n.channel,
+		"to":      msg.To,
+		"subject": msg.Subject,
+		"body":    msg.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	_, err = n.w.Write(append(line, '\n'))
+	return err
+}
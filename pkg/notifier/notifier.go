@@ -0,0 +1,52 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+)
+
+// Delivery channels
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelPush  = "push"
+)
+
+// ErrUnsupportedChannel is returned when no notifier is registered for a channel
+var ErrUnsupportedChannel = errors.New("unsupported notification channel")
+
+// Message is a rendered notification addressed to one recipient. Subject is used as
+// the email subject and push title; channels without one ignore it.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages over one channel
+type Notifier interface {
+	Channel() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// Registry looks up the notifier of a channel
+type Registry map[string]Notifier
+
+// NewRegistry keys the given notifiers by their channel; a later notifier replaces an
+// earlier one for the same channel
+func NewRegistry(notifiers ...Notifier) Registry {
+	registry := make(Registry, len(notifiers))
+	for _, n := range notifiers {
+		registry[n.Channel()] = n
+	}
+	return registry
+}
+
+// Send delivers msg over the given channel
+func (r Registry) Send(ctx context.Context, channel string, msg Message) error {
+	n, ok := r[channel]
+	if !ok {
+		return ErrUnsupportedChannel
+	}
+	return n.Send(ctx, msg)
+}
@@ -0,0 +1,95 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"digital-wallet/pkg/notifier/smtptest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTPNotifier_Send(t *testing.T) {
+	sink, err := smtptest.NewSink()
+	require.NoError(t, err)
+	defer sink.Close()
+
+	n := NewSMTPNotifier(SMTPConfig{Host: sink.Host(), Port: sink.Port(), From: "wallet@example.com"})
+
+	err = n.Send(context.Background(), Message{To: "user@example.com", Subject: "Withdrawal completed", Body: "You withdrew IDR 50,000.\nThanks."})
+	require.NoError(t, err)
+
+	messages := sink.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "wallet@example.com", messages[0].From)
+	assert.Equal(t, []string{"user@example.com"}, messages[0].To)
+	assert.Contains(t, messages[0].Data, "Subject: Withdrawal completed")
+	assert.Contains(t, messages[0].Data, "You withdrew IDR 50,000.\nThanks.")
+}
+
+func TestSMSNotifier_Send(t *testing.T) {
+	t.Run("posts the message to the gateway", func(t *testing.T) {
+		var payload map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		n := NewSMSNotifier(SMSConfig{GatewayURL: server.URL, APIKey: "secret", Sender: "WALLET"}, server.Client())
+
+		err := n.Send(context.Background(), Message{To: "+628123456789", Body: "IDR 50,000 withdrawn"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"from": "WALLET", "to": "+628123456789", "message": "IDR 50,000 withdrawn"}, payload)
+	})
+
+	t.Run("gateway error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "invalid number", http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		n := NewSMSNotifier(SMSConfig{GatewayURL: server.URL}, server.Client())
+
+		err := n.Send(context.Background(), Message{To: "x", Body: "y"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid number")
+	})
+}
+
+func TestPushNotifier_Send(t *testing.T) {
+	var payload struct {
+		To           string            `json:"to"`
+		Notification map[string]string `json:"notification"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key=server-key", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	defer server.Close()
+
+	n := NewPushNotifier(PushConfig{GatewayURL: server.URL, ServerKey: "server-key"}, server.Client())
+
+	err := n.Send(context.Background(), Message{To: "device-token", Subject: "Deposit received", Body: "IDR 10,000"})
+	require.NoError(t, err)
+	assert.Equal(t, "device-token", payload.To)
+	assert.Equal(t, "Deposit received", payload.Notification["title"])
+}
+
+func TestRegistry_Send(t *testing.T) {
+	var buf bytes.Buffer
+	registry := NewRegistry(NewLogNotifier(ChannelEmail, &buf))
+
+	require.NoError(t, registry.Send(context.Background(), ChannelEmail, Message{To: "user@example.com", Subject: "Hi", Body: "Body"}))
+	assert.True(t, strings.HasSuffix(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), `"to":"user@example.com"`)
+
+	assert.ErrorIs(t, registry.Send(context.Background(), ChannelSMS, Message{}), ErrUnsupportedChannel)
+}
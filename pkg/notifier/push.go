@@ -0,0 +1,42 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+)
+
+// PushConfig holds the settings of an HTTP push gateway
+type PushConfig struct {
+	GatewayURL string
+	ServerKey  string
+}
+
+// PushNotifier posts to a push gateway in the FCM legacy format,
+// {"to": device token, "notification": {"title": subject, "body": body}}
+type PushNotifier struct {
+	cfg    PushConfig
+	client *http.Client
+}
+
+// Ensure PushNotifier implements Notifier
+var _ Notifier = (*PushNotifier)(nil)
+
+func NewPushNotifier(cfg PushConfig, client *http.Client) *PushNotifier {
+	return &PushNotifier{cfg: cfg, client: client}
+}
+
+func (n *PushNotifier) Channel() string {
+	return ChannelPush
+}
+
+func (n *PushNotifier) Send(ctx context.Context, msg Message) error {
+	return postJSON(ctx, n.client, n.cfg.GatewayURL, map[string]string{
+		"Authorization": "key=" + n.cfg.ServerKey,
+	}, map[string]interface{}{
+		"to": msg.To,
+		"notification": map[string]string{
+			"title": msg.Subject,
+			"body":  msg.Body,
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+)
+
+// SMSConfig holds the settings of an HTTP SMS gateway
+type SMSConfig struct {
+	GatewayURL string
+	APIKey     string
+	Sender     string
+}
+
+// SMSNotifier posts text messages to an SMS gateway as
+// {"from": sender, "to": phone number, "message": body}, authenticated with a bearer API key
+type SMSNotifier struct {
+	cfg    SMSConfig
+	client *http.Client
+}
+
+// Ensure SMSNotifier implements Notifier
+var _ Notifier = (*SMSNotifier)(nil)
+
+func NewSMSNotifier(cfg SMSConfig, client *http.Client) *SMSNotifier {
+	return &SMSNotifier{cfg: cfg, client: client}
+}
+
+func (n *SMSNotifier) Channel() string {
+	return ChannelSMS
+}
+
+func (n *SMSNotifier) Send(ctx context.Context, msg Message) error {
+	return postJSON(ctx, n.client, n.cfg.GatewayURL, map[string]string{
+		"Authorization": "Bearer " + n.cfg.APIKey,
+	}, map[string]string{
+		"from":    n.cfg.Sender,
+		"to":      msg.To,
+		"message": msg.Body,
+	})
+}
@@ -0,0 +1,158 @@
+// Package smtptest provides an in-process SMTP server that accepts every message and
+// keeps it in memory, so email delivery can be tested without a real mail server.
+package smtptest
+
+import (
+	"bufio"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// Message is one email accepted by the sink
+type Message struct {
+	From string
+	To   []string
+	Data string
+}
+
+// Sink is a minimal SMTP server. It speaks enough of RFC 5321 for net/smtp clients and
+// does not offer STARTTLS or AUTH.
+type Sink struct {
+	listener net.Listener
+	mu       sync.Mutex
+	messages []Message
+	wg       sync.WaitGroup
+}
+
+// NewSink starts a sink on a random local port
+func NewSink() (*Sink, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Sink{listener: listener}
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// Host and Port of the sink, for SMTP client configuration
+func (s *Sink) Host() string {
+	host, _, _ := net.SplitHostPort(s.listener.Addr().String())
+	return host
+}
+
+func (s *Sink) Port() string {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return port
+}
+
+// Messages returns the messages received so far
+func (s *Sink) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message(nil), s.messages...)
+}
+
+// Close stops accepting connections and waits for open sessions to finish
+func (s *Sink) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Sink) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *Sink) handle(conn net.Conn) {
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	reply := func(code int, msg string) bool {
+		return tp.PrintfLine("%d %s", code, msg) == nil
+	}
+
+	if !reply(220, "smtptest ready") {
+		return
+	}
+
+	var current Message
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply(250, "smtptest")
+		case "MAIL":
+			current = Message{From: addressOf(arg)}
+			reply(250, "OK")
+		case "RCPT":
+			current.To = append(current.To, addressOf(arg))
+			reply(250, "OK")
+		case "DATA":
+			if !reply(354, "End data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			data, err := readData(tp.R)
+			if err != nil {
+				return
+			}
+			current.Data = data
+
+			s.mu.Lock()
+			s.messages = append(s.messages, current)
+			s.mu.Unlock()
+
+			current = Message{}
+			reply(250, "OK")
+		case "RSET":
+			current = Message{}
+			reply(250, "OK")
+		case "NOOP":
+			reply(250, "OK")
+		case "QUIT":
+			reply(221, "Bye")
+			return
+		default:
+			reply(502, "Command not implemented")
+		}
+	}
+}
+
+// addressOf extracts the address from "FROM:<a@b>" or "TO:<a@b>"
+func addressOf(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr, _, _ = strings.Cut(strings.TrimSpace(addr), " ")
+	return strings.Trim(addr, "<>")
+}
+
+func readData(r *bufio.Reader) (string, error) {
+	data, err := textproto.NewReader(r).ReadDotBytes()
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -1,6 +1,7 @@
 package response
 
 import (
+	"digital-wallet/pkg/audit"
 	"log"
 	"net/http"
 
@@ -33,17 +34,28 @@ func CustomHTTPErrorHandler(err error, c echo.Context) {
 	}
 }
 
+// maxRequestIDLength is the longest X-Request-ID kept from the client
+const maxRequestIDLength = 64
+
 // EchoMiddleware creates an Echo middleware for error handling
 func EchoMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// Add request ID to context if not already present
-			if c.Request().Header.Get("X-Request-ID") == "" {
+			// Add request ID to context if not already present; IDs too long for the audit log
+			// are replaced
+			if id := c.Request().Header.Get("X-Request-ID"); id == "" || len(id) > maxRequestIDLength {
 				requestID := uuid.New().String()
 				c.Request().Header.Set("X-Request-ID", requestID)
 				c.Response().Header().Set("X-Request-ID", requestID)
 			}
 
+			// the audit log records which request and address a change came from
+			ctx := audit.WithRequest(c.Request().Context(), audit.Request{
+				ID:        c.Request().Header.Get("X-Request-ID"),
+				IPAddress: c.RealIP(),
+			})
+			c.SetRequest(c.Request().WithContext(ctx))
+
 			// Call the next handler
 			err := next(c)
 			if err != nil {
@@ -99,6 +111,11 @@ func Created(c echo.Context, message string, data interface{}) error {
 	return SendSuccessResponse(c, http.StatusCreated, message, data)
 }
 
+// Accepted sends a 202 Accepted response
+func Accepted(c echo.Context, message string, data interface{}) error {
+	return SendSuccessResponse(c, http.StatusAccepted, message, data)
+}
+
 // OK sends a 200 OK response
 func OK(c echo.Context, message string, data interface{}) error {
 	return SendSuccessResponse(c, http.StatusOK, message, data)
@@ -55,6 +55,23 @@ var (
 	ErrInsufficientPermissions = IError{Code: "40007", Message: "Insufficient permissions"}
 	ErrMaxFileSizeExceed       = IError{Code: "40008", Message: "file size exceed, maxmimum is 10MB"}
 	ErrFileExtNotAllowed       = IError{Code: "40009", Message: "file extension is not allowed"}
+	ErrInvalidPIN              = IError{Code: "40010", Message: "Invalid PIN"}
+	ErrPINLocked               = IError{Code: "40011", Message: "PIN is locked after too many failed attempts"}
+	ErrPINNotSet               = IError{Code: "40012", Message: "Transaction PIN has not been set"}
+	ErrInvalidMFACode          = IError{Code: "40013", Message: "Invalid verification code"}
+	ErrMFARequired             = IError{Code: "40014", Message: "Two-factor verification code is required"}
+	ErrMFALocked               = IError{Code: "40015", Message: "Too many failed verification attempts"}
+	ErrInvalidChallengeCode    = IError{Code: "40016", Message: "Invalid challenge code"}
+	ErrChallengeExpired        = IError{Code: "40017", Message: "Challenge has expired"}
+	ErrChallengeRateLimited    = IError{Code: "40018", Message: "Too many challenges requested, try again later"}
+	ErrChallengeMismatch       = IError{Code: "40019", Message: "Challenge does not match this withdrawal"}
+	ErrAccountNotActivated     = IError{Code: "40020", Message: "Account has not been activated"}
+	ErrSelfApproval            = IError{Code: "40021", Message: "A request cannot be approved by the person who made it"}
+	ErrApprovalExpired         = IError{Code: "40022", Message: "Approval request has expired"}
+	ErrNewDeviceLimit          = IError{Code: "40023", Message: "Withdrawals from a new device are limited, try a smaller amount or wait until the device is trusted"}
+	ErrBalanceLimitExceeded    = IError{Code: "40025", Message: "The balance would exceed the maximum for the account's verification level"}
+	ErrWithdrawalLimitExceeded = IError{Code: "40026", Message: "The daily withdrawal limit for the account's verification level has been reached"}
+	ErrPINResetLocked          = IError{Code: "40027", Message: "Too many failed PIN reset attempts, try again later"}
 )
 
 type stackTracer interface {
@@ -313,10 +330,12 @@ func GenerateResponseFromIError(err error) ErrorResponse {
 		switch iErr.Code {
 		case ErrUnauthorizedType.Code:
 			return ErrUnauthorized(err)
-		case ErrForbiddenType.Code:
+		case ErrForbiddenType.Code, ErrInsufficientPermissions.Code, ErrPINLocked.Code, ErrMFALocked.Code, ErrAccountDeactivated.Code, ErrAccountNotActivated.Code, ErrSelfApproval.Code, ErrNewDeviceLimit.Code, ErrBalanceLimitExceeded.Code, ErrWithdrawalLimitExceeded.Code, ErrPINResetLocked.Code:
 			return ErrForbidden(err)
 		case ErrSessionExpiredType.Code:
 			return ErrSessionExpired(err)
+		case ErrChallengeRateLimited.Code:
+			return HTTPError(err, http.StatusTooManyRequests, iErr.Code, iErr.Message)
 		case ErrResourceNotFound.Code:
 			return ErrNotFound(err)
 		case ErrInternal.Code:
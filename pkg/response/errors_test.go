@@ -180,12 +180,44 @@ func TestGenerateResponseFromIError(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, result.HTTPCode)
 	})
 
+	t.Run("with insufficient permissions IError", func(t *testing.T) {
+		result := GenerateResponseFromIError(ErrInsufficientPermissions)
+		assert.Equal(t, http.StatusForbidden, result.HTTPCode)
+		assert.Equal(t, ErrInsufficientPermissions.Code, result.Code)
+	})
+
+	t.Run("with self approval IError", func(t *testing.T) {
+		result := GenerateResponseFromIError(ErrSelfApproval)
+		assert.Equal(t, http.StatusForbidden, result.HTTPCode)
+		assert.Equal(t, ErrSelfApproval.Code, result.Code)
+	})
+
+	t.Run("with new device limit IError", func(t *testing.T) {
+		result := GenerateResponseFromIError(ErrNewDeviceLimit)
+		assert.Equal(t, http.StatusForbidden, result.HTTPCode)
+		assert.Equal(t, ErrNewDeviceLimit.Code, result.Code)
+	})
+
+	t.Run("with KYC limit IErrors", func(t *testing.T) {
+		for _, err := range []IError{ErrBalanceLimitExceeded, ErrWithdrawalLimitExceeded} {
+			result := GenerateResponseFromIError(err)
+			assert.Equal(t, http.StatusForbidden, result.HTTPCode)
+			assert.Equal(t, err.Code, result.Code)
+		}
+	})
+
 	t.Run("with session expired IError", func(t *testing.T) {
 		err := ErrSessionExpiredType
 		result := GenerateResponseFromIError(err)
 		assert.Equal(t, 440, result.HTTPCode)
 	})
 
+	t.Run("with challenge rate limited IError", func(t *testing.T) {
+		result := GenerateResponseFromIError(ErrChallengeRateLimited)
+		assert.Equal(t, http.StatusTooManyRequests, result.HTTPCode)
+		assert.Equal(t, ErrChallengeRateLimited.Code, result.Code)
+	})
+
 	t.Run("with not found IError", func(t *testing.T) {
 		err := ErrResourceNotFound
 		result := GenerateResponseFromIError(err)
@@ -3,8 +3,10 @@ package utils
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/rand"
 	"digital-wallet/pkg/response"
 	"encoding/base64"
+	"errors"
 )
 
 var bytess = []byte{35, 46, 57, 24, 85, 35, 24, 74, 87, 35, 88, 98, 66, 32, 14, 05}
@@ -24,3 +26,52 @@ func Encrypt(text, MySecret string) (string, error) {
 	cfb.XORKeyStream(cipherText, plainText)
 	return Encode(cipherText), nil
 }
+
+// Seal encrypts and authenticates text with AES-GCM under a 16, 24 or 32 byte key. The
+// random nonce is prepended to the ciphertext, so sealing the same text twice differs.
+func Seal(text, key string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", response.Wrap(err, "cannot read random bytes")
+	}
+
+	return Encode(gcm.Seal(nonce, nonce, []byte(text), nil)), nil
+}
+
+// Open decrypts a value produced by Seal with the same key
+func Open(sealed, key string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", response.Wrap(err, "cannot decode sealed value")
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("sealed value is too short")
+	}
+
+	nonce, cipherText := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plainText, err := gcm.Open(nil, nonce, cipherText, nil)
+	if err != nil {
+		return "", response.Wrap(err, "cannot open sealed value")
+	}
+
+	return string(plainText), nil
+}
+
+func newGCM(key string) (cipher.AEAD, error) {
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return nil, response.Wrap(err, "cannot init new chiper")
+	}
+	return cipher.NewGCM(block)
+}
@@ -80,3 +80,24 @@ func TestEncrypt(t *testing.T) {
 		assert.NotEmpty(t, encrypted)
 	})
 }
+
+func TestSealOpen(t *testing.T) {
+	key := "12345678901234567890123456789012"
+
+	sealed, err := Seal("JBSWY3DPEHPK3PXP", key)
+	require.NoError(t, err)
+
+	again, err := Seal("JBSWY3DPEHPK3PXP", key)
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, again)
+
+	opened, err := Open(sealed, key)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", opened)
+
+	_, err = Open(sealed, "abcdefghijklmnopqrstuvwxyz123456")
+	assert.Error(t, err)
+
+	_, err = Seal("text", "short")
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"digital-wallet/pkg/response"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// RandomToken returns a URL-safe random string built from n random bytes
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", response.Wrap(err, "cannot read random bytes")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// RandomHex returns a random hex string built from n random bytes
+func RandomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", response.Wrap(err, "cannot read random bytes")
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// RandomDigits returns a string of n random decimal digits, for codes users type in
+func RandomDigits(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", response.Wrap(err, "cannot read random bytes")
+	}
+	for i := range b {
+		// 250 is the largest multiple of 10 below 256; redraw above it to avoid bias
+		for b[i] >= 250 {
+			if _, err := rand.Read(b[i : i+1]); err != nil {
+				return "", response.Wrap(err, "cannot read random bytes")
+			}
+		}
+		b[i] = '0' + b[i]%10
+	}
+	return string(b), nil
+}
+
+// HashToken returns the hex encoded SHA-256 digest of a token, for storing secrets at rest
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CompareTokenHash reports whether token hashes to the stored digest, in constant time
+func CompareTokenHash(hashed, token string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashed), []byte(HashToken(token))) == 1
+}
+
+// codeAlphabet leaves out characters that are easy to misread, such as 0/O and 1/I
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// RandomCode returns a random human-friendly code of the given length, for codes users type in
+func RandomCode(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", response.Wrap(err, "cannot read random bytes")
+	}
+	for i := range b {
+		b[i] = codeAlphabet[int(b[i])%len(codeAlphabet)]
+	}
+	return string(b), nil
+}
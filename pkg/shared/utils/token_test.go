@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomCode(t *testing.T) {
+	t.Run("uses only the unambiguous alphabet", func(t *testing.T) {
+		code, err := RandomCode(32)
+		require.NoError(t, err)
+		assert.Len(t, code, 32)
+		for _, c := range code {
+			assert.True(t, strings.ContainsRune(codeAlphabet, c), "unexpected character %q", c)
+		}
+	})
+
+	t.Run("codes differ between calls", func(t *testing.T) {
+		a, err := RandomCode(12)
+		require.NoError(t, err)
+		b, err := RandomCode(12)
+		require.NoError(t, err)
+		assert.NotEqual(t, a, b)
+	})
+}
+
+func TestRandomDigits(t *testing.T) {
+	code, err := RandomDigits(6)
+	require.NoError(t, err)
+	assert.Regexp(t, `^[0-9]{6}$`, code)
+}
+
+func TestCompareTokenHash(t *testing.T) {
+	hashed := HashToken("secret")
+	assert.True(t, CompareTokenHash(hashed, "secret"))
+	assert.False(t, CompareTokenHash(hashed, "other"))
+}
@@ -0,0 +1,132 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrNoSigningKey is returned by Sign when the keyring was loaded without a private key
+var ErrNoSigningKey = errors.New("no signing key configured")
+
+// Keyring signs messages with an Ed25519 private key and verifies signatures made by it or by
+// the public keys kept from earlier keys. Signatures are verified against the key ID they were
+// made under, so keys can be rotated without invalidating what the old key signed.
+type Keyring struct {
+	keyID   string
+	private ed25519.PrivateKey
+	public  map[string]ed25519.PublicKey
+}
+
+// NewKeyring loads the PEM private key in keyFile under keyID, and the "kid:path" PEM public
+// keys of verificationKeys. Without a keyFile the keyring only verifies.
+func NewKeyring(keyID, keyFile string, verificationKeys []string) (*Keyring, error) {
+	k := &Keyring{public: map[string]ed25519.PublicKey{}}
+
+	if keyFile != "" {
+		if keyID == "" {
+			return nil, errors.New("a key ID is required with a signing key")
+		}
+
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading signing key: %w", err)
+		}
+
+		private, err := parsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing signing key: %w", err)
+		}
+
+		k.keyID = keyID
+		k.private = private
+		k.public[keyID] = private.Public().(ed25519.PublicKey)
+	}
+
+	for _, entry := range verificationKeys {
+		kid, path, ok := strings.Cut(entry, ":")
+		if !ok || kid == "" || path == "" {
+			return nil, fmt.Errorf("verification key %q is not kid:path", entry)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading verification key %s: %w", kid, err)
+		}
+
+		public, err := parsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing verification key %s: %w", kid, err)
+		}
+		k.public[kid] = public
+	}
+
+	return k, nil
+}
+
+// KeyID is the ID of the signing key, empty when the keyring only verifies
+func (k *Keyring) KeyID() string {
+	return k.keyID
+}
+
+// Sign signs the message with the private key and returns the signature with the key ID to
+// verify it under
+func (k *Keyring) Sign(message []byte) (string, []byte, error) {
+	if k.private == nil {
+		return "", nil, ErrNoSigningKey
+	}
+	return k.keyID, ed25519.Sign(k.private, message), nil
+}
+
+// Verify checks the signature of the message against the public key named by keyID
+func (k *Keyring) Verify(keyID string, message, signature []byte) error {
+	public, ok := k.public[keyID]
+	if !ok {
+		return fmt.Errorf("unknown key ID %q", keyID)
+	}
+
+	if !ed25519.Verify(public, message, signature) {
+		return errors.New("signature does not match")
+	}
+	return nil
+}
+
+func parsePrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("not a PEM file")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	private, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an Ed25519 private key")
+	}
+	return private, nil
+}
+
+func parsePublicKey(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("not a PEM file")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	public, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("not an Ed25519 public key")
+	}
+	return public, nil
+}
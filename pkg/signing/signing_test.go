@@ -0,0 +1,105 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeyPair writes the PEM private and public key files of key and returns their paths
+func writeKeyPair(t *testing.T, name string, key crypto.Signer) (string, string) {
+	dir := t.TempDir()
+
+	private, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	public, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	privatePath := filepath.Join(dir, name+".pem")
+	publicPath := filepath.Join(dir, name+".pub.pem")
+	require.NoError(t, os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: private}), 0o600))
+	require.NoError(t, os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}), 0o600))
+
+	return privatePath, publicPath
+}
+
+func edKeyPair(t *testing.T, name string) (string, string) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return writeKeyPair(t, name, key)
+}
+
+func TestKeyring_SignAndVerify(t *testing.T) {
+	private, _ := edKeyPair(t, "2026-10")
+
+	keyring, err := NewKeyring("2026-10", private, nil)
+	require.NoError(t, err)
+
+	keyID, signature, err := keyring.Sign([]byte("wallet-1\n1\nabc"))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10", keyID)
+
+	assert.NoError(t, keyring.Verify(keyID, []byte("wallet-1\n1\nabc"), signature))
+	assert.Error(t, keyring.Verify(keyID, []byte("wallet-1\n2\nabc"), signature))
+	assert.Error(t, keyring.Verify("2026-01", []byte("wallet-1\n1\nabc"), signature))
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	oldPrivate, oldPublic := edKeyPair(t, "2026-01")
+	newPrivate, _ := edKeyPair(t, "2026-10")
+
+	oldKeyring, err := NewKeyring("2026-01", oldPrivate, nil)
+	require.NoError(t, err)
+	keyID, signature, err := oldKeyring.Sign([]byte("checkpoint"))
+	require.NoError(t, err)
+
+	keyring, err := NewKeyring("2026-10", newPrivate, []string{"2026-01:" + oldPublic})
+	require.NoError(t, err)
+
+	assert.NoError(t, keyring.Verify(keyID, []byte("checkpoint"), signature))
+}
+
+func TestKeyring_VerifyOnly(t *testing.T) {
+	_, public := edKeyPair(t, "2026-10")
+
+	keyring, err := NewKeyring("", "", []string{"2026-10:" + public})
+	require.NoError(t, err)
+
+	_, _, err = keyring.Sign([]byte("checkpoint"))
+	assert.ErrorIs(t, err, ErrNoSigningKey)
+}
+
+func TestNewKeyring_Errors(t *testing.T) {
+	edPrivate, _ := edKeyPair(t, "ed")
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaPrivate, _ := writeKeyPair(t, "rsa", rsaKey)
+
+	for _, tc := range []struct {
+		name             string
+		keyID            string
+		keyFile          string
+		verificationKeys []string
+	}{
+		{name: "signing key without key ID", keyFile: edPrivate},
+		{name: "missing signing key file", keyID: "k", keyFile: filepath.Join(t.TempDir(), "missing.pem")},
+		{name: "RSA signing key", keyID: "k", keyFile: rsaPrivate},
+		{name: "verification key without kid", verificationKeys: []string{edPrivate}},
+		{name: "private key as verification key", verificationKeys: []string{"k:" + edPrivate}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewKeyring(tc.keyID, tc.keyFile, tc.verificationKeys)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store := NewLocal(root)
+
+	require.NoError(t, store.Save(ctx, "kyc/user-1/doc.jpg", strings.NewReader("content")))
+
+	f, err := store.Open(ctx, "kyc/user-1/doc.jpg")
+	require.NoError(t, err)
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.Equal(t, "content", string(content))
+
+	_, err = os.Stat(filepath.Join(root, "kyc", "user-1", "doc.jpg"))
+	assert.NoError(t, err)
+
+	require.NoError(t, store.Delete(ctx, "kyc/user-1/doc.jpg"))
+	_, err = store.Open(ctx, "kyc/user-1/doc.jpg")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, store.Delete(ctx, "kyc/user-1/doc.jpg"), "deleting a missing file is not an error")
+}
+
+func TestLocal_InvalidKeys(t *testing.T) {
+	store := NewLocal(t.TempDir())
+
+	for _, key := range []string{"", "../outside", "kyc/../../outside", "/absolute", "kyc//double", `kyc\user`} {
+		assert.Error(t, store.Save(context.Background(), key, strings.NewReader("x")), key)
+	}
+}
@@ -0,0 +1,58 @@
+
+-- +migrate Up
+-- Create merchants table
+CREATE TABLE IF NOT EXISTS merchants (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    settlement_wallet_id VARCHAR(36) NOT NULL,
+    api_key_prefix VARCHAR(32) NOT NULL,
+    api_key_hash VARCHAR(64) NOT NULL,
+    callback_url VARCHAR(2048),
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    UNIQUE KEY unique_api_key_prefix (api_key_prefix),
+    KEY idx_user_id (user_id),
+    KEY idx_settlement_wallet_id (settlement_wallet_id),
+    KEY idx_deleted_at (deleted_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (settlement_wallet_id) REFERENCES wallets(id)
+);
+
+-- Create payment intents table
+CREATE TABLE IF NOT EXISTS payment_intents (
+    id VARCHAR(36) PRIMARY KEY,
+    merchant_id VARCHAR(36) NOT NULL,
+    merchant_reference VARCHAR(255),
+    amount DECIMAL(15,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    description TEXT,
+    status ENUM('REQUIRES_CONFIRMATION', 'SUCCEEDED', 'CANCELED', 'EXPIRED') NOT NULL DEFAULT 'REQUIRES_CONFIRMATION',
+    payer_user_id VARCHAR(36),
+    wallet_transaction_id VARCHAR(36),
+    settlement_transaction_id VARCHAR(36),
+    cancellation_reason VARCHAR(255),
+    expires_at TIMESTAMP NOT NULL,
+    confirmed_at TIMESTAMP NULL,
+    canceled_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    KEY idx_merchant_id (merchant_id),
+    KEY idx_payer_user_id (payer_user_id),
+    KEY idx_status_expires_at (status, expires_at),
+    KEY idx_deleted_at (deleted_at),
+    FOREIGN KEY (merchant_id) REFERENCES merchants(id) ON DELETE CASCADE,
+    FOREIGN KEY (wallet_transaction_id) REFERENCES wallet_transactions(id),
+    FOREIGN KEY (settlement_transaction_id) REFERENCES wallet_transactions(id)
+);
+
+-- Allow payments to be recorded against wallets
+ALTER TABLE wallet_transactions MODIFY type ENUM('WITHDRAWAL', 'DEPOSIT', 'PAYMENT') NOT NULL;
+
+-- +migrate Down
+ALTER TABLE wallet_transactions MODIFY type ENUM('WITHDRAWAL', 'DEPOSIT') NOT NULL;
+DROP TABLE IF EXISTS payment_intents;
+DROP TABLE IF EXISTS merchants;
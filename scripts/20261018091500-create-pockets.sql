@@ -0,0 +1,24 @@
+
+-- +migrate Up
+-- Create pockets table
+CREATE TABLE IF NOT EXISTS pockets (
+    id VARCHAR(36) PRIMARY KEY,
+    wallet_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    balance DECIMAL(15,2) NOT NULL DEFAULT 0,
+    target_amount DECIMAL(15,2) NULL,
+    deadline TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    KEY idx_wallet_id (wallet_id),
+    KEY idx_deleted_at (deleted_at),
+    FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE
+);
+
+-- Record transfers between the main balance and pockets
+ALTER TABLE wallet_transactions MODIFY type ENUM('WITHDRAWAL', 'DEPOSIT', 'PAYMENT', 'TRANSFER') NOT NULL;
+
+-- +migrate Down
+ALTER TABLE wallet_transactions MODIFY type ENUM('WITHDRAWAL', 'DEPOSIT', 'PAYMENT') NOT NULL;
+DROP TABLE IF EXISTS pockets;
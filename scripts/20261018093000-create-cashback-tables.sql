@@ -0,0 +1,49 @@
+
+-- +migrate Up
+-- Create cashback campaigns table
+CREATE TABLE IF NOT EXISTS cashback_campaigns (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    percentage DECIMAL(5,2) NOT NULL,
+    max_cashback DECIMAL(15,2) NOT NULL DEFAULT 0,
+    min_spend DECIMAL(15,2) NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    per_user_budget DECIMAL(15,2) NOT NULL DEFAULT 0,
+    total_budget DECIMAL(15,2) NOT NULL,
+    used_budget DECIMAL(15,2) NOT NULL DEFAULT 0,
+    holding_days INT NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    KEY idx_active_window (is_active, starts_at, ends_at),
+    KEY idx_deleted_at (deleted_at)
+);
+
+-- Create cashback rewards table
+CREATE TABLE IF NOT EXISTS cashback_rewards (
+    id VARCHAR(36) PRIMARY KEY,
+    campaign_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    source_transaction_id VARCHAR(36) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    status ENUM('HELD', 'CREDITED') NOT NULL DEFAULT 'HELD',
+    release_at TIMESTAMP NOT NULL,
+    credited_transaction_id VARCHAR(36),
+    credited_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_campaign_source (campaign_id, source_transaction_id),
+    KEY idx_campaign_user (campaign_id, user_id),
+    KEY idx_status_release_at (status, release_at),
+    FOREIGN KEY (campaign_id) REFERENCES cashback_campaigns(id),
+    FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE,
+    FOREIGN KEY (source_transaction_id) REFERENCES wallet_transactions(id),
+    FOREIGN KEY (credited_transaction_id) REFERENCES wallet_transactions(id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS cashback_rewards;
+DROP TABLE IF EXISTS cashback_campaigns;
@@ -0,0 +1,57 @@
+-- +migrate Up
+-- Create promo codes table
+CREATE TABLE IF NOT EXISTS promo_codes (
+    id VARCHAR(36) PRIMARY KEY,
+    code VARCHAR(64) NOT NULL UNIQUE,
+    batch_id VARCHAR(36),
+    description VARCHAR(255),
+    reward_type ENUM('CREDIT', 'DISCOUNT') NOT NULL,
+    value_type ENUM('FIXED', 'PERCENTAGE') NOT NULL,
+    value DECIMAL(15,2) NOT NULL,
+    max_value DECIMAL(15,2) NOT NULL DEFAULT 0,
+    min_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    transaction_type VARCHAR(20),
+    max_redemptions INT NOT NULL DEFAULT 0,
+    max_per_user INT NOT NULL DEFAULT 0,
+    redemption_count INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NULL,
+    is_active BOOLEAN NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    KEY idx_batch_id (batch_id),
+    KEY idx_deleted_at (deleted_at)
+);
+
+-- Create promo redemptions table
+CREATE TABLE IF NOT EXISTS promo_redemptions (
+    id VARCHAR(36) PRIMARY KEY,
+    promo_code_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    source_transaction_id VARCHAR(36),
+    payment_intent_id VARCHAR(36),
+    transaction_id VARCHAR(36),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_promo_source (promo_code_id, source_transaction_id),
+    UNIQUE KEY unique_payment_intent (payment_intent_id),
+    KEY idx_promo_user (promo_code_id, user_id),
+    FOREIGN KEY (promo_code_id) REFERENCES promo_codes(id),
+    FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE,
+    FOREIGN KEY (source_transaction_id) REFERENCES wallet_transactions(id),
+    FOREIGN KEY (payment_intent_id) REFERENCES payment_intents(id),
+    FOREIGN KEY (transaction_id) REFERENCES wallet_transactions(id)
+);
+
+-- Discounts are reserved on the payment intent and paid at confirmation
+ALTER TABLE payment_intents
+    ADD COLUMN discount_amount DECIMAL(15,2) NOT NULL DEFAULT 0 AFTER amount,
+    ADD COLUMN promo_code_id VARCHAR(36) NULL AFTER discount_amount;
+
+-- +migrate Down
+ALTER TABLE payment_intents
+    DROP COLUMN promo_code_id,
+    DROP COLUMN discount_amount;
+DROP TABLE IF EXISTS promo_redemptions;
+DROP TABLE IF EXISTS promo_codes;
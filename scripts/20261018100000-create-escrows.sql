@@ -0,0 +1,58 @@
+-- +migrate Up
+-- Create escrows table
+CREATE TABLE IF NOT EXISTS escrows (
+    id VARCHAR(36) PRIMARY KEY,
+    buyer_user_id VARCHAR(36) NOT NULL,
+    buyer_wallet_id VARCHAR(36) NOT NULL,
+    seller_user_id VARCHAR(36) NOT NULL,
+    seller_wallet_id VARCHAR(36) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    fee_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    currency VARCHAR(3) DEFAULT 'IDR',
+    reference VARCHAR(255),
+    description TEXT,
+    status ENUM('FUNDED', 'RELEASED', 'REFUNDED') NOT NULL DEFAULT 'FUNDED',
+    auto_release_at TIMESTAMP NOT NULL,
+    funding_transaction_id VARCHAR(36),
+    release_transaction_id VARCHAR(36),
+    fee_transaction_id VARCHAR(36),
+    refund_transaction_id VARCHAR(36),
+    released_at TIMESTAMP NULL,
+    refunded_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    KEY idx_buyer_user_id (buyer_user_id),
+    KEY idx_seller_user_id (seller_user_id),
+    KEY idx_status_auto_release_at (status, auto_release_at),
+    KEY idx_deleted_at (deleted_at),
+    FOREIGN KEY (buyer_wallet_id) REFERENCES wallets(id),
+    FOREIGN KEY (seller_wallet_id) REFERENCES wallets(id),
+    FOREIGN KEY (funding_transaction_id) REFERENCES wallet_transactions(id),
+    FOREIGN KEY (release_transaction_id) REFERENCES wallet_transactions(id),
+    FOREIGN KEY (fee_transaction_id) REFERENCES wallet_transactions(id),
+    FOREIGN KEY (refund_transaction_id) REFERENCES wallet_transactions(id)
+);
+
+-- Create escrow events table, the append-only status history of an escrow
+CREATE TABLE IF NOT EXISTS escrow_events (
+    id VARCHAR(36) PRIMARY KEY,
+    escrow_id VARCHAR(36) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(36) NOT NULL,
+    transaction_id VARCHAR(36),
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_escrow_id (escrow_id),
+    FOREIGN KEY (escrow_id) REFERENCES escrows(id),
+    FOREIGN KEY (transaction_id) REFERENCES wallet_transactions(id)
+);
+
+ALTER TABLE wallet_transactions MODIFY type ENUM('WITHDRAWAL', 'DEPOSIT', 'PAYMENT', 'TRANSFER', 'ESCROW', 'REFUND', 'FEE') NOT NULL;
+
+-- +migrate Down
+ALTER TABLE wallet_transactions MODIFY type ENUM('WITHDRAWAL', 'DEPOSIT', 'PAYMENT', 'TRANSFER') NOT NULL;
+DROP TABLE IF EXISTS escrow_events;
+DROP TABLE IF EXISTS escrows;
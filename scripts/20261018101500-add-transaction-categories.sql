@@ -0,0 +1,61 @@
+-- +migrate Up
+-- Direction and category of every wallet transaction
+ALTER TABLE wallet_transactions
+    ADD COLUMN direction ENUM('DEBIT', 'CREDIT') NULL AFTER type,
+    ADD COLUMN category VARCHAR(32) NOT NULL DEFAULT 'OTHER' AFTER status,
+    ADD KEY idx_created_at_id (created_at, id);
+
+UPDATE wallet_transactions SET direction = CASE
+    WHEN type IN ('WITHDRAWAL', 'PAYMENT') THEN 'DEBIT'
+    WHEN type = 'ESCROW' AND description LIKE 'Funds held in escrow%' THEN 'DEBIT'
+    WHEN type = 'TRANSFER' AND JSON_UNQUOTE(JSON_EXTRACT(metadata, '$.direction')) = 'IN' THEN 'DEBIT'
+    ELSE 'CREDIT'
+END;
+
+ALTER TABLE wallet_transactions MODIFY direction ENUM('DEBIT', 'CREDIT') NOT NULL;
+
+-- Coarse backfill by type; new transactions are categorized by the application rules
+UPDATE wallet_transactions SET category = CASE
+    WHEN type = 'TRANSFER' THEN 'SAVINGS'
+    WHEN type = 'FEE' THEN 'FEES'
+    WHEN type = 'REFUND' THEN 'REFUNDS'
+    WHEN type = 'WITHDRAWAL' THEN 'CASH_OUT'
+    WHEN type = 'DEPOSIT' AND (description LIKE 'Cashback%' OR description LIKE 'Promo code%') THEN 'REWARDS'
+    WHEN type = 'DEPOSIT' AND description LIKE 'Settlement%' THEN 'INCOME'
+    WHEN type = 'DEPOSIT' THEN 'TOP_UP'
+    WHEN type = 'ESCROW' AND direction = 'CREDIT' THEN 'INCOME'
+    WHEN type = 'ESCROW' THEN 'SHOPPING'
+    ELSE 'OTHER'
+END;
+
+-- Create monthly category summaries table
+CREATE TABLE IF NOT EXISTS transaction_summaries (
+    wallet_id VARCHAR(36) NOT NULL,
+    period DATE NOT NULL,
+    category VARCHAR(32) NOT NULL,
+    direction ENUM('DEBIT', 'CREDIT') NOT NULL,
+    total_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    transaction_count BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (wallet_id, period, category, direction),
+    FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE
+);
+
+-- Create summary watermarks table
+CREATE TABLE IF NOT EXISTS summary_watermarks (
+    name VARCHAR(64) PRIMARY KEY,
+    last_created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:01',
+    last_transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    processed_count BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+INSERT INTO summary_watermarks (name) VALUES ('transaction_summaries');
+
+-- +migrate Down
+DROP TABLE IF EXISTS summary_watermarks;
+DROP TABLE IF EXISTS transaction_summaries;
+ALTER TABLE wallet_transactions
+    DROP KEY idx_created_at_id,
+    DROP COLUMN category,
+    DROP COLUMN direction;
@@ -0,0 +1,35 @@
+-- +migrate Up
+-- Create budgets table, one monthly budget per wallet and category
+CREATE TABLE IF NOT EXISTS budgets (
+    id VARCHAR(36) PRIMARY KEY,
+    wallet_id VARCHAR(36) NOT NULL,
+    category VARCHAR(32) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_wallet_category (wallet_id, category),
+    FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE
+);
+
+-- Create budget alerts table; each threshold fires at most once per budget and month
+CREATE TABLE IF NOT EXISTS budget_alerts (
+    id VARCHAR(36) PRIMARY KEY,
+    budget_id VARCHAR(36) NOT NULL,
+    period DATE NOT NULL,
+    threshold INT NOT NULL,
+    budget_amount DECIMAL(15,2) NOT NULL,
+    spent_amount DECIMAL(15,2) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_budget_period_threshold (budget_id, period, threshold),
+    FOREIGN KEY (budget_id) REFERENCES budgets(id) ON DELETE CASCADE,
+    FOREIGN KEY (transaction_id) REFERENCES wallet_transactions(id)
+);
+
+-- Monthly spending per category is summed on every completed debit
+ALTER TABLE wallet_transactions ADD KEY idx_wallet_category_created_at (wallet_id, category, created_at);
+
+-- +migrate Down
+ALTER TABLE wallet_transactions DROP KEY idx_wallet_category_created_at;
+DROP TABLE IF EXISTS budget_alerts;
+DROP TABLE IF EXISTS budgets;
@@ -0,0 +1,41 @@
+-- +migrate Up
+-- Create notifications table, the delivery queue and history of user notifications
+CREATE TABLE IF NOT EXISTS notifications (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    event VARCHAR(64) NOT NULL,
+    channel VARCHAR(16) NOT NULL,
+    reference_id VARCHAR(36) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    locale VARCHAR(8) NOT NULL,
+    subject VARCHAR(255),
+    body TEXT,
+    status ENUM('PENDING', 'SENT', 'FAILED') NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    sent_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_reference_event_channel (reference_id, event, channel),
+    KEY idx_user_id (user_id),
+    KEY idx_status_next_attempt_at (status, next_attempt_at)
+);
+
+-- Create notification preferences table
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id VARCHAR(36) PRIMARY KEY,
+    locale VARCHAR(8) NOT NULL DEFAULT 'en',
+    email VARCHAR(255),
+    phone_number VARCHAR(32),
+    push_token VARCHAR(512),
+    email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    sms_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    push_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,7 @@
+-- +migrate Up
+-- Role of the principal; admin and service principals may act on other users' wallets
+ALTER TABLE users
+    ADD COLUMN role ENUM('USER', 'ADMIN', 'SERVICE') NOT NULL DEFAULT 'USER' AFTER is_active;
+
+-- +migrate Down
+ALTER TABLE users DROP COLUMN role;
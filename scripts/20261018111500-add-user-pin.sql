@@ -0,0 +1,7 @@
+-- +migrate Up
+-- bcrypt hash of the 6-digit transaction PIN
+ALTER TABLE users
+    ADD COLUMN pin VARCHAR(255) NULL AFTER password;
+
+-- +migrate Down
+ALTER TABLE users DROP COLUMN pin;
@@ -0,0 +1,10 @@
+-- +migrate Up
+-- destination of a withdrawal, used to tell new beneficiaries from known ones
+ALTER TABLE wallet_transactions
+    ADD COLUMN beneficiary VARCHAR(100) NULL AFTER description,
+    ADD INDEX idx_wallet_beneficiary (wallet_id, beneficiary);
+
+-- +migrate Down
+ALTER TABLE wallet_transactions
+    DROP INDEX idx_wallet_beneficiary,
+    DROP COLUMN beneficiary;
@@ -0,0 +1,9 @@
+-- +migrate Up
+-- new accounts stay inactive until activated; existing active accounts count as activated
+ALTER TABLE users
+    ADD COLUMN activated_at DATETIME(3) NULL AFTER is_active;
+
+UPDATE users SET activated_at = created_at WHERE is_active = 1;
+
+-- +migrate Down
+ALTER TABLE users DROP COLUMN activated_at;
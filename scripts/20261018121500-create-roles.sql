@@ -0,0 +1,61 @@
+-- +migrate Up
+-- Roles and the permissions they grant; the permissions of a user's role are carried in their access token
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(32) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role VARCHAR(32) NOT NULL,
+    permission VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (role, permission),
+    FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE
+);
+
+INSERT INTO roles (name, description) VALUES
+    ('USER', 'Wallet customer'),
+    ('SUPPORT', 'Customer support, read-only access to users and wallets'),
+    ('OPS', 'Operations, manages wallets and campaigns'),
+    ('COMPLIANCE', 'Compliance, reviews and freezes wallets and exports transactions'),
+    ('ADMIN', 'Administrator with every permission'),
+    ('SERVICE', 'Internal service acting on users'' wallets');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('SUPPORT', 'user.read'),
+    ('SUPPORT', 'wallet.read'),
+    ('OPS', 'user.read'),
+    ('OPS', 'wallet.read'),
+    ('OPS', 'wallet.manage'),
+    ('OPS', 'wallet.freeze'),
+    ('OPS', 'wallet.adjust'),
+    ('OPS', 'cashback.read'),
+    ('OPS', 'cashback.manage'),
+    ('COMPLIANCE', 'user.read'),
+    ('COMPLIANCE', 'wallet.read'),
+    ('COMPLIANCE', 'wallet.freeze'),
+    ('COMPLIANCE', 'wallet.export'),
+    ('ADMIN', 'user.read'),
+    ('ADMIN', 'wallet.read'),
+    ('ADMIN', 'wallet.manage'),
+    ('ADMIN', 'wallet.withdraw'),
+    ('ADMIN', 'wallet.freeze'),
+    ('ADMIN', 'wallet.adjust'),
+    ('ADMIN', 'wallet.export'),
+    ('ADMIN', 'cashback.read'),
+    ('ADMIN', 'cashback.manage'),
+    ('SERVICE', 'wallet.read'),
+    ('SERVICE', 'wallet.manage'),
+    ('SERVICE', 'wallet.withdraw');
+
+ALTER TABLE users
+    MODIFY COLUMN role VARCHAR(32) NOT NULL DEFAULT 'USER',
+    ADD CONSTRAINT fk_users_role FOREIGN KEY (role) REFERENCES roles(name);
+
+-- +migrate Down
+ALTER TABLE users DROP FOREIGN KEY fk_users_role;
+UPDATE users SET role = 'USER' WHERE role NOT IN ('USER', 'ADMIN', 'SERVICE');
+ALTER TABLE users MODIFY COLUMN role ENUM('USER', 'ADMIN', 'SERVICE') NOT NULL DEFAULT 'USER';
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS roles;
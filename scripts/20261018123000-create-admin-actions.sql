@@ -0,0 +1,26 @@
+-- +migrate Up
+-- Manual credits and debits made from the back office
+ALTER TABLE wallet_transactions MODIFY type ENUM('WITHDRAWAL', 'DEPOSIT', 'PAYMENT', 'TRANSFER', 'ESCROW', 'REFUND', 'FEE', 'ADJUSTMENT') NOT NULL;
+
+-- Who did what to a wallet from the back office, and why
+CREATE TABLE IF NOT EXISTS admin_actions (
+    id VARCHAR(36) PRIMARY KEY,
+    actor_id VARCHAR(36) NOT NULL,
+    action ENUM('CREDIT', 'DEBIT', 'FREEZE', 'UNFREEZE', 'EXPORT') NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    transaction_id VARCHAR(36) NULL,
+    amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    reason VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_admin_actions_actor (actor_id),
+    INDEX idx_admin_actions_wallet (wallet_id, created_at),
+    FOREIGN KEY (wallet_id) REFERENCES wallets(id),
+    FOREIGN KEY (transaction_id) REFERENCES wallet_transactions(id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS admin_actions;
+UPDATE wallet_transactions SET type = 'DEPOSIT' WHERE type = 'ADJUSTMENT' AND direction = 'CREDIT';
+UPDATE wallet_transactions SET type = 'WITHDRAWAL' WHERE type = 'ADJUSTMENT' AND direction = 'DEBIT';
+ALTER TABLE wallet_transactions MODIFY type ENUM('WITHDRAWAL', 'DEPOSIT', 'PAYMENT', 'TRANSFER', 'ESCROW', 'REFUND', 'FEE') NOT NULL;
@@ -0,0 +1,43 @@
+-- +migrate Up
+-- Back-office operations waiting for a second person's approval
+CREATE TABLE IF NOT EXISTS approval_requests (
+    id VARCHAR(36) PRIMARY KEY,
+    operation VARCHAR(64) NOT NULL,
+    payload JSON NOT NULL,
+    maker_id VARCHAR(36) NOT NULL,
+    status ENUM('PENDING', 'APPROVED', 'REJECTED', 'EXPIRED') NOT NULL DEFAULT 'PENDING',
+    checker_id VARCHAR(36) NULL,
+    note VARCHAR(255) NULL,
+    decided_at TIMESTAMP NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_approval_requests_status (status, expires_at),
+    INDEX idx_approval_requests_maker (maker_id)
+);
+
+-- A transaction can be reversed at most once
+ALTER TABLE wallet_transactions
+    ADD COLUMN reversal_of VARCHAR(36) NULL AFTER metadata,
+    ADD UNIQUE INDEX idx_wallet_transactions_reversal_of (reversal_of);
+
+ALTER TABLE admin_actions
+    MODIFY action ENUM('CREDIT', 'DEBIT', 'FREEZE', 'UNFREEZE', 'EXPORT', 'REVERSE') NOT NULL,
+    ADD COLUMN approval_id VARCHAR(36) NULL AFTER transaction_id,
+    ADD CONSTRAINT fk_admin_actions_approval FOREIGN KEY (approval_id) REFERENCES approval_requests(id);
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('OPS', 'wallet.reverse'),
+    ('OPS', 'approval.review'),
+    ('COMPLIANCE', 'approval.review'),
+    ('ADMIN', 'wallet.reverse'),
+    ('ADMIN', 'approval.review');
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permission IN ('wallet.reverse', 'approval.review');
+ALTER TABLE admin_actions DROP FOREIGN KEY fk_admin_actions_approval;
+ALTER TABLE admin_actions DROP COLUMN approval_id;
+DELETE FROM admin_actions WHERE action = 'REVERSE';
+ALTER TABLE admin_actions MODIFY action ENUM('CREDIT', 'DEBIT', 'FREEZE', 'UNFREEZE', 'EXPORT') NOT NULL;
+ALTER TABLE wallet_transactions DROP INDEX idx_wallet_transactions_reversal_of, DROP COLUMN reversal_of;
+DROP TABLE IF EXISTS approval_requests;
@@ -0,0 +1,24 @@
+-- +migrate Up
+-- API keys of internal services calling the wallet API; only a hash of each key is stored
+CREATE TABLE IF NOT EXISTS api_keys (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(32) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes JSON NOT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    replaced_by VARCHAR(36) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_api_keys_prefix (prefix)
+);
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('ADMIN', 'apikey.manage');
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permission = 'apikey.manage';
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,8 @@
+-- +migrate Up
+-- Support staff and admins can lift the login lockout of a user
+INSERT INTO role_permissions (role, permission) VALUES
+    ('SUPPORT', 'user.unlock'),
+    ('ADMIN', 'user.unlock');
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permission = 'user.unlock';
@@ -0,0 +1,34 @@
+-- +migrate Up
+-- The KYC level decides the maximum balance and daily withdrawals of a user's wallet
+ALTER TABLE users
+    ADD COLUMN kyc_level VARCHAR(16) NOT NULL DEFAULT 'BASIC' AFTER role;
+
+-- Identity documents uploaded for review; the files are kept in the file storage
+CREATE TABLE IF NOT EXISTS kyc_submissions (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    level VARCHAR(16) NOT NULL,
+    document_type ENUM('KTP', 'PASSPORT') NOT NULL,
+    file_key VARCHAR(255) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(64) NOT NULL,
+    size BIGINT NOT NULL,
+    status ENUM('PENDING', 'APPROVED', 'REJECTED') NOT NULL DEFAULT 'PENDING',
+    reviewer_id VARCHAR(36) NULL,
+    review_note VARCHAR(255) NULL,
+    reviewed_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_kyc_submissions_user (user_id, created_at),
+    INDEX idx_kyc_submissions_status (status, created_at),
+    CONSTRAINT fk_kyc_submissions_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('COMPLIANCE', 'kyc.review'),
+    ('ADMIN', 'kyc.review');
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permission = 'kyc.review';
+DROP TABLE IF EXISTS kyc_submissions;
+ALTER TABLE users DROP COLUMN kyc_level;
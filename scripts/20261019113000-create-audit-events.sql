@@ -0,0 +1,40 @@
+-- +migrate Up
+-- Append-only log of every change, written in the same transaction as the change
+CREATE TABLE IF NOT EXISTS audit_events (
+    id VARCHAR(36) PRIMARY KEY,
+    actor_id VARCHAR(36) NULL,
+    principal_type VARCHAR(16) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    resource_type VARCHAR(32) NOT NULL,
+    resource_id VARCHAR(64) NOT NULL,
+    `before` JSON NULL,
+    `after` JSON NULL,
+    request_id VARCHAR(64) NULL,
+    ip_address VARCHAR(45) NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    INDEX idx_audit_events_created (created_at),
+    INDEX idx_audit_events_actor (actor_id, created_at),
+    INDEX idx_audit_events_resource (resource_type, resource_id, created_at),
+    INDEX idx_audit_events_action (action, created_at),
+    INDEX idx_audit_events_request (request_id)
+);
+
+-- Rows cannot be changed or removed by any database user, the application's included.
+-- Dropping the triggers needs the TRIGGER privilege. Migrations run as DB_MIGRATION_USERNAME,
+-- which owns the schema; the application's user is granted row access only and never TRIGGER,
+-- ALTER or DROP. See the README for the grants.
+CREATE TRIGGER audit_events_no_update BEFORE UPDATE ON audit_events
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_events is append-only';
+
+CREATE TRIGGER audit_events_no_delete BEFORE DELETE ON audit_events
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_events is append-only';
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('COMPLIANCE', 'audit.read'),
+    ('ADMIN', 'audit.read');
+
+-- +migrate Down
+DELETE FROM role_permissions WHERE permission = 'audit.read';
+DROP TRIGGER IF EXISTS audit_events_no_delete;
+DROP TRIGGER IF EXISTS audit_events_no_update;
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,35 @@
+-- +migrate Up
+-- Settled transactions are linked into a hash chain per wallet. Transactions settled before
+-- this migration are left out of the chain.
+ALTER TABLE wallet_transactions
+    ADD COLUMN chain_seq BIGINT NULL AFTER reversal_of,
+    ADD COLUMN chain_hash CHAR(64) NOT NULL DEFAULT '' AFTER chain_seq,
+    ADD UNIQUE KEY idx_wallet_chain (wallet_id, chain_seq);
+
+-- Signed chain heads, written by the checkpoint-ledger cron job
+CREATE TABLE IF NOT EXISTS ledger_checkpoints (
+    id VARCHAR(36) PRIMARY KEY,
+    wallet_id VARCHAR(36) NOT NULL,
+    chain_seq BIGINT NOT NULL,
+    chain_hash CHAR(64) NOT NULL,
+    key_id VARCHAR(64) NOT NULL,
+    signature VARCHAR(128) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_ledger_checkpoints_wallet (wallet_id, chain_seq),
+    FOREIGN KEY (wallet_id) REFERENCES wallets(id)
+);
+
+CREATE TRIGGER ledger_checkpoints_no_update BEFORE UPDATE ON ledger_checkpoints
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'ledger_checkpoints is append-only';
+
+CREATE TRIGGER ledger_checkpoints_no_delete BEFORE DELETE ON ledger_checkpoints
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'ledger_checkpoints is append-only';
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS ledger_checkpoints_no_delete;
+DROP TRIGGER IF EXISTS ledger_checkpoints_no_update;
+DROP TABLE IF EXISTS ledger_checkpoints;
+ALTER TABLE wallet_transactions
+    DROP INDEX idx_wallet_chain,
+    DROP COLUMN chain_hash,
+    DROP COLUMN chain_seq;
@@ -0,0 +1,8 @@
+-- +migrate Up
+-- Record who created each cashback campaign; campaigns created before have none
+ALTER TABLE cashback_campaigns
+    ADD COLUMN created_by VARCHAR(36) NULL AFTER is_active;
+
+-- +migrate Down
+ALTER TABLE cashback_campaigns
+    DROP COLUMN created_by;
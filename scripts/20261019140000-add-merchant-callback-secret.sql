@@ -0,0 +1,9 @@
+-- +migrate Up
+-- Key the payment intent callbacks are signed with, sealed with the application encryption key.
+-- Merchants created before get one when they rotate their API key.
+ALTER TABLE merchants
+    ADD COLUMN callback_secret VARCHAR(255) NULL AFTER callback_url;
+
+-- +migrate Down
+ALTER TABLE merchants
+    DROP COLUMN callback_secret;